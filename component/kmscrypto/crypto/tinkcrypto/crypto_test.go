@@ -13,14 +13,18 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
 	tinkaead "github.com/google/tink/go/aead"
 	tinkaeadsubtle "github.com/google/tink/go/aead/subtle"
 	hybrid "github.com/google/tink/go/hybrid/subtle"
 	"github.com/google/tink/go/keyset"
 	"github.com/google/tink/go/mac"
+	commonpb "github.com/google/tink/go/proto/common_go_proto"
 	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
 	"github.com/google/tink/go/signature"
 	"github.com/google/tink/go/subtle/random"
+	"github.com/google/tink/go/testkeyset"
+	"github.com/google/tink/go/testutil"
 	"github.com/stretchr/testify/require"
 	chacha "golang.org/x/crypto/chacha20poly1305"
 
@@ -406,6 +410,53 @@ func TestCrypto_ECDHES_Wrap_Unwrap_Key(t *testing.T) {
 	require.EqualValues(t, cek, uCEK)
 }
 
+func TestCrypto_ECDHES_Wrap_Unwrap_Key_Secp256k1(t *testing.T) {
+	recPrivKey, err := ecdsa.GenerateKey(S256(), rand.Reader)
+	require.NoError(t, err)
+
+	recPubKeyProto := ecdhAEADPublicKey(t, commonpb.EllipticCurveType_UNKNOWN_CURVE, commonpb.EcPointFormat_UNCOMPRESSED,
+		ecdhpb.KeyType_EC, tinkaead.AES256GCMKeyTemplate(), recPrivKey.X.Bytes(), recPrivKey.Y.Bytes(), nil)
+
+	recPrivKeyProto, err := proto.Marshal(ecdhesAEADPrivateKey(t, recPubKeyProto, recPrivKey.D.Bytes()))
+	require.NoError(t, err)
+
+	recKey := testutil.NewKey(
+		testutil.NewKeyData(secp256k1ECDHKWPrivateKeyTypeURL, recPrivKeyProto, tinkpb.KeyData_ASYMMETRIC_PRIVATE),
+		tinkpb.KeyStatusType_ENABLED, 15, tinkpb.OutputPrefixType_RAW)
+
+	recipientKeyHandle, err := testkeyset.NewHandle(testutil.NewKeyset(recKey.KeyId, []*tinkpb.Keyset_Key{recKey}))
+	require.NoError(t, err)
+
+	c, err := New()
+	require.NoError(t, err)
+
+	cek := random.GetRandomBytes(uint32(crypto.DefKeySize))
+	apu := random.GetRandomBytes(uint32(10))
+	apv := random.GetRandomBytes(uint32(10))
+
+	wrappedKey, err := c.WrapKey(cek, apu, apv, &cryptoapi.PublicKey{
+		X:     recPrivKey.X.Bytes(),
+		Y:     recPrivKey.Y.Bytes(),
+		Curve: "secp256k1",
+		Type:  ecdhpb.KeyType_EC.String(),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "secp256k1", wrappedKey.EPK.Curve)
+	require.Equal(t, ECDHESA256KWAlg, wrappedKey.Alg)
+
+	uCEK, err := c.UnwrapKey(wrappedKey, recipientKeyHandle)
+	require.NoError(t, err)
+	require.EqualValues(t, cek, uCEK)
+
+	// a secp256k1 EPK can't be unwrapped with a NIST P-256 recipient key
+	p256KeyHandle, err := keyset.NewHandle(ecdh.NISTP256ECDHKWKeyTemplate())
+	require.NoError(t, err)
+
+	_, err = c.UnwrapKey(wrappedKey, p256KeyHandle)
+	require.EqualError(t, err, "unwrapKey: deriveKEKAndUnwrap: error ECDH-ES kek derivation: deriveESKEKForUnwrap:"+
+		" error: deriveESWithECKeyForUnwrap: recipient and ephemeral keys are not on the same curve")
+}
+
 func TestCrypto_ECDHES_Wrap_Unwrap_ForAllKeyTypes(t *testing.T) {
 	tests := []struct {
 		tcName   string
@@ -13,6 +13,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math/big"
 
 	"github.com/golang/protobuf/proto"
 	hybrid "github.com/google/tink/go/hybrid/subtle"
@@ -84,12 +85,26 @@ func extractPrivKey(kh *keyset.Handle) (interface{}, error) {
 			return nil, errors.New("extractPrivKey: invalid key in keyset")
 		}
 
-		return hybrid.GetECPrivateKey(S256(), pbKey.KeyValue), nil
+		return getS256PrivateKey(pbKey.KeyValue), nil
 	}
 
 	return nil, fmt.Errorf("extractPrivKey: can't extract unsupported private key '%s'", primaryKey.KeyData.TypeUrl)
 }
 
+// getS256PrivateKey is hybrid.GetECPrivateKey for secp256k1: the latter computes the public key with the generic
+// CurveParams arithmetic, which assumes a = -3 and panics on secp256k1 points.
+func getS256PrivateKey(d []byte) *hybrid.ECPrivateKey {
+	x, y := S256().ScalarBaseMult(d)
+
+	return &hybrid.ECPrivateKey{
+		PublicKey: hybrid.ECPublicKey{
+			Curve: S256(),
+			Point: hybrid.ECPoint{X: x, Y: y},
+		},
+		D: new(big.Int).SetBytes(d),
+	}
+}
+
 func hybridECPrivToECDSAKey(hybridEcPriv *hybrid.ECPrivateKey) *ecdsa.PrivateKey {
 	return &ecdsa.PrivateKey{
 		PublicKey: ecdsa.PublicKey{
@@ -42,6 +42,11 @@ type keyWrapper interface {
 type ecKWSupport struct{}
 
 func (w *ecKWSupport) getCurve(curve string) (elliptic.Curve, error) {
+	// hybrid.GetCurve only resolves NIST curves.
+	if curve == S256().Params().Name || curve == "SECP256K1" {
+		return S256(), nil
+	}
+
 	return hybrid.GetCurve(curve)
 }
 
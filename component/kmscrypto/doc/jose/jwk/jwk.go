@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package jwk
 
 import (
+	"crypto"
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rsa"
@@ -49,6 +50,9 @@ type JWK struct {
 
 	Kty string
 	Crv string
+
+	// KeyOps is the normalized "key_ops" (key operations) parameter.
+	KeyOps []string
 }
 
 // PublicKeyBytes converts a public key to bytes.
@@ -147,6 +151,13 @@ func (j *JWK) UnmarshalJSON(jwkBytes []byte) error {
 	j.Kty = key.Kty
 	j.Crv = key.Crv
 
+	keyOps, err := normalizeKeyOps(key.Use, key.KeyOps)
+	if err != nil {
+		return fmt.Errorf("unable to read JWK: %w", err)
+	}
+
+	j.KeyOps = keyOps
+
 	return nil
 }
 
@@ -164,7 +175,12 @@ func (j *JWK) MarshalJSON() ([]byte, error) {
 		return marshalBLS12381G2(j)
 	}
 
-	return (&j.JSONWebKey).MarshalJSON()
+	data, err := (&j.JSONWebKey).MarshalJSON()
+	if err != nil || len(j.KeyOps) == 0 {
+		return data, err
+	}
+
+	return withKeyOps(data, j.KeyOps)
 }
 
 // KeyType returns the kms KeyType of the JWK, or an error if the JWK is of an unrecognized type.
@@ -194,6 +210,38 @@ func (j *JWK) KeyType() (kms.KeyType, error) {
 	}
 }
 
+// Thumbprint computes the JWK thumbprint (RFC 7638) of the key. Unlike jose.JSONWebKey.Thumbprint, it supports
+// secp256k1 keys, whose thumbprint input is {"crv":"secp256k1","kty":"EC","x":...,"y":...}.
+func (j *JWK) Thumbprint(hash crypto.Hash) ([]byte, error) {
+	if !j.isSecp256k1() {
+		return j.JSONWebKey.Thumbprint(hash)
+	}
+
+	var pubKey *ecdsa.PublicKey
+
+	switch key := j.Key.(type) {
+	case *ecdsa.PublicKey:
+		pubKey = key
+	case *ecdsa.PrivateKey:
+		pubKey = &key.PublicKey
+	default:
+		return nil, fmt.Errorf("thumbprint: unsupported secp256k1 key type %T", key)
+	}
+
+	if len(pubKey.X.Bytes()) > secp256k1Size || len(pubKey.Y.Bytes()) > secp256k1Size {
+		return nil, errors.New("thumbprint: invalid secp256k1 key (too large)")
+	}
+
+	input := fmt.Sprintf(`{"crv":"%s","kty":"%s","x":"%s","y":"%s"}`, secp256k1Crv, ecKty,
+		newFixedSizeBuffer(pubKey.X.Bytes(), secp256k1Size).base64(),
+		newFixedSizeBuffer(pubKey.Y.Bytes(), secp256k1Size).base64())
+
+	h := hash.New()
+	_, _ = h.Write([]byte(input))
+
+	return h.Sum(nil), nil
+}
+
 func ecdsaPubKeyType(pub *ecdsa.PublicKey) (kms.KeyType, error) {
 	switch pub.Curve {
 	case btcec.S256():
@@ -354,6 +402,7 @@ func marshalX25519(jwk *JWK) ([]byte, error) {
 	raw.Kid = jwk.KeyID
 	raw.Alg = jwk.Algorithm
 	raw.Use = jwk.Use
+	raw.KeyOps = jwk.KeyOps
 
 	return json.Marshal(raw)
 }
@@ -448,6 +497,7 @@ func marshalBLS12381G2(jwk *JWK) ([]byte, error) {
 	raw.Kid = jwk.KeyID
 	raw.Alg = jwk.Algorithm
 	raw.Use = jwk.Use
+	raw.KeyOps = jwk.KeyOps
 
 	return json.Marshal(raw)
 }
@@ -477,17 +527,19 @@ func marshalSecp256k1(jwk *JWK) ([]byte, error) {
 	raw.Kid = jwk.KeyID
 	raw.Alg = jwk.Algorithm
 	raw.Use = jwk.Use
+	raw.KeyOps = jwk.KeyOps
 
 	return json.Marshal(raw)
 }
 
 // jsonWebKey contains subset of json web key json properties.
 type jsonWebKey struct {
-	Use string `json:"use,omitempty"`
-	Kty string `json:"kty,omitempty"`
-	Kid string `json:"kid,omitempty"`
-	Crv string `json:"crv,omitempty"`
-	Alg string `json:"alg,omitempty"`
+	Use    string   `json:"use,omitempty"`
+	KeyOps []string `json:"key_ops,omitempty"`
+	Kty    string   `json:"kty,omitempty"`
+	Kid    string   `json:"kid,omitempty"`
+	Crv    string   `json:"crv,omitempty"`
+	Alg    string   `json:"alg,omitempty"`
 
 	X *byteBuffer `json:"x,omitempty"`
 	Y *byteBuffer `json:"y,omitempty"`
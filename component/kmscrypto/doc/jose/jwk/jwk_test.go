@@ -7,11 +7,14 @@ SPDX-License-Identifier: Apache-2.0
 package jwk
 
 import (
+	"crypto"
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/sha256"
+	"encoding/base64"
 	"fmt"
+	"math/big"
 	"testing"
 
 	"github.com/btcsuite/btcd/btcec"
@@ -543,3 +546,68 @@ func TestJWK_KeyType(t *testing.T) {
 		require.Equal(t, kms.KeyType(""), kt)
 	})
 }
+
+func TestJWK_Thumbprint(t *testing.T) {
+	t.Run("success: secp256k1 thumbprint", func(t *testing.T) {
+		// secp256k1 base point
+		jwkJSON := `{
+			"kty": "EC",
+			"crv": "secp256k1",
+			"x": "eb5mfvncu6xVoGKVzocLBwKb_NstzijZWfKBWxb4F5g",
+			"y": "SDradyajxGVdpPv8DhEIqP0XtEimhVQZnEfQj_sQ1Lg",
+			"kid": "sample@sample.id",
+			"alg": "ES256K"
+		}`
+
+		j := JWK{}
+		require.NoError(t, j.UnmarshalJSON([]byte(jwkJSON)))
+
+		tp, err := j.Thumbprint(crypto.SHA256)
+		require.NoError(t, err)
+		require.Equal(t, "2JF8vg9etJzjFwZwmkvhBLLZ0bfMVVOPivYR5lFtcec", base64.RawURLEncoding.EncodeToString(tp))
+	})
+
+	t.Run("success: secp256k1 coordinates are left-padded", func(t *testing.T) {
+		x, ok := new(big.Int).SetString("139ae46a1133f1f9d23f25efba0f6dd87bf7ddaf568a5fb9e0a3bfda73176237", 16)
+		require.True(t, ok)
+
+		// 31-byte y coordinate
+		y, ok := new(big.Int).SetString("995e555c8aabd263fd238833a12188b8a5ffbeb480ba0e3e6ec481a8991472", 16)
+		require.True(t, ok)
+
+		pubKey := &ecdsa.PublicKey{Curve: btcec.S256(), X: x, Y: y}
+
+		j := JWK{JSONWebKey: jose.JSONWebKey{Key: pubKey}}
+
+		tp, err := j.Thumbprint(crypto.SHA256)
+		require.NoError(t, err)
+		require.Equal(t, "hLZA8vfEaf0u5jj3aS8iZ5gQhS1Jr2-F1N5U6-Ld_Oo", base64.RawURLEncoding.EncodeToString(tp))
+
+		j = JWK{JSONWebKey: jose.JSONWebKey{Key: &ecdsa.PrivateKey{PublicKey: *pubKey, D: big.NewInt(122)}}}
+
+		tp, err = j.Thumbprint(crypto.SHA256)
+		require.NoError(t, err)
+		require.Equal(t, "hLZA8vfEaf0u5jj3aS8iZ5gQhS1Jr2-F1N5U6-Ld_Oo", base64.RawURLEncoding.EncodeToString(tp))
+	})
+
+	t.Run("success: thumbprint of other keys is computed by go-jose", func(t *testing.T) {
+		privKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		j := JWK{JSONWebKey: jose.JSONWebKey{Key: &privKey.PublicKey}}
+
+		tp, err := j.Thumbprint(crypto.SHA256)
+		require.NoError(t, err)
+
+		expected, err := j.JSONWebKey.Thumbprint(crypto.SHA256)
+		require.NoError(t, err)
+		require.Equal(t, expected, tp)
+	})
+
+	t.Run("fail: secp256k1 key of unsupported type", func(t *testing.T) {
+		j := JWK{JSONWebKey: jose.JSONWebKey{Key: []byte("key"), Algorithm: "ES256K"}}
+
+		_, err := j.Thumbprint(crypto.SHA256)
+		require.EqualError(t, err, "thumbprint: unsupported secp256k1 key type []uint8")
+	})
+}
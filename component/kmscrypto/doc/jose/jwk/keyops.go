@@ -0,0 +1,137 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jwk
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Public key uses and key operations (https://www.rfc-editor.org/rfc/rfc7517#section-4.2).
+const (
+	UseSignature  = "sig"
+	UseEncryption = "enc"
+
+	KeyOpSign       = "sign"
+	KeyOpVerify     = "verify"
+	KeyOpEncrypt    = "encrypt"
+	KeyOpDecrypt    = "decrypt"
+	KeyOpWrapKey    = "wrapKey"
+	KeyOpUnwrapKey  = "unwrapKey"
+	KeyOpDeriveKey  = "deriveKey"
+	KeyOpDeriveBits = "deriveBits"
+)
+
+// ErrInconsistentKeyUsage is returned when "use" and "key_ops" of the JWK contradict each other.
+var ErrInconsistentKeyUsage = errors.New("inconsistent key usage")
+
+// keyOpsOrder is the canonical order of the known key operations.
+var keyOpsOrder = []string{ //nolint:gochecknoglobals
+	KeyOpSign, KeyOpVerify, KeyOpEncrypt, KeyOpDecrypt,
+	KeyOpWrapKey, KeyOpUnwrapKey, KeyOpDeriveKey, KeyOpDeriveBits,
+}
+
+// useKeyOps are the key operations allowed for the public key use.
+var useKeyOps = map[string][]string{ //nolint:gochecknoglobals
+	UseSignature: {KeyOpSign, KeyOpVerify},
+	UseEncryption: {
+		KeyOpEncrypt, KeyOpDecrypt, KeyOpWrapKey, KeyOpUnwrapKey, KeyOpDeriveKey, KeyOpDeriveBits,
+	},
+}
+
+// CanSign reports whether the declared usage of the key permits creating signatures.
+// A key without "use" and "key_ops" is not restricted.
+func (j *JWK) CanSign() bool {
+	return j.permits(UseSignature, KeyOpSign)
+}
+
+// CanVerify reports whether the declared usage of the key permits verifying signatures.
+// A key without "use" and "key_ops" is not restricted.
+func (j *JWK) CanVerify() bool {
+	return j.permits(UseSignature, KeyOpVerify)
+}
+
+// CanEncrypt reports whether the declared usage of the key permits encryption, either directly
+// or by wrapping or deriving a content encryption key. A key without "use" and "key_ops" is not restricted.
+func (j *JWK) CanEncrypt() bool {
+	return j.permits(UseEncryption, KeyOpEncrypt, KeyOpWrapKey, KeyOpDeriveKey, KeyOpDeriveBits)
+}
+
+func (j *JWK) permits(use string, ops ...string) bool {
+	if len(j.KeyOps) > 0 {
+		for _, op := range ops {
+			if containsKeyOp(j.KeyOps, op) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return j.Use == "" || j.Use == use
+}
+
+// normalizeKeyOps checks that key operations are consistent with the public key use and
+// returns them without duplicates, with the known operations first in the canonical order.
+func normalizeKeyOps(use string, keyOps []string) ([]string, error) {
+	if len(keyOps) == 0 {
+		return nil, nil
+	}
+
+	allowed, knownUse := useKeyOps[use]
+
+	var normalized []string
+
+	for _, op := range keyOpsOrder {
+		if !containsKeyOp(keyOps, op) {
+			continue
+		}
+
+		if knownUse && !containsKeyOp(allowed, op) {
+			return nil, fmt.Errorf("%w: key_ops %q is not allowed for use %q", ErrInconsistentKeyUsage, op, use)
+		}
+
+		normalized = append(normalized, op)
+	}
+
+	// other key operation values may be used (RFC 7517 section 4.3), they are kept in the original order
+	for _, op := range keyOps {
+		if !containsKeyOp(keyOpsOrder, op) && !containsKeyOp(normalized, op) {
+			normalized = append(normalized, op)
+		}
+	}
+
+	return normalized, nil
+}
+
+func containsKeyOp(keyOps []string, op string) bool {
+	for _, o := range keyOps {
+		if o == op {
+			return true
+		}
+	}
+
+	return false
+}
+
+// withKeyOps adds "key_ops" to the serialized JWK.
+func withKeyOps(data []byte, keyOps []string) ([]byte, error) {
+	var raw map[string]json.RawMessage
+
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return nil, err
+	}
+
+	raw["key_ops"], err = json.Marshal(keyOps)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(raw)
+}
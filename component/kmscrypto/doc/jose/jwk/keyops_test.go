@@ -0,0 +1,130 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jwk
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	ed25519JWK = `{"kty":"OKP","crv":"Ed25519","x":"11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"%s}`
+	x25519JWK  = `{"kty":"OKP","crv":"X25519","x":"3p7bfXt9wbTTW2HC7OQ1Nz-DQ8hbeGdNrfx-FG-IK08"%s}`
+)
+
+func TestJWK_KeyOps(t *testing.T) {
+	t.Run("consistent use and key_ops", func(t *testing.T) {
+		tests := []struct {
+			name       string
+			jwk        string
+			params     string
+			keyOps     []string
+			canSign    bool
+			canVerify  bool
+			canEncrypt bool
+		}{
+			{
+				name:      "signature key",
+				jwk:       ed25519JWK,
+				params:    `,"use":"sig","key_ops":["verify","sign","verify"]`,
+				keyOps:    []string{KeyOpSign, KeyOpVerify},
+				canSign:   true,
+				canVerify: true,
+			},
+			{
+				name:      "verification only key",
+				jwk:       ed25519JWK,
+				params:    `,"key_ops":["verify"]`,
+				keyOps:    []string{KeyOpVerify},
+				canVerify: true,
+			},
+			{
+				name:      "use without key_ops",
+				jwk:       ed25519JWK,
+				params:    `,"use":"sig"`,
+				canSign:   true,
+				canVerify: true,
+			},
+			{
+				name:       "encryption key",
+				jwk:        x25519JWK,
+				params:     `,"use":"enc","key_ops":["deriveKey","deriveBits"]`,
+				keyOps:     []string{KeyOpDeriveKey, KeyOpDeriveBits},
+				canEncrypt: true,
+			},
+			{
+				name:       "encryption key with custom key operation",
+				jwk:        x25519JWK,
+				params:     `,"key_ops":["custom","encrypt"]`,
+				keyOps:     []string{KeyOpEncrypt, "custom"},
+				canEncrypt: true,
+			},
+			{
+				name:       "unrestricted key",
+				jwk:        ed25519JWK,
+				canSign:    true,
+				canVerify:  true,
+				canEncrypt: true,
+			},
+		}
+
+		for _, tc := range tests {
+			tc := tc
+
+			t.Run(tc.name, func(t *testing.T) {
+				j := &JWK{}
+
+				err := json.Unmarshal([]byte(fmt.Sprintf(tc.jwk, tc.params)), j)
+				require.NoError(t, err)
+
+				require.Equal(t, tc.keyOps, j.KeyOps)
+				require.Equal(t, tc.canSign, j.CanSign())
+				require.Equal(t, tc.canVerify, j.CanVerify())
+				require.Equal(t, tc.canEncrypt, j.CanEncrypt())
+
+				data, err := json.Marshal(j)
+				require.NoError(t, err)
+
+				parsed := &JWK{}
+
+				err = json.Unmarshal(data, parsed)
+				require.NoError(t, err)
+				require.Equal(t, tc.keyOps, parsed.KeyOps)
+				require.Equal(t, j.Use, parsed.Use)
+			})
+		}
+	})
+
+	t.Run("contradictory use and key_ops", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			jwk    string
+			params string
+		}{
+			{name: "signature key with encrypt", jwk: ed25519JWK, params: `,"use":"sig","key_ops":["encrypt"]`},
+			{name: "signature key with sign and wrapKey", jwk: ed25519JWK, params: `,"use":"sig","key_ops":["sign","wrapKey"]`},
+			{name: "encryption key with sign", jwk: x25519JWK, params: `,"use":"enc","key_ops":["deriveKey","sign"]`},
+		}
+
+		for _, tc := range tests {
+			tc := tc
+
+			t.Run(tc.name, func(t *testing.T) {
+				j := &JWK{}
+
+				err := json.Unmarshal([]byte(fmt.Sprintf(tc.jwk, tc.params)), j)
+				require.Error(t, err)
+				require.True(t, errors.Is(err, ErrInconsistentKeyUsage))
+				require.Contains(t, err.Error(), "is not allowed for use")
+			})
+		}
+	})
+}
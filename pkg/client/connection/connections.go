@@ -0,0 +1,85 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package connection
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+)
+
+// State is the state of a DIDComm connection.
+type State string
+
+// States of a DIDComm connection.
+const (
+	StateInvited   State = "invited"
+	StateRequested State = "requested"
+	StateResponded State = "responded"
+	StateCompleted State = connection.StateNameCompleted
+	StateAbandoned State = "abandoned"
+)
+
+// ConnectionRecord holds info about a DIDComm connection.
+type ConnectionRecord = connection.Record
+
+// StateFilter selects connections by state. A filter without states matches all connections.
+type StateFilter struct {
+	States []State
+}
+
+func (f StateFilter) matches(state State) bool {
+	if len(f.States) == 0 {
+		return true
+	}
+
+	for _, s := range f.States {
+		if s == state {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Connections provides read access to the DIDComm connections of the connection store.
+type Connections struct {
+	lookup *connection.Lookup
+}
+
+// Connections returns the connection query API.
+func (c *Client) Connections() *Connections {
+	return &Connections{lookup: c.connectionRecorder.Lookup}
+}
+
+// State returns the current state of the given connection.
+func (c *Connections) State(connectionID string) (State, error) {
+	record, err := c.lookup.GetConnectionRecord(connectionID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get connection: %w", err)
+	}
+
+	return State(record.State), nil
+}
+
+// List returns the connections whose current state matches the filter.
+func (c *Connections) List(filter StateFilter) ([]ConnectionRecord, error) {
+	records, err := c.lookup.QueryConnectionRecords()
+	if err != nil {
+		return nil, fmt.Errorf("failed to query connections: %w", err)
+	}
+
+	var result []ConnectionRecord
+
+	for _, record := range records {
+		if filter.matches(State(record.State)) {
+			result = append(result, *record)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,126 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package connection
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+func TestConnections_State(t *testing.T) {
+	t.Run("state transitions", func(t *testing.T) {
+		prov := mockProvider(t)
+
+		connStore, err := connection.NewRecorder(prov)
+		require.NoError(t, err)
+
+		c, err := New(prov)
+		require.NoError(t, err)
+
+		for _, state := range []State{StateInvited, StateRequested, StateResponded, StateCompleted} {
+			require.NoError(t, connStore.SaveConnectionRecord(&connection.Record{
+				ConnectionID: connectionID,
+				State:        string(state),
+			}))
+
+			current, err := c.Connections().State(connectionID)
+			require.NoError(t, err)
+			require.Equal(t, state, current)
+		}
+	})
+
+	t.Run("fail: connection ID not found", func(t *testing.T) {
+		c, err := New(mockProvider(t))
+		require.NoError(t, err)
+
+		_, err = c.Connections().State(connectionID)
+		require.ErrorIs(t, err, storage.ErrDataNotFound)
+	})
+}
+
+func TestConnections_List(t *testing.T) {
+	t.Run("filtered listing", func(t *testing.T) {
+		prov := mockProvider(t)
+
+		connStore, err := connection.NewRecorder(prov)
+		require.NoError(t, err)
+
+		c, err := New(prov)
+		require.NoError(t, err)
+
+		for id, state := range map[string]State{
+			"conn-1": StateInvited,
+			"conn-2": StateRequested,
+			"conn-3": StateCompleted,
+			"conn-4": StateCompleted,
+		} {
+			require.NoError(t, connStore.SaveConnectionRecord(&connection.Record{
+				ConnectionID: id,
+				State:        string(state),
+			}))
+		}
+
+		records, err := c.Connections().List(StateFilter{})
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"conn-1", "conn-2", "conn-3", "conn-4"}, connectionIDs(records))
+
+		records, err = c.Connections().List(StateFilter{States: []State{StateCompleted}})
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"conn-3", "conn-4"}, connectionIDs(records))
+
+		records, err = c.Connections().List(StateFilter{States: []State{StateInvited, StateRequested}})
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"conn-1", "conn-2"}, connectionIDs(records))
+
+		records, err = c.Connections().List(StateFilter{States: []State{StateResponded}})
+		require.NoError(t, err)
+		require.Empty(t, records)
+
+		// connection moves to the next state
+		require.NoError(t, connStore.SaveConnectionRecord(&connection.Record{
+			ConnectionID: "conn-2",
+			State:        string(StateResponded),
+		}))
+
+		records, err = c.Connections().List(StateFilter{States: []State{StateResponded}})
+		require.NoError(t, err)
+		require.Equal(t, []string{"conn-2"}, connectionIDs(records))
+	})
+
+	t.Run("fail: query error", func(t *testing.T) {
+		prov := mockProvider(t)
+
+		expectErr := fmt.Errorf("expected error")
+
+		prov.StorageProviderValue = mockstore.NewCustomMockStoreProvider(&mockstore.MockStore{
+			Store:    map[string]mockstore.DBEntry{},
+			ErrQuery: expectErr,
+		})
+
+		c, err := New(prov)
+		require.NoError(t, err)
+
+		_, err = c.Connections().List(StateFilter{})
+		require.ErrorIs(t, err, expectErr)
+	})
+}
+
+func connectionIDs(records []ConnectionRecord) []string {
+	var ids []string
+
+	for i := range records {
+		ids = append(ids, records[i].ConnectionID)
+	}
+
+	return ids
+}
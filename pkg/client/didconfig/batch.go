@@ -0,0 +1,131 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package didconfig
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+)
+
+const defaultMaxConcurrency = 10
+
+// DIDDomainPair is a DID and domain whose linkage is verified by VerifyBatch.
+type DIDDomainPair struct {
+	DID    string
+	Domain string
+}
+
+// BatchResult is the outcome of the verification of a DID and domain pair by VerifyBatch.
+type BatchResult struct {
+	// Pair is the verified DID and domain pair.
+	Pair DIDDomainPair
+	// Err is nil if the domain linkage of the pair is verified.
+	Err error
+}
+
+// WithMaxConcurrency caps the number of pairs verified concurrently by VerifyBatch (10 by default).
+func WithMaxConcurrency(n int) Option {
+	return func(opts *Client) {
+		opts.maxConcurrency = n
+	}
+}
+
+// VerifyBatch verifies the domain linkage of each pair like VerifyDIDAndDomainContext. The pairs are fetched and
+// verified concurrently by a bounded pool of workers (see WithMaxConcurrency), and the DID resolutions are shared
+// by the pairs with the same DID. The results are in the order of the pairs.
+func (c *Client) VerifyBatch(ctx context.Context, pairs []DIDDomainPair) []BatchResult {
+	results := make([]BatchResult, len(pairs))
+
+	for i, pair := range pairs {
+		results[i].Pair = pair
+	}
+
+	if c.err != nil {
+		for i := range results {
+			results[i].Err = c.err
+		}
+
+		return results
+	}
+
+	workers := c.maxConcurrency
+	if workers <= 0 {
+		workers = defaultMaxConcurrency
+	}
+
+	if workers > len(pairs) {
+		workers = len(pairs)
+	}
+
+	resolver := &batchResolver{resolver: c.resolver(), resolutions: map[string]*batchResolution{}}
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range indexes {
+				results[i].Err = c.chain(func(did, domain string) error {
+					_, err := c.verifyDIDAndDomain(ctx, did, domain, resolver, c.didConfigOpts)
+
+					return err
+				})(pairs[i].DID, pairs[i].Domain)
+			}
+		}()
+	}
+
+	for i := range pairs {
+		indexes <- i
+	}
+
+	close(indexes)
+	wg.Wait()
+
+	return results
+}
+
+// batchResolver resolves each DID once and shares the resolution between the pairs of the batch.
+type batchResolver struct {
+	resolver    didResolver
+	mu          sync.Mutex
+	resolutions map[string]*batchResolution
+}
+
+type batchResolution struct {
+	once          sync.Once
+	docResolution *did.DocResolution
+	err           error
+}
+
+func (r *batchResolver) Resolve(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+	if len(opts) > 0 {
+		return r.resolver.Resolve(didID, opts...)
+	}
+
+	r.mu.Lock()
+
+	resolution, ok := r.resolutions[didID]
+	if !ok {
+		resolution = &batchResolution{}
+		r.resolutions[didID] = resolution
+	}
+
+	r.mu.Unlock()
+
+	resolution.once.Do(func() {
+		resolution.docResolution, resolution.err = r.resolver.Resolve(didID)
+	})
+
+	return resolution.docResolution, resolution.err
+}
@@ -8,10 +8,19 @@ package didconfig
 
 import (
 	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net"
 	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	jsonld "github.com/piprate/json-gold/ld"
@@ -19,32 +28,247 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/didconfig"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	jsonutil "github.com/hyperledger/aries-framework-go/pkg/doc/util/json"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/key"
 )
 
 var logger = log.New("aries-framework/client/did-config")
 
-const defaultTimeout = time.Minute
+const (
+	defaultTimeout   = time.Minute
+	defaultKeepAlive = 30 * time.Second
+
+	defaultMaxResponseBytes = 1 << 20
+
+	defaultWellKnownPath = "/.well-known/did-configuration.json"
+
+	// maxRedirects is the number of redirects followed by the default HTTP client, as by net/http.
+	maxRedirects = 10
+)
 
 // Client is a JSON-LD SDK client.
 type Client struct {
-	httpClient    HTTPClient
-	didConfigOpts []didconfig.DIDConfigurationOpt
+	httpClient       HTTPClient
+	customHTTPClient bool
+	timeouts         *timeouts
+	didResolver      didResolver
+	didConfigOpts    []didconfig.DIDConfigurationOpt
+	verified         *verificationCache
+	middleware       []Middleware
+	expectedHashes   map[string]string
+	stats            *transportStats
+	wellKnownPath    string
+	maxConcurrency   int
+	http2            *bool
+	bodyTransformer  func([]byte, http.Header) ([]byte, error)
+	resolutionCache  ResolutionCache
+	inspectResponse  func(*http.Response)
+	fallbackPaths    []string
+	maxResponseBytes int64
+	requireHTTPS     bool
+	sameHostRedirect bool
+	err              error
+	// preparedCount identifies the prepared verifiers for the verification cache.
+	preparedCount atomic.Uint64
+}
+
+// verificationCache keeps the last successful verification per (did, domain, options) with the hash of the
+// verified did configuration.
+type verificationCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	now     func() time.Time
+	entries map[verificationKey]*verificationEntry
+}
+
+type verificationKey struct {
+	did    string
+	domain string
+	// options is the fingerprint of the DID resolver and the didconfig options of the verification.
+	options string
+}
+
+type verificationEntry struct {
+	hash    [sha256.Size]byte
+	result  *VerificationResult
+	expires time.Time
+}
+
+func (vc *verificationCache) isVerified(k verificationKey, hash [sha256.Size]byte) (*VerificationResult, bool) {
+	vc.mu.Lock()
+	defer vc.mu.Unlock()
+
+	entry, ok := vc.entries[k]
+	if !ok {
+		return nil, false
+	}
+
+	if entry.hash != hash || !vc.now().Before(entry.expires) {
+		// did configuration has changed or the verification has expired
+		delete(vc.entries, k)
+
+		return nil, false
+	}
+
+	return entry.result, true
+}
+
+func (vc *verificationCache) setVerified(k verificationKey, hash [sha256.Size]byte, result *VerificationResult) {
+	now := vc.now()
+	expires := now.Add(vc.ttl)
+
+	// the verification is not valid beyond the expiration of the matched credential
+	if result.Credential != nil && result.Credential.Expired != nil && result.Credential.Expired.Before(expires) {
+		expires = result.Credential.Expired.Time
+	}
+
+	if !now.Before(expires) {
+		return
+	}
+
+	vc.mu.Lock()
+	defer vc.mu.Unlock()
+
+	vc.entries[k] = &verificationEntry{hash: hash, result: result, expires: expires}
+}
+
+type timeouts struct {
+	connect        time.Duration
+	tlsHandshake   time.Duration
+	responseHeader time.Duration
+	total          time.Duration
 }
 
 // New creates new did configuration client.
+//
+// An invalid combination of options is reported by the first call to VerifyDIDAndDomain.
 func New(opts ...Option) *Client {
 	client := &Client{
-		httpClient: &http.Client{Timeout: defaultTimeout},
+		stats:            &transportStats{},
+		maxResponseBytes: defaultMaxResponseBytes,
 	}
 
 	for _, opt := range opts {
 		opt(client)
 	}
 
+	switch {
+	case client.timeouts != nil && client.customHTTPClient:
+		client.err = errors.New("timeouts can't be set for a custom HTTP client")
+	case client.http2 != nil && client.customHTTPClient:
+		client.err = errors.New("HTTP/2 can't be configured for a custom HTTP client")
+	case !client.customHTTPClient:
+		t := client.timeouts
+		if t == nil {
+			t = &timeouts{}
+		}
+
+		client.httpClient = newHTTPClient(t, client.http2, client.stats, client.checkRedirectRequest)
+	}
+
 	return client
 }
 
+// Secure defaults of NewSecure.
+const (
+	secureMaxResponseBytes = 256 << 10
+	secureMaxLinkedDIDs    = 32
+)
+
+// secureAllowedAlgorithms are the JWS algorithms and linked data proof types allowed by NewSecure.
+//
+//nolint:gochecknoglobals
+var secureAllowedAlgorithms = []string{
+	jose.AlgEdDSA, jose.AlgES256, jose.AlgES384, jose.AlgES256K, jose.AlgPS256,
+	"Ed25519Signature2018", "Ed25519Signature2020", "JsonWebSignature2020", "EcdsaSecp256k1Signature2019",
+}
+
+// NewSecure creates new did configuration client with hardened defaults for production use. Compared to New:
+//   - the domain must be an HTTPS origin and the did configuration must be served over HTTPS (WithRequireHTTPS),
+//   - the response body is limited to 256 KiB instead of 1 MiB (WithMaxResponseBytes),
+//   - the proofs of domain linkage credentials are limited to the EdDSA, ES256, ES384, ES256K and PS256 JWS
+//     algorithms and the Ed25519Signature2018, Ed25519Signature2020, JsonWebSignature2020 and
+//     EcdsaSecp256k1Signature2019 linked data proofs (WithAllowedAlgorithms),
+//   - a redirect to another host is rejected (WithSameHostRedirects),
+//   - a did configuration with more than 32 linked_dids entries is rejected (WithMaxLinkedDIDs).
+//
+// The options are applied after the defaults, so that the caller can relax them, e.g. WithRequireHTTPS(false)
+// for a test domain.
+func NewSecure(opts ...Option) *Client {
+	return New(append([]Option{
+		WithRequireHTTPS(true),
+		WithMaxResponseBytes(secureMaxResponseBytes),
+		WithAllowedAlgorithms(secureAllowedAlgorithms...),
+		WithSameHostRedirects(true),
+		WithMaxLinkedDIDs(secureMaxLinkedDIDs),
+	}, opts...)...)
+}
+
+func newHTTPClient(t *timeouts, http2 *bool, stats *transportStats,
+	checkRedirect func(*http.Request, []*http.Request) error) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone() //nolint:forcetypeassert
+
+	if http2 != nil {
+		transport.ForceAttemptHTTP2 = *http2
+
+		if !*http2 {
+			// a non-nil empty map disables HTTP/2
+			transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+		}
+	}
+
+	if t.connect > 0 {
+		transport.DialContext = (&net.Dialer{
+			Timeout:   t.connect,
+			KeepAlive: defaultKeepAlive,
+		}).DialContext
+	}
+
+	if t.tlsHandshake > 0 {
+		transport.TLSHandshakeTimeout = t.tlsHandshake
+	}
+
+	if t.responseHeader > 0 {
+		transport.ResponseHeaderTimeout = t.responseHeader
+	}
+
+	stats.countConnections(transport)
+
+	total := defaultTimeout
+	if t.total > 0 {
+		total = t.total
+	}
+
+	return &http.Client{Transport: transport, Timeout: total, CheckRedirect: checkRedirect}
+}
+
+// ErrStatusCode is returned if the did configuration endpoint responds with a status other than 200 OK.
+type ErrStatusCode struct {
+	Endpoint string
+	Code     int
+	// Message is the body of the response.
+	Message string
+}
+
+func (e *ErrStatusCode) Error() string {
+	return fmt.Sprintf("endpoint %s returned status '%d' and message '%s'", e.Endpoint, e.Code, e.Message)
+}
+
+// ErrResponseTooLarge is returned if the body of the did configuration response exceeds the maximum size.
+var ErrResponseTooLarge = errors.New("response too large")
+
+// ErrHTTPSRequired is returned if the did configuration would be fetched over plain HTTP from a client
+// requiring HTTPS.
+var ErrHTTPSRequired = errors.New("HTTPS is required")
+
+// ErrRedirectNotAllowed is returned if the did configuration request is redirected to another host
+// by a client restricting redirects to the same host.
+var ErrRedirectNotAllowed = errors.New("redirect not allowed")
+
 // HTTPClient represents an HTTP client.
 type HTTPClient interface {
 	Do(req *http.Request) (*http.Response, error)
@@ -57,6 +281,181 @@ type Option func(opts *Client)
 func WithHTTPClient(httpClient HTTPClient) Option {
 	return func(opts *Client) {
 		opts.httpClient = httpClient
+		opts.customHTTPClient = true
+	}
+}
+
+// WithTimeouts configures granular timeouts of the default HTTP transport: connect (TCP dial),
+// TLS handshake, waiting for response headers and the total request time including reading the body.
+// A zero duration keeps the default value. This option can't be combined with WithHTTPClient.
+func WithTimeouts(connect, tlsHandshake, responseHeader, total time.Duration) Option {
+	return func(opts *Client) {
+		opts.timeouts = &timeouts{
+			connect:        connect,
+			tlsHandshake:   tlsHandshake,
+			responseHeader: responseHeader,
+			total:          total,
+		}
+	}
+}
+
+// WithHTTP2 enables or disables HTTP/2 for the default HTTP transport. If enabled, HTTP/2 is negotiated with
+// TLS servers supporting it, otherwise HTTP/1.1 is always used. Server push is disabled in any case, the HTTP/2
+// client never accepts pushed streams. This option can't be combined with WithHTTPClient.
+func WithHTTP2(enabled bool) Option {
+	return func(opts *Client) {
+		opts.http2 = &enabled
+	}
+}
+
+// WithBodyTransformer transforms the fetched did configuration before it's parsed as JSON, e.g. to unwrap
+// a configuration served in an envelope like {"data": {...}} or to convert a YAML configuration.
+// The transformer receives the body and the headers of the response. The configuration hash pinned by
+// WithExpectedConfigHash is checked against the body before the transformation.
+func WithBodyTransformer(fn func([]byte, http.Header) ([]byte, error)) Option {
+	return func(opts *Client) {
+		opts.bodyTransformer = fn
+	}
+}
+
+// WithMaxResponseBytes limits the size of the did configuration response body read from the domain, so that
+// a malicious or misconfigured domain can't exhaust the memory. A larger response is rejected with
+// ErrResponseTooLarge. The default limit is 1 MiB, a non-positive n keeps the default.
+func WithMaxResponseBytes(n int64) Option {
+	return func(opts *Client) {
+		if n > 0 {
+			opts.maxResponseBytes = n
+		}
+	}
+}
+
+// WithRequireHTTPS requires the did configuration to be fetched over HTTPS: a domain with another scheme
+// is rejected with ErrHTTPSRequired before the request, as well as a redirect to plain HTTP. The default HTTP
+// client refuses such a redirect before following it, while with a custom HTTP client (WithHTTPClient) only
+// the final response can be rejected, after the redirects were followed.
+func WithRequireHTTPS(required bool) Option {
+	return func(opts *Client) {
+		opts.requireHTTPS = required
+	}
+}
+
+// WithSameHostRedirects rejects the did configuration request with ErrRedirectNotAllowed if it is redirected to
+// a host other than the one of the domain. The default HTTP client refuses such a redirect before following it,
+// so that no request is sent to the other host. With a custom HTTP client (WithHTTPClient) the redirects are
+// followed by the client and only the final response is rejected, i.e. the other hosts still receive requests;
+// such a client should set its own redirect policy (e.g. http.Client.CheckRedirect).
+func WithSameHostRedirects(enabled bool) Option {
+	return func(opts *Client) {
+		opts.sameHostRedirect = enabled
+	}
+}
+
+// WithResponseInspector calls fn with the response of each did configuration request, before its body is read,
+// e.g. to log the status, Content-Type and caching headers returned by a misconfigured server. The response
+// is passed for inspection only: fn gets a copy of the response without body, and it's called for any status.
+func WithResponseInspector(fn func(*http.Response)) Option {
+	return func(opts *Client) {
+		opts.inspectResponse = fn
+	}
+}
+
+// WithVerificationCache enables memoization of successful verifications keyed by did, domain, DID resolver and
+// options, and the SHA-256 hash of the fetched did configuration. The did configuration is still fetched on every
+// call, but the verification is skipped if the body is byte-identical to the previously verified one.
+//
+// A verification is reused for at most ttl, and not beyond the expirationDate of the matched domain linkage
+// credential, so that a rotated key or a deactivated DID is eventually detected. The ttl must be positive.
+func WithVerificationCache(ttl time.Duration) Option {
+	return func(opts *Client) {
+		if ttl <= 0 {
+			opts.err = fmt.Errorf("invalid verification cache TTL %s", ttl)
+
+			return
+		}
+
+		opts.verified = &verificationCache{
+			ttl:     ttl,
+			now:     time.Now,
+			entries: map[verificationKey]*verificationEntry{},
+		}
+	}
+}
+
+// WithExpectedConfigHash pins the SHA-256 hash (hex encoded) of the did configuration served by the domain.
+// The fetched did configuration of a pinned domain is rejected before parsing if the hash of the response body
+// doesn't match. Domains are matched by their normalized origin (see didconfig.NormalizeOrigin), so e.g.
+// "https://Example.com/" pins "https://example.com". The option can be used several times to pin multiple domains.
+func WithExpectedConfigHash(domain, sha256Hex string) Option {
+	return func(opts *Client) {
+		hash, err := hex.DecodeString(sha256Hex)
+		if err != nil || len(hash) != sha256.Size {
+			opts.err = fmt.Errorf("invalid SHA-256 hash '%s' for domain %s", sha256Hex, domain)
+
+			return
+		}
+
+		origin, err := didconfig.NormalizeOrigin(domain)
+		if err != nil {
+			opts.err = fmt.Errorf("invalid domain %s of SHA-256 hash: %w", domain, err)
+
+			return
+		}
+
+		if opts.expectedHashes == nil {
+			opts.expectedHashes = map[string]string{}
+		}
+
+		opts.expectedHashes[origin] = strings.ToLower(sha256Hex)
+	}
+}
+
+// expectedHash returns the pinned SHA-256 hash of the did configuration of the domain.
+func (c *Client) expectedHash(domain string) (string, bool) {
+	if len(c.expectedHashes) == 0 {
+		return "", false
+	}
+
+	origin, err := didconfig.NormalizeOrigin(domain)
+	if err != nil {
+		return "", false
+	}
+
+	expected, ok := c.expectedHashes[origin]
+
+	return expected, ok
+}
+
+// VerifyFunc verifies domain linkage of the did and domain.
+type VerifyFunc func(did, domain string) error
+
+// Middleware wraps the next VerifyFunc of the verification chain to add behaviour around it,
+// e.g. caching, logging, metrics or policy checks. A middleware may skip calling next to short-circuit
+// the verification.
+type Middleware func(next VerifyFunc) VerifyFunc
+
+// WithMiddleware adds middleware around the core verification (fetching and verifying did configuration)
+// made by VerifyDIDAndDomain and PreparedVerifier.VerifyDomain. The first middleware is the outermost one,
+// i.e. it is called first. The option can be used several times, the middleware is appended.
+func WithMiddleware(mw ...Middleware) Option {
+	return func(opts *Client) {
+		opts.middleware = append(opts.middleware, mw...)
+	}
+}
+
+// WithWellKnownPath overrides the path of the did configuration resource (by default
+// /.well-known/did-configuration.json), e.g. for a gateway serving it with a path prefix.
+// An empty path falls back to the default.
+func WithWellKnownPath(path string) Option {
+	return func(opts *Client) {
+		opts.wellKnownPath = path
+	}
+}
+
+// WithFallbackPaths sets the paths tried in order if the did configuration is not found (404) at the well-known
+// path, e.g. /did-configuration.json for a misconfigured server. Other errors don't fall back.
+func WithFallbackPaths(paths ...string) Option {
+	return func(opts *Client) {
+		opts.fallbackPaths = paths
 	}
 }
 
@@ -67,6 +466,80 @@ func WithJSONLDDocumentLoader(documentLoader jsonld.DocumentLoader) Option {
 	}
 }
 
+// WithAllowedConfigContexts defines the allowed top-level @context values of the fetched did configuration,
+// e.g. only didconfig.ContextV1 to reject the deprecated v0 form. By default both v0 and v1 are allowed.
+func WithAllowedConfigContexts(urls ...string) Option {
+	return func(opts *Client) {
+		opts.didConfigOpts = append(opts.didConfigOpts, didconfig.WithAllowedContexts(urls...))
+	}
+}
+
+// WithRequireAllMatching requires every domain linkage credential of the did configuration which targets
+// the DID and domain to have a valid proof. By default, the linkage is verified by any valid credential.
+func WithRequireAllMatching() Option {
+	return func(opts *Client) {
+		opts.didConfigOpts = append(opts.didConfigOpts, didconfig.WithRequireAllMatching())
+	}
+}
+
+// WithRequireSelfIssued requires the domain linkage credentials for the DID to be self-issued
+// (issuer equal to the subject id) and fails the verification on a credential issued by another DID.
+func WithRequireSelfIssued() Option {
+	return func(opts *Client) {
+		opts.didConfigOpts = append(opts.didConfigOpts, didconfig.WithRequireSelfIssued())
+	}
+}
+
+// WithProofVerifier delegates the signature check of the domain linkage credential proofs to the verifier,
+// e.g. a remote KMS or HSM.
+func WithProofVerifier(v verifiable.ProofVerifier) Option {
+	return func(opts *Client) {
+		opts.didConfigOpts = append(opts.didConfigOpts, didconfig.WithProofVerifier(v))
+	}
+}
+
+// WithBindVerificationMethodToIssuer requires the verification method of the domain linkage credential proof
+// to belong to the issuer DID, a proof made with a key of another DID fails the verification.
+func WithBindVerificationMethodToIssuer() Option {
+	return func(opts *Client) {
+		opts.didConfigOpts = append(opts.didConfigOpts, didconfig.WithBindVerificationMethodToIssuer())
+	}
+}
+
+// WithAllowedProofPurposes sets the verification relationships under which the verification method of the domain
+// linkage credential proof must be authorized in the issuer DID document (did.AssertionMethod by default),
+// e.g. did.AssertionMethod and did.CapabilityDelegation to accept credentials issued by a delegated key.
+func WithAllowedProofPurposes(purposes ...did.VerificationRelationship) Option {
+	return func(opts *Client) {
+		opts.didConfigOpts = append(opts.didConfigOpts, didconfig.WithAllowedProofPurposes(purposes...))
+	}
+}
+
+// WithAllowedAlgorithms restricts the algorithms of the domain linkage credential proofs to the allowlist:
+// the JWS algorithm of a JWT credential (e.g. EdDSA) or the type of a linked data proof
+// (e.g. Ed25519Signature2018). Calling it without algorithms allows every supported algorithm, as by default.
+func WithAllowedAlgorithms(algs ...string) Option {
+	return func(opts *Client) {
+		opts.didConfigOpts = append(opts.didConfigOpts, didconfig.WithAllowedAlgorithms(algs...))
+	}
+}
+
+// WithMaxLinkedDIDs rejects a did configuration with more than n linked_dids entries with
+// didconfig.ErrTooManyLinkedDIDs. A non-positive n doesn't limit the entries, as by default.
+func WithMaxLinkedDIDs(n int) Option {
+	return func(opts *Client) {
+		opts.didConfigOpts = append(opts.didConfigOpts, didconfig.WithMaxLinkedDIDs(n))
+	}
+}
+
+// WithVerificationTimeout bounds the cryptographic verification of the proof of each domain linkage credential,
+// independently of the HTTP timeouts.
+func WithVerificationTimeout(d time.Duration) Option {
+	return func(opts *Client) {
+		opts.didConfigOpts = append(opts.didConfigOpts, didconfig.WithVerificationTimeout(d))
+	}
+}
+
 type didResolver interface {
 	Resolve(did string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error)
 }
@@ -74,6 +547,7 @@ type didResolver interface {
 // WithVDRegistry defines a vdr service.
 func WithVDRegistry(didResolver didResolver) Option {
 	return func(opts *Client) {
+		opts.didResolver = didResolver
 		opts.didConfigOpts = append(opts.didConfigOpts, didconfig.WithVDRegistry(didResolver))
 	}
 }
@@ -81,31 +555,444 @@ func WithVDRegistry(didResolver didResolver) Option {
 // VerifyDIDAndDomain will verify that there is valid domain linkage credential in did configuration
 // for specified did and domain.
 func (c *Client) VerifyDIDAndDomain(did, domain string) error {
-	endpoint := domain + "/.well-known/did-configuration.json"
+	return c.VerifyDIDAndDomainContext(context.Background(), did, domain)
+}
 
-	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, endpoint, nil)
+// VerifyDIDAndDomainContext is like VerifyDIDAndDomain, the context bounds fetching the did configuration.
+// DID resolution is not cancellable: once the context is done no DID is resolved anymore, but a resolution
+// in progress isn't interrupted. If the context is done, the returned error wraps ctx.Err() as well as
+// the verification error.
+func (c *Client) VerifyDIDAndDomainContext(ctx context.Context, did, domain string) error {
+	_, err := c.verifyDIDAndDomainWithResult(ctx, did, domain)
+
+	return err
+}
+
+// VerifyDIDAndDomainDocument is like VerifyDIDAndDomain for a did configuration delivered out-of-band
+// (e.g. pinned in the configuration or fetched through a proxy), the client doesn't fetch it from the domain.
+// The did configuration is parsed and verified like a fetched one. The options applied to the HTTP response
+// (e.g. WithExpectedConfigHash and WithBodyTransformer) and the middleware are not used.
+func (c *Client) VerifyDIDAndDomainDocument(did, domain string, config []byte) error {
+	if c.err != nil {
+		return c.err
+	}
+
+	_, err := c.verifyDocument(context.Background(), did, domain, config, c.resolver(), c.didConfigOpts)
+
+	return err
+}
+
+// VerificationResult describes the domain linkage credential which satisfied the verification.
+type VerificationResult = didconfig.VerificationResult
+
+// VerifyDIDAndDomainWithResult is like VerifyDIDAndDomain and returns which domain linkage credential
+// satisfied the verification, e.g. to record the verification method which was used.
+// If a middleware short-circuits the verification without error, the result is nil.
+func (c *Client) VerifyDIDAndDomainWithResult(did, domain string) (*VerificationResult, error) {
+	return c.verifyDIDAndDomainWithResult(context.Background(), did, domain)
+}
+
+func (c *Client) verifyDIDAndDomainWithResult(ctx context.Context, did, domain string) (*VerificationResult, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	var result *VerificationResult
+
+	err := c.chain(func(did, domain string) error {
+		var err error
+
+		result, err = c.verifyDIDAndDomain(ctx, did, domain, c.resolver(), c.didConfigOpts)
+
+		return err
+	})(did, domain)
 	if err != nil {
-		return fmt.Errorf("new HTTP request: %w", err)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// resolver returns the DID resolver of the client.
+func (c *Client) resolver() didResolver {
+	resolver := c.didResolver
+	if resolver == nil {
+		resolver = vdr.New(vdr.WithVDR(key.New()))
+	}
+
+	if c.resolutionCache != nil {
+		return &cachingResolver{resolver: resolver, cache: c.resolutionCache}
+	}
+
+	return resolver
+}
+
+// chain wraps the core verification with the middleware of the client.
+func (c *Client) chain(verify VerifyFunc) VerifyFunc {
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		verify = c.middleware[i](verify)
+	}
+
+	return verify
+}
+
+// PreparedVerifier verifies domain linkage for a DID whose document has already been resolved.
+type PreparedVerifier struct {
+	client        *Client
+	id            uint64
+	did           string
+	docResolution *did.DocResolution
+}
+
+// PrepareVerification resolves the DID document once. The returned PreparedVerifier can be used to verify
+// any number of domains against the resolved document without resolving the DID again.
+func (c *Client) PrepareVerification(didID string) (*PreparedVerifier, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	docResolution, err := c.resolver().Resolve(didID)
+	if err != nil {
+		return nil, fmt.Errorf("resolve DID %s: %w", didID, err)
+	}
+
+	return &PreparedVerifier{
+		client:        c,
+		id:            c.preparedCount.Add(1),
+		did:           didID,
+		docResolution: docResolution,
+	}, nil
+}
+
+// DocResolution returns the resolved DID document.
+func (p *PreparedVerifier) DocResolution() *did.DocResolution {
+	return p.docResolution
+}
+
+// VerifyDomain will verify that there is valid domain linkage credential in did configuration
+// for the prepared did and specified domain.
+func (p *PreparedVerifier) VerifyDomain(domain string) error {
+	_, err := p.verifyDomain(domain)
+
+	return err
+}
+
+func (p *PreparedVerifier) verifyDomain(domain string) (*VerificationResult, error) {
+	resolver := &preparedResolver{id: p.id, did: p.did, docResolution: p.docResolution}
+
+	var result *VerificationResult
+
+	err := p.client.chain(func(did, domain string) error {
+		var err error
+
+		result, err = p.client.verifyDIDAndDomain(context.Background(), did, domain, resolver, p.client.didConfigOpts)
+
+		return err
+	})(p.did, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// preparedResolver resolves only the prepared DID to its already resolved document.
+type preparedResolver struct {
+	id            uint64
+	did           string
+	docResolution *did.DocResolution
+}
+
+func (r *preparedResolver) Resolve(didID string, _ ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+	if didID != r.did {
+		return nil, fmt.Errorf("DID %s is not prepared for verification: %w", didID, vdrapi.ErrNotFound)
+	}
+
+	return r.docResolution, nil
+}
+
+// Diagnosis is a report of all did configuration verification steps.
+type Diagnosis = didconfig.Diagnosis
+
+// DiagnoseDIDAndDomain attempts every step of the did configuration verification for specified did and domain
+// (fetch, parse, and subject, type, validity window and proof of each linked credential) and reports
+// all problems found instead of stopping at the first one. Failed checks are reported in the diagnosis,
+// an error is returned only if the client is misconfigured.
+func (c *Client) DiagnoseDIDAndDomain(did, domain string) (*Diagnosis, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	responseBytes, err := c.fetchDIDConfiguration(context.Background(), domain)
+	if err != nil {
+		return &Diagnosis{
+			DID:    did,
+			Domain: domain,
+			Checks: []didconfig.CheckResult{{Name: didconfig.CheckFetch, Err: err}},
+		}, nil
+	}
+
+	diagnosis := didconfig.Diagnose(responseBytes, did, domain, c.didConfigOpts...)
+	diagnosis.Checks = append([]didconfig.CheckResult{{Name: didconfig.CheckFetch}}, diagnosis.Checks...)
+
+	return diagnosis, nil
+}
+
+// fetchDIDConfiguration fetches the did configuration from the well-known path of the domain, or from the fallback
+// paths in order if it's not found. The error of the well-known path is returned if the fallbacks are not found either.
+func (c *Client) fetchDIDConfiguration(ctx context.Context, domain string) ([]byte, error) {
+	responseBytes, err := c.fetchEndpoint(ctx, domain, c.endpoint(domain))
+	if !isNotFound(err) {
+		return responseBytes, err
+	}
+
+	for _, path := range c.fallbackPaths {
+		fallbackBytes, fallbackErr := c.fetchEndpoint(ctx, domain, endpointURL(domain, path))
+		if !isNotFound(fallbackErr) {
+			return fallbackBytes, fallbackErr
+		}
+	}
+
+	return nil, err
+}
+
+func isNotFound(err error) bool {
+	var statusErr *ErrStatusCode
+
+	return errors.As(err, &statusErr) && statusErr.Code == http.StatusNotFound
+}
+
+func (c *Client) fetchEndpoint(ctx context.Context, domain, endpoint string) ([]byte, error) {
+	ctx, releaseConn := c.stats.withTrace(ctx)
+	defer releaseConn()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("new HTTP request: %w", err)
+	}
+
+	if c.requireHTTPS && req.URL.Scheme != "https" {
+		return nil, fmt.Errorf("%w: endpoint %s", ErrHTTPSRequired, endpoint)
 	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("httpClient.Do: %w", err)
+		return nil, fmt.Errorf("httpClient.Do: %w", err)
 	}
 
 	defer closeResponseBody(resp.Body)
 
-	responseBytes, err := ioutil.ReadAll(resp.Body)
+	// the default HTTP client refuses the redirects with checkRedirectRequest before following them
+	if c.customHTTPClient {
+		if err = c.checkRedirect(req, resp); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.inspectResponse != nil {
+		inspected := *resp
+		inspected.Header = resp.Header.Clone()
+		inspected.Body = http.NoBody
+
+		c.inspectResponse(&inspected)
+	}
+
+	// read one byte more than the limit to detect a response exceeding it
+	responseBytes, err := ioutil.ReadAll(io.LimitReader(resp.Body, c.maxResponseBytes+1))
 	if err != nil {
-		return fmt.Errorf("failed to read response: %w", err)
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if int64(len(responseBytes)) > c.maxResponseBytes {
+		return nil, fmt.Errorf("%w: response of endpoint %s exceeds %d bytes",
+			ErrResponseTooLarge, endpoint, c.maxResponseBytes)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("endpoint %s returned status '%d' and message '%s'",
-			endpoint, resp.StatusCode, responseBytes)
+		return nil, &ErrStatusCode{Endpoint: endpoint, Code: resp.StatusCode, Message: string(responseBytes)}
+	}
+
+	if expected, ok := c.expectedHash(domain); ok {
+		hash := sha256.Sum256(responseBytes)
+
+		if actual := hex.EncodeToString(hash[:]); actual != expected {
+			return nil, fmt.Errorf("did configuration of domain %s has SHA-256 hash %s, expected %s",
+				domain, actual, expected)
+		}
+	}
+
+	// some servers prepend a byte order mark or whitespace to the JSON
+	responseBytes = jsonutil.TrimBOM(responseBytes)
+
+	if c.bodyTransformer != nil {
+		responseBytes, err = c.bodyTransformer(responseBytes, resp.Header)
+		if err != nil {
+			return nil, fmt.Errorf("transform response of endpoint %s: %w", endpoint, err)
+		}
+	}
+
+	if !json.Valid(responseBytes) {
+		return nil, fmt.Errorf("endpoint %s returned invalid JSON", endpoint)
+	}
+
+	return responseBytes, nil
+}
+
+// checkRedirectRequest is the redirect policy of the default HTTP client, it refuses a redirect before
+// it's followed.
+func (c *Client) checkRedirectRequest(req *http.Request, via []*http.Request) error {
+	if len(via) == 0 {
+		return nil
+	}
+
+	first := via[0].URL
+
+	if len(via) >= maxRedirects {
+		return fmt.Errorf("%w: endpoint %s redirected more than %d times", ErrRedirectNotAllowed, first, maxRedirects)
+	}
+
+	if c.requireHTTPS && req.URL.Scheme != "https" {
+		return fmt.Errorf("%w: endpoint %s redirected to %s", ErrHTTPSRequired, first, req.URL)
+	}
+
+	if c.sameHostRedirect && !strings.EqualFold(req.URL.Host, first.Host) {
+		return fmt.Errorf("%w: endpoint %s redirected to %s", ErrRedirectNotAllowed, first, req.URL)
+	}
+
+	return nil
+}
+
+// checkRedirect checks the final URL of the response of a custom HTTP client if the request was redirected.
+func (c *Client) checkRedirect(req *http.Request, resp *http.Response) error {
+	if resp.Request == nil || resp.Request.URL == nil {
+		return nil
+	}
+
+	finalURL := resp.Request.URL
+
+	if c.requireHTTPS && finalURL.Scheme != "https" {
+		return fmt.Errorf("%w: endpoint %s redirected to %s", ErrHTTPSRequired, req.URL, finalURL)
+	}
+
+	if c.sameHostRedirect && !strings.EqualFold(finalURL.Host, req.URL.Host) {
+		return fmt.Errorf("%w: endpoint %s redirected to %s", ErrRedirectNotAllowed, req.URL, finalURL)
+	}
+
+	return nil
+}
+
+// endpoint returns the URL of the did configuration resource of the domain.
+func (c *Client) endpoint(domain string) string {
+	path := c.wellKnownPath
+	if path == "" {
+		path = defaultWellKnownPath
+	}
+
+	return endpointURL(domain, path)
+}
+
+func endpointURL(domain, path string) string {
+	return strings.TrimRight(domain, "/") + "/" + strings.TrimLeft(path, "/")
+}
+
+func (c *Client) verifyDIDAndDomain(ctx context.Context, did, domain string, resolver didResolver,
+	opts []didconfig.DIDConfigurationOpt) (*VerificationResult, error) {
+	result, err := c.verifyDIDAndDomainWithResolver(ctx, did, domain, resolver, opts)
+	if err != nil && ctx.Err() != nil && !errors.Is(err, ctx.Err()) {
+		// the DID resolution error may be reported by didconfig only as a missing valid proof
+		return nil, &contextError{err: err, ctxErr: ctx.Err()}
+	}
+
+	return result, err
+}
+
+// contextError is the verification error of a done context. It matches both the verification error
+// and the context error with errors.Is and errors.As.
+type contextError struct {
+	err    error
+	ctxErr error
+}
+
+func (e *contextError) Error() string {
+	return e.err.Error() + ": " + e.ctxErr.Error()
+}
+
+func (e *contextError) Unwrap() error {
+	return e.err
+}
+
+func (e *contextError) Is(target error) bool {
+	return errors.Is(e.ctxErr, target)
+}
+
+func (e *contextError) As(target interface{}) bool {
+	return errors.As(e.ctxErr, target)
+}
+
+func (c *Client) verifyDIDAndDomainWithResolver(ctx context.Context, did, domain string, resolver didResolver,
+	opts []didconfig.DIDConfigurationOpt) (*VerificationResult, error) {
+	responseBytes, err := c.fetchDIDConfiguration(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.verifyDocument(ctx, did, domain, responseBytes, resolver, opts)
+}
+
+// verifyDocument verifies the domain linkage of the did and domain by the did configuration.
+func (c *Client) verifyDocument(ctx context.Context, did, domain string, responseBytes []byte, resolver didResolver,
+	opts []didconfig.DIDConfigurationOpt) (*VerificationResult, error) {
+	cacheKey := verificationKey{did: did, domain: domain, options: optionsFingerprint(resolver, opts)}
+
+	opts = append(append([]didconfig.DIDConfigurationOpt{}, opts...),
+		didconfig.WithVDRegistry(&contextResolver{ctx: ctx, resolver: resolver}))
+
+	if c.verified == nil {
+		return didconfig.VerifyDIDAndDomainWithResult(responseBytes, did, domain, opts...)
+	}
+
+	hash := sha256.Sum256(responseBytes)
+
+	if result, ok := c.verified.isVerified(cacheKey, hash); ok {
+		return result, nil
+	}
+
+	result, err := didconfig.VerifyDIDAndDomainWithResult(responseBytes, did, domain, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	c.verified.setVerified(cacheKey, hash, result)
+
+	return result, nil
+}
+
+// optionsFingerprint identifies the DID resolver and the didconfig options of a verification, so that a cached
+// verification is reused only with the same resolver and options. The resolver of the client (possibly wrapped
+// per call or per batch) is the same for every verification, each prepared verifier has its own resolved document.
+func optionsFingerprint(resolver didResolver, opts []didconfig.DIDConfigurationOpt) string {
+	resolverID := "client"
+	if r, ok := resolver.(*preparedResolver); ok {
+		resolverID = fmt.Sprintf("prepared/%d", r.id)
+	}
+
+	// the options are not comparable, the slice identifies them as it is never modified
+	return fmt.Sprintf("%s;%p/%d", resolverID, opts, len(opts))
+}
+
+// contextResolver doesn't resolve DIDs once the context is done. The context is not passed to the DID resolver,
+// a resolution which has already started is not interrupted.
+type contextResolver struct {
+	ctx      context.Context //nolint:containedctx
+	resolver didResolver
+}
+
+func (r *contextResolver) Resolve(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+	if err := r.ctx.Err(); err != nil {
+		return nil, fmt.Errorf("resolve DID %s: %w", didID, err)
 	}
 
-	return didconfig.VerifyDIDAndDomain(responseBytes, did, domain, c.didConfigOpts...)
+	return r.resolver.Resolve(didID, opts...)
 }
 
 func closeResponseBody(respBody io.Closer) {
@@ -8,21 +8,45 @@ package didconfig
 
 import (
 	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/btcsuite/btcutil/base58"
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/didconfig"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/ldcontext"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/ldtestutil"
+	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/httpbinding"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/key"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/web"
 )
 
 const (
@@ -93,59 +117,2062 @@ func TestVerifyDIDAndDomain(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("success - did configuration with BOM and leading whitespace", func(t *testing.T) {
+		httpClient := &mockHTTPClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewReader([]byte("\xEF\xBB\xBF \r\n" + didCfg + "\n"))),
+				}, nil
+			},
+		}
+
+		c := New(WithJSONLDDocumentLoader(loader), WithHTTPClient(httpClient))
+
+		err := c.VerifyDIDAndDomain(testDID, testDomain)
+		require.NoError(t, err)
+	})
+
+	t.Run("error - verification timeout", func(t *testing.T) {
+		httpClient := &mockHTTPClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewReader([]byte(didCfg))),
+				}, nil
+			},
+		}
+
+		c := New(WithJSONLDDocumentLoader(loader), WithHTTPClient(httpClient), WithVerificationTimeout(time.Nanosecond))
+
+		err := c.VerifyDIDAndDomain(testDID, testDomain)
+		require.EqualError(t, err, "domain linkage credential(s) with valid proof not found")
+
+		c = New(WithJSONLDDocumentLoader(loader), WithHTTPClient(httpClient), WithVerificationTimeout(time.Minute))
+
+		require.NoError(t, c.VerifyDIDAndDomain(testDID, testDomain))
+	})
+
+	t.Run("error - did configuration is not valid JSON", func(t *testing.T) {
+		httpClient := &mockHTTPClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewReader([]byte("\xEF\xBB\xBF<html></html>"))),
+				}, nil
+			},
+		}
+
+		c := New(WithJSONLDDocumentLoader(loader), WithHTTPClient(httpClient))
+
+		err := c.VerifyDIDAndDomain(testDID, testDomain)
+		require.EqualError(t, err,
+			"endpoint https://identity.foundation/.well-known/did-configuration.json returned invalid JSON")
+	})
+
 	t.Run("error - http client error", func(t *testing.T) {
 		c := New(WithJSONLDDocumentLoader(loader))
 
-		err := c.VerifyDIDAndDomain(testDID, "https://non-existent-abc.com")
+		err := c.VerifyDIDAndDomain(testDID, "https://non-existent-abc.com")
+		require.Error(t, err)
+		require.Contains(t, err.Error(),
+			"Get \"https://non-existent-abc.com/.well-known/did-configuration.json\": dial tcp: "+
+				"lookup non-existent-abc.com: no such host")
+	})
+
+	t.Run("error - http request error", func(t *testing.T) {
+		c := New(WithJSONLDDocumentLoader(loader))
+
+		err := c.VerifyDIDAndDomain(testDID, ":invalid.com")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "missing protocol scheme")
+	})
+
+	t.Run("error - http status error", func(t *testing.T) {
+		httpClient := &mockHTTPClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusNotFound,
+					Body:       io.NopCloser(bytes.NewReader([]byte("data not found"))),
+				}, nil
+			},
+		}
+
+		c := New(WithJSONLDDocumentLoader(loader), WithHTTPClient(httpClient))
+
+		err := c.VerifyDIDAndDomain(testDID, testDomain)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "endpoint https://identity.foundation/.well-known/did-configuration.json "+
+			"returned status '404' and message 'data not found'")
+
+		var statusErr *ErrStatusCode
+
+		require.ErrorAs(t, err, &statusErr)
+		require.Equal(t, http.StatusNotFound, statusErr.Code)
+	})
+
+	t.Run("error - did configuration missing linked DIDs", func(t *testing.T) {
+		httpClient := &mockHTTPClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewReader([]byte(didCfgNoLinkedDIDs))),
+				}, nil
+			},
+		}
+
+		c := New(WithJSONLDDocumentLoader(loader),
+			WithVDRegistry(vdr.New(vdr.WithVDR(key.New()))),
+			WithHTTPClient(httpClient))
+
+		err := c.VerifyDIDAndDomain(testDID, testDomain)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "did configuration: property 'linked_dids' is required ")
+		require.ErrorIs(t, err, didconfig.ErrMissingLinkedDIDs)
+	})
+}
+
+func TestVerifyDIDAndDomain_DIDWeb(t *testing.T) {
+	loader, err := ldtestutil.DocumentLoader(ldcontext.Document{
+		URL:     contextV1,
+		Content: json.RawMessage(didCfgCtxV1),
+	})
+	require.NoError(t, err)
+
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	signer := &ed25519TestSigner{privKey: privKey}
+
+	var didDoc, didCfgBytes []byte
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/.well-known/did.json":
+			_, _ = w.Write(didDoc) //nolint:errcheck
+		case defaultWellKnownPath:
+			_, _ = w.Write(didCfgBytes) //nolint:errcheck
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	domain := server.URL
+	didWeb := "did:web:" + url.QueryEscape(strings.TrimPrefix(server.URL, "https://"))
+	// did:web verification method IDs are absolute DID URLs
+	keyID := didWeb + "#key-1"
+
+	newDIDDoc := func(vmID string) []byte {
+		return []byte(fmt.Sprintf(`{
+  "@context": ["https://www.w3.org/ns/did/v1"],
+  "id": "%[1]s",
+  "verificationMethod": [{
+    "id": "%[2]s",
+    "type": "Ed25519VerificationKey2018",
+    "controller": "%[1]s",
+    "publicKeyBase58": "%[3]s"
+  }],
+  "assertionMethod": ["%[2]s"]
+}`, didWeb, vmID, base58.Encode(pubKey)))
+	}
+
+	newDLC := func() *verifiable.Credential {
+		return &verifiable.Credential{
+			Context: []string{verifiable.ContextURI, contextV1},
+			Types:   []string{verifiable.VCType, "DomainLinkageCredential"},
+			Issuer:  verifiable.Issuer{ID: didWeb},
+			Issued:  util.NewTime(time.Now().Truncate(time.Second)),
+			Expired: util.NewTime(time.Now().Add(time.Hour).Truncate(time.Second)),
+			Subject: []verifiable.Subject{{ID: didWeb, CustomFields: map[string]interface{}{"origin": domain}}},
+		}
+	}
+
+	ldVC := newDLC()
+
+	err = ldVC.AddLinkedDataProof(&verifiable.LinkedDataProofContext{
+		SignatureType:           "Ed25519Signature2018",
+		Suite:                   ed25519signature2018.New(suite.WithSigner(signer)),
+		SignatureRepresentation: verifiable.SignatureJWS,
+		VerificationMethod:      keyID,
+	}, jsonld.WithDocumentLoader(loader))
+	require.NoError(t, err)
+
+	jwtClaims, err := newDLC().JWTClaims(false)
+	require.NoError(t, err)
+
+	jwtVC, err := jwtClaims.MarshalJWS(verifiable.EdDSA, signer, keyID)
+	require.NoError(t, err)
+
+	// the did:web VDR fetches the DID document from the test server
+	registry := &optionsResolver{
+		resolver: vdr.New(vdr.WithVDR(web.New())),
+		opts:     []vdrapi.DIDMethodOption{vdrapi.WithOption(web.HTTPClientOpt, server.Client())},
+	}
+
+	for name, linkedDID := range map[string]interface{}{"linked data": ldVC, "JWT": jwtVC} {
+		didCfg, err := json.Marshal(map[string]interface{}{
+			"@context":    contextV1,
+			"linked_dids": []interface{}{linkedDID},
+		})
+		require.NoError(t, err)
+
+		for form, vmID := range map[string]string{"absolute": keyID, "relative": "#key-1"} {
+			t.Run(fmt.Sprintf("success - %s domain linkage credential, %s verification method ID", name, form),
+				func(t *testing.T) {
+					didDoc, didCfgBytes = newDIDDoc(vmID), didCfg
+
+					c := New(WithJSONLDDocumentLoader(loader), WithVDRegistry(registry),
+						WithHTTPClient(server.Client()), WithBindVerificationMethodToIssuer())
+
+					result, err := c.VerifyDIDAndDomainWithResult(didWeb, domain)
+					require.NoError(t, err)
+					require.Equal(t, didWeb, result.Issuer)
+					require.Equal(t, keyID, result.VerificationMethod)
+				})
+		}
+	}
+
+	t.Run("error - unknown key", func(t *testing.T) {
+		didDoc = newDIDDoc(didWeb + "#key-2")
+
+		c := New(WithJSONLDDocumentLoader(loader), WithVDRegistry(registry), WithHTTPClient(server.Client()))
+
+		err := c.VerifyDIDAndDomain(didWeb, domain)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "domain linkage credential(s) with valid proof not found")
+	})
+}
+
+// optionsResolver resolves DIDs with the given DID method options, e.g. an HTTP client of did:web VDR.
+type optionsResolver struct {
+	resolver didResolver
+	opts     []vdrapi.DIDMethodOption
+}
+
+func (r *optionsResolver) Resolve(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+	return r.resolver.Resolve(didID, append(r.opts, opts...)...)
+}
+
+type ed25519TestSigner struct {
+	privKey ed25519.PrivateKey
+}
+
+func (s *ed25519TestSigner) Sign(data []byte) ([]byte, error) {
+	return ed25519.Sign(s.privKey, data), nil
+}
+
+func (s *ed25519TestSigner) Alg() string {
+	return "EdDSA"
+}
+
+func TestPrepareVerification(t *testing.T) {
+	loader, err := ldtestutil.DocumentLoader(ldcontext.Document{
+		URL:     contextV1,
+		Content: json.RawMessage(didCfgCtxV1),
+	})
+	require.NoError(t, err)
+
+	httpClient := &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewReader([]byte(didCfg))),
+			}, nil
+		},
+	}
+
+	t.Run("success - one prepared verifier for two domains", func(t *testing.T) {
+		resolver := &countingResolver{resolver: vdr.New(vdr.WithVDR(key.New()))}
+
+		c := New(WithJSONLDDocumentLoader(loader), WithVDRegistry(resolver), WithHTTPClient(httpClient))
+
+		verifier, err := c.PrepareVerification(testDID)
+		require.NoError(t, err)
+		require.Equal(t, testDID, verifier.DocResolution().DIDDocument.ID)
+
+		err = verifier.VerifyDomain(testDomain)
+		require.NoError(t, err)
+
+		// did configuration served by the second domain links the DID to the first domain only
+		err = verifier.VerifyDomain("https://example.com")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "domain linkage credential(s) not found")
+
+		require.Equal(t, 1, resolver.count)
+	})
+
+	t.Run("success - default resolver", func(t *testing.T) {
+		c := New(WithJSONLDDocumentLoader(loader), WithHTTPClient(httpClient))
+
+		verifier, err := c.PrepareVerification(testDID)
+		require.NoError(t, err)
+
+		err = verifier.VerifyDomain(testDomain)
+		require.NoError(t, err)
+	})
+
+	t.Run("error - DID resolution", func(t *testing.T) {
+		c := New(WithJSONLDDocumentLoader(loader), WithHTTPClient(httpClient))
+
+		verifier, err := c.PrepareVerification("did:web:example.com")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "resolve DID did:web:example.com")
+		require.Nil(t, verifier)
+	})
+
+	t.Run("error - invalid client options", func(t *testing.T) {
+		c := New(WithHTTPClient(httpClient), WithTimeouts(time.Second, 0, 0, 0))
+
+		verifier, err := c.PrepareVerification(testDID)
+		require.EqualError(t, err, "timeouts can't be set for a custom HTTP client")
+		require.Nil(t, verifier)
+	})
+
+	t.Run("error - DID is not prepared", func(t *testing.T) {
+		r := &preparedResolver{did: testDID}
+
+		docResolution, err := r.Resolve("did:example:123")
+		require.ErrorIs(t, err, vdrapi.ErrNotFound)
+		require.Nil(t, docResolution)
+	})
+}
+
+func TestVerifyLinkedDomains(t *testing.T) {
+	loader, err := ldtestutil.DocumentLoader(ldcontext.Document{
+		URL:     contextV1,
+		Content: json.RawMessage(didCfgCtxV1),
+	})
+	require.NoError(t, err)
+
+	httpClient := &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewReader([]byte(didCfg))),
+			}, nil
+		},
+	}
+
+	// newResolver resolves the did:key DID to a document with the services
+	newResolver := func(services ...did.Service) *countingResolver {
+		keyVDR := vdr.New(vdr.WithVDR(key.New()))
+
+		return &countingResolver{resolver: &mockvdr.MockVDRegistry{
+			ResolveFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+				docResolution, err := keyVDR.Resolve(didID, opts...)
+				if err != nil {
+					return nil, err
+				}
+
+				docResolution.DIDDocument.Service = services
+
+				return docResolution, nil
+			},
+		}}
+	}
+
+	t.Run("success - origins array and plain string endpoints", func(t *testing.T) {
+		resolver := newResolver(
+			did.Service{
+				ID:   testDID + "#linked-domains",
+				Type: didconfig.LinkedDomainsServiceType,
+				ServiceEndpoint: model.NewDIDCoreEndpoint(map[string]interface{}{
+					"origins": []interface{}{testDomain, "https://example.com"},
+				}),
+			},
+			did.Service{
+				ID:              testDID + "#linked-domain",
+				Type:            didconfig.LinkedDomainsServiceType,
+				ServiceEndpoint: model.NewDIDCommV1Endpoint(testDomain),
+			},
+		)
+
+		c := New(WithJSONLDDocumentLoader(loader), WithVDRegistry(resolver), WithHTTPClient(httpClient))
+
+		verifications, err := c.VerifyLinkedDomains(testDID)
+		require.NoError(t, err)
+		require.Len(t, verifications, 2)
+
+		require.Equal(t, testDomain, verifications[0].Domain)
+		require.NoError(t, verifications[0].Err)
+		require.Equal(t, testDID, verifications[0].Result.Issuer)
+
+		// did configuration served by the second domain links the DID to the first domain only
+		require.Equal(t, "https://example.com", verifications[1].Domain)
+		require.ErrorIs(t, verifications[1].Err, didconfig.ErrNoMatchingCredential)
+		require.Nil(t, verifications[1].Result)
+
+		require.Equal(t, 1, resolver.count)
+	})
+
+	t.Run("success - no linked domains", func(t *testing.T) {
+		c := New(WithJSONLDDocumentLoader(loader), WithVDRegistry(newResolver()), WithHTTPClient(httpClient))
+
+		verifications, err := c.VerifyLinkedDomains(testDID)
+		require.NoError(t, err)
+		require.Empty(t, verifications)
+	})
+
+	t.Run("error - invalid LinkedDomains service", func(t *testing.T) {
+		resolver := newResolver(did.Service{
+			ID:              testDID + "#linked-domains",
+			Type:            didconfig.LinkedDomainsServiceType,
+			ServiceEndpoint: model.NewDIDCoreEndpoint(map[string]interface{}{"origins": []interface{}{1}}),
+		})
+
+		c := New(WithJSONLDDocumentLoader(loader), WithVDRegistry(resolver), WithHTTPClient(httpClient))
+
+		verifications, err := c.VerifyLinkedDomains(testDID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "domains claimed by DID "+testDID)
+		require.Nil(t, verifications)
+	})
+
+	t.Run("error - DID resolution", func(t *testing.T) {
+		c := New(WithJSONLDDocumentLoader(loader), WithHTTPClient(httpClient))
+
+		verifications, err := c.VerifyLinkedDomains("did:web:example.com")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "resolve DID did:web:example.com")
+		require.Nil(t, verifications)
+	})
+}
+
+func TestWithVerificationCache(t *testing.T) {
+	loader, err := ldtestutil.DocumentLoader(ldcontext.Document{
+		URL:     contextV1,
+		Content: json.RawMessage(didCfgCtxV1),
+	})
+	require.NoError(t, err)
+
+	body := didCfg
+
+	httpClient := &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewReader([]byte(body))),
+			}, nil
+		},
+	}
+
+	t.Run("unchanged did configuration skips verification", func(t *testing.T) {
+		body = didCfg
+		resolver := &countingResolver{resolver: vdr.New(vdr.WithVDR(key.New()))}
+
+		c := New(WithJSONLDDocumentLoader(loader), WithVDRegistry(resolver), WithHTTPClient(httpClient),
+			WithVerificationCache(time.Hour))
+		setVerificationCacheTime(c, didCfgValidTime)
+
+		require.NoError(t, c.VerifyDIDAndDomain(testDID, testDomain))
+		require.Equal(t, 1, resolver.count)
+
+		require.NoError(t, c.VerifyDIDAndDomain(testDID, testDomain))
+		require.Equal(t, 1, resolver.count)
+
+		// the cache is keyed by the domain as well
+		err = c.VerifyDIDAndDomain(testDID, "https://example.com")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "domain linkage credential(s) not found")
+	})
+
+	t.Run("changed did configuration is verified again", func(t *testing.T) {
+		body = didCfg
+		resolver := &countingResolver{resolver: vdr.New(vdr.WithVDR(key.New()))}
+
+		c := New(WithJSONLDDocumentLoader(loader), WithVDRegistry(resolver), WithHTTPClient(httpClient),
+			WithVerificationCache(time.Hour))
+		setVerificationCacheTime(c, didCfgValidTime)
+
+		require.NoError(t, c.VerifyDIDAndDomain(testDID, testDomain))
+		require.Equal(t, 1, resolver.count)
+
+		body = strings.Replace(didCfg, "{", "{ ", 1)
+
+		require.NoError(t, c.VerifyDIDAndDomain(testDID, testDomain))
+		require.Equal(t, 2, resolver.count)
+
+		require.NoError(t, c.VerifyDIDAndDomain(testDID, testDomain))
+		require.Equal(t, 2, resolver.count)
+
+		body = `{"@context": "https://identity.foundation/.well-known/did-configuration/v1"}`
+
+		require.Error(t, c.VerifyDIDAndDomain(testDID, testDomain))
+
+		// the previous verification was invalidated by the changed body
+		body = strings.Replace(didCfg, "{", "{ ", 1)
+
+		require.NoError(t, c.VerifyDIDAndDomain(testDID, testDomain))
+		require.Equal(t, 3, resolver.count)
+	})
+
+	t.Run("verification expires after the TTL", func(t *testing.T) {
+		body = didCfg
+		resolver := &countingResolver{resolver: vdr.New(vdr.WithVDR(key.New()))}
+
+		c := New(WithJSONLDDocumentLoader(loader), WithVDRegistry(resolver), WithHTTPClient(httpClient),
+			WithVerificationCache(time.Hour))
+		setVerificationCacheTime(c, didCfgValidTime)
+
+		require.NoError(t, c.VerifyDIDAndDomain(testDID, testDomain))
+		require.Equal(t, 1, resolver.count)
+
+		setVerificationCacheTime(c, didCfgValidTime.Add(time.Hour-time.Second))
+
+		require.NoError(t, c.VerifyDIDAndDomain(testDID, testDomain))
+		require.Equal(t, 1, resolver.count)
+
+		setVerificationCacheTime(c, didCfgValidTime.Add(time.Hour))
+
+		require.NoError(t, c.VerifyDIDAndDomain(testDID, testDomain))
+		require.Equal(t, 2, resolver.count)
+	})
+
+	t.Run("verification expires with the credential", func(t *testing.T) {
+		body = didCfg
+		resolver := &countingResolver{resolver: vdr.New(vdr.WithVDR(key.New()))}
+
+		c := New(WithJSONLDDocumentLoader(loader), WithVDRegistry(resolver), WithHTTPClient(httpClient),
+			WithVerificationCache(24*time.Hour))
+		setVerificationCacheTime(c, didCfgExpirationTime.Add(-time.Hour))
+
+		require.NoError(t, c.VerifyDIDAndDomain(testDID, testDomain))
+		require.NoError(t, c.VerifyDIDAndDomain(testDID, testDomain))
+		require.Equal(t, 1, resolver.count)
+
+		setVerificationCacheTime(c, didCfgExpirationTime)
+
+		// the verification of the expired credential is not cached anymore
+		require.NoError(t, c.VerifyDIDAndDomain(testDID, testDomain))
+		require.NoError(t, c.VerifyDIDAndDomain(testDID, testDomain))
+		require.Equal(t, 3, resolver.count)
+	})
+
+	t.Run("verification of a prepared verifier is cached separately", func(t *testing.T) {
+		body = didCfg
+		resolver := &countingResolver{resolver: vdr.New(vdr.WithVDR(key.New()))}
+
+		c := New(WithJSONLDDocumentLoader(loader), WithVDRegistry(resolver), WithHTTPClient(httpClient),
+			WithVerificationCache(time.Hour))
+		setVerificationCacheTime(c, didCfgValidTime)
+
+		verifier, err := c.PrepareVerification(testDID)
+		require.NoError(t, err)
+		require.Equal(t, 1, resolver.count)
+
+		require.NoError(t, verifier.VerifyDomain(testDomain))
+
+		// the verification made with the prepared document is not reused
+		require.NoError(t, c.VerifyDIDAndDomain(testDID, testDomain))
+		require.Equal(t, 2, resolver.count)
+
+		require.NoError(t, c.VerifyDIDAndDomain(testDID, testDomain))
+		require.Equal(t, 2, resolver.count)
+
+		require.Len(t, c.verified.entries, 2)
+	})
+
+	t.Run("error - invalid TTL", func(t *testing.T) {
+		err := New(WithHTTPClient(httpClient), WithVerificationCache(0)).VerifyDIDAndDomain(testDID, testDomain)
+		require.EqualError(t, err, "invalid verification cache TTL 0s")
+	})
+
+	t.Run("verification is not cached by default", func(t *testing.T) {
+		body = didCfg
+		resolver := &countingResolver{resolver: vdr.New(vdr.WithVDR(key.New()))}
+
+		c := New(WithJSONLDDocumentLoader(loader), WithVDRegistry(resolver), WithHTTPClient(httpClient))
+
+		require.NoError(t, c.VerifyDIDAndDomain(testDID, testDomain))
+		require.NoError(t, c.VerifyDIDAndDomain(testDID, testDomain))
+		require.Equal(t, 2, resolver.count)
+	})
+}
+
+// didCfgValidTime is a time within the validity period of the domain linkage credential of didCfg,
+// didCfgExpirationTime is its expirationDate.
+//
+//nolint:gochecknoglobals
+var (
+	didCfgValidTime      = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	didCfgExpirationTime = time.Date(2025, 12, 4, 20, 8, 28, 0, time.UTC)
+)
+
+func setVerificationCacheTime(c *Client, now time.Time) {
+	c.verified.now = func() time.Time {
+		return now
+	}
+}
+
+func TestWithDIDResolutionCache(t *testing.T) {
+	loader, err := ldtestutil.DocumentLoader(ldcontext.Document{
+		URL:     contextV1,
+		Content: json.RawMessage(didCfgCtxV1),
+	})
+	require.NoError(t, err)
+
+	httpClient := &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewReader([]byte(didCfg))),
+			}, nil
+		},
+	}
+
+	t.Run("success - cached resolution is reused", func(t *testing.T) {
+		resolver := &countingResolver{resolver: vdr.New(vdr.WithVDR(key.New()))}
+		cache := &mockResolutionCache{resolutions: map[string]*did.DocResolution{}}
+
+		c := New(WithJSONLDDocumentLoader(loader), WithVDRegistry(resolver), WithHTTPClient(httpClient),
+			WithDIDResolutionCache(cache))
+
+		require.NoError(t, c.VerifyDIDAndDomain(testDID, testDomain))
+		require.Equal(t, 1, resolver.count)
+		require.Contains(t, cache.resolutions, testDID)
+		require.Equal(t, DefaultResolutionCacheTTL, cache.ttl)
+
+		require.NoError(t, c.VerifyDIDAndDomain(testDID, testDomain))
+		require.Equal(t, 1, resolver.count)
+
+		// expired resolution
+		delete(cache.resolutions, testDID)
+
+		require.NoError(t, c.VerifyDIDAndDomain(testDID, testDomain))
+		require.Equal(t, 2, resolver.count)
+	})
+
+	t.Run("success - default resolver", func(t *testing.T) {
+		cache := &mockResolutionCache{resolutions: map[string]*did.DocResolution{}}
+
+		c := New(WithJSONLDDocumentLoader(loader), WithHTTPClient(httpClient), WithDIDResolutionCache(cache))
+
+		require.NoError(t, c.VerifyDIDAndDomain(testDID, testDomain))
+		require.Contains(t, cache.resolutions, testDID)
+	})
+
+	t.Run("error - failed resolution is not cached", func(t *testing.T) {
+		resolver := &countingResolver{resolver: &mockvdr.MockVDRegistry{ResolveErr: errors.New("resolve error")}}
+		cache := &mockResolutionCache{resolutions: map[string]*did.DocResolution{}}
+
+		c := New(WithJSONLDDocumentLoader(loader), WithVDRegistry(resolver), WithHTTPClient(httpClient),
+			WithDIDResolutionCache(cache))
+
+		require.Error(t, c.VerifyDIDAndDomain(testDID, testDomain))
+		require.Empty(t, cache.resolutions)
+	})
+}
+
+type mockResolutionCache struct {
+	mu          sync.Mutex
+	resolutions map[string]*did.DocResolution
+	ttl         time.Duration
+}
+
+func (c *mockResolutionCache) Get(didID string) (*did.DocResolution, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	docResolution, ok := c.resolutions[didID]
+
+	return docResolution, ok
+}
+
+func (c *mockResolutionCache) Put(didID string, docResolution *did.DocResolution, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.resolutions[didID] = docResolution
+	c.ttl = ttl
+}
+
+func TestWithExpectedConfigHash(t *testing.T) {
+	loader, err := ldtestutil.DocumentLoader(ldcontext.Document{
+		URL:     contextV1,
+		Content: json.RawMessage(didCfgCtxV1),
+	})
+	require.NoError(t, err)
+
+	httpClient := &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewReader([]byte(didCfg))),
+			}, nil
+		},
+	}
+
+	hash := sha256.Sum256([]byte(didCfg))
+	didCfgHash := hex.EncodeToString(hash[:])
+
+	otherHash := sha256.Sum256([]byte("other did configuration"))
+
+	t.Run("success - matching hash", func(t *testing.T) {
+		c := New(WithJSONLDDocumentLoader(loader), WithHTTPClient(httpClient),
+			WithExpectedConfigHash(testDomain, strings.ToUpper(didCfgHash)),
+			WithExpectedConfigHash("https://example.com", hex.EncodeToString(otherHash[:])))
+
+		require.NoError(t, c.VerifyDIDAndDomain(testDID, testDomain))
+	})
+
+	t.Run("error - mismatching hash", func(t *testing.T) {
+		c := New(WithJSONLDDocumentLoader(loader), WithHTTPClient(httpClient),
+			WithExpectedConfigHash(testDomain, didCfgHash),
+			WithExpectedConfigHash("https://example.com", hex.EncodeToString(otherHash[:])))
+
+		err := c.VerifyDIDAndDomain(testDID, "https://example.com")
+		require.EqualError(t, err, fmt.Sprintf("did configuration of domain https://example.com "+
+			"has SHA-256 hash %s, expected %s", didCfgHash, hex.EncodeToString(otherHash[:])))
+
+		diagnosis, err := c.DiagnoseDIDAndDomain(testDID, "https://example.com")
+		require.NoError(t, err)
+		require.False(t, diagnosis.Passed())
+		require.Equal(t, didconfig.CheckFetch, diagnosis.Checks[0].Name)
+		require.Error(t, diagnosis.Checks[0].Err)
+	})
+
+	t.Run("error - mismatching hash of the domain in other form", func(t *testing.T) {
+		c := New(WithJSONLDDocumentLoader(loader), WithHTTPClient(httpClient),
+			WithExpectedConfigHash("HTTPS://Example.com/", hex.EncodeToString(otherHash[:])))
+
+		err := c.VerifyDIDAndDomain(testDID, "https://example.com")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "did configuration of domain https://example.com has SHA-256 hash")
+
+		c = New(WithJSONLDDocumentLoader(loader), WithHTTPClient(httpClient),
+			WithExpectedConfigHash("https://example.com", hex.EncodeToString(otherHash[:])))
+
+		err = c.VerifyDIDAndDomain(testDID, "https://EXAMPLE.com/")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "did configuration of domain https://EXAMPLE.com/ has SHA-256 hash")
+	})
+
+	t.Run("error - invalid domain", func(t *testing.T) {
+		c := New(WithHTTPClient(httpClient), WithExpectedConfigHash("https://", didCfgHash))
+
+		err := c.VerifyDIDAndDomain(testDID, testDomain)
+		require.EqualError(t, err, "invalid domain https:// of SHA-256 hash: origin[https://] has no host")
+	})
+
+	t.Run("error - invalid hash", func(t *testing.T) {
+		c := New(WithHTTPClient(httpClient), WithExpectedConfigHash(testDomain, "abc"))
+
+		err := c.VerifyDIDAndDomain(testDID, testDomain)
+		require.EqualError(t, err, "invalid SHA-256 hash 'abc' for domain https://identity.foundation")
+	})
+}
+
+func TestWithRequireAllMatching(t *testing.T) {
+	loader, err := ldtestutil.DocumentLoader(ldcontext.Document{
+		URL:     contextV1,
+		Content: json.RawMessage(didCfgCtxV1),
+	})
+	require.NoError(t, err)
+
+	cfg := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal([]byte(didCfg), &cfg))
+
+	validCred, ok := cfg["linked_dids"].([]interface{})[0].(map[string]interface{})
+	require.True(t, ok)
+
+	// the same credential with the issuance date changed after signing
+	tamperedCred := map[string]interface{}{}
+	for k, v := range validCred {
+		tamperedCred[k] = v
+	}
+
+	tamperedCred["issuanceDate"] = "2020-12-04T14:08:29-06:00"
+
+	// two credentials for the DID and domain, the second one is invalid
+	cfg["linked_dids"] = []interface{}{validCred, tamperedCred}
+
+	body, err := json.Marshal(cfg)
+	require.NoError(t, err)
+
+	httpClient := &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewReader(body)),
+			}, nil
+		},
+	}
+
+	t.Run("success - any valid credential by default", func(t *testing.T) {
+		c := New(WithJSONLDDocumentLoader(loader), WithHTTPClient(httpClient))
+
+		require.NoError(t, c.VerifyDIDAndDomain(testDID, testDomain))
+	})
+
+	t.Run("error - one of matching credentials is invalid", func(t *testing.T) {
+		c := New(WithJSONLDDocumentLoader(loader), WithHTTPClient(httpClient), WithRequireAllMatching())
+
+		err := c.VerifyDIDAndDomain(testDID, testDomain)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "domain linkage credential 2 of 2")
+	})
+}
+
+func TestWithWellKnownPath(t *testing.T) {
+	loader, err := ldtestutil.DocumentLoader(ldcontext.Document{
+		URL:     contextV1,
+		Content: json.RawMessage(didCfgCtxV1),
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		domain   string
+		path     string
+		expected string
+	}{
+		{
+			name:     "default path",
+			domain:   testDomain,
+			expected: testDomain + "/.well-known/did-configuration.json",
+		},
+		{
+			name:     "default path - domain with trailing slash",
+			domain:   testDomain + "/",
+			expected: testDomain + "/.well-known/did-configuration.json",
+		},
+		{
+			name:     "custom path",
+			domain:   testDomain,
+			path:     "/did/.well-known/did-configuration.json",
+			expected: testDomain + "/did/.well-known/did-configuration.json",
+		},
+		{
+			name:     "custom path without leading slash - domain with trailing slash",
+			domain:   testDomain + "/",
+			path:     "did-configuration.json",
+			expected: testDomain + "/did-configuration.json",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			var requested string
+
+			httpClient := &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					requested = req.URL.String()
+
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       io.NopCloser(bytes.NewReader([]byte(didCfg))),
+					}, nil
+				},
+			}
+
+			c := New(WithJSONLDDocumentLoader(loader), WithHTTPClient(httpClient), WithWellKnownPath(tc.path))
+
+			require.NoError(t, c.VerifyDIDAndDomain(testDID, tc.domain))
+			require.Equal(t, tc.expected, requested)
+		})
+	}
+}
+
+func TestWithFallbackPaths(t *testing.T) {
+	loader, err := ldtestutil.DocumentLoader(ldcontext.Document{
+		URL:     contextV1,
+		Content: json.RawMessage(didCfgCtxV1),
+	})
+	require.NoError(t, err)
+
+	// newHTTPClient serves the did configuration at the path, other paths respond with the status
+	newHTTPClient := func(path string, status int, requested *[]string) *mockHTTPClient {
+		return &mockHTTPClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				*requested = append(*requested, req.URL.Path)
+
+				if req.URL.Path == path {
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       io.NopCloser(bytes.NewReader([]byte(didCfg))),
+					}, nil
+				}
+
+				return &http.Response{
+					StatusCode: status,
+					Body:       io.NopCloser(bytes.NewReader([]byte(http.StatusText(status)))),
+				}, nil
+			},
+		}
+	}
+
+	t.Run("success - well-known path is not found, fallback paths are tried in order", func(t *testing.T) {
+		var requested []string
+
+		c := New(WithJSONLDDocumentLoader(loader),
+			WithHTTPClient(newHTTPClient("/did-configuration.json", http.StatusNotFound, &requested)),
+			WithFallbackPaths("/config/did-configuration.json", "did-configuration.json", "/other.json"))
+
+		require.NoError(t, c.VerifyDIDAndDomain(testDID, testDomain))
+		require.Equal(t, []string{defaultWellKnownPath, "/config/did-configuration.json", "/did-configuration.json"},
+			requested)
+	})
+
+	t.Run("success - fallback paths are not tried if well-known path is found", func(t *testing.T) {
+		var requested []string
+
+		c := New(WithJSONLDDocumentLoader(loader),
+			WithHTTPClient(newHTTPClient(defaultWellKnownPath, http.StatusNotFound, &requested)),
+			WithFallbackPaths("/did-configuration.json"))
+
+		require.NoError(t, c.VerifyDIDAndDomain(testDID, testDomain))
+		require.Equal(t, []string{defaultWellKnownPath}, requested)
+	})
+
+	t.Run("error - no fallback on other errors", func(t *testing.T) {
+		var requested []string
+
+		c := New(WithJSONLDDocumentLoader(loader),
+			WithHTTPClient(newHTTPClient("/did-configuration.json", http.StatusInternalServerError, &requested)),
+			WithFallbackPaths("/did-configuration.json"))
+
+		err := c.VerifyDIDAndDomain(testDID, testDomain)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "returned status '500'")
+		require.Equal(t, []string{defaultWellKnownPath}, requested)
+	})
+
+	t.Run("error - fallback paths are not found", func(t *testing.T) {
+		var requested []string
+
+		c := New(WithJSONLDDocumentLoader(loader),
+			WithHTTPClient(newHTTPClient("/unknown.json", http.StatusNotFound, &requested)),
+			WithFallbackPaths("/did-configuration.json", "/config.json"))
+
+		err := c.VerifyDIDAndDomain(testDID, testDomain)
+
+		var statusErr *ErrStatusCode
+
+		require.ErrorAs(t, err, &statusErr)
+		require.Equal(t, testDomain+defaultWellKnownPath, statusErr.Endpoint)
+		require.Equal(t, []string{defaultWellKnownPath, "/did-configuration.json", "/config.json"}, requested)
+	})
+
+	t.Run("error - fallback path fails with other error", func(t *testing.T) {
+		var requested []string
+
+		httpClient := &mockHTTPClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				requested = append(requested, req.URL.Path)
+
+				if req.URL.Path == defaultWellKnownPath {
+					return &http.Response{StatusCode: http.StatusNotFound, Body: http.NoBody}, nil
+				}
+
+				return nil, errors.New("connection reset")
+			},
+		}
+
+		c := New(WithJSONLDDocumentLoader(loader), WithHTTPClient(httpClient),
+			WithFallbackPaths("/did-configuration.json", "/config.json"))
+
+		err := c.VerifyDIDAndDomain(testDID, testDomain)
+		require.EqualError(t, err, "httpClient.Do: connection reset")
+		require.Equal(t, []string{defaultWellKnownPath, "/did-configuration.json"}, requested)
+	})
+}
+
+func TestWithAllowedProofPurposes(t *testing.T) {
+	loader, err := ldtestutil.DocumentLoader(ldcontext.Document{
+		URL:     contextV1,
+		Content: json.RawMessage(didCfgCtxV1),
+	})
+	require.NoError(t, err)
+
+	issuerPubKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	delegatePubKey, delegatePrivKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	const didID = "did:example:delegating"
+
+	// the domain linkage issuance is delegated to the key authorized under capabilityDelegation only
+	issuerVM := did.NewVerificationMethodFromBytes(didID+"#key-1", "Ed25519VerificationKey2018", didID, issuerPubKey)
+	delegateVM := did.NewVerificationMethodFromBytes(didID+"#delegate", "Ed25519VerificationKey2018", didID,
+		delegatePubKey)
+
+	registry := &mockvdr.MockVDRegistry{ResolveValue: &did.Doc{
+		ID:                   didID,
+		VerificationMethod:   []did.VerificationMethod{*issuerVM, *delegateVM},
+		AssertionMethod:      []did.Verification{*did.NewReferencedVerification(issuerVM, did.AssertionMethod)},
+		CapabilityDelegation: []did.Verification{*did.NewReferencedVerification(delegateVM, did.CapabilityDelegation)},
+	}}
+
+	vc := &verifiable.Credential{
+		Context: []string{verifiable.ContextURI, contextV1},
+		Types:   []string{verifiable.VCType, "DomainLinkageCredential"},
+		Issuer:  verifiable.Issuer{ID: didID},
+		Issued:  util.NewTime(time.Now().Truncate(time.Second)),
+		Expired: util.NewTime(time.Now().Add(time.Hour).Truncate(time.Second)),
+		Subject: []verifiable.Subject{{ID: didID, CustomFields: map[string]interface{}{"origin": testDomain}}},
+	}
+
+	err = vc.AddLinkedDataProof(&verifiable.LinkedDataProofContext{
+		SignatureType:           "Ed25519Signature2018",
+		Suite:                   ed25519signature2018.New(suite.WithSigner(&ed25519TestSigner{privKey: delegatePrivKey})),
+		SignatureRepresentation: verifiable.SignatureJWS,
+		VerificationMethod:      delegateVM.ID,
+	}, jsonld.WithDocumentLoader(loader))
+	require.NoError(t, err)
+
+	didCfgBytes, err := json.Marshal(map[string]interface{}{
+		"@context":    contextV1,
+		"linked_dids": []interface{}{vc},
+	})
+	require.NoError(t, err)
+
+	httpClient := &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewReader(didCfgBytes)),
+			}, nil
+		},
+	}
+
+	t.Run("success - capabilityDelegation key is accepted with the option", func(t *testing.T) {
+		c := New(WithJSONLDDocumentLoader(loader), WithVDRegistry(registry), WithHTTPClient(httpClient),
+			WithAllowedProofPurposes(did.AssertionMethod, did.CapabilityDelegation))
+
+		result, err := c.VerifyDIDAndDomainWithResult(didID, testDomain)
+		require.NoError(t, err)
+		require.Equal(t, delegateVM.ID, result.VerificationMethod)
+	})
+
+	t.Run("error - capabilityDelegation key is rejected by default", func(t *testing.T) {
+		c := New(WithJSONLDDocumentLoader(loader), WithVDRegistry(registry), WithHTTPClient(httpClient))
+
+		err := c.VerifyDIDAndDomain(didID, testDomain)
+		require.ErrorIs(t, err, didconfig.ErrNoValidProof)
+
+		c = New(WithJSONLDDocumentLoader(loader), WithVDRegistry(registry), WithHTTPClient(httpClient),
+			WithRequireAllMatching())
+
+		err = c.VerifyDIDAndDomain(didID, testDomain)
+		require.Error(t, err)
+		require.Contains(t, err.Error(),
+			"verification method did:example:delegating#delegate is not authorized for proof purpose(s) assertionMethod")
+	})
+
+	t.Run("error - capabilityDelegation key is rejected if only assertionMethod is allowed", func(t *testing.T) {
+		c := New(WithJSONLDDocumentLoader(loader), WithVDRegistry(registry), WithHTTPClient(httpClient),
+			WithAllowedProofPurposes(did.AssertionMethod))
+
+		err := c.VerifyDIDAndDomain(didID, testDomain)
+		require.ErrorIs(t, err, didconfig.ErrNoValidProof)
+	})
+
+	t.Run("diagnosis - proof of capabilityDelegation key", func(t *testing.T) {
+		c := New(WithJSONLDDocumentLoader(loader), WithVDRegistry(registry), WithHTTPClient(httpClient))
+
+		diagnosis, err := c.DiagnoseDIDAndDomain(didID, testDomain)
+		require.NoError(t, err)
+		require.False(t, diagnosis.Passed())
+		require.Len(t, diagnosis.Credentials, 1)
+
+		// the proof is reported as invalid as by the proof check
+		proofs := diagnosis.Credentials[0].Proofs
+		require.Len(t, proofs, 1)
+		require.Equal(t, delegateVM.ID, proofs[0].VerificationMethod)
+		require.Error(t, proofs[0].Err)
+		require.Contains(t, proofs[0].Err.Error(), "is not authorized for proof purpose(s) assertionMethod")
+
+		checks := diagnosis.Credentials[0].Checks
+		require.Equal(t, didconfig.CheckProof, checks[len(checks)-1].Name)
+		require.Error(t, checks[len(checks)-1].Err)
+
+		c = New(WithJSONLDDocumentLoader(loader), WithVDRegistry(registry), WithHTTPClient(httpClient),
+			WithAllowedProofPurposes(did.AssertionMethod, did.CapabilityDelegation))
+
+		diagnosis, err = c.DiagnoseDIDAndDomain(didID, testDomain)
+		require.NoError(t, err)
+		require.True(t, diagnosis.Passed())
+		require.Len(t, diagnosis.Credentials[0].Proofs, 1)
+		require.NoError(t, diagnosis.Credentials[0].Proofs[0].Err)
+	})
+}
+
+func TestWithBodyTransformer(t *testing.T) {
+	loader, err := ldtestutil.DocumentLoader(ldcontext.Document{
+		URL:     contextV1,
+		Content: json.RawMessage(didCfgCtxV1),
+	})
+	require.NoError(t, err)
+
+	httpClient := &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": []string{"application/vnd.envelope+json"}},
+				Body:       io.NopCloser(bytes.NewReader([]byte(`{"data":` + didCfg + `}`))),
+			}, nil
+		},
+	}
+
+	unwrap := func(body []byte, header http.Header) ([]byte, error) {
+		if header.Get("Content-Type") != "application/vnd.envelope+json" {
+			return body, nil
+		}
+
+		envelope := struct {
+			Data json.RawMessage `json:"data"`
+		}{}
+
+		if err := json.Unmarshal(body, &envelope); err != nil {
+			return nil, err
+		}
+
+		return envelope.Data, nil
+	}
+
+	t.Run("success - enveloped did configuration is unwrapped", func(t *testing.T) {
+		c := New(WithJSONLDDocumentLoader(loader), WithHTTPClient(httpClient), WithBodyTransformer(unwrap))
+
+		require.NoError(t, c.VerifyDIDAndDomain(testDID, testDomain))
+	})
+
+	t.Run("error - enveloped did configuration without transformer", func(t *testing.T) {
+		c := New(WithJSONLDDocumentLoader(loader), WithHTTPClient(httpClient))
+
+		err := c.VerifyDIDAndDomain(testDID, testDomain)
+		require.Error(t, err)
+	})
+
+	t.Run("error - transformer failure", func(t *testing.T) {
+		c := New(WithJSONLDDocumentLoader(loader), WithHTTPClient(httpClient),
+			WithBodyTransformer(func([]byte, http.Header) ([]byte, error) {
+				return nil, errors.New("unsupported format")
+			}))
+
+		err := c.VerifyDIDAndDomain(testDID, testDomain)
+		require.EqualError(t, err, "transform response of endpoint "+testDomain+
+			"/.well-known/did-configuration.json: unsupported format")
+	})
+}
+
+func TestWithResponseInspector(t *testing.T) {
+	loader, err := ldtestutil.DocumentLoader(ldcontext.Document{
+		URL:     contextV1,
+		Content: json.RawMessage(didCfgCtxV1),
+	})
+	require.NoError(t, err)
+
+	status := http.StatusOK
+
+	httpClient := &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: status,
+				Header: http.Header{
+					"Content-Type":  []string{"application/json"},
+					"Cache-Control": []string{"max-age=3600"},
+				},
+				Body: io.NopCloser(bytes.NewReader([]byte(didCfg))),
+			}, nil
+		},
+	}
+
+	var inspected []*http.Response
+
+	inspector := func(resp *http.Response) {
+		// reading the body doesn't consume the body used for the verification
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.Empty(t, body)
+
+		inspected = append(inspected, resp)
+	}
+
+	c := New(WithJSONLDDocumentLoader(loader), WithHTTPClient(httpClient), WithResponseInspector(inspector))
+
+	t.Run("success", func(t *testing.T) {
+		require.NoError(t, c.VerifyDIDAndDomain(testDID, testDomain))
+		require.Len(t, inspected, 1)
+		require.Equal(t, http.StatusOK, inspected[0].StatusCode)
+		require.Equal(t, "max-age=3600", inspected[0].Header.Get("Cache-Control"))
+	})
+
+	t.Run("error status is inspected as well", func(t *testing.T) {
+		status = http.StatusNotFound
+
+		require.Error(t, c.VerifyDIDAndDomain(testDID, testDomain))
+		require.Len(t, inspected, 2)
+		require.Equal(t, http.StatusNotFound, inspected[1].StatusCode)
+	})
+}
+
+func TestWithMaxResponseBytes(t *testing.T) {
+	loader, err := ldtestutil.DocumentLoader(ldcontext.Document{
+		URL:     contextV1,
+		Content: json.RawMessage(didCfgCtxV1),
+	})
+	require.NoError(t, err)
+
+	body := []byte(didCfg)
+
+	httpClient := &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewReader(body)),
+			}, nil
+		},
+	}
+
+	t.Run("response within the limit", func(t *testing.T) {
+		c := New(WithJSONLDDocumentLoader(loader), WithHTTPClient(httpClient),
+			WithMaxResponseBytes(int64(len(didCfg))))
+
+		require.NoError(t, c.VerifyDIDAndDomain(testDID, testDomain))
+	})
+
+	t.Run("error - response exceeds the limit", func(t *testing.T) {
+		c := New(WithJSONLDDocumentLoader(loader), WithHTTPClient(httpClient),
+			WithMaxResponseBytes(int64(len(didCfg)-1)))
+
+		err := c.VerifyDIDAndDomain(testDID, testDomain)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, ErrResponseTooLarge))
+		require.Contains(t, err.Error(), "response too large")
+	})
+
+	t.Run("error - response exceeds the default limit", func(t *testing.T) {
+		// the configuration is padded with whitespace beyond 1 MiB
+		body = append(bytes.Repeat([]byte(" "), defaultMaxResponseBytes), didCfg...)
+
+		c := New(WithJSONLDDocumentLoader(loader), WithHTTPClient(httpClient), WithMaxResponseBytes(0))
+
+		err := c.VerifyDIDAndDomain(testDID, testDomain)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, ErrResponseTooLarge))
+	})
+}
+
+func TestNewSecure(t *testing.T) {
+	loader, err := ldtestutil.DocumentLoader(ldcontext.Document{
+		URL:     contextV1,
+		Content: json.RawMessage(didCfgCtxV1),
+	})
+	require.NoError(t, err)
+
+	var requests int32
+
+	body := []byte(didCfg)
+
+	httpClient := &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			atomic.AddInt32(&requests, 1)
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewReader(body)),
+			}, nil
+		},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		c := NewSecure(WithJSONLDDocumentLoader(loader), WithHTTPClient(httpClient))
+
+		require.NoError(t, c.VerifyDIDAndDomain(testDID, testDomain))
+	})
+
+	t.Run("error - http domain", func(t *testing.T) {
+		atomic.StoreInt32(&requests, 0)
+
+		c := NewSecure(WithJSONLDDocumentLoader(loader), WithHTTPClient(httpClient))
+
+		err := c.VerifyDIDAndDomain(testDID, "http://identity.foundation")
+		require.ErrorIs(t, err, ErrHTTPSRequired)
+		require.Zero(t, atomic.LoadInt32(&requests))
+
+		// the did configuration is fetched if HTTPS isn't required, its origin doesn't match the domain
+		c = NewSecure(WithJSONLDDocumentLoader(loader), WithHTTPClient(httpClient), WithRequireHTTPS(false))
+
+		err = c.VerifyDIDAndDomain(testDID, "http://identity.foundation")
+		require.ErrorIs(t, err, didconfig.ErrNoMatchingCredential)
+		require.EqualValues(t, 1, atomic.LoadInt32(&requests))
+	})
+
+	t.Run("error - oversized body", func(t *testing.T) {
+		body = append(bytes.Repeat([]byte(" "), secureMaxResponseBytes), didCfg...)
+		defer func() { body = []byte(didCfg) }()
+
+		c := NewSecure(WithJSONLDDocumentLoader(loader), WithHTTPClient(httpClient))
+
+		err := c.VerifyDIDAndDomain(testDID, testDomain)
+		require.ErrorIs(t, err, ErrResponseTooLarge)
+
+		// the body is within the default limit of New
+		c = New(WithJSONLDDocumentLoader(loader), WithHTTPClient(httpClient))
+
+		require.NoError(t, c.VerifyDIDAndDomain(testDID, testDomain))
+	})
+
+	t.Run("error - too many linked DIDs", func(t *testing.T) {
+		var cfg map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(didCfg), &cfg))
+
+		linkedDIDs, ok := cfg["linked_dids"].([]interface{})
+		require.True(t, ok)
+
+		for len(linkedDIDs) <= secureMaxLinkedDIDs {
+			linkedDIDs = append(linkedDIDs, linkedDIDs[0])
+		}
+
+		cfg["linked_dids"] = linkedDIDs
+
+		body, err = json.Marshal(cfg)
+		require.NoError(t, err)
+
+		defer func() { body = []byte(didCfg) }()
+
+		c := NewSecure(WithJSONLDDocumentLoader(loader), WithHTTPClient(httpClient))
+
+		err = c.VerifyDIDAndDomain(testDID, testDomain)
+		require.ErrorIs(t, err, didconfig.ErrTooManyLinkedDIDs)
+
+		c = NewSecure(WithJSONLDDocumentLoader(loader), WithHTTPClient(httpClient), WithMaxLinkedDIDs(0))
+
+		require.NoError(t, c.VerifyDIDAndDomain(testDID, testDomain))
+	})
+
+	t.Run("error - redirect to another host", func(t *testing.T) {
+		var targetRequests int32
+
+		target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&targetRequests, 1)
+
+			_, e := w.Write([]byte(didCfg))
+			require.NoError(t, e)
+		}))
+		defer target.Close()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/did-configuration.json" {
+				_, e := w.Write([]byte(didCfg))
+				require.NoError(t, e)
+
+				return
+			}
+
+			location := "/did-configuration.json"
+			if r.URL.Query().Has("other-host") {
+				location = target.URL + location
+			}
+
+			http.Redirect(w, r, location, http.StatusFound)
+		}))
+		defer server.Close()
+
+		c := NewSecure(WithJSONLDDocumentLoader(loader), WithRequireHTTPS(false),
+			WithWellKnownPath("/.well-known/did-configuration.json?other-host"))
+
+		err := c.VerifyDIDAndDomain(testDID, server.URL)
+		require.ErrorIs(t, err, ErrRedirectNotAllowed)
+		// the redirect is refused before it's followed
+		require.Zero(t, atomic.LoadInt32(&targetRequests))
+
+		// the did configuration redirected to the same host is fetched, its origin doesn't match the domain
+		c = NewSecure(WithJSONLDDocumentLoader(loader), WithRequireHTTPS(false))
+
+		err = c.VerifyDIDAndDomain(testDID, server.URL)
+		require.ErrorIs(t, err, didconfig.ErrNoMatchingCredential)
+
+		c = NewSecure(WithJSONLDDocumentLoader(loader), WithRequireHTTPS(false), WithSameHostRedirects(false),
+			WithWellKnownPath("/.well-known/did-configuration.json?other-host"))
+
+		err = c.VerifyDIDAndDomain(testDID, server.URL)
+		require.ErrorIs(t, err, didconfig.ErrNoMatchingCredential)
+		require.EqualValues(t, 1, atomic.LoadInt32(&targetRequests))
+	})
+
+	t.Run("error - too many redirects", func(t *testing.T) {
+		var serverRequests int32
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&serverRequests, 1)
+
+			http.Redirect(w, r, r.URL.Path, http.StatusFound)
+		}))
+		defer server.Close()
+
+		c := NewSecure(WithJSONLDDocumentLoader(loader), WithRequireHTTPS(false))
+
+		err := c.VerifyDIDAndDomain(testDID, server.URL)
+		require.ErrorIs(t, err, ErrRedirectNotAllowed)
+		require.Contains(t, err.Error(), fmt.Sprintf("redirected more than %d times", maxRedirects))
+		require.EqualValues(t, maxRedirects, atomic.LoadInt32(&serverRequests))
+	})
+
+	t.Run("error - redirect to plain HTTP", func(t *testing.T) {
+		target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, e := w.Write([]byte(didCfg))
+			require.NoError(t, e)
+		}))
+		defer target.Close()
+
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, target.URL+r.URL.Path, http.StatusFound)
+		}))
+		defer server.Close()
+
+		c := NewSecure(WithJSONLDDocumentLoader(loader), WithHTTPClient(server.Client()), WithSameHostRedirects(false))
+
+		err := c.VerifyDIDAndDomain(testDID, server.URL)
+		require.ErrorIs(t, err, ErrHTTPSRequired)
+		require.Contains(t, err.Error(), "redirected to "+target.URL)
+	})
+}
+
+func TestVerifyDIDAndDomainWithResult(t *testing.T) {
+	loader, err := ldtestutil.DocumentLoader(ldcontext.Document{
+		URL:     contextV1,
+		Content: json.RawMessage(didCfgCtxV1),
+	})
+	require.NoError(t, err)
+
+	httpClient := &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewReader([]byte(didCfg))),
+			}, nil
+		},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		c := New(WithJSONLDDocumentLoader(loader), WithHTTPClient(httpClient))
+
+		result, err := c.VerifyDIDAndDomainWithResult(testDID, testDomain)
+		require.NoError(t, err)
+		require.Equal(t, testDID, result.Issuer)
+		require.Equal(t, testDomain, result.Origin)
+		require.Equal(t, "Ed25519Signature2018", result.ProofType)
+		require.Equal(t, testDID+"#z6MkoTHsgNNrby8JzCNQ1iRLyW5QQ6R8Xuu6AA8igGrMVPUM", result.VerificationMethod)
+	})
+
+	t.Run("success - cached result", func(t *testing.T) {
+		c := New(WithJSONLDDocumentLoader(loader), WithHTTPClient(httpClient), WithVerificationCache(time.Hour))
+		setVerificationCacheTime(c, didCfgValidTime)
+
+		result, err := c.VerifyDIDAndDomainWithResult(testDID, testDomain)
+		require.NoError(t, err)
+
+		cached, err := c.VerifyDIDAndDomainWithResult(testDID, testDomain)
+		require.NoError(t, err)
+		require.Equal(t, result, cached)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		c := New(WithJSONLDDocumentLoader(loader), WithHTTPClient(httpClient))
+
+		result, err := c.VerifyDIDAndDomainWithResult("did:key:z6MkwWsAe8D7XNpCVMhiFhjBXWVgem4qVRNE3SfdihSqRZAX",
+			testDomain)
+		require.Error(t, err)
+		require.Nil(t, result)
+	})
+}
+
+func TestVerifyDIDAndDomainDocument(t *testing.T) {
+	loader, err := ldtestutil.DocumentLoader(ldcontext.Document{
+		URL:     contextV1,
+		Content: json.RawMessage(didCfgCtxV1),
+	})
+	require.NoError(t, err)
+
+	// the did configuration is not fetched
+	httpClient := &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return nil, fmt.Errorf("unexpected request %s", req.URL)
+		},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		c := New(WithJSONLDDocumentLoader(loader), WithHTTPClient(httpClient))
+
+		require.NoError(t, c.VerifyDIDAndDomainDocument(testDID, testDomain, []byte(didCfg)))
+	})
+
+	t.Run("success - verification cache", func(t *testing.T) {
+		resolver := &countingResolver{resolver: vdr.New(vdr.WithVDR(key.New()))}
+
+		c := New(WithJSONLDDocumentLoader(loader), WithHTTPClient(httpClient), WithVDRegistry(resolver),
+			WithVerificationCache(time.Hour))
+		setVerificationCacheTime(c, didCfgValidTime)
+
+		require.NoError(t, c.VerifyDIDAndDomainDocument(testDID, testDomain, []byte(didCfg)))
+		require.NoError(t, c.VerifyDIDAndDomainDocument(testDID, testDomain, []byte(didCfg)))
+		require.Equal(t, 1, resolver.count)
+	})
+
+	t.Run("error - no credential for the DID", func(t *testing.T) {
+		c := New(WithJSONLDDocumentLoader(loader), WithHTTPClient(httpClient))
+
+		err := c.VerifyDIDAndDomainDocument("did:key:z6MkwWsAe8D7XNpCVMhiFhjBXWVgem4qVRNE3SfdihSqRZAX",
+			testDomain, []byte(didCfg))
+		require.ErrorIs(t, err, didconfig.ErrNoMatchingCredential)
+	})
+
+	t.Run("error - invalid did configuration", func(t *testing.T) {
+		c := New(WithJSONLDDocumentLoader(loader), WithHTTPClient(httpClient))
+
+		err := c.VerifyDIDAndDomainDocument(testDID, testDomain, []byte(didCfgNoLinkedDIDs))
+		require.ErrorIs(t, err, didconfig.ErrMissingLinkedDIDs)
+	})
+
+	t.Run("error - invalid client options", func(t *testing.T) {
+		c := New(WithHTTPClient(httpClient), WithHTTP2(true))
+
+		err := c.VerifyDIDAndDomainDocument(testDID, testDomain, []byte(didCfg))
+		require.EqualError(t, err, "HTTP/2 can't be configured for a custom HTTP client")
+	})
+}
+
+func TestVerifyDIDAndDomainContext(t *testing.T) {
+	loader, err := ldtestutil.DocumentLoader(ldcontext.Document{
+		URL:     contextV1,
+		Content: json.RawMessage(didCfgCtxV1),
+	})
+	require.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		httpClient := &mockHTTPClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewReader([]byte(didCfg))),
+				}, nil
+			},
+		}
+
+		c := New(WithJSONLDDocumentLoader(loader), WithHTTPClient(httpClient))
+
+		require.NoError(t, c.VerifyDIDAndDomainContext(context.Background(), testDID, testDomain))
+	})
+
+	t.Run("error - context deadline exceeded while fetching did configuration", func(t *testing.T) {
+		done := make(chan struct{})
+		defer close(done)
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-done:
+			case <-r.Context().Done():
+			}
+		}))
+		defer server.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		c := New(WithJSONLDDocumentLoader(loader))
+
+		err := c.VerifyDIDAndDomainContext(ctx, testDID, server.URL)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, context.DeadlineExceeded))
+	})
+
+	t.Run("error - context canceled before DID resolution", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		httpClient := &mockHTTPClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				cancel()
+
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewReader([]byte(didCfg))),
+				}, nil
+			},
+		}
+
+		c := New(WithJSONLDDocumentLoader(loader), WithHTTPClient(httpClient))
+
+		err := c.VerifyDIDAndDomainContext(ctx, testDID, testDomain)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, context.Canceled))
+		// the verification error is wrapped too
+		require.ErrorIs(t, err, didconfig.ErrNoValidProof)
+	})
+}
+
+func TestWithRequireSelfIssued(t *testing.T) {
+	loader, err := ldtestutil.DocumentLoader(ldcontext.Document{
+		URL:     contextV1,
+		Content: json.RawMessage(didCfgCtxV1),
+	})
+	require.NoError(t, err)
+
+	cfg := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal([]byte(didCfg), &cfg))
+
+	selfIssuedCred, ok := cfg["linked_dids"].([]interface{})[0].(map[string]interface{})
+	require.True(t, ok)
+
+	// the same credential for the DID issued by another DID
+	delegatedCred := map[string]interface{}{}
+	for k, v := range selfIssuedCred {
+		delegatedCred[k] = v
+	}
+
+	delegatedCred["issuer"] = "did:key:z6MkwWsAe8D7XNpCVMhiFhjBXWVgem4qVRNE3SfdihSqRZAX"
+
+	newHTTPClient := func(t *testing.T, linkedDIDs ...interface{}) *mockHTTPClient {
+		t.Helper()
+
+		cfg["linked_dids"] = linkedDIDs
+
+		body, err := json.Marshal(cfg)
+		require.NoError(t, err)
+
+		return &mockHTTPClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewReader(body)),
+				}, nil
+			},
+		}
+	}
+
+	t.Run("success - self-issued credential", func(t *testing.T) {
+		c := New(WithJSONLDDocumentLoader(loader), WithHTTPClient(newHTTPClient(t, selfIssuedCred)),
+			WithRequireSelfIssued())
+
+		require.NoError(t, c.VerifyDIDAndDomain(testDID, testDomain))
+	})
+
+	t.Run("success - delegated credential is skipped by default", func(t *testing.T) {
+		c := New(WithJSONLDDocumentLoader(loader), WithHTTPClient(newHTTPClient(t, delegatedCred, selfIssuedCred)))
+
+		require.NoError(t, c.VerifyDIDAndDomain(testDID, testDomain))
+	})
+
+	t.Run("error - delegated credential", func(t *testing.T) {
+		c := New(WithJSONLDDocumentLoader(loader), WithHTTPClient(newHTTPClient(t, delegatedCred, selfIssuedCred)),
+			WithRequireSelfIssued())
+
+		err := c.VerifyDIDAndDomain(testDID, testDomain)
 		require.Error(t, err)
-		require.Contains(t, err.Error(),
-			"Get \"https://non-existent-abc.com/.well-known/did-configuration.json\": dial tcp: "+
-				"lookup non-existent-abc.com: no such host")
+		require.Contains(t, err.Error(), "is not self-issued")
 	})
+}
 
-	t.Run("error - http request error", func(t *testing.T) {
-		c := New(WithJSONLDDocumentLoader(loader))
+func TestWithMiddleware(t *testing.T) {
+	loader, err := ldtestutil.DocumentLoader(ldcontext.Document{
+		URL:     contextV1,
+		Content: json.RawMessage(didCfgCtxV1),
+	})
+	require.NoError(t, err)
 
-		err := c.VerifyDIDAndDomain(testDID, ":invalid.com")
+	fetched := 0
+
+	httpClient := &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			fetched++
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewReader([]byte(didCfg))),
+			}, nil
+		},
+	}
+
+	var calls []string
+
+	trace := func(name string) Middleware {
+		return func(next VerifyFunc) VerifyFunc {
+			return func(did, domain string) error {
+				calls = append(calls, name+" before")
+
+				err := next(did, domain)
+
+				calls = append(calls, name+" after")
+
+				return err
+			}
+		}
+	}
+
+	core := func(next VerifyFunc) VerifyFunc {
+		return func(did, domain string) error {
+			calls = append(calls, "verify")
+
+			return next(did, domain)
+		}
+	}
+
+	t.Run("middleware is called in order", func(t *testing.T) {
+		calls = nil
+
+		c := New(WithJSONLDDocumentLoader(loader), WithHTTPClient(httpClient),
+			WithMiddleware(trace("first"), trace("second")), WithMiddleware(core))
+
+		require.NoError(t, c.VerifyDIDAndDomain(testDID, testDomain))
+		require.Equal(t, []string{"first before", "second before", "verify", "second after", "first after"}, calls)
+
+		calls = nil
+
+		verifier, err := c.PrepareVerification(testDID)
+		require.NoError(t, err)
+
+		require.NoError(t, verifier.VerifyDomain(testDomain))
+		require.Equal(t, []string{"first before", "second before", "verify", "second after", "first after"}, calls)
+	})
+
+	t.Run("middleware short-circuits verification", func(t *testing.T) {
+		calls = nil
+		fetched = 0
+
+		deny := func(_ VerifyFunc) VerifyFunc {
+			return func(did, domain string) error {
+				return fmt.Errorf("domain %s is not allowed", domain)
+			}
+		}
+
+		c := New(WithJSONLDDocumentLoader(loader), WithHTTPClient(httpClient),
+			WithMiddleware(trace("first"), deny, trace("second")))
+
+		err := c.VerifyDIDAndDomain(testDID, testDomain)
+		require.EqualError(t, err, "domain "+testDomain+" is not allowed")
+		require.Equal(t, []string{"first before", "first after"}, calls)
+		require.Zero(t, fetched)
+	})
+
+	t.Run("verification error is propagated through middleware", func(t *testing.T) {
+		calls = nil
+
+		c := New(WithJSONLDDocumentLoader(loader), WithHTTPClient(httpClient), WithMiddleware(trace("first")))
+
+		err := c.VerifyDIDAndDomain("did:example:123", testDomain)
 		require.Error(t, err)
-		require.Contains(t, err.Error(), "missing protocol scheme")
+		require.Contains(t, err.Error(), "domain linkage credential(s) not found")
+		require.Equal(t, []string{"first before", "first after"}, calls)
 	})
+}
 
-	t.Run("error - http status error", func(t *testing.T) {
+func TestDiagnoseDIDAndDomain(t *testing.T) {
+	loader, err := ldtestutil.DocumentLoader(ldcontext.Document{
+		URL:     contextV1,
+		Content: json.RawMessage(didCfgCtxV1),
+	})
+	require.NoError(t, err)
+
+	var cfg map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(didCfg), &cfg))
+
+	goodVC := cfg["linked_dids"].([]interface{})[0].(map[string]interface{})
+
+	badVC := make(map[string]interface{}, len(goodVC))
+	for k, v := range goodVC {
+		badVC[k] = v
+	}
+
+	badVC["credentialSubject"] = map[string]interface{}{"id": testDID, "origin": "https://other.example.com"}
+	cfg["linked_dids"] = []interface{}{badVC, goodVC}
+
+	body, err := json.Marshal(cfg)
+	require.NoError(t, err)
+
+	t.Run("success - one bad and one good credential", func(t *testing.T) {
+		httpClient := &mockHTTPClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewReader(body)),
+				}, nil
+			},
+		}
+
+		c := New(WithJSONLDDocumentLoader(loader), WithHTTPClient(httpClient))
+
+		diagnosis, err := c.DiagnoseDIDAndDomain(testDID, testDomain)
+		require.NoError(t, err)
+		require.Len(t, diagnosis.Checks, 3)
+		require.Equal(t, didconfig.CheckFetch, diagnosis.Checks[0].Name)
+		require.True(t, diagnosis.Checks[0].Passed())
+		require.Len(t, diagnosis.Credentials, 2)
+
+		checks := map[string]error{}
+		for _, check := range diagnosis.Credentials[0].Checks {
+			checks[check.Name] = check.Err
+		}
+
+		require.Error(t, checks[didconfig.CheckSubject])
+		require.Error(t, checks[didconfig.CheckProof])
+		require.NoError(t, checks[didconfig.CheckType])
+
+		checks = map[string]error{}
+		for _, check := range diagnosis.Credentials[1].Checks {
+			checks[check.Name] = check.Err
+		}
+
+		require.NoError(t, checks[didconfig.CheckSubject])
+		require.NoError(t, checks[didconfig.CheckProof])
+	})
+
+	t.Run("success - fetch error is reported", func(t *testing.T) {
 		httpClient := &mockHTTPClient{
 			DoFunc: func(req *http.Request) (*http.Response, error) {
 				return &http.Response{
 					StatusCode: http.StatusNotFound,
-					Body:       io.NopCloser(bytes.NewReader([]byte("data not found"))),
+					Body:       io.NopCloser(bytes.NewReader([]byte("not found"))),
 				}, nil
 			},
 		}
 
 		c := New(WithJSONLDDocumentLoader(loader), WithHTTPClient(httpClient))
 
+		diagnosis, err := c.DiagnoseDIDAndDomain(testDID, testDomain)
+		require.NoError(t, err)
+		require.False(t, diagnosis.Passed())
+		require.Len(t, diagnosis.Checks, 1)
+		require.Equal(t, didconfig.CheckFetch, diagnosis.Checks[0].Name)
+		require.Contains(t, diagnosis.Checks[0].Err.Error(), "returned status '404'")
+		require.Empty(t, diagnosis.Credentials)
+	})
+
+	t.Run("error - invalid client options", func(t *testing.T) {
+		c := New(WithHTTPClient(&http.Client{}), WithTimeouts(time.Second, 0, 0, 0))
+
+		diagnosis, err := c.DiagnoseDIDAndDomain(testDID, testDomain)
+		require.EqualError(t, err, "timeouts can't be set for a custom HTTP client")
+		require.Nil(t, diagnosis)
+	})
+}
+
+func TestWithTimeouts(t *testing.T) {
+	t.Run("success - timeouts are set on default transport", func(t *testing.T) {
+		c := New(WithTimeouts(time.Second, 2*time.Second, 3*time.Second, 4*time.Second))
+		require.NoError(t, c.err)
+
+		httpClient, ok := c.httpClient.(*http.Client)
+		require.True(t, ok)
+		require.Equal(t, 4*time.Second, httpClient.Timeout)
+
+		transport, ok := httpClient.Transport.(*http.Transport)
+		require.True(t, ok)
+		require.Equal(t, 2*time.Second, transport.TLSHandshakeTimeout)
+		require.Equal(t, 3*time.Second, transport.ResponseHeaderTimeout)
+		require.NotNil(t, transport.DialContext)
+	})
+
+	t.Run("success - zero values keep defaults", func(t *testing.T) {
+		c := New(WithTimeouts(0, 0, 0, 0))
+		require.NoError(t, c.err)
+
+		httpClient, ok := c.httpClient.(*http.Client)
+		require.True(t, ok)
+		require.Equal(t, defaultTimeout, httpClient.Timeout)
+
+		transport, ok := httpClient.Transport.(*http.Transport)
+		require.True(t, ok)
+		require.Equal(t, http.DefaultTransport.(*http.Transport).TLSHandshakeTimeout, transport.TLSHandshakeTimeout)
+	})
+
+	t.Run("error - custom HTTP client", func(t *testing.T) {
+		c := New(WithHTTPClient(&http.Client{}), WithTimeouts(time.Second, 0, 0, 0))
+
 		err := c.VerifyDIDAndDomain(testDID, testDomain)
+		require.EqualError(t, err, "timeouts can't be set for a custom HTTP client")
+	})
+
+	t.Run("error - connect timeout", func(t *testing.T) {
+		// non-routable address, the connection is never established
+		c := New(WithTimeouts(100*time.Millisecond, 0, 0, 0))
+
+		start := time.Now()
+
+		err := c.VerifyDIDAndDomain(testDID, "http://10.255.255.1")
 		require.Error(t, err)
-		require.Contains(t, err.Error(), "endpoint https://identity.foundation/.well-known/did-configuration.json "+
-			"returned status '404' and message 'data not found'")
+		require.Less(t, time.Since(start), 5*time.Second)
 	})
 
-	t.Run("error - did configuration missing linked DIDs", func(t *testing.T) {
+	t.Run("error - unresponsive listener", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+
+		defer func() {
+			require.NoError(t, listener.Close())
+		}()
+
+		go func() {
+			for {
+				conn, e := listener.Accept()
+				if e != nil {
+					return
+				}
+
+				// keep connection open without ever responding
+				defer conn.Close() //nolint:errcheck,gocritic // closed once the listener is closed
+			}
+		}()
+
+		c := New(WithTimeouts(100*time.Millisecond, 100*time.Millisecond, 100*time.Millisecond, time.Minute))
+
+		start := time.Now()
+
+		err = c.VerifyDIDAndDomain(testDID, "https://"+listener.Addr().String())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "TLS handshake timeout")
+		require.Less(t, time.Since(start), 5*time.Second)
+
+		start = time.Now()
+
+		err = c.VerifyDIDAndDomain(testDID, "http://"+listener.Addr().String())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "timeout awaiting response headers")
+		require.Less(t, time.Since(start), 5*time.Second)
+	})
+}
+
+func TestVerifyBatch(t *testing.T) {
+	loader, err := ldtestutil.DocumentLoader(ldcontext.Document{
+		URL:     contextV1,
+		Content: json.RawMessage(didCfgCtxV1),
+	})
+	require.NoError(t, err)
+
+	t.Run("success - DID resolved once, results in the order of the pairs", func(t *testing.T) {
+		var inFlight, maxInFlight int32
+
 		httpClient := &mockHTTPClient{
 			DoFunc: func(req *http.Request) (*http.Response, error) {
+				n := atomic.AddInt32(&inFlight, 1)
+				defer atomic.AddInt32(&inFlight, -1)
+
+				for {
+					m := atomic.LoadInt32(&maxInFlight)
+					if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+						break
+					}
+				}
+
+				time.Sleep(10 * time.Millisecond)
+
 				return &http.Response{
 					StatusCode: http.StatusOK,
-					Body:       io.NopCloser(bytes.NewReader([]byte(didCfgNoLinkedDIDs))),
+					Body:       io.NopCloser(bytes.NewReader([]byte(didCfg))),
 				}, nil
 			},
 		}
 
-		c := New(WithJSONLDDocumentLoader(loader),
-			WithVDRegistry(vdr.New(vdr.WithVDR(key.New()))),
-			WithHTTPClient(httpClient))
+		resolver := &countingResolver{resolver: vdr.New(vdr.WithVDR(key.New()))}
+
+		c := New(WithJSONLDDocumentLoader(loader), WithVDRegistry(resolver), WithHTTPClient(httpClient),
+			WithMaxConcurrency(2))
+
+		pairs := []DIDDomainPair{
+			{DID: testDID, Domain: testDomain},
+			{DID: testDID, Domain: "https://example.com"},
+			{DID: testDID, Domain: testDomain},
+			{DID: testDID, Domain: testDomain},
+			{DID: testDID, Domain: testDomain},
+		}
+
+		results := c.VerifyBatch(context.Background(), pairs)
+		require.Len(t, results, len(pairs))
+
+		for i, result := range results {
+			require.Equal(t, pairs[i], result.Pair)
+
+			if pairs[i].Domain == testDomain {
+				require.NoError(t, result.Err)
+			} else {
+				require.Error(t, result.Err)
+				require.Contains(t, result.Err.Error(), "domain linkage credential(s) not found")
+			}
+		}
+
+		require.Equal(t, 1, resolver.count)
+		require.LessOrEqual(t, atomic.LoadInt32(&maxInFlight), int32(2))
+	})
+
+	t.Run("success - empty batch", func(t *testing.T) {
+		require.Empty(t, New().VerifyBatch(context.Background(), nil))
+	})
+
+	t.Run("error - context canceled", func(t *testing.T) {
+		httpClient := &mockHTTPClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				return nil, req.Context().Err()
+			},
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		results := New(WithHTTPClient(httpClient)).VerifyBatch(ctx, []DIDDomainPair{{DID: testDID, Domain: testDomain}})
+		require.Len(t, results, 1)
+		require.ErrorIs(t, results[0].Err, context.Canceled)
+	})
+
+	t.Run("error - invalid client options", func(t *testing.T) {
+		c := New(WithHTTPClient(&mockHTTPClient{}), WithTimeouts(time.Second, 0, 0, 0))
+
+		results := c.VerifyBatch(context.Background(), []DIDDomainPair{{DID: testDID, Domain: testDomain}})
+		require.Len(t, results, 1)
+		require.EqualError(t, results[0].Err, "timeouts can't be set for a custom HTTP client")
+	})
+}
+
+func TestWithHTTP2(t *testing.T) {
+	var proto string
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proto = r.Proto
+
+		_, err := w.Write([]byte(didCfg))
+		require.NoError(t, err)
+	}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+
+	defer server.Close()
+
+	newClient := func(t *testing.T, opts ...Option) *Client {
+		t.Helper()
+
+		c := New(opts...)
+		require.NoError(t, c.err)
+
+		// trust the certificate of the test server
+		transport := c.httpClient.(*http.Client).Transport.(*http.Transport) //nolint:forcetypeassert
+		transport.TLSClientConfig = &tls.Config{
+			RootCAs:    server.Client().Transport.(*http.Transport).TLSClientConfig.RootCAs, //nolint:forcetypeassert
+			MinVersion: tls.VersionTLS12,
+		}
+
+		return c
+	}
+
+	t.Run("success - HTTP/2 is negotiated", func(t *testing.T) {
+		_, err := newClient(t, WithHTTP2(true)).fetchDIDConfiguration(context.Background(), server.URL)
+		require.NoError(t, err)
+		require.Equal(t, "HTTP/2.0", proto)
+	})
+
+	t.Run("success - HTTP/2 is disabled", func(t *testing.T) {
+		_, err := newClient(t, WithHTTP2(false)).fetchDIDConfiguration(context.Background(), server.URL)
+		require.NoError(t, err)
+		require.Equal(t, "HTTP/1.1", proto)
+	})
+
+	t.Run("error - custom HTTP client", func(t *testing.T) {
+		c := New(WithHTTPClient(&http.Client{}), WithHTTP2(true))
 
 		err := c.VerifyDIDAndDomain(testDID, testDomain)
-		require.Error(t, err)
-		require.Contains(t, err.Error(), "did configuration: property 'linked_dids' is required ")
+		require.EqualError(t, err, "HTTP/2 can't be configured for a custom HTTP client")
 	})
 }
 
@@ -155,6 +2182,20 @@ func TestCloseResponseBody(t *testing.T) {
 	})
 }
 
+type countingResolver struct {
+	resolver didResolver
+	mu       sync.Mutex
+	count    int
+}
+
+func (r *countingResolver) Resolve(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+	r.mu.Lock()
+	r.count++
+	r.mu.Unlock()
+
+	return r.resolver.Resolve(didID, opts...)
+}
+
 type mockHTTPClient struct {
 	DoFunc func(req *http.Request) (*http.Response, error)
 }
@@ -274,6 +2315,30 @@ func TestInterop(t *testing.T) {
 		err = c.VerifyDIDAndDomain(msDID, msDomain)
 		require.NoError(t, err)
 	})
+
+	t.Run("error - v0 configuration is rejected if only v1 is allowed", func(t *testing.T) {
+		c := New(WithJSONLDDocumentLoader(loader), WithHTTPClient(httpClient),
+			WithAllowedConfigContexts(didconfig.ContextV1))
+
+		err = c.VerifyDIDAndDomain(msDID, msDomain)
+		require.EqualError(t, err, "did configuration @context["+contextV0+"] is not allowed")
+	})
+
+	t.Run("success - v1 configuration is accepted if only v1 is allowed", func(t *testing.T) {
+		httpClient := &mockHTTPClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewReader([]byte(didCfg))),
+				}, nil
+			},
+		}
+
+		c := New(WithJSONLDDocumentLoader(loader), WithHTTPClient(httpClient),
+			WithAllowedConfigContexts(didconfig.ContextV1))
+
+		require.NoError(t, c.VerifyDIDAndDomain(testDID, testDomain))
+	})
 }
 
 // ms constants.
@@ -393,3 +2458,65 @@ const (
   "didResolutionMetadata": ` + msResolutionMetadata + `
 }`
 )
+
+func TestClient_Stats(t *testing.T) {
+	loader, err := ldtestutil.DocumentLoader(ldcontext.Document{
+		URL:     contextV1,
+		Content: json.RawMessage(didCfgCtxV1),
+	})
+	require.NoError(t, err)
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		res.WriteHeader(http.StatusOK)
+		_, err := res.Write([]byte(didCfg))
+		require.NoError(t, err)
+	}))
+
+	defer testServer.Close()
+
+	t.Run("connection is reused across same-host verifications", func(t *testing.T) {
+		c := New(WithJSONLDDocumentLoader(loader))
+		require.Equal(t, TransportStats{}, c.Stats())
+
+		const verifications = 3
+
+		for i := 0; i < verifications; i++ {
+			// the did configuration doesn't link the test server domain, only the connections matter here
+			err := c.VerifyDIDAndDomain(testDID, testServer.URL)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "domain linkage credential(s) not found")
+
+			stats := c.Stats()
+			require.Equal(t, int64(i+1), stats.Requests)
+			require.Equal(t, int64(i), stats.ReusedConnections)
+			require.Equal(t, int64(1), stats.OpenConnections)
+			require.Equal(t, int64(0), stats.ActiveConnections)
+			require.Equal(t, int64(1), stats.IdleConnections)
+		}
+	})
+
+	t.Run("open connections are not counted for custom HTTP client", func(t *testing.T) {
+		c := New(WithJSONLDDocumentLoader(loader), WithHTTPClient(&http.Client{Transport: &http.Transport{}}))
+
+		for i := 0; i < 2; i++ {
+			require.Error(t, c.VerifyDIDAndDomain(testDID, testServer.URL))
+		}
+
+		stats := c.Stats()
+		require.Equal(t, int64(2), stats.Requests)
+		require.Equal(t, int64(1), stats.ReusedConnections)
+		require.Equal(t, int64(0), stats.OpenConnections)
+		require.Equal(t, int64(0), stats.IdleConnections)
+	})
+
+	t.Run("failed request doesn't count connection", func(t *testing.T) {
+		c := New(WithJSONLDDocumentLoader(loader), WithHTTPClient(&mockHTTPClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				return nil, fmt.Errorf("http client error")
+			},
+		}))
+
+		require.Error(t, c.VerifyDIDAndDomain(testDID, testDomain))
+		require.Equal(t, TransportStats{}, c.Stats())
+	})
+}
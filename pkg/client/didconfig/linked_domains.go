@@ -0,0 +1,53 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package didconfig
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/didconfig"
+)
+
+// DomainVerification is the outcome of the verification of a domain claimed by a DID, refer VerifyLinkedDomains.
+type DomainVerification struct {
+	// Domain is the origin claimed in a LinkedDomains service of the DID document.
+	Domain string
+	// Result describes the domain linkage credential which satisfied the verification, nil if Err is not nil.
+	Result *VerificationResult
+	// Err is nil if the domain linkage is verified.
+	Err error
+}
+
+// VerifyLinkedDomains resolves the DID, discovers the domains it claims in the LinkedDomains services of its
+// DID document (see didconfig.ClaimedDomains) and verifies the domain linkage of each domain like
+// VerifyDIDAndDomainWithResult. The DID is resolved once. Each domain has its own outcome so that a partial success
+// is visible, an error is returned only if the DID can't be resolved or its LinkedDomains services are invalid.
+func (c *Client) VerifyLinkedDomains(did string) ([]DomainVerification, error) {
+	verifier, err := c.PrepareVerification(did)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := verifier.DocResolution().DIDDocument
+	if doc == nil {
+		return nil, fmt.Errorf("DID %s is resolved without DID document", did)
+	}
+
+	domains, err := didconfig.ClaimedDomains(doc)
+	if err != nil {
+		return nil, fmt.Errorf("domains claimed by DID %s: %w", did, err)
+	}
+
+	verifications := make([]DomainVerification, len(domains))
+
+	for i, domain := range domains {
+		verifications[i].Domain = domain
+		verifications[i].Result, verifications[i].Err = verifier.verifyDomain(domain)
+	}
+
+	return verifications, nil
+}
@@ -0,0 +1,60 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package didconfig
+
+import (
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+)
+
+// DefaultResolutionCacheTTL is the time to live of the DID resolutions stored in the ResolutionCache.
+const DefaultResolutionCacheTTL = 5 * time.Minute
+
+// ResolutionCache caches DID resolutions. Implementations must be safe for concurrent use.
+type ResolutionCache interface {
+	// Get returns the cached resolution of the DID, false if the DID isn't cached or the resolution expired.
+	Get(did string) (*did.DocResolution, bool)
+	// Put caches the resolution of the DID for ttl.
+	Put(did string, doc *did.DocResolution, ttl time.Duration)
+}
+
+// WithDIDResolutionCache looks up the DID resolutions in the cache before resolving DIDs with the VDR registry,
+// and stores the successful resolutions in the cache for DefaultResolutionCacheTTL. By default, DIDs are
+// resolved on every verification.
+func WithDIDResolutionCache(cache ResolutionCache) Option {
+	return func(opts *Client) {
+		opts.resolutionCache = cache
+	}
+}
+
+// cachingResolver resolves DIDs through the resolution cache of the client.
+type cachingResolver struct {
+	resolver didResolver
+	cache    ResolutionCache
+}
+
+func (r *cachingResolver) Resolve(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+	if len(opts) > 0 {
+		// DID method options may change the resolution
+		return r.resolver.Resolve(didID, opts...)
+	}
+
+	if docResolution, ok := r.cache.Get(didID); ok {
+		return docResolution, nil
+	}
+
+	docResolution, err := r.resolver.Resolve(didID)
+	if err != nil {
+		return nil, err
+	}
+
+	r.cache.Put(didID, docResolution, DefaultResolutionCacheTTL)
+
+	return docResolution, nil
+}
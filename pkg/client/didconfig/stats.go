@@ -0,0 +1,118 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package didconfig
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"sync/atomic"
+)
+
+// TransportStats holds HTTP connection statistics of the did configuration requests made by the client.
+type TransportStats struct {
+	// Requests is the number of requests which got a connection.
+	Requests int64
+	// ReusedConnections is the number of requests which reused a previously opened (kept-alive) connection.
+	ReusedConnections int64
+	// OpenConnections is the number of connections opened by the default transport and not closed yet.
+	// It is always zero for a custom HTTP client (WithHTTPClient).
+	OpenConnections int64
+	// ActiveConnections is the number of connections in use by in-flight requests.
+	ActiveConnections int64
+	// IdleConnections is the number of open connections idle in the pool of the default transport.
+	// It is always zero for a custom HTTP client (WithHTTPClient).
+	IdleConnections int64
+}
+
+// transportStats counts connections of the did configuration requests.
+type transportStats struct {
+	requests int64
+	reused   int64
+	open     int64
+	active   int64
+}
+
+// Stats returns HTTP connection statistics (e.g. pool usage and connection reuse) of the client,
+// which help to tune the pool sizes of the transport under load.
+func (c *Client) Stats() TransportStats {
+	open := atomic.LoadInt64(&c.stats.open)
+	active := atomic.LoadInt64(&c.stats.active)
+
+	idle := open - active
+	if idle < 0 {
+		// connections of a custom HTTP client are not counted
+		idle = 0
+	}
+
+	return TransportStats{
+		Requests:          atomic.LoadInt64(&c.stats.requests),
+		ReusedConnections: atomic.LoadInt64(&c.stats.reused),
+		OpenConnections:   open,
+		ActiveConnections: active,
+		IdleConnections:   idle,
+	}
+}
+
+// withTrace returns the request context traced for connection statistics, and a function to be called once
+// the response body is closed.
+func (s *transportStats) withTrace(ctx context.Context) (context.Context, func()) {
+	var gotConn int32
+
+	trace := &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			atomic.StoreInt32(&gotConn, 1)
+			atomic.AddInt64(&s.requests, 1)
+			atomic.AddInt64(&s.active, 1)
+
+			if info.Reused {
+				atomic.AddInt64(&s.reused, 1)
+			}
+		},
+	}
+
+	return httptrace.WithClientTrace(ctx, trace), func() {
+		if atomic.LoadInt32(&gotConn) == 1 {
+			atomic.AddInt64(&s.active, -1)
+		}
+	}
+}
+
+// countConnections makes the transport count the connections it opens and closes.
+func (s *transportStats) countConnections(transport *http.Transport) {
+	dial := transport.DialContext
+	if dial == nil {
+		dial = (&net.Dialer{}).DialContext
+	}
+
+	transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := dial(ctx, network, addr)
+		if err != nil {
+			return nil, err
+		}
+
+		atomic.AddInt64(&s.open, 1)
+
+		return &countedConn{Conn: conn, stats: s}, nil
+	}
+}
+
+type countedConn struct {
+	net.Conn
+	stats     *transportStats
+	closeOnce sync.Once
+}
+
+func (c *countedConn) Close() error {
+	c.closeOnce.Do(func() {
+		atomic.AddInt64(&c.stats.open, -1)
+	})
+
+	return c.Conn.Close()
+}
@@ -10,6 +10,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/utils"
 )
 
 // EndpointType endpoint type.
@@ -76,6 +78,28 @@ func NewDIDCoreEndpoint(genericEndpoint interface{}) Endpoint {
 	}
 }
 
+// Clone returns a deep copy of the endpoint.
+func (s *Endpoint) Clone() Endpoint {
+	var clone Endpoint
+
+	if s.rawDIDCommV2 != nil {
+		clone.rawDIDCommV2 = make([]DIDCommV2Endpoint, len(s.rawDIDCommV2))
+
+		for i, e := range s.rawDIDCommV2 {
+			clone.rawDIDCommV2[i] = DIDCommV2Endpoint{
+				URI:         e.URI,
+				Accept:      utils.CopyStrings(e.Accept),
+				RoutingKeys: utils.CopyStrings(e.RoutingKeys),
+			}
+		}
+	}
+
+	clone.rawDIDCommV1 = s.rawDIDCommV1
+	clone.rawObj = utils.DeepCopyValue(s.rawObj)
+
+	return clone
+}
+
 // URI is the URI of a service endpoint.
 // It will return the value based on the underlying endpoint type in the following order:
 // 1- DIDComm V2 URI (currently the first element's URI). TODO enhance API to pass in an optional index.
@@ -21,3 +21,59 @@ func CopyMap(m map[string]interface{}) map[string]interface{} {
 
 	return cm
 }
+
+// DeepCopyValue performs deep copy of a JSON-like value: maps, slices and nested maps and slices are copied,
+// other values (e.g. strings and numbers) are shared.
+func DeepCopyValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		if t == nil {
+			return t
+		}
+
+		cm := make(map[string]interface{}, len(t))
+
+		for k, e := range t {
+			cm[k] = DeepCopyValue(e)
+		}
+
+		return cm
+	case []interface{}:
+		if t == nil {
+			return t
+		}
+
+		cs := make([]interface{}, len(t))
+
+		for i, e := range t {
+			cs[i] = DeepCopyValue(e)
+		}
+
+		return cs
+	case []string:
+		return CopyStrings(t)
+	case []map[string]interface{}:
+		if t == nil {
+			return t
+		}
+
+		cs := make([]map[string]interface{}, len(t))
+
+		for i, e := range t {
+			cs[i], _ = DeepCopyValue(e).(map[string]interface{}) //nolint:errcheck
+		}
+
+		return cs
+	default:
+		return v
+	}
+}
+
+// CopyStrings returns a copy of the string slice, nil if the slice is nil.
+func CopyStrings(s []string) []string {
+	if s == nil {
+		return nil
+	}
+
+	return append(make([]string, 0, len(s)), s...)
+}
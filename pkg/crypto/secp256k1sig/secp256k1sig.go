@@ -0,0 +1,105 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package secp256k1sig signs and verifies arbitrary bytes with ECDSA secp256k1 keys. The message is hashed
+// with SHA-256 and the signature is the 64-byte concatenation of R and S with canonical low S, as used
+// by the ES256K JWS algorithm.
+package secp256k1sig
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+)
+
+const (
+	// SignatureSize is the size of the signature in bytes.
+	SignatureSize = 2 * keySize
+
+	keySize = 32
+)
+
+// ErrInvalidSignature is returned if the signature doesn't verify.
+var ErrInvalidSignature = errors.New("invalid secp256k1 signature")
+
+// Sign signs the SHA-256 hash of msg with the secp256k1 private key.
+func Sign(priv *ecdsa.PrivateKey, msg []byte) ([]byte, error) {
+	if priv == nil || !isS256(priv.PublicKey) {
+		return nil, errors.New("private key is not a secp256k1 key")
+	}
+
+	hashed := sha256.Sum256(msg)
+
+	// sign over jose.S256() to not depend on the curve implementation of the key
+	key := &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{Curve: jose.S256(), X: priv.X, Y: priv.Y},
+		D:         priv.D,
+	}
+
+	r, s, err := ecdsa.Sign(rand.Reader, key, hashed[:])
+	if err != nil {
+		return nil, fmt.Errorf("sign: %w", err)
+	}
+
+	n := jose.S256().Params().N
+
+	// canonical signature has S in the lower half of the curve order
+	if s.Cmp(new(big.Int).Rsh(n, 1)) > 0 {
+		s.Sub(n, s)
+	}
+
+	sig := make([]byte, SignatureSize)
+	r.FillBytes(sig[:keySize])
+	s.FillBytes(sig[keySize:])
+
+	return sig, nil
+}
+
+// Verify verifies the signature of msg with the secp256k1 public key.
+// Signatures which are not 64 bytes long or have high S are rejected.
+func Verify(pub *ecdsa.PublicKey, msg, sig []byte) error {
+	if pub == nil || !isS256(*pub) {
+		return errors.New("public key is not a secp256k1 key")
+	}
+
+	if len(sig) != SignatureSize {
+		return fmt.Errorf("%w: invalid signature size %d", ErrInvalidSignature, len(sig))
+	}
+
+	r := new(big.Int).SetBytes(sig[:keySize])
+	s := new(big.Int).SetBytes(sig[keySize:])
+
+	if s.Cmp(new(big.Int).Rsh(jose.S256().Params().N, 1)) > 0 {
+		return fmt.Errorf("%w: signature is not canonical (high S)", ErrInvalidSignature)
+	}
+
+	hashed := sha256.Sum256(msg)
+
+	key := &ecdsa.PublicKey{Curve: jose.S256(), X: pub.X, Y: pub.Y}
+
+	if !ecdsa.Verify(key, hashed[:], r, s) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+// isS256 checks that the key is on secp256k1, regardless of the curve implementation.
+func isS256(pub ecdsa.PublicKey) bool {
+	if pub.Curve == nil || pub.X == nil || pub.Y == nil {
+		return false
+	}
+
+	params, s256 := pub.Curve.Params(), jose.S256().Params()
+
+	return params.P.Cmp(s256.P) == 0 && params.N.Cmp(s256.N) == 0 && params.B.Cmp(s256.B) == 0 &&
+		params.Gx.Cmp(s256.Gx) == 0 && params.Gy.Cmp(s256.Gy) == 0 && jose.S256().IsOnCurve(pub.X, pub.Y)
+}
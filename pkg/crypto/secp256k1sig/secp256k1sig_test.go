@@ -0,0 +1,132 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package secp256k1sig_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/secp256k1sig"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/signature"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+func TestSignVerify(t *testing.T) {
+	privKey, err := ecdsa.GenerateKey(jose.S256(), rand.Reader)
+	require.NoError(t, err)
+
+	msg := []byte("test message")
+
+	t.Run("success", func(t *testing.T) {
+		for i := 0; i < 20; i++ {
+			sig, err := secp256k1sig.Sign(privKey, msg)
+			require.NoError(t, err)
+			require.Len(t, sig, secp256k1sig.SignatureSize)
+			require.False(t, isHighS(sig))
+
+			require.NoError(t, secp256k1sig.Verify(&privKey.PublicKey, msg, sig))
+		}
+	})
+
+	t.Run("fail: tampered message", func(t *testing.T) {
+		sig, err := secp256k1sig.Sign(privKey, msg)
+		require.NoError(t, err)
+
+		err = secp256k1sig.Verify(&privKey.PublicKey, []byte("other message"), sig)
+		require.ErrorIs(t, err, secp256k1sig.ErrInvalidSignature)
+	})
+
+	t.Run("fail: high S signature", func(t *testing.T) {
+		sig, err := secp256k1sig.Sign(privKey, msg)
+		require.NoError(t, err)
+
+		// (r, N-s) is a valid ECDSA signature as well, but not a canonical one
+		s := new(big.Int).SetBytes(sig[32:])
+		s.Sub(jose.S256().Params().N, s).FillBytes(sig[32:])
+
+		err = secp256k1sig.Verify(&privKey.PublicKey, msg, sig)
+		require.ErrorIs(t, err, secp256k1sig.ErrInvalidSignature)
+		require.Contains(t, err.Error(), "high S")
+	})
+
+	t.Run("fail: invalid signature size", func(t *testing.T) {
+		err := secp256k1sig.Verify(&privKey.PublicKey, msg, make([]byte, 65))
+		require.ErrorIs(t, err, secp256k1sig.ErrInvalidSignature)
+		require.Contains(t, err.Error(), "invalid signature size 65")
+	})
+
+	t.Run("fail: zero signature", func(t *testing.T) {
+		err := secp256k1sig.Verify(&privKey.PublicKey, msg, make([]byte, secp256k1sig.SignatureSize))
+		require.ErrorIs(t, err, secp256k1sig.ErrInvalidSignature)
+	})
+
+	t.Run("fail: not a secp256k1 key", func(t *testing.T) {
+		p256Key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		_, err = secp256k1sig.Sign(p256Key, msg)
+		require.EqualError(t, err, "private key is not a secp256k1 key")
+
+		_, err = secp256k1sig.Sign(nil, msg)
+		require.EqualError(t, err, "private key is not a secp256k1 key")
+
+		err = secp256k1sig.Verify(&p256Key.PublicKey, msg, make([]byte, secp256k1sig.SignatureSize))
+		require.EqualError(t, err, "public key is not a secp256k1 key")
+	})
+}
+
+func TestCrossVerification(t *testing.T) {
+	msg := []byte("test message")
+
+	t.Run("ES256K verifier verifies secp256k1sig signature", func(t *testing.T) {
+		privKey, err := ecdsa.GenerateKey(jose.S256(), rand.Reader)
+		require.NoError(t, err)
+
+		sig, err := secp256k1sig.Sign(privKey, msg)
+		require.NoError(t, err)
+
+		pubKeyBytes := elliptic.Marshal(privKey.Curve, privKey.X, privKey.Y)
+
+		err = verifier.NewECDSASecp256k1SignatureVerifier().Verify(&verifier.PublicKey{
+			Type:  "EcdsaSecp256k1VerificationKey2019",
+			Value: pubKeyBytes,
+		}, msg, sig)
+		require.NoError(t, err)
+	})
+
+	t.Run("secp256k1sig verifies ES256K signer signature", func(t *testing.T) {
+		signer, err := signature.NewSigner(kms.ECDSASecp256k1TypeIEEEP1363)
+		require.NoError(t, err)
+
+		pubKey, ok := signer.PublicKey().(*ecdsa.PublicKey)
+		require.True(t, ok)
+
+		sig, err := signer.Sign(msg)
+		require.NoError(t, err)
+
+		// ES256K signer doesn't normalize S, bring the signature to the canonical form
+		if isHighS(sig) {
+			s := new(big.Int).SetBytes(sig[32:])
+			s.Sub(jose.S256().Params().N, s).FillBytes(sig[32:])
+		}
+
+		require.NoError(t, secp256k1sig.Verify(pubKey, msg, sig))
+	})
+}
+
+func isHighS(sig []byte) bool {
+	s := new(big.Int).SetBytes(sig[32:])
+
+	return s.Cmp(new(big.Int).Rsh(jose.S256().Params().N, 1)) > 0
+}
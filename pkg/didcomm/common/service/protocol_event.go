@@ -0,0 +1,263 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// DefaultProtocolEventBuffer is the default buffer size of the channels returned by ProtocolEventStream.Subscribe.
+const DefaultProtocolEventBuffer = 100
+
+// ProtocolEventType is the type of ProtocolEvent.
+type ProtocolEventType int
+
+const (
+	// ProtocolStateChanging is emitted before the protocol transitions to the state (StateMsg of PreState type).
+	ProtocolStateChanging ProtocolEventType = iota
+
+	// ProtocolStateChanged is emitted after the protocol transitioned to the state (StateMsg of PostState type).
+	ProtocolStateChanged
+
+	// ProtocolError is emitted when the protocol failed to process the message, ProtocolEvent.Err holds the error.
+	ProtocolError
+)
+
+// ProtocolEvent is a lifecycle event of a DIDComm protocol. Refer ProtocolEventStream.
+type ProtocolEvent struct {
+	// Type of the event.
+	Type ProtocolEventType
+
+	// Name of the protocol, e.g. didexchange.DIDExchange.
+	ProtocolName string
+
+	// ThreadID of the protocol message, empty if the message has no thread.
+	ThreadID string
+
+	// MsgType is the type of the DIDComm message being processed.
+	MsgType string
+
+	// StateID is the state of the protocol. Refer protocol RFC for possible states.
+	StateID string
+
+	// Err is the processing error of ProtocolError events.
+	Err error
+}
+
+// ProtocolEventStream merges the message events (StateMsg) of any number of protocol services into
+// a single stream of ProtocolEvent, with any number of subscribers.
+//
+// Events are never blocking the protocol services: if the buffer of a subscriber is full, the event is
+// dropped for that subscriber and counted by Dropped().
+type ProtocolEventStream struct {
+	mu          sync.RWMutex
+	subscribers map[<-chan ProtocolEvent]chan ProtocolEvent
+	listened    []listenedService
+	bufferSize  int
+	closed      bool
+	done        chan struct{}
+	dropped     uint64
+}
+
+type listenedService struct {
+	svc Event
+	ch  chan StateMsg
+}
+
+// NewProtocolEventStream creates a new ProtocolEventStream. The channels returned by Subscribe buffer
+// bufferSize events, DefaultProtocolEventBuffer if bufferSize is not positive.
+func NewProtocolEventStream(bufferSize int) *ProtocolEventStream {
+	if bufferSize <= 0 {
+		bufferSize = DefaultProtocolEventBuffer
+	}
+
+	return &ProtocolEventStream{
+		subscribers: map[<-chan ProtocolEvent]chan ProtocolEvent{},
+		bufferSize:  bufferSize,
+		done:        make(chan struct{}),
+	}
+}
+
+// Subscribe returns a new channel receiving the protocol events. The channel is closed by Unsubscribe or Close.
+func (s *ProtocolEventStream) Subscribe() <-chan ProtocolEvent {
+	ch := make(chan ProtocolEvent, s.bufferSize)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		close(ch)
+
+		return ch
+	}
+
+	s.subscribers[ch] = ch
+
+	return ch
+}
+
+// Unsubscribe stops sending the protocol events to the channel returned by Subscribe and closes it.
+func (s *ProtocolEventStream) Unsubscribe(ch <-chan ProtocolEvent) error {
+	if ch == nil {
+		return ErrNilChannel
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	subscriber, ok := s.subscribers[ch]
+	if !ok {
+		return ErrInvalidChannel
+	}
+
+	delete(s.subscribers, ch)
+	close(subscriber)
+
+	return nil
+}
+
+// Listen registers a message event channel with the protocol service and publishes its message events
+// until the stream is closed.
+func (s *ProtocolEventStream) Listen(svc Event) error {
+	ch := make(chan StateMsg, s.bufferSize)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return errors.New("protocol event stream is closed")
+	}
+
+	if err := svc.RegisterMsgEvent(ch); err != nil {
+		return err
+	}
+
+	s.listened = append(s.listened, listenedService{svc: svc, ch: ch})
+
+	go func() {
+		for {
+			select {
+			case msg := <-ch:
+				s.Publish(msg)
+			case <-s.done:
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Publish sends the protocol event of the message event to the subscribers.
+func (s *ProtocolEventStream) Publish(msg StateMsg) {
+	event := newProtocolEvent(msg)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, subscriber := range s.subscribers {
+		select {
+		case subscriber <- event:
+		default:
+			atomic.AddUint64(&s.dropped, 1)
+		}
+	}
+}
+
+// Dropped returns the number of events dropped because the buffer of a subscriber was full.
+func (s *ProtocolEventStream) Dropped() uint64 {
+	return atomic.LoadUint64(&s.dropped)
+}
+
+// Close unregisters the stream from the protocol services and closes the channels of the subscribers.
+func (s *ProtocolEventStream) Close() error {
+	s.mu.Lock()
+
+	if s.closed {
+		s.mu.Unlock()
+
+		return nil
+	}
+
+	s.closed = true
+	listened := s.listened
+	s.listened = nil
+
+	s.mu.Unlock()
+
+	var err error
+
+	// the services are unregistered before the forwarding goroutines stop, so that a service sending
+	// a message event meanwhile is not blocked by a full channel nobody reads; the lock is not held as
+	// the forwarding goroutines need it to publish. The message event channels are not closed as
+	// a protocol service may still be sending to them.
+	for _, l := range listened {
+		if e := l.svc.UnregisterMsgEvent(l.ch); e != nil && err == nil {
+			err = e
+		}
+	}
+
+	close(s.done)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for ch, subscriber := range s.subscribers {
+		delete(s.subscribers, ch)
+		close(subscriber)
+	}
+
+	return err
+}
+
+func newProtocolEvent(msg StateMsg) ProtocolEvent {
+	event := ProtocolEvent{
+		Type:         ProtocolStateChanging,
+		ProtocolName: msg.ProtocolName,
+		StateID:      msg.StateID,
+		Err:          eventError(msg.Properties),
+	}
+
+	if msg.Type == PostState {
+		event.Type = ProtocolStateChanged
+	}
+
+	if event.Err != nil {
+		event.Type = ProtocolError
+	}
+
+	if msg.Msg != nil {
+		event.MsgType = msg.Msg.Type()
+		event.ThreadID, _ = msg.Msg.ThreadID() //nolint:errcheck
+	}
+
+	return event
+}
+
+// eventError returns the processing error reported by the protocol in the event properties.
+func eventError(props EventProperties) error {
+	if props == nil {
+		return nil
+	}
+
+	if err, ok := props.(error); ok && err.Error() != "" {
+		return err
+	}
+
+	switch e := props.All()["error"].(type) {
+	case error:
+		return e
+	case string:
+		if e != "" {
+			return errors.New(e)
+		}
+	}
+
+	return nil
+}
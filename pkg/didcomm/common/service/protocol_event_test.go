@@ -0,0 +1,163 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type eventService struct {
+	Action
+	Message
+}
+
+func (s *eventService) send(msg StateMsg) {
+	for _, ch := range s.MsgEvents() {
+		ch <- msg
+	}
+}
+
+// unregisterService records whether the stream was still running when the service was unregistered.
+type unregisterService struct {
+	eventService
+	stream  *ProtocolEventStream
+	running bool
+}
+
+func (s *unregisterService) UnregisterMsgEvent(ch chan<- StateMsg) error {
+	select {
+	case <-s.stream.done:
+	default:
+		s.running = true
+	}
+
+	return s.eventService.UnregisterMsgEvent(ch)
+}
+
+type errorProperties struct {
+	err error
+}
+
+func (p *errorProperties) All() map[string]interface{} {
+	return map[string]interface{}{"error": p.err}
+}
+
+func TestProtocolEventStream(t *testing.T) {
+	t.Run("events of all listened services", func(t *testing.T) {
+		stream := NewProtocolEventStream(0)
+
+		svc1, svc2 := &eventService{}, &eventService{}
+		require.NoError(t, stream.Listen(svc1))
+		require.NoError(t, stream.Listen(svc2))
+
+		events := stream.Subscribe()
+
+		msg := NewDIDCommMsgMap(struct {
+			ID   string `json:"@id"`
+			Type string `json:"@type"`
+		}{ID: "thread-1", Type: "https://didcomm.org/test/1.0/request"})
+
+		svc1.send(StateMsg{ProtocolName: "proto-1", Type: PreState, StateID: "requested", Msg: msg})
+		require.Equal(t, ProtocolEvent{
+			Type:         ProtocolStateChanging,
+			ProtocolName: "proto-1",
+			ThreadID:     "thread-1",
+			MsgType:      "https://didcomm.org/test/1.0/request",
+			StateID:      "requested",
+		}, receiveEvent(t, events))
+
+		svc2.send(StateMsg{ProtocolName: "proto-2", Type: PostState, StateID: "completed", Msg: msg})
+		event := receiveEvent(t, events)
+		require.Equal(t, ProtocolStateChanged, event.Type)
+		require.Equal(t, "proto-2", event.ProtocolName)
+
+		svc2.send(StateMsg{
+			ProtocolName: "proto-2", Type: PostState, StateID: "abandoned", Msg: msg,
+			Properties: &errorProperties{err: errors.New("processing failed")},
+		})
+		event = receiveEvent(t, events)
+		require.Equal(t, ProtocolError, event.Type)
+		require.EqualError(t, event.Err, "processing failed")
+
+		require.NoError(t, stream.Close())
+		require.Empty(t, svc1.MsgEvents())
+		require.Empty(t, svc2.MsgEvents())
+
+		_, ok := <-events
+		require.False(t, ok)
+	})
+
+	t.Run("unsubscribe", func(t *testing.T) {
+		stream := NewProtocolEventStream(0)
+
+		events := stream.Subscribe()
+		other := stream.Subscribe()
+
+		require.NoError(t, stream.Unsubscribe(events))
+
+		_, ok := <-events
+		require.False(t, ok)
+
+		stream.Publish(StateMsg{ProtocolName: "proto", StateID: "completed"})
+		require.Equal(t, "proto", receiveEvent(t, other).ProtocolName)
+
+		require.ErrorIs(t, stream.Unsubscribe(events), ErrInvalidChannel)
+		require.ErrorIs(t, stream.Unsubscribe(nil), ErrNilChannel)
+	})
+
+	t.Run("events are dropped for a slow subscriber", func(t *testing.T) {
+		stream := NewProtocolEventStream(2)
+
+		slow := stream.Subscribe()
+
+		for i := 0; i < 5; i++ {
+			stream.Publish(StateMsg{ProtocolName: "proto", StateID: "completed"})
+		}
+
+		require.Len(t, slow, 2)
+		require.EqualValues(t, 3, stream.Dropped())
+	})
+
+	t.Run("services are unregistered before the stream stops", func(t *testing.T) {
+		stream := NewProtocolEventStream(0)
+
+		svc := &unregisterService{stream: stream}
+		require.NoError(t, stream.Listen(svc))
+
+		require.NoError(t, stream.Close())
+		require.True(t, svc.running)
+		require.Empty(t, svc.MsgEvents())
+	})
+
+	t.Run("closed stream", func(t *testing.T) {
+		stream := NewProtocolEventStream(0)
+		require.NoError(t, stream.Close())
+		require.NoError(t, stream.Close())
+
+		_, ok := <-stream.Subscribe()
+		require.False(t, ok)
+
+		require.EqualError(t, stream.Listen(&eventService{}), "protocol event stream is closed")
+	})
+}
+
+func receiveEvent(t *testing.T, events <-chan ProtocolEvent) ProtocolEvent {
+	t.Helper()
+
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(time.Second):
+		require.Fail(t, "protocol event is not received")
+	}
+
+	return ProtocolEvent{}
+}
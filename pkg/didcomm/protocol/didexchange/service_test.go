@@ -965,6 +965,81 @@ func TestEventsSuccess(t *testing.T) {
 	}
 }
 
+func TestProtocolEvents(t *testing.T) {
+	sp := mockstorage.NewMockStoreProvider()
+	k := newKMS(t, sp)
+	ctx := &context{
+		kms:              k,
+		keyType:          kms.ED25519Type,
+		keyAgreementType: kms.X25519ECDHKWType,
+	}
+
+	svc, err := New(&protocol.MockProvider{
+		ServiceMap: map[string]interface{}{
+			mediator.Coordination: &mockroute.MockMediatorSvc{},
+		},
+		CustomKMS:             k,
+		KeyTypeValue:          ctx.keyType,
+		KeyAgreementTypeValue: ctx.keyAgreementType,
+	})
+	require.NoError(t, err)
+
+	actionCh := make(chan service.DIDCommAction, 10)
+	err = svc.RegisterActionEvent(actionCh)
+	require.NoError(t, err)
+
+	go func() { service.AutoExecuteActionEvent(actionCh) }()
+
+	stream := service.NewProtocolEventStream(0)
+	defer func() { require.NoError(t, stream.Close()) }()
+
+	require.NoError(t, stream.Listen(svc))
+
+	events := stream.Subscribe()
+
+	pubKey, _ := newSigningAndEncryptionDIDKeys(t, ctx)
+	id := randomString()
+	invite, err := json.Marshal(
+		&Invitation{
+			Type:          InvitationMsgType,
+			ID:            id,
+			Label:         "test",
+			RecipientKeys: []string{pubKey},
+		},
+	)
+	require.NoError(t, err)
+
+	didMsg, err := service.ParseDIDCommMsgMap(invite)
+	require.NoError(t, err)
+
+	_, err = svc.HandleInbound(didMsg, service.EmptyDIDCommContext())
+	require.NoError(t, err)
+
+	var states []string
+
+	for {
+		select {
+		case e := <-events:
+			require.Equal(t, DIDExchange, e.ProtocolName)
+			require.Equal(t, id, e.ThreadID)
+			require.Equal(t, InvitationMsgType, e.MsgType)
+			require.NoError(t, e.Err)
+
+			if e.Type == service.ProtocolStateChanged {
+				states = append(states, e.StateID)
+			}
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "protocol events are not emitted")
+		}
+
+		if len(states) > 0 && states[len(states)-1] == StateIDRequested {
+			break
+		}
+	}
+
+	require.Equal(t, []string{StateIDInvited, StateIDRequested}, states)
+}
+
 func TestContinueWithPublicDID(t *testing.T) {
 	sp := mockstorage.NewMockStoreProvider()
 	k := newKMS(t, sp)
@@ -0,0 +1,78 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package problem provides helpers for the DIDComm report-problem protocol
+// (https://identity.foundation/didcomm-messaging/spec/#problem-reports).
+package problem
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+)
+
+// ReportMsgType is the type of the problem-report message.
+const ReportMsgType = "https://didcomm.org/report-problem/2.0/problem-report"
+
+const jsonThreadID = "thid"
+
+// ErrNotProblemReport is returned when the parsed message is not a problem report.
+var ErrNotProblemReport = errors.New("not a problem report")
+
+// Problem is the problem reported by an inbound problem-report message.
+type Problem struct {
+	ThreadID string
+	Code     string
+	Comment  string
+}
+
+// Report creates a problem-report message for the thread with the given problem code and comment.
+func Report(thid, code, comment string) service.DIDCommMsgMap {
+	msg := service.NewDIDCommMsgMap(&model.ProblemReportV2{
+		Type: ReportMsgType,
+		Body: model.ProblemReportV2Body{
+			Code:    code,
+			Comment: comment,
+		},
+	})
+
+	if thid != "" {
+		msg[jsonThreadID] = thid
+	}
+
+	return msg
+}
+
+// Parse parses the inbound problem-report message.
+func Parse(msg service.DIDCommMsgMap) (*Problem, error) {
+	if msg.Type() != ReportMsgType {
+		return nil, fmt.Errorf("%w: message type %q", ErrNotProblemReport, msg.Type())
+	}
+
+	report := &model.ProblemReportV2{}
+
+	err := msg.Decode(report)
+	if err != nil {
+		return nil, fmt.Errorf("decode problem report: %w", err)
+	}
+
+	if report.Body.Code == "" {
+		return nil, errors.New("problem report code is missing")
+	}
+
+	thid, err := msg.ThreadID()
+	if err != nil {
+		return nil, fmt.Errorf("problem report thread ID: %w", err)
+	}
+
+	return &Problem{
+		ThreadID: thid,
+		Code:     report.Body.Code,
+		Comment:  report.Body.Comment,
+	}, nil
+}
@@ -0,0 +1,111 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+)
+
+func TestReport(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		msg := Report("thread-1", "e.p.xfer.cant-use-endpoint", "endpoint is not reachable")
+
+		require.Equal(t, ReportMsgType, msg.Type())
+		require.NotEmpty(t, msg.ID())
+
+		thid, err := msg.ThreadID()
+		require.NoError(t, err)
+		require.Equal(t, "thread-1", thid)
+
+		raw, err := json.Marshal(msg)
+		require.NoError(t, err)
+
+		var report map[string]interface{}
+
+		require.NoError(t, json.Unmarshal(raw, &report))
+		require.Equal(t, "thread-1", report["thid"])
+		require.Equal(t, map[string]interface{}{
+			"code":    "e.p.xfer.cant-use-endpoint",
+			"comment": "endpoint is not reachable",
+		}, report["body"])
+	})
+
+	t.Run("without thread", func(t *testing.T) {
+		msg := Report("", "e.p.req", "")
+
+		thid, err := msg.ThreadID()
+		require.NoError(t, err)
+		require.Equal(t, msg.ID(), thid)
+	})
+}
+
+func TestParse(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		raw, err := json.Marshal(Report("thread-1", "e.p.xfer.cant-use-endpoint", "endpoint is not reachable"))
+		require.NoError(t, err)
+
+		msg, err := service.ParseDIDCommMsgMap(raw)
+		require.NoError(t, err)
+
+		problem, err := Parse(msg)
+		require.NoError(t, err)
+		require.Equal(t, &Problem{
+			ThreadID: "thread-1",
+			Code:     "e.p.xfer.cant-use-endpoint",
+			Comment:  "endpoint is not reachable",
+		}, problem)
+	})
+
+	t.Run("success - inbound message", func(t *testing.T) {
+		msg, err := service.ParseDIDCommMsgMap([]byte(`{
+			"type": "https://didcomm.org/report-problem/2.0/problem-report",
+			"id": "7c9de639-c51c-4d60-ab95-103fa613c805",
+			"pthid": "1e513ad4-48c9-444e-9e7e-5b8b45c5e325",
+			"body": {"code": "e.p.xfer.cant-use-endpoint", "args": ["https://agents.r.us/inbox"]}
+		}`))
+		require.NoError(t, err)
+
+		problem, err := Parse(msg)
+		require.NoError(t, err)
+		require.Equal(t, "e.p.xfer.cant-use-endpoint", problem.Code)
+		require.Empty(t, problem.Comment)
+		require.Equal(t, "7c9de639-c51c-4d60-ab95-103fa613c805", problem.ThreadID)
+	})
+
+	t.Run("error - not a problem report", func(t *testing.T) {
+		msg := service.DIDCommMsgMap{
+			"type": "https://didcomm.org/basicmessage/2.0/message",
+			"id":   "7c9de639-c51c-4d60-ab95-103fa613c805",
+		}
+
+		problem, err := Parse(msg)
+		require.Nil(t, problem)
+		require.True(t, errors.Is(err, ErrNotProblemReport))
+	})
+
+	t.Run("error - code is missing", func(t *testing.T) {
+		problem, err := Parse(Report("thread-1", "", "no code"))
+		require.Nil(t, problem)
+		require.EqualError(t, err, "problem report code is missing")
+	})
+
+	t.Run("error - invalid body", func(t *testing.T) {
+		msg := Report("thread-1", "e.p.req", "")
+		msg["body"] = "invalid"
+
+		problem, err := Parse(msg)
+		require.Nil(t, problem)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decode problem report")
+	})
+}
@@ -0,0 +1,207 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package did
+
+import (
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/utils"
+)
+
+// Clone returns a deep copy of the DID document, so that the copy can be mutated (e.g. to resolve relative IDs)
+// without affecting the document, which may be shared e.g. by a cache. The JSON serialization of the copy
+// is the same as of the document.
+//
+// The cryptographic keys and X.509 certificates of JSON Web Keys are immutable and shared by the copy.
+func (doc *Doc) Clone() *Doc {
+	if doc == nil {
+		return nil
+	}
+
+	clone := &Doc{
+		Context:              Context(utils.DeepCopyValue(doc.Context)),
+		ID:                   doc.ID,
+		AlsoKnownAs:          utils.CopyStrings(doc.AlsoKnownAs),
+		Authentication:       cloneVerifications(doc.Authentication),
+		AssertionMethod:      cloneVerifications(doc.AssertionMethod),
+		CapabilityDelegation: cloneVerifications(doc.CapabilityDelegation),
+		CapabilityInvocation: cloneVerifications(doc.CapabilityInvocation),
+		KeyAgreement:         cloneVerifications(doc.KeyAgreement),
+		Created:              cloneTime(doc.Created),
+		Updated:              cloneTime(doc.Updated),
+		processingMeta:       doc.processingMeta,
+	}
+
+	if doc.VerificationMethod != nil {
+		clone.VerificationMethod = make([]VerificationMethod, len(doc.VerificationMethod))
+
+		for i := range doc.VerificationMethod {
+			clone.VerificationMethod[i] = *doc.VerificationMethod[i].clone()
+		}
+	}
+
+	if doc.Service != nil {
+		clone.Service = make([]Service, len(doc.Service))
+
+		for i := range doc.Service {
+			clone.Service[i] = *doc.Service[i].clone()
+		}
+	}
+
+	if doc.Proof != nil {
+		clone.Proof = make([]Proof, len(doc.Proof))
+
+		for i := range doc.Proof {
+			clone.Proof[i] = doc.Proof[i]
+			clone.Proof[i].Created = cloneTime(doc.Proof[i].Created)
+			clone.Proof[i].ProofValue = cloneBytes(doc.Proof[i].ProofValue)
+			clone.Proof[i].Nonce = cloneBytes(doc.Proof[i].Nonce)
+		}
+	}
+
+	return clone
+}
+
+// Clone returns a deep copy of the DID resolution, refer Doc.Clone.
+func (r *DocResolution) Clone() *DocResolution {
+	if r == nil {
+		return nil
+	}
+
+	clone := &DocResolution{
+		Context:     Context(utils.DeepCopyValue(r.Context)),
+		DIDDocument: r.DIDDocument.Clone(),
+	}
+
+	if r.DocumentMetadata != nil {
+		metadata := *r.DocumentMetadata
+		metadata.EquivalentID = utils.CopyStrings(metadata.EquivalentID)
+
+		if metadata.Method != nil {
+			method := *metadata.Method
+			method.UnpublishedOperations = cloneProtocolOperations(method.UnpublishedOperations)
+			method.PublishedOperations = cloneProtocolOperations(method.PublishedOperations)
+
+			metadata.Method = &method
+		}
+
+		clone.DocumentMetadata = &metadata
+	}
+
+	return clone
+}
+
+func (pk *VerificationMethod) clone() *VerificationMethod {
+	clone := *pk
+	clone.Value = cloneBytes(pk.Value)
+
+	if pk.jsonWebKey != nil {
+		jsonWebKey := *pk.jsonWebKey
+		jsonWebKey.KeyOps = utils.CopyStrings(jsonWebKey.KeyOps)
+		jsonWebKey.CertificateThumbprintSHA1 = cloneBytes(jsonWebKey.CertificateThumbprintSHA1)
+		jsonWebKey.CertificateThumbprintSHA256 = cloneBytes(jsonWebKey.CertificateThumbprintSHA256)
+
+		if jsonWebKey.Certificates != nil {
+			jsonWebKey.Certificates = append(jsonWebKey.Certificates[:0:0], jsonWebKey.Certificates...)
+		}
+
+		if jsonWebKey.CertificatesURL != nil {
+			certificatesURL := *jsonWebKey.CertificatesURL
+			jsonWebKey.CertificatesURL = &certificatesURL
+		}
+
+		clone.jsonWebKey = &jsonWebKey
+	}
+
+	return &clone
+}
+
+func (s *Service) clone() *Service {
+	clone := *s
+	clone.Type = utils.DeepCopyValue(s.Type)
+	clone.Priority = utils.DeepCopyValue(s.Priority)
+	clone.RecipientKeys = utils.CopyStrings(s.RecipientKeys)
+	clone.RoutingKeys = utils.CopyStrings(s.RoutingKeys)
+	clone.ServiceEndpoint = s.ServiceEndpoint.Clone()
+	clone.Accept = utils.CopyStrings(s.Accept)
+	clone.recipientKeysRelativeURL = cloneBoolMap(s.recipientKeysRelativeURL)
+	clone.routingKeysRelativeURL = cloneBoolMap(s.routingKeysRelativeURL)
+
+	if s.Properties != nil {
+		clone.Properties, _ = utils.DeepCopyValue(s.Properties).(map[string]interface{}) //nolint:errcheck
+	}
+
+	return &clone
+}
+
+func cloneVerifications(verifications []Verification) []Verification {
+	if verifications == nil {
+		return nil
+	}
+
+	clone := make([]Verification, len(verifications))
+
+	for i := range verifications {
+		clone[i] = verifications[i]
+		clone[i].VerificationMethod = *verifications[i].VerificationMethod.clone()
+	}
+
+	return clone
+}
+
+func cloneProtocolOperations(operations []*ProtocolOperation) []*ProtocolOperation {
+	if operations == nil {
+		return nil
+	}
+
+	clone := make([]*ProtocolOperation, len(operations))
+
+	for i, op := range operations {
+		if op == nil {
+			continue
+		}
+
+		opClone := *op
+		opClone.EquivalentReferences = utils.CopyStrings(op.EquivalentReferences)
+
+		clone[i] = &opClone
+	}
+
+	return clone
+}
+
+func cloneTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+
+	clone := *t
+
+	return &clone
+}
+
+func cloneBytes(b []byte) []byte {
+	if b == nil {
+		return nil
+	}
+
+	return append(make([]byte, 0, len(b)), b...)
+}
+
+func cloneBoolMap(m map[string]bool) map[string]bool {
+	if m == nil {
+		return nil
+	}
+
+	clone := make(map[string]bool, len(m))
+
+	for k, v := range m {
+		clone[k] = v
+	}
+
+	return clone
+}
@@ -0,0 +1,149 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package did
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	gojose "github.com/go-jose/go-jose/v3"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/model"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
+)
+
+func TestDoc_Clone(t *testing.T) {
+	t.Run("clone is serialized as the document", func(t *testing.T) {
+		for _, docJSON := range []string{validDoc, validDocWithBase, validDocV011} {
+			doc, err := ParseDocument([]byte(docJSON))
+			require.NoError(t, err)
+
+			clone := doc.Clone()
+			require.NotSame(t, doc, clone)
+
+			docBytes, err := doc.JSONBytes()
+			require.NoError(t, err)
+
+			cloneBytes, err := clone.JSONBytes()
+			require.NoError(t, err)
+			require.JSONEq(t, string(docBytes), string(cloneBytes))
+		}
+	})
+
+	t.Run("mutation of clone doesn't affect the document", func(t *testing.T) {
+		doc, err := ParseDocument([]byte(validDocWithBase))
+		require.NoError(t, err)
+
+		pubKey, _, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		jwkVM, err := NewVerificationMethodFromJWK(did+"#jwk", "JsonWebKey2020", did,
+			&jwk.JWK{JSONWebKey: gojose.JSONWebKey{Key: pubKey, KeyID: "jwk"}, Kty: "OKP", Crv: "Ed25519"})
+		require.NoError(t, err)
+
+		doc.VerificationMethod = append(doc.VerificationMethod, *jwkVM)
+		doc.Service = append(doc.Service, Service{
+			ID:   did + "#linked-domains",
+			Type: []interface{}{"LinkedDomains"},
+			ServiceEndpoint: model.NewDIDCoreEndpoint(map[string]interface{}{
+				"origins": []interface{}{"https://example.com"},
+			}),
+			Properties: map[string]interface{}{"nested": map[string]interface{}{"key": "value"}},
+		})
+
+		docBytes, err := doc.JSONBytes()
+		require.NoError(t, err)
+
+		clone := doc.Clone()
+
+		clone.Context.([]string)[0] = "https://example.com/context" //nolint:forcetypeassert
+		clone.ID = "did:example:mutated"
+		clone.AlsoKnownAs = append(clone.AlsoKnownAs, "https://example.com")
+
+		clone.VerificationMethod[0].ID = "#mutated"
+		clone.VerificationMethod[0].Value[0] ^= 0xff
+		clone.VerificationMethod[len(clone.VerificationMethod)-1].JSONWebKey().KeyID = "mutated"
+		clone.VerificationMethod[len(clone.VerificationMethod)-1].JSONWebKey().Crv = "X25519"
+
+		clone.Authentication[0].VerificationMethod.Value[0] ^= 0xff
+		clone.Authentication[1].VerificationMethod.Value[0] ^= 0xff
+
+		svc := &clone.Service[len(clone.Service)-1]
+		svc.Type.([]interface{})[0] = "Mutated"                              //nolint:forcetypeassert
+		svc.Properties["nested"].(map[string]interface{})["key"] = "mutated" //nolint:forcetypeassert
+		svc.ServiceEndpoint = model.NewDIDCommV1Endpoint("https://mutated.example.com")
+		clone.Service[1].RecipientKeys[0] = "#mutated"
+		clone.Service[1].RoutingKeys = append(clone.Service[1].RoutingKeys[:0], "#mutated")
+
+		*clone.Created = clone.Created.Add(time.Hour)
+		clone.Proof[0].ProofValue[0] ^= 0xff
+		clone.Proof[0].Creator = "did:example:mutated#key-1"
+
+		mutatedBytes, err := clone.JSONBytes()
+		require.NoError(t, err)
+		require.NotEqual(t, string(docBytes), string(mutatedBytes))
+
+		afterBytes, err := doc.JSONBytes()
+		require.NoError(t, err)
+		require.JSONEq(t, string(docBytes), string(afterBytes))
+	})
+
+	t.Run("mutation of endpoint of clone doesn't affect the document", func(t *testing.T) {
+		origins := []interface{}{"https://example.com"}
+
+		doc := &Doc{ID: did, Service: []Service{{
+			ID:              did + "#linked-domains",
+			Type:            "LinkedDomains",
+			ServiceEndpoint: model.NewDIDCoreEndpoint(map[string]interface{}{"origins": origins}),
+		}}}
+
+		clone := doc.Clone()
+
+		endpointBytes, err := clone.Service[0].ServiceEndpoint.MarshalJSON()
+		require.NoError(t, err)
+		require.JSONEq(t, `{"origins":["https://example.com"]}`, string(endpointBytes))
+
+		// the endpoint of the clone doesn't share the origins
+		origins[0] = "https://mutated.example.com"
+
+		endpointBytes, err = clone.Service[0].ServiceEndpoint.MarshalJSON()
+		require.NoError(t, err)
+		require.JSONEq(t, `{"origins":["https://example.com"]}`, string(endpointBytes))
+	})
+
+	t.Run("nil document", func(t *testing.T) {
+		var doc *Doc
+
+		require.Nil(t, doc.Clone())
+	})
+}
+
+func TestDocResolution_Clone(t *testing.T) {
+	docResolution, err := ParseDocumentResolution([]byte(validDocResolution))
+	require.NoError(t, err)
+
+	resolutionBytes, err := docResolution.JSONBytes()
+	require.NoError(t, err)
+
+	clone := docResolution.Clone()
+	require.Equal(t, docResolution.DocumentMetadata, clone.DocumentMetadata)
+
+	clone.DIDDocument.ID = "did:example:mutated"
+	clone.DocumentMetadata.CanonicalID = "did:example:mutated"
+	clone.DocumentMetadata.Method.Published = false
+
+	afterBytes, err := docResolution.JSONBytes()
+	require.NoError(t, err)
+	require.JSONEq(t, string(resolutionBytes), string(afterBytes))
+
+	var nilResolution *DocResolution
+
+	require.Nil(t, nilResolution.Clone())
+}
@@ -0,0 +1,141 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package did
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// DocDiff is the difference between two versions of a DID document, refer Diff.
+//
+// A verification method or a service with the same ID but a different value (e.g. a rotated key)
+// is both in the removed and in the added ones.
+type DocDiff struct {
+	AddedVerificationMethods   []VerificationMethod
+	RemovedVerificationMethods []VerificationMethod
+	AddedServices              []Service
+	RemovedServices            []Service
+	// ChangedRelationships are the verification relationships (e.g. Authentication) with changed verification methods.
+	ChangedRelationships []VerificationRelationship
+}
+
+// IsEmpty checks whether the documents are the same.
+func (d *DocDiff) IsEmpty() bool {
+	return len(d.AddedVerificationMethods) == 0 && len(d.RemovedVerificationMethods) == 0 &&
+		len(d.AddedServices) == 0 && len(d.RemovedServices) == 0 && len(d.ChangedRelationships) == 0
+}
+
+// Diff returns the difference of the verification methods, services and verification relationships
+// between the old and the new version of a DID document. Other properties of the document are not compared.
+func Diff(oldDoc, newDoc *Doc) *DocDiff {
+	diff := &DocDiff{}
+
+	diff.AddedVerificationMethods = missingVerificationMethods(newDoc.VerificationMethod, oldDoc.VerificationMethod)
+	diff.RemovedVerificationMethods = missingVerificationMethods(oldDoc.VerificationMethod, newDoc.VerificationMethod)
+	diff.AddedServices = missingServices(newDoc.Service, oldDoc.Service)
+	diff.RemovedServices = missingServices(oldDoc.Service, newDoc.Service)
+
+	relationships := []struct {
+		relationship VerificationRelationship
+		old, new     []Verification
+	}{
+		{Authentication, oldDoc.Authentication, newDoc.Authentication},
+		{AssertionMethod, oldDoc.AssertionMethod, newDoc.AssertionMethod},
+		{CapabilityDelegation, oldDoc.CapabilityDelegation, newDoc.CapabilityDelegation},
+		{CapabilityInvocation, oldDoc.CapabilityInvocation, newDoc.CapabilityInvocation},
+		{KeyAgreement, oldDoc.KeyAgreement, newDoc.KeyAgreement},
+	}
+
+	for _, r := range relationships {
+		if !equalVerifications(r.old, r.new) {
+			diff.ChangedRelationships = append(diff.ChangedRelationships, r.relationship)
+		}
+	}
+
+	return diff
+}
+
+// missingVerificationMethods returns the verification methods of vms which are not in other.
+func missingVerificationMethods(vms, other []VerificationMethod) []VerificationMethod {
+	var missing []VerificationMethod
+
+	for i := range vms {
+		found := false
+
+		for j := range other {
+			if equalVerificationMethods(&vms[i], &other[j]) {
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			missing = append(missing, vms[i])
+		}
+	}
+
+	return missing
+}
+
+func equalVerificationMethods(vm1, vm2 *VerificationMethod) bool {
+	return vm1.ID == vm2.ID && vm1.Type == vm2.Type && vm1.Controller == vm2.Controller &&
+		bytes.Equal(vm1.Value, vm2.Value)
+}
+
+func equalVerifications(v1, v2 []Verification) bool {
+	if len(v1) != len(v2) {
+		return false
+	}
+
+	for i := range v1 {
+		if v1[i].Embedded != v2[i].Embedded ||
+			!equalVerificationMethods(&v1[i].VerificationMethod, &v2[i].VerificationMethod) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// missingServices returns the services which are not in other.
+func missingServices(services, other []Service) []Service {
+	var missing []Service
+
+	for i := range services {
+		found := false
+
+		for j := range other {
+			if services[i].ID == other[j].ID && equalServices(&services[i], &other[j]) {
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			missing = append(missing, services[i])
+		}
+	}
+
+	return missing
+}
+
+func equalServices(s1, s2 *Service) bool {
+	b1, err := json.Marshal(s1)
+	if err != nil {
+		return false
+	}
+
+	b2, err := json.Marshal(s2)
+	if err != nil {
+		return false
+	}
+
+	return bytes.Equal(b1, b2)
+}
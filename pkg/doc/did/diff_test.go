@@ -0,0 +1,82 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package did
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/model"
+)
+
+func TestDiff(t *testing.T) {
+	const didID = "did:example:123"
+
+	key1 := NewVerificationMethodFromBytes(didID+"#key-1", "Ed25519VerificationKey2018", didID, []byte("key 1"))
+	key2 := NewVerificationMethodFromBytes(didID+"#key-2", "Ed25519VerificationKey2018", didID, []byte("key 2"))
+	service := Service{ID: didID + "#linked-domain", Type: "LinkedDomains",
+		ServiceEndpoint: model.NewDIDCommV1Endpoint("https://example.com")}
+
+	newDoc := func() *Doc {
+		return &Doc{
+			ID:                 didID,
+			VerificationMethod: []VerificationMethod{*key1, *key2},
+			Service:            []Service{service},
+			Authentication:     []Verification{*NewReferencedVerification(key1, Authentication)},
+			AssertionMethod:    []Verification{*NewReferencedVerification(key2, AssertionMethod)},
+		}
+	}
+
+	t.Run("same documents", func(t *testing.T) {
+		diff := Diff(newDoc(), newDoc())
+		require.True(t, diff.IsEmpty())
+	})
+
+	t.Run("rotated key", func(t *testing.T) {
+		rotated := NewVerificationMethodFromBytes(key1.ID, key1.Type, didID, []byte("rotated key 1"))
+
+		doc := newDoc()
+		doc.VerificationMethod[0] = *rotated
+		doc.Authentication = []Verification{*NewReferencedVerification(rotated, Authentication)}
+
+		diff := Diff(newDoc(), doc)
+		require.False(t, diff.IsEmpty())
+		require.Equal(t, []VerificationMethod{*rotated}, diff.AddedVerificationMethods)
+		require.Equal(t, []VerificationMethod{*key1}, diff.RemovedVerificationMethods)
+		require.Equal(t, []VerificationRelationship{Authentication}, diff.ChangedRelationships)
+		require.Empty(t, diff.AddedServices)
+		require.Empty(t, diff.RemovedServices)
+	})
+
+	t.Run("removed key and added service", func(t *testing.T) {
+		doc := newDoc()
+		doc.VerificationMethod = doc.VerificationMethod[:1]
+		doc.AssertionMethod = nil
+
+		otherService := Service{ID: didID + "#other", Type: "LinkedDomains",
+			ServiceEndpoint: model.NewDIDCommV1Endpoint("https://other.example.com")}
+		doc.Service = append(doc.Service, otherService)
+
+		diff := Diff(newDoc(), doc)
+		require.Empty(t, diff.AddedVerificationMethods)
+		require.Equal(t, []VerificationMethod{*key2}, diff.RemovedVerificationMethods)
+		require.Equal(t, []VerificationRelationship{AssertionMethod}, diff.ChangedRelationships)
+		require.Equal(t, []Service{otherService}, diff.AddedServices)
+		require.Empty(t, diff.RemovedServices)
+	})
+
+	t.Run("changed service endpoint", func(t *testing.T) {
+		doc := newDoc()
+		doc.Service[0].ServiceEndpoint = model.NewDIDCommV1Endpoint("https://new.example.com")
+
+		diff := Diff(newDoc(), doc)
+		require.Equal(t, []Service{doc.Service[0]}, diff.AddedServices)
+		require.Equal(t, []Service{service}, diff.RemovedServices)
+		require.Empty(t, diff.ChangedRelationships)
+	})
+}
@@ -228,6 +228,8 @@ type DocumentMetadata struct {
 	EquivalentID []string `json:"equivalentId,omitempty"`
 	// Method is used for method metadata within did document metadata.
 	Method *MethodMetadata `json:"method,omitempty"`
+	// NextUpdate is the time of the next update of the DID document (RFC 3339), if it is known.
+	NextUpdate string `json:"nextUpdate,omitempty"`
 }
 
 type rawDocResolution struct {
@@ -237,7 +239,7 @@ type rawDocResolution struct {
 }
 
 // ParseDocumentResolution parse document resolution.
-func ParseDocumentResolution(data []byte) (*DocResolution, error) {
+func ParseDocumentResolution(data []byte, opts ...ParseOption) (*DocResolution, error) {
 	raw := &rawDocResolution{}
 
 	if err := json.Unmarshal(data, raw); err != nil {
@@ -248,7 +250,7 @@ func ParseDocumentResolution(data []byte) (*DocResolution, error) {
 		return nil, ErrDIDDocumentNotExist
 	}
 
-	doc, err := ParseDocument(raw.DIDDocument)
+	doc, err := ParseDocument(raw.DIDDocument, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -475,7 +477,13 @@ func (doc *Doc) UnmarshalJSON(data []byte) error {
 }
 
 // ParseDocument creates an instance of DIDDocument by reading a JSON document from bytes.
-func ParseDocument(data []byte) (*Doc, error) { // nolint:funlen,gocyclo
+func ParseDocument(data []byte, opts ...ParseOption) (*Doc, error) { // nolint:funlen,gocyclo
+	pOpts := &parseOpts{}
+
+	for _, opt := range opts {
+		opt(pOpts)
+	}
+
 	raw := &rawDoc{}
 
 	err := json.Unmarshal(data, &raw)
@@ -540,9 +548,78 @@ func ParseDocument(data []byte) (*Doc, error) { // nolint:funlen,gocyclo
 
 	doc.Proof = proofs
 
+	if pOpts.controllerCheck {
+		err = doc.CheckControllers(pOpts.trustedControllers...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return doc, nil
 }
 
+// ErrUntrustedController is returned when the controller of a verification method is neither
+// the DID subject nor one of the trusted controllers.
+var ErrUntrustedController = errors.New("verification method controller is not trusted")
+
+// ParseOption is a DID document parsing option.
+type ParseOption func(opts *parseOpts)
+
+type parseOpts struct {
+	controllerCheck    bool
+	trustedControllers []string
+}
+
+// WithControllerCheck enables the check that the controller of every verification method
+// (including the ones embedded into verification relationships) is the DID subject or one of the trusted controllers.
+func WithControllerCheck(trustedControllers ...string) ParseOption {
+	return func(opts *parseOpts) {
+		opts.controllerCheck = true
+		opts.trustedControllers = trustedControllers
+	}
+}
+
+// CheckControllers checks that the controller of every verification method (including the ones embedded into
+// verification relationships) is the DID subject or one of the trusted controllers.
+// Verification methods without controller are considered to be controlled by the DID subject.
+func (doc *Doc) CheckControllers(trustedControllers ...string) error {
+	trusted := make(map[string]bool, len(trustedControllers)+1)
+	trusted[doc.ID] = true
+
+	for _, c := range trustedControllers {
+		trusted[c] = true
+	}
+
+	check := func(vm *VerificationMethod) error {
+		if vm.Controller != "" && !trusted[vm.Controller] {
+			return fmt.Errorf("verification method %s with controller %s: %w", vm.ID, vm.Controller,
+				ErrUntrustedController)
+		}
+
+		return nil
+	}
+
+	for i := range doc.VerificationMethod {
+		if err := check(&doc.VerificationMethod[i]); err != nil {
+			return err
+		}
+	}
+
+	for _, verifications := range doc.relationshipSlices() {
+		for i := range verifications {
+			if !verifications[i].Embedded {
+				continue
+			}
+
+			if err := check(&verifications[i].VerificationMethod); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
 func requiresLegacyHandling(raw *rawDoc) bool {
 	// aca-py issue: https://github.com/hyperledger/aries-cloudagent-python/issues/1048
 	//  old v1 context is (currently) only used by projects like aca-py that
@@ -1287,6 +1364,108 @@ func (doc *Doc) VerificationMethods(customVerificationRelationships ...Verificat
 	return verificationMethods
 }
 
+// VerificationMethodByID returns the verification method with the given ID. The ID can be either absolute
+// (did:example:123#key-1) or a relative fragment (#key-1). Both the doc's verification methods and the
+// verification methods embedded into verification relationships are looked up.
+//
+// The lookup scans the doc, so that it always reflects the current doc. Use NewVerificationMethodIndex
+// for many lookups in a doc that is not modified anymore.
+func (doc *Doc) VerificationMethodByID(id string) (*VerificationMethod, bool) {
+	var found *VerificationMethod
+
+	doc.forEachVerificationMethod(func(vm *VerificationMethod) bool {
+		if containsString(verificationMethodKeys(doc, vm), id) {
+			found = vm
+		}
+
+		return found == nil
+	})
+
+	return found, found != nil
+}
+
+// VerificationMethodIndex looks up the verification methods of a doc by their absolute or relative ID in O(1).
+// Refer Doc.NewVerificationMethodIndex.
+type VerificationMethodIndex struct {
+	methods map[string]*VerificationMethod
+}
+
+// NewVerificationMethodIndex indexes the verification methods of the doc, as looked up by VerificationMethodByID.
+// The index is a snapshot of the doc: it isn't updated once the doc is modified, a new index must be created then.
+func (doc *Doc) NewVerificationMethodIndex() *VerificationMethodIndex {
+	index := &VerificationMethodIndex{methods: make(map[string]*VerificationMethod)}
+
+	doc.forEachVerificationMethod(func(vm *VerificationMethod) bool {
+		for _, id := range verificationMethodKeys(doc, vm) {
+			// verification methods defined first take precedence
+			if _, ok := index.methods[id]; !ok {
+				index.methods[id] = vm
+			}
+		}
+
+		return true
+	})
+
+	return index
+}
+
+// VerificationMethodByID returns the indexed verification method with the given absolute or relative ID.
+func (idx *VerificationMethodIndex) VerificationMethodByID(id string) (*VerificationMethod, bool) {
+	vm, ok := idx.methods[id]
+
+	return vm, ok
+}
+
+// forEachVerificationMethod calls fn with the doc's verification methods and then with the verification methods
+// embedded into verification relationships, until fn returns false.
+func (doc *Doc) forEachVerificationMethod(fn func(vm *VerificationMethod) bool) {
+	for i := range doc.VerificationMethod {
+		if !fn(&doc.VerificationMethod[i]) {
+			return
+		}
+	}
+
+	for _, verifications := range doc.relationshipSlices() {
+		for i := range verifications {
+			if verifications[i].Embedded && !fn(&verifications[i].VerificationMethod) {
+				return
+			}
+		}
+	}
+}
+
+// verificationMethodKeys returns the absolute and the relative form of the verification method ID.
+// The relative form is only returned for the IDs of the doc's own DID, as the fragment of other DID's
+// verification method (e.g. a controller's key) is relative to that DID.
+func verificationMethodKeys(doc *Doc, vm *VerificationMethod) []string {
+	keys := []string{vm.ID}
+
+	if strings.HasPrefix(vm.ID, "#") {
+		keys = append(keys, resolveRelativeDIDURL(doc.ID, doc.processingMeta.baseURI, vm.ID))
+	} else if i := strings.Index(vm.ID, "#"); i > 0 && vm.ID[:i] == doc.ID {
+		keys = append(keys, vm.ID[i:])
+	}
+
+	return keys
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (doc *Doc) relationshipSlices() [][]Verification {
+	return [][]Verification{
+		doc.Authentication, doc.AssertionMethod, doc.CapabilityDelegation,
+		doc.CapabilityInvocation, doc.KeyAgreement,
+	}
+}
+
 // ErrProofNotFound is returned when proof is not found.
 var ErrProofNotFound = errors.New("proof not found")
 
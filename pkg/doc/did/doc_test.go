@@ -687,6 +687,78 @@ func TestParseDocument(t *testing.T) {
 	require.Contains(t, err.Error(), "JSON marshalling of did doc bytes bytes failed")
 }
 
+func TestParseDocument_WithControllerCheck(t *testing.T) {
+	const docTemplate = `{
+  "@context": "https://www.w3.org/ns/did/v1",
+  "id": "did:example:123456789abcdefghi",
+  "verificationMethod": [
+    {
+      "id": "did:example:123456789abcdefghi#key-1",
+      "type": "Ed25519VerificationKey2018",
+      "controller": "%s",
+      "publicKeyBase58": "H3C2AVvLMv6gmMNam3uVAjZpfkcJCwDwnZn6z3wXmqPV"
+    }
+  ],
+  "authentication": [
+    {
+      "id": "did:example:123456789abcdefghi#key-2",
+      "type": "Ed25519VerificationKey2018",
+      "controller": "%s",
+      "publicKeyBase58": "5G2ZhLCFBwq4x8CDNBrXxpLmpvRHABpY9kq3jmAmGvcn"
+    }
+  ]
+}`
+
+	const (
+		subject = "did:example:123456789abcdefghi"
+		foreign = "did:example:attacker"
+	)
+
+	t.Run("success - controllers are the DID subject", func(t *testing.T) {
+		doc, err := ParseDocument([]byte(fmt.Sprintf(docTemplate, subject, subject)), WithControllerCheck())
+		require.NoError(t, err)
+		require.NotNil(t, doc)
+	})
+
+	t.Run("success - foreign controller is trusted", func(t *testing.T) {
+		doc, err := ParseDocument([]byte(fmt.Sprintf(docTemplate, subject, foreign)), WithControllerCheck(foreign))
+		require.NoError(t, err)
+		require.NotNil(t, doc)
+	})
+
+	t.Run("success - check is disabled by default", func(t *testing.T) {
+		doc, err := ParseDocument([]byte(fmt.Sprintf(docTemplate, foreign, foreign)))
+		require.NoError(t, err)
+		require.NotNil(t, doc)
+
+		err = doc.CheckControllers()
+		require.ErrorIs(t, err, ErrUntrustedController)
+	})
+
+	t.Run("error - foreign controller of verification method", func(t *testing.T) {
+		doc, err := ParseDocument([]byte(fmt.Sprintf(docTemplate, foreign, subject)), WithControllerCheck())
+		require.ErrorIs(t, err, ErrUntrustedController)
+		require.EqualError(t, err, "verification method did:example:123456789abcdefghi#key-1 "+
+			"with controller did:example:attacker: verification method controller is not trusted")
+		require.Nil(t, doc)
+	})
+
+	t.Run("error - foreign controller of embedded verification method", func(t *testing.T) {
+		doc, err := ParseDocument([]byte(fmt.Sprintf(docTemplate, subject, foreign)), WithControllerCheck())
+		require.ErrorIs(t, err, ErrUntrustedController)
+		require.Contains(t, err.Error(), "did:example:123456789abcdefghi#key-2")
+		require.Nil(t, doc)
+	})
+
+	t.Run("error - doc resolution with foreign controller", func(t *testing.T) {
+		docResolution := fmt.Sprintf(`{"didDocument": %s}`, fmt.Sprintf(docTemplate, foreign, subject))
+
+		res, err := ParseDocumentResolution([]byte(docResolution), WithControllerCheck())
+		require.ErrorIs(t, err, ErrUntrustedController)
+		require.Nil(t, res)
+	})
+}
+
 func TestValidateDidDocContext(t *testing.T) {
 	t.Run("test did doc with empty context", func(t *testing.T) {
 		docs := []string{validDoc, validDocV011}
@@ -1979,6 +2051,186 @@ func TestDoc_VerificationMethods(t *testing.T) {
 	require.Len(t, methods[VerificationRelationshipGeneral], 4)
 }
 
+func TestDoc_VerificationMethodByID(t *testing.T) {
+	const didID = "did:example:123456789abcdefghi"
+
+	didDocStr := `{
+  "@context": "https://www.w3.org/ns/did/v1",
+  "id": "did:example:123456789abcdefghi",
+  "verificationMethod": [
+    {
+      "id": "#key-1",
+      "type": "Ed25519VerificationKey2018",
+      "controller": "did:example:123456789abcdefghi",
+      "publicKeyBase58": "H3C2AVvLMv6gmMNam3uVAjZpfkcJCwDwnZn6z3wXmqPV"
+    }
+  ],
+  "authentication": [
+    "#key-1",
+    {
+      "id": "did:example:123456789abcdefghi#key-2",
+      "type": "Ed25519VerificationKey2018",
+      "controller": "did:example:123456789abcdefghi",
+      "publicKeyBase58": "5G2ZhLCFBwq4x8CDNBrXxpLmpvRHABpY9kq3jmAmGvcn"
+    }
+  ]
+}`
+
+	t.Run("lookup by absolute and relative ID", func(t *testing.T) {
+		doc, err := ParseDocument([]byte(didDocStr))
+		require.NoError(t, err)
+
+		for _, id := range []string{didID + "#key-1", "#key-1"} {
+			vm, ok := doc.VerificationMethodByID(id)
+			require.True(t, ok, id)
+			require.Equal(t, didID+"#key-1", vm.ID)
+		}
+
+		for _, id := range []string{didID + "#key-2", "#key-2"} {
+			vm, ok := doc.VerificationMethodByID(id)
+			require.True(t, ok, id)
+			require.Equal(t, didID+"#key-2", vm.ID)
+		}
+
+		vm, ok := doc.VerificationMethodByID("#key-3")
+		require.False(t, ok)
+		require.Nil(t, vm)
+	})
+
+	t.Run("lookup in doc with relative verification method IDs", func(t *testing.T) {
+		doc := &Doc{
+			ID: didID,
+			VerificationMethod: []VerificationMethod{
+				*NewVerificationMethodFromBytes("#key-1", "Ed25519VerificationKey2018", didID, []byte("key")),
+			},
+		}
+
+		for _, id := range []string{didID + "#key-1", "#key-1"} {
+			vm, ok := doc.VerificationMethodByID(id)
+			require.True(t, ok, id)
+			require.Equal(t, "#key-1", vm.ID)
+		}
+	})
+
+	t.Run("fragment of other DID's verification method is not relative to the doc", func(t *testing.T) {
+		const controllerID = "did:example:controller"
+
+		doc := &Doc{
+			ID: didID,
+			VerificationMethod: []VerificationMethod{
+				*NewVerificationMethodFromBytes(controllerID+"#key-1", "Ed25519VerificationKey2018",
+					controllerID, []byte("controller key")),
+			},
+		}
+
+		vm, ok := doc.VerificationMethodByID(controllerID + "#key-1")
+		require.True(t, ok)
+		require.Equal(t, controllerID+"#key-1", vm.ID)
+
+		for _, id := range []string{"#key-1", didID + "#key-1"} {
+			_, ok = doc.VerificationMethodByID(id)
+			require.False(t, ok, id)
+		}
+	})
+
+	t.Run("lookup reflects mutation", func(t *testing.T) {
+		doc, err := ParseDocument([]byte(didDocStr))
+		require.NoError(t, err)
+
+		_, ok := doc.VerificationMethodByID("#key-1")
+		require.True(t, ok)
+
+		doc.VerificationMethod = append(doc.VerificationMethod,
+			*NewVerificationMethodFromBytes(didID+"#key-3", "Ed25519VerificationKey2018", didID, []byte("key")))
+
+		vm, ok := doc.VerificationMethodByID("#key-3")
+		require.True(t, ok)
+		require.Equal(t, didID+"#key-3", vm.ID)
+
+		doc.VerificationMethod[0].ID = didID + "#key-4"
+
+		_, ok = doc.VerificationMethodByID("#key-1")
+		require.False(t, ok)
+
+		vm, ok = doc.VerificationMethodByID("#key-4")
+		require.True(t, ok)
+		require.Equal(t, didID+"#key-4", vm.ID)
+
+		// element replaced in place
+		doc.VerificationMethod[1] = *NewVerificationMethodFromBytes(didID+"#key-5", "Ed25519VerificationKey2018",
+			didID, []byte("key"))
+
+		_, ok = doc.VerificationMethodByID("#key-3")
+		require.False(t, ok)
+
+		vm, ok = doc.VerificationMethodByID("#key-5")
+		require.True(t, ok)
+		require.Equal(t, didID+"#key-5", vm.ID)
+
+		doc.Authentication = nil
+
+		_, ok = doc.VerificationMethodByID("#key-2")
+		require.False(t, ok)
+	})
+
+	t.Run("lookup doesn't change the doc", func(t *testing.T) {
+		doc, err := ParseDocument([]byte(didDocStr))
+		require.NoError(t, err)
+
+		doc2, err := ParseDocument([]byte(didDocStr))
+		require.NoError(t, err)
+
+		_, ok := doc.VerificationMethodByID("#key-1")
+		require.True(t, ok)
+
+		require.Equal(t, doc2, doc)
+	})
+}
+
+func TestDoc_NewVerificationMethodIndex(t *testing.T) {
+	const didID = "did:example:123456789abcdefghi"
+
+	doc := &Doc{
+		ID: didID,
+		VerificationMethod: []VerificationMethod{
+			*NewVerificationMethodFromBytes("#key-1", "Ed25519VerificationKey2018", didID, []byte("key")),
+			*NewVerificationMethodFromBytes(didID+"#key-2", "Ed25519VerificationKey2018", didID, []byte("key")),
+			*NewVerificationMethodFromBytes("did:example:other#key-3", "Ed25519VerificationKey2018",
+				"did:example:other", []byte("key")),
+		},
+		Authentication: []Verification{{
+			VerificationMethod: *NewVerificationMethodFromBytes(didID+"#key-4", "Ed25519VerificationKey2018",
+				didID, []byte("key")),
+			Embedded: true,
+		}},
+	}
+
+	index := doc.NewVerificationMethodIndex()
+
+	for _, id := range []string{
+		"#key-1", didID + "#key-1", "#key-2", didID + "#key-2", "did:example:other#key-3", "#key-4", didID + "#key-4",
+	} {
+		vm, ok := index.VerificationMethodByID(id)
+		require.True(t, ok, id)
+
+		docVM, ok := doc.VerificationMethodByID(id)
+		require.True(t, ok, id)
+		require.Same(t, docVM, vm)
+	}
+
+	_, ok := index.VerificationMethodByID("#key-3")
+	require.False(t, ok)
+
+	// the index is a snapshot of the doc
+	doc.VerificationMethod = doc.VerificationMethod[:1]
+
+	_, ok = index.VerificationMethodByID("#key-2")
+	require.True(t, ok)
+
+	_, ok = doc.NewVerificationMethodIndex().VerificationMethodByID("#key-2")
+	require.False(t, ok)
+}
+
 func TestDoc_SerializeInterop(t *testing.T) {
 	doc, err := ParseDocument([]byte(validDoc))
 	require.NoError(t, err)
@@ -0,0 +1,67 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package did
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+// AssertionJWKSet returns all verification methods authorized for assertionMethod as a JWK Set, e.g. to look up
+// a JWS signing key by "kid". The "kid" of every key is set to the absolute ID of its verification method.
+func AssertionJWKSet(doc *Doc) (*jwk.JWKSet, error) {
+	jwkSet := &jwk.JWKSet{}
+
+	for _, verification := range doc.VerificationMethods(AssertionMethod)[AssertionMethod] {
+		vm := verification.VerificationMethod
+
+		key, err := verificationMethodToJWK(&vm)
+		if err != nil {
+			return nil, fmt.Errorf("convert verification method %s to JWK: %w", vm.ID, err)
+		}
+
+		key.KeyID = vm.ID
+		if strings.HasPrefix(vm.ID, "#") {
+			key.KeyID = resolveRelativeDIDURL(doc.ID, doc.processingMeta.baseURI, vm.ID)
+		}
+
+		jwkSet.Keys = append(jwkSet.Keys, key)
+	}
+
+	return jwkSet, nil
+}
+
+func verificationMethodToJWK(vm *VerificationMethod) (*jwk.JWK, error) {
+	if vm.JSONWebKey() != nil {
+		// copy to not modify the key ID of the doc's key
+		key := *vm.JSONWebKey()
+
+		return &key, nil
+	}
+
+	switch vm.Type {
+	case "Ed25519VerificationKey2018", "Ed25519VerificationKey2020":
+		return jwksupport.PubKeyBytesToJWK(vm.Value, kms.ED25519Type)
+	case "Bls12381G2Key2020":
+		return jwksupport.PubKeyBytesToJWK(vm.Value, kms.BLS12381G2Type)
+	case "EcdsaSecp256k1VerificationKey2019", "Secp256k1VerificationKey2018":
+		// compressed or uncompressed secp256k1 point
+		pubKey, err := btcec.ParsePubKey(vm.Value, btcec.S256())
+		if err != nil {
+			return nil, fmt.Errorf("parse secp256k1 public key: %w", err)
+		}
+
+		return jwksupport.JWKFromKey(pubKey.ToECDSA())
+	default:
+		return nil, fmt.Errorf("unsupported verification method type: %s", vm.Type)
+	}
+}
@@ -0,0 +1,147 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package did_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/hyperledger/aries-framework-go/pkg/doc/did"
+)
+
+func TestAssertionJWKSet(t *testing.T) {
+	edPubKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	secp256k1Key, err := btcec.NewPrivateKey(btcec.S256())
+	require.NoError(t, err)
+
+	secp256k1PubKey := secp256k1Key.PubKey()
+
+	// based on the ION doc of the did configuration interop tests
+	doc, err := ParseDocument([]byte(fmt.Sprintf(interopDoc,
+		base58.Encode(edPubKey), hex.EncodeToString(secp256k1PubKey.SerializeCompressed()))))
+	require.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		jwkSet, err := AssertionJWKSet(doc)
+		require.NoError(t, err)
+		require.Len(t, jwkSet.Keys, 3)
+
+		ionKey, ok := jwkSet.Key(interopDID + "#vcSigningKey")
+		require.True(t, ok)
+		require.Equal(t, "EC", ionKey.Kty)
+		require.Equal(t, "secp256k1", ionKey.Crv)
+
+		edKey, ok := jwkSet.Key(interopDID + "#ed25519")
+		require.True(t, ok)
+		require.Equal(t, "OKP", edKey.Kty)
+		require.Equal(t, edPubKey, edKey.Key)
+
+		hexKey, ok := jwkSet.Key("did:example:123#secp256k1")
+		require.True(t, ok)
+		require.Equal(t, "secp256k1", hexKey.Crv)
+		require.True(t, secp256k1PubKey.ToECDSA().Equal(hexKey.Key.(*ecdsa.PublicKey)))
+
+		_, ok = jwkSet.Key(interopDID + "#authOnly")
+		require.False(t, ok)
+
+		// the keys of the doc are not modified
+		vm, ok := doc.VerificationMethodByID("#vcSigningKey")
+		require.True(t, ok)
+		require.Empty(t, vm.JSONWebKey().KeyID)
+	})
+
+	t.Run("no assertion methods", func(t *testing.T) {
+		jwkSet, err := AssertionJWKSet(&Doc{ID: interopDID})
+		require.NoError(t, err)
+		require.Empty(t, jwkSet.Keys)
+	})
+
+	t.Run("unsupported verification method type", func(t *testing.T) {
+		vm := NewVerificationMethodFromBytes("#rsa", "RsaVerificationKey2018", interopDID, []byte("key"))
+
+		_, err := AssertionJWKSet(&Doc{
+			ID:              interopDID,
+			AssertionMethod: []Verification{*NewEmbeddedVerification(vm, AssertionMethod)},
+		})
+		require.EqualError(t, err,
+			"convert verification method #rsa to JWK: unsupported verification method type: RsaVerificationKey2018")
+	})
+
+	t.Run("invalid secp256k1 key", func(t *testing.T) {
+		vm := NewVerificationMethodFromBytes("#secp256k1", "EcdsaSecp256k1VerificationKey2019", interopDID,
+			[]byte("key"))
+
+		_, err := AssertionJWKSet(&Doc{
+			ID:              interopDID,
+			AssertionMethod: []Verification{*NewEmbeddedVerification(vm, AssertionMethod)},
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse secp256k1 public key")
+	})
+}
+
+const (
+	interopDID = "did:ion:EiCMdVLtzqqW5n6zUC3_srZxWPCseVxKXu9FqQ8LyS1mTA"
+
+	interopDoc = `{
+  "id": "did:ion:EiCMdVLtzqqW5n6zUC3_srZxWPCseVxKXu9FqQ8LyS1mTA",
+  "@context": [
+    "https://www.w3.org/ns/did/v1",
+    {
+      "@base": "did:ion:EiCMdVLtzqqW5n6zUC3_srZxWPCseVxKXu9FqQ8LyS1mTA"
+    }
+  ],
+  "verificationMethod": [
+    {
+      "id": "#vcSigningKey",
+      "controller": "did:ion:EiCMdVLtzqqW5n6zUC3_srZxWPCseVxKXu9FqQ8LyS1mTA",
+      "type": "EcdsaSecp256k1VerificationKey2019",
+      "publicKeyJwk": {
+        "kty": "EC",
+        "crv": "secp256k1",
+        "x": "j5T8KQ_C_HDlRmyE_ZpF9mlMQgpx7__0RPDxOVc8ukw",
+        "y": "zrl0VJYGZxU-qcekvJV84k9SlvI41jnw4n2M-V2px0c"
+      }
+    },
+    {
+      "id": "#ed25519",
+      "controller": "did:ion:EiCMdVLtzqqW5n6zUC3_srZxWPCseVxKXu9FqQ8LyS1mTA",
+      "type": "Ed25519VerificationKey2018",
+      "publicKeyBase58": "%s"
+    },
+    {
+      "id": "#authOnly",
+      "controller": "did:ion:EiCMdVLtzqqW5n6zUC3_srZxWPCseVxKXu9FqQ8LyS1mTA",
+      "type": "Ed25519VerificationKey2018",
+      "publicKeyBase58": "H3C2AVvLMv6gmMNam3uVAjZpfkcJCwDwnZn6z3wXmqPV"
+    }
+  ],
+  "authentication": [
+    "#vcSigningKey",
+    "#authOnly"
+  ],
+  "assertionMethod": [
+    "#vcSigningKey",
+    "#ed25519",
+    {
+      "id": "did:example:123#secp256k1",
+      "controller": "did:example:123",
+      "type": "EcdsaSecp256k1VerificationKey2019",
+      "publicKeyHex": "%s"
+    }
+  ]
+}`
+)
@@ -0,0 +1,86 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package did
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+const (
+	x25519KeyAgreementKey2019 = "X25519KeyAgreementKey2019"
+	x25519KeyAgreementKey2020 = "X25519KeyAgreementKey2020"
+	x25519Curve               = "X25519"
+	x25519KeySize             = 32
+)
+
+// x25519MultiCodecPrefix is the varint of the X25519 public key multicodec (0xec), it prefixes
+// publicKeyMultibase values of X25519KeyAgreementKey2020.
+var x25519MultiCodecPrefix = []byte{0xec, 0x01} //nolint:gochecknoglobals
+
+// KeyAgreementKey is an X25519 key agreement key of the DID document.
+type KeyAgreementKey struct {
+	// ID is the absolute ID of the verification method.
+	ID string
+	// PublicKey is the 32-byte X25519 public key.
+	PublicKey []byte
+}
+
+// KeyAgreementKeys returns the X25519 keys of the keyAgreement verification methods, e.g. the DIDComm
+// recipient keys. The keys are decoded from publicKeyJwk (OKP with X25519 curve), publicKeyMultibase
+// or publicKeyBase58. Key agreement methods with keys of other curves are skipped.
+func (doc *Doc) KeyAgreementKeys() ([]KeyAgreementKey, error) {
+	var keys []KeyAgreementKey
+
+	for _, verification := range doc.KeyAgreement {
+		vm := verification.VerificationMethod
+
+		value, ok := x25519PublicKey(&vm)
+		if !ok {
+			continue
+		}
+
+		id := vm.ID
+		if strings.HasPrefix(id, "#") {
+			id = resolveRelativeDIDURL(doc.ID, doc.processingMeta.baseURI, id)
+		}
+
+		if len(value) != x25519KeySize {
+			return nil, fmt.Errorf("key agreement key %s: X25519 public key is %d bytes, expected %d",
+				id, len(value), x25519KeySize)
+		}
+
+		keys = append(keys, KeyAgreementKey{ID: id, PublicKey: value})
+	}
+
+	return keys, nil
+}
+
+// x25519PublicKey returns the raw X25519 public key of the verification method, false if it's not an X25519 key.
+func x25519PublicKey(vm *VerificationMethod) ([]byte, bool) {
+	if j := vm.JSONWebKey(); j != nil {
+		if j.Crv != x25519Curve {
+			return nil, false
+		}
+
+		value, ok := j.Key.([]byte)
+
+		return value, ok
+	}
+
+	switch vm.Type {
+	case x25519KeyAgreementKey2019, x25519KeyAgreementKey2020:
+		if len(vm.Value) == len(x25519MultiCodecPrefix)+x25519KeySize &&
+			bytes.HasPrefix(vm.Value, x25519MultiCodecPrefix) {
+			return vm.Value[len(x25519MultiCodecPrefix):], true
+		}
+
+		return vm.Value, true
+	default:
+		return nil, false
+	}
+}
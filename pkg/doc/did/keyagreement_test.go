@@ -0,0 +1,122 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package did_test
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"testing"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/hyperledger/aries-framework-go/pkg/doc/did"
+)
+
+func TestDoc_KeyAgreementKeys(t *testing.T) {
+	t.Run("success - did:key with derived X25519 key", func(t *testing.T) {
+		doc, err := ParseDocument([]byte(didKeyDoc))
+		require.NoError(t, err)
+
+		keys, err := doc.KeyAgreementKeys()
+		require.NoError(t, err)
+		require.Len(t, keys, 1)
+		require.Equal(t, didKeyID+"#z6LShs9GGnqk85isEBzzshkuVWrVKsRp24GnDuHk8QWkARMW", keys[0].ID)
+		require.Equal(t, base58.Decode("7By6kV2t2d188odEM4ExAve1UithKT6dLva4dwsDT3ak"), keys[0].PublicKey)
+	})
+
+	t.Run("success - JWK, multibase and relative IDs", func(t *testing.T) {
+		jwkKey := make([]byte, 32)
+		_, err := rand.Read(jwkKey)
+		require.NoError(t, err)
+
+		multibaseKey := make([]byte, 32)
+		_, err = rand.Read(multibaseKey)
+		require.NoError(t, err)
+
+		doc, err := ParseDocument([]byte(fmt.Sprintf(keyAgreementDoc,
+			base64.RawURLEncoding.EncodeToString(jwkKey),
+			"z"+base58.Encode(append([]byte{0xec, 0x01}, multibaseKey...)))))
+		require.NoError(t, err)
+
+		keys, err := doc.KeyAgreementKeys()
+		require.NoError(t, err)
+		require.Equal(t, []KeyAgreementKey{
+			{ID: "did:example:123#jwk", PublicKey: jwkKey},
+			{ID: "did:example:123#multibase", PublicKey: multibaseKey},
+		}, keys)
+	})
+
+	t.Run("success - no key agreement", func(t *testing.T) {
+		doc, err := ParseDocument([]byte(`{"@context":["https://www.w3.org/ns/did/v1"],"id":"did:example:123"}`))
+		require.NoError(t, err)
+
+		keys, err := doc.KeyAgreementKeys()
+		require.NoError(t, err)
+		require.Empty(t, keys)
+	})
+
+	t.Run("error - invalid X25519 key size", func(t *testing.T) {
+		doc, err := ParseDocument([]byte(fmt.Sprintf(keyAgreementDoc,
+			base64.RawURLEncoding.EncodeToString(make([]byte, 32)), "z"+base58.Encode(make([]byte, 31)))))
+		require.NoError(t, err)
+
+		_, err = doc.KeyAgreementKeys()
+		require.EqualError(t, err,
+			"key agreement key did:example:123#multibase: X25519 public key is 31 bytes, expected 32")
+	})
+}
+
+const didKeyID = "did:key:z6MkiTBz1ymuepAQ4HEHYSF1H8quG5GLVVQR3djdX3mDooWp"
+
+//nolint:lll
+const didKeyDoc = `{
+  "@context": ["https://w3id.org/did/v1"],
+  "id": "did:key:z6MkiTBz1ymuepAQ4HEHYSF1H8quG5GLVVQR3djdX3mDooWp",
+  "verificationMethod": [
+    {
+      "id": "did:key:z6MkiTBz1ymuepAQ4HEHYSF1H8quG5GLVVQR3djdX3mDooWp#z6MkiTBz1ymuepAQ4HEHYSF1H8quG5GLVVQR3djdX3mDooWp",
+      "type": "Ed25519VerificationKey2018",
+      "controller": "did:key:z6MkiTBz1ymuepAQ4HEHYSF1H8quG5GLVVQR3djdX3mDooWp",
+      "publicKeyBase58": "4zvwRjXUKGfvwnParsHAS3HuSVzV5cA4McphgmoCtajS"
+    }
+  ],
+  "authentication": ["did:key:z6MkiTBz1ymuepAQ4HEHYSF1H8quG5GLVVQR3djdX3mDooWp#z6MkiTBz1ymuepAQ4HEHYSF1H8quG5GLVVQR3djdX3mDooWp"],
+  "keyAgreement": [
+    {
+      "id": "did:key:z6MkiTBz1ymuepAQ4HEHYSF1H8quG5GLVVQR3djdX3mDooWp#z6LShs9GGnqk85isEBzzshkuVWrVKsRp24GnDuHk8QWkARMW",
+      "type": "X25519KeyAgreementKey2019",
+      "controller": "did:key:z6MkiTBz1ymuepAQ4HEHYSF1H8quG5GLVVQR3djdX3mDooWp",
+      "publicKeyBase58": "7By6kV2t2d188odEM4ExAve1UithKT6dLva4dwsDT3ak"
+    }
+  ]
+}`
+
+const keyAgreementDoc = `{
+  "@context": ["https://www.w3.org/ns/did/v1"],
+  "id": "did:example:123",
+  "keyAgreement": [
+    {
+      "id": "#jwk",
+      "type": "JsonWebKey2020",
+      "controller": "did:example:123",
+      "publicKeyJwk": {"kty": "OKP", "crv": "X25519", "x": "%s"}
+    },
+    {
+      "id": "#multibase",
+      "type": "X25519KeyAgreementKey2020",
+      "controller": "did:example:123",
+      "publicKeyMultibase": "%s"
+    },
+    {
+      "id": "#secp256k1",
+      "type": "EcdsaSecp256k1VerificationKey2019",
+      "controller": "did:example:123",
+      "publicKeyBase58": "8Vq7TKkVNbiSmsnYuc6UHPFT9rVc3Ahwef4YP7ziKPfP"
+    }
+  ]
+}`
@@ -0,0 +1,274 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package didconfig
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	jsonutil "github.com/hyperledger/aries-framework-go/pkg/doc/util/json"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+)
+
+// Names of the diagnosis checks.
+const (
+	CheckFetch      = "fetch"
+	CheckProperties = "properties"
+	CheckParse      = "parse"
+	CheckIssuer     = "issuer"
+	CheckSubject    = "subject"
+	CheckType       = "type"
+	CheckFormat     = "format"
+	CheckValidity   = "validity"
+	CheckProof      = "proof"
+)
+
+var errNotParsed = errors.New("skipped: credential could not be parsed") //nolint:gochecknoglobals
+
+// CheckResult is the result of a single verification step. Err is nil if the check passed.
+type CheckResult struct {
+	Name string
+	Err  error
+}
+
+// Passed returns true if the check passed.
+func (r CheckResult) Passed() bool {
+	return r.Err == nil
+}
+
+// CredentialDiagnosis holds the results of all checks of a single linked DID entry.
+type CredentialDiagnosis struct {
+	// Index of the credential in linked_dids.
+	Index  int
+	Checks []CheckResult
+
+	// Proofs holds the verification result of every embedded proof of a parsed Linked Data credential.
+	Proofs []verifiable.ProofResult
+}
+
+// Passed returns true if all checks of the credential passed.
+func (c *CredentialDiagnosis) Passed() bool {
+	return allPassed(c.Checks)
+}
+
+// Diagnosis is a report of the did configuration verification for the given DID and domain.
+type Diagnosis struct {
+	DID    string
+	Domain string
+
+	// Checks of the did configuration as a whole (fetch, properties and parse).
+	Checks []CheckResult
+
+	// Credentials holds a diagnosis of every linked DID entry.
+	Credentials []CredentialDiagnosis
+}
+
+func (d *Diagnosis) addCheck(name string, err error) {
+	d.Checks = append(d.Checks, CheckResult{Name: name, Err: err})
+}
+
+// Passed returns true if all did configuration checks passed and there is at least one
+// domain linkage credential which passed all checks.
+func (d *Diagnosis) Passed() bool {
+	if !allPassed(d.Checks) {
+		return false
+	}
+
+	for i := range d.Credentials {
+		if d.Credentials[i].Passed() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Failures returns all failed checks, prefixed with the index of the credential for credential checks.
+func (d *Diagnosis) Failures() []string {
+	var failures []string
+
+	for _, check := range d.Checks {
+		if !check.Passed() {
+			failures = append(failures, fmt.Sprintf("%s: %s", check.Name, check.Err))
+		}
+	}
+
+	for _, cred := range d.Credentials {
+		for _, check := range cred.Checks {
+			if !check.Passed() {
+				failures = append(failures, fmt.Sprintf("linked_dids[%d] %s: %s", cred.Index, check.Name, check.Err))
+			}
+		}
+	}
+
+	return failures
+}
+
+// Diagnose attempts every verification step of the did configuration for the specified did and domain
+// and reports all problems found instead of stopping at the first error. It is intended for troubleshooting,
+// use VerifyDIDAndDomain for the actual verification.
+func Diagnose(didConfig []byte, did, domain string, opts ...DIDConfigurationOpt) *Diagnosis {
+	didCfgOpts := getDIDConfigurationOpts(opts)
+	diagnosis := &Diagnosis{DID: did, Domain: domain}
+
+	didConfig = jsonutil.TrimBOM(didConfig)
+
+	diagnosis.addCheck(CheckProperties, verifyDidConfigurationProperties(didConfig))
+
+	raw := rawDoc{}
+
+	err := json.Unmarshal(didConfig, &raw)
+	if err != nil {
+		diagnosis.addCheck(CheckParse,
+			fmt.Errorf("JSON unmarshalling of DID configuration bytes failed: %w", err))
+
+		return diagnosis
+	}
+
+	diagnosis.addCheck(CheckParse, checkRawDoc(&raw, didCfgOpts))
+
+	for i, linkedDID := range raw.LinkedDIDs {
+		diagnosis.Credentials = append(diagnosis.Credentials, diagnoseCredential(i, linkedDID, did, domain, didCfgOpts))
+	}
+
+	return diagnosis
+}
+
+func diagnoseCredential(index int, linkedDID interface{}, did, domain string,
+	opts *didConfigOpts) CredentialDiagnosis {
+	diagnosis := CredentialDiagnosis{Index: index}
+
+	add := func(name string, err error) {
+		diagnosis.Checks = append(diagnosis.Checks, CheckResult{Name: name, Err: err})
+	}
+
+	rawBytes, err := linkedDIDBytes(linkedDID)
+	if err != nil {
+		add(CheckParse, err)
+
+		return diagnosis
+	}
+
+	vc, err := verifiable.ParseCredential(rawBytes, getParseCredentialOptions(true, opts)...)
+	if err != nil {
+		add(CheckParse, err)
+
+		for _, name := range []string{CheckIssuer, CheckSubject, CheckType, CheckFormat, CheckValidity} {
+			add(name, errNotParsed)
+		}
+	} else {
+		add(CheckParse, nil)
+		add(CheckIssuer, checkIssuer(vc, did))
+		add(CheckSubject, checkSubject(vc, did, domain))
+		add(CheckType, checkType(vc))
+		add(CheckFormat, checkFormat(vc, did))
+		add(CheckValidity, checkValidity(vc, time.Now()))
+
+		// the proofs are checked with the keys authorized for the proof purposes, as by CheckProof
+		diagnosis.Proofs, err = verifiable.VerifyAllProofs(vc,
+			authorizedKeyFetcher(opts.didResolver, opts.allowedProofPurposes),
+			verifiable.WithJSONLDDocumentLoader(&pinnedContextLoader{loader: opts.jsonldDocumentLoader}),
+			verifiable.WithVerificationTimeout(opts.verificationTimeout))
+		if err != nil {
+			logger.Debugf("verify all proofs of linked_dids[%d]: %s", index, err.Error())
+		}
+	}
+
+	// proof is verified even if the credential could not be parsed without it, the error may differ
+	_, err = verifyProof(rawBytes, opts)
+	add(CheckProof, err)
+
+	return diagnosis
+}
+
+func linkedDIDBytes(linkedDID interface{}) ([]byte, error) {
+	switch linkedDID := linkedDID.(type) {
+	case string: // JWT
+		return []byte(linkedDID), nil
+	case map[string]interface{}: // Linked Data
+		return json.Marshal(linkedDID)
+	default:
+		return nil, fmt.Errorf("unexpected interface[%T] for linked DID", linkedDID)
+	}
+}
+
+func checkIssuer(vc *verifiable.Credential, did string) error {
+	if vc.Issuer.ID != did {
+		return fmt.Errorf("issuer[%s] is different from DID[%s]", vc.Issuer.ID, did)
+	}
+
+	return nil
+}
+
+func checkSubject(vc *verifiable.Credential, did, domain string) error {
+	if vc.Subject == nil {
+		return fmt.Errorf("subject MUST be present")
+	}
+
+	return validateSubject(vc.Subject, did, domain)
+}
+
+func checkType(vc *verifiable.Credential) error {
+	if !contains(domainLinkageCredentialType, vc.Types) {
+		return fmt.Errorf("credential is not of %s type", domainLinkageCredentialType)
+	}
+
+	return nil
+}
+
+func checkFormat(vc *verifiable.Credential, did string) error {
+	if vc.ID != "" {
+		return fmt.Errorf("id MUST NOT be present")
+	}
+
+	if vc.JWT == "" {
+		return nil
+	}
+
+	jsonWebToken, _, err := jwt.Parse(vc.JWT, jwt.WithSignatureVerifier(&noVerifier{}))
+	if err != nil {
+		return fmt.Errorf("parse JWT: %w", err)
+	}
+
+	if err := validateJWTHeader(jsonWebToken.Headers); err != nil {
+		return err
+	}
+
+	return validateJWTPayload(vc, jsonWebToken.Payload, did)
+}
+
+func checkValidity(vc *verifiable.Credential, now time.Time) error {
+	if vc.Issued == nil {
+		return fmt.Errorf("issuance date MUST be present")
+	}
+
+	if vc.Expired == nil {
+		return fmt.Errorf("expiration date MUST be present")
+	}
+
+	if now.Before(vc.Issued.Time) {
+		return fmt.Errorf("credential is not valid before %s", vc.Issued.Time.Format(time.RFC3339))
+	}
+
+	if now.After(vc.Expired.Time) {
+		return fmt.Errorf("credential expired at %s", vc.Expired.Time.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+func allPassed(checks []CheckResult) bool {
+	for _, check := range checks {
+		if !check.Passed() {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,188 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package didconfig
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/ldcontext"
+	jsonldsig "github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/ldtestutil"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
+)
+
+func TestDiagnose(t *testing.T) {
+	loader, err := ldtestutil.DocumentLoader(ldcontext.Document{
+		URL:     ContextV1,
+		Content: json.RawMessage(didCfgCtxV1),
+	})
+	require.NoError(t, err)
+
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	didKey, keyID := fingerprint.CreateDIDKey(signer.PublicKeyBytes())
+
+	newSignedVC := func(issued, expired time.Time) *verifiable.Credential {
+		vc := &verifiable.Credential{
+			Context: []string{verifiable.ContextURI, ContextV1},
+			Types:   []string{verifiable.VCType, domainLinkageCredentialType},
+			Issuer:  verifiable.Issuer{ID: didKey},
+			Issued:  util.NewTime(issued),
+			Expired: util.NewTime(expired),
+			Subject: map[string]interface{}{
+				"id":     didKey,
+				"origin": testDomain,
+			},
+		}
+
+		err = vc.AddEd25519Signature2018JWSProof(signer, keyID, time.Now(), jsonldsig.WithDocumentLoader(loader))
+		require.NoError(t, err)
+
+		return vc
+	}
+
+	goodVC := newSignedVC(time.Now(), time.Now().Add(time.Hour))
+
+	// expired and with origin tampered after signing
+	badVC := newSignedVC(time.Now().Add(-2*time.Hour), time.Now().Add(-time.Hour))
+	badVC.Subject = map[string]interface{}{
+		"id":     didKey,
+		"origin": "https://other.example.com",
+	}
+
+	didCfg, err := json.Marshal(map[string]interface{}{
+		"@context":    ContextV1,
+		"linked_dids": []interface{}{badVC, goodVC},
+	})
+	require.NoError(t, err)
+
+	t.Run("one bad and one good credential", func(t *testing.T) {
+		diagnosis := Diagnose(didCfg, didKey, testDomain, WithJSONLDDocumentLoader(loader))
+		require.Equal(t, didKey, diagnosis.DID)
+		require.Equal(t, testDomain, diagnosis.Domain)
+		require.Len(t, diagnosis.Checks, 2)
+		require.Len(t, diagnosis.Credentials, 2)
+
+		bad := diagnosis.Credentials[0]
+		require.False(t, bad.Passed())
+		require.Equal(t, []string{CheckSubject, CheckValidity, CheckProof}, failedChecks(bad.Checks))
+
+		require.Len(t, bad.Proofs, 1)
+		require.Equal(t, "Ed25519Signature2018", bad.Proofs[0].Type)
+		require.Equal(t, keyID, bad.Proofs[0].VerificationMethod)
+		require.Error(t, bad.Proofs[0].Err)
+
+		good := diagnosis.Credentials[1]
+		require.True(t, good.Passed())
+		require.Len(t, good.Checks, 7)
+		require.Len(t, good.Proofs, 1)
+		require.NoError(t, good.Proofs[0].Err)
+
+		require.True(t, diagnosis.Passed())
+
+		failures := diagnosis.Failures()
+		require.Len(t, failures, 3)
+		require.Contains(t, failures[0], "linked_dids[0] subject: origin[https://other.example.com]")
+		require.Contains(t, failures[1], "linked_dids[0] validity: credential expired at")
+	})
+
+	t.Run("no good credential", func(t *testing.T) {
+		diagnosis := Diagnose(didCfg, "did:example:123", testDomain, WithJSONLDDocumentLoader(loader))
+		require.False(t, diagnosis.Passed())
+
+		for _, cred := range diagnosis.Credentials {
+			require.Contains(t, failedChecks(cred.Checks), CheckIssuer)
+			require.Contains(t, failedChecks(cred.Checks), CheckSubject)
+		}
+	})
+
+	t.Run("invalid did configuration properties are reported", func(t *testing.T) {
+		diagnosis := Diagnose([]byte(didCfgLinkedDataExtraProperty), testDID, testDomain,
+			WithJSONLDDocumentLoader(loader))
+		require.False(t, diagnosis.Passed())
+		require.Equal(t, []string{CheckProperties}, failedChecks(diagnosis.Checks))
+		require.Len(t, diagnosis.Credentials, 1)
+	})
+
+	t.Run("credential that can't be parsed", func(t *testing.T) {
+		diagnosis := Diagnose([]byte(didCfgLinkedDataInvalidVC), testDID, testDomain,
+			WithJSONLDDocumentLoader(loader))
+		require.False(t, diagnosis.Passed())
+		require.Len(t, diagnosis.Credentials, 1)
+
+		checks := diagnosis.Credentials[0].Checks
+		require.Len(t, checks, 7)
+		require.Equal(t, CheckParse, checks[0].Name)
+		require.Error(t, checks[0].Err)
+		require.ErrorIs(t, checks[1].Err, errNotParsed)
+		require.Equal(t, CheckProof, checks[6].Name)
+		require.Error(t, checks[6].Err)
+	})
+
+	t.Run("credential with proof suite context", func(t *testing.T) {
+		vc := &verifiable.Credential{
+			Context: []string{verifiable.ContextURI, ContextV1, "https://w3id.org/security/suites/ed25519-2020/v1"},
+			Types:   []string{verifiable.VCType, domainLinkageCredentialType},
+			Issuer:  verifiable.Issuer{ID: didKey},
+			Issued:  util.NewTime(time.Now()),
+			Expired: util.NewTime(time.Now().Add(time.Hour)),
+			Subject: map[string]interface{}{
+				"id":     didKey,
+				"origin": testDomain,
+			},
+		}
+
+		err = vc.AddEd25519Signature2018JWSProof(signer, keyID, time.Now(), jsonldsig.WithDocumentLoader(loader))
+		require.NoError(t, err)
+
+		cfg, err := json.Marshal(map[string]interface{}{
+			"@context":    ContextV1,
+			"linked_dids": []interface{}{vc},
+		})
+		require.NoError(t, err)
+
+		diagnosis := Diagnose(cfg, didKey, testDomain, WithJSONLDDocumentLoader(loader))
+		require.True(t, diagnosis.Passed())
+	})
+
+	t.Run("unexpected linked DID", func(t *testing.T) {
+		diagnosis := Diagnose([]byte(didCfgLinkedDataInvalidLinkedDIDs), testDID, testDomain)
+		require.False(t, diagnosis.Passed())
+		require.Len(t, diagnosis.Credentials, 2)
+
+		for _, cred := range diagnosis.Credentials {
+			require.Equal(t, []string{CheckParse}, failedChecks(cred.Checks))
+		}
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		diagnosis := Diagnose([]byte("{"), testDID, testDomain)
+		require.False(t, diagnosis.Passed())
+		require.Equal(t, []string{CheckProperties, CheckParse}, failedChecks(diagnosis.Checks))
+		require.Empty(t, diagnosis.Credentials)
+	})
+}
+
+func failedChecks(checks []CheckResult) []string {
+	var failed []string
+
+	for _, check := range checks {
+		if !check.Passed() {
+			failed = append(failed, check.Name)
+		}
+	}
+
+	return failed
+}
@@ -9,16 +9,22 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"net/http"
+	"net"
 	"net/url"
+	"strings"
+	"time"
 
 	jsonld "github.com/piprate/json-gold/ld"
+	"golang.org/x/net/idna"
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	diddoc "github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/ld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	jsonutil "github.com/hyperledger/aries-framework-go/pkg/doc/util/json"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr"
@@ -34,6 +40,9 @@ const (
 	// ContextV1 is did configuration context version 1.
 	ContextV1 = "https://identity.foundation/.well-known/did-configuration/v1"
 
+	// ContextV1Integrity is the pinned integrity hash of did configuration context version 1 (see ld.ContextIntegrity).
+	ContextV1Integrity = "sha256-gTxfINXeju4kxpEtg8g5bGcJle9IMaVr3APB4FyyTYs="
+
 	domainLinkageCredentialType = "DomainLinkageCredential"
 
 	contextProperty    = "@context"
@@ -48,12 +57,22 @@ type didResolver interface {
 type didConfigOpts struct {
 	jsonldDocumentLoader jsonld.DocumentLoader
 	didResolver          didResolver
+	allowedContexts      []string
+	verificationTimeout  time.Duration
+	requireAllMatching   bool
+	requireSelfIssued    bool
+	proofVerifier        verifiable.ProofVerifier
+	bindVMToIssuer       bool
+	allowedProofPurposes []did.VerificationRelationship
+	allowedAlgorithms    []string
+	maxLinkedDIDs        int
 }
 
 // DIDConfigurationOpt is the DID Configuration decoding option.
 type DIDConfigurationOpt func(opts *didConfigOpts)
 
-// WithJSONLDDocumentLoader defines a JSON-LD document loader.
+// WithJSONLDDocumentLoader defines a JSON-LD document loader. By default, contexts are fetched
+// with ld.NewHTTPDocumentLoader, which bounds the redirects of each fetch.
 func WithJSONLDDocumentLoader(documentLoader jsonld.DocumentLoader) DIDConfigurationOpt {
 	return func(opts *didConfigOpts) {
 		opts.jsonldDocumentLoader = documentLoader
@@ -67,48 +86,151 @@ func WithVDRegistry(didResolver didResolver) DIDConfigurationOpt {
 	}
 }
 
+// WithAllowedContexts defines the allowed @context values of the did configuration.
+// By default both ContextV0 and ContextV1 are allowed.
+func WithAllowedContexts(urls ...string) DIDConfigurationOpt {
+	return func(opts *didConfigOpts) {
+		opts.allowedContexts = urls
+	}
+}
+
+// WithVerificationTimeout bounds the cryptographic verification of the proof of each domain linkage credential.
+func WithVerificationTimeout(d time.Duration) DIDConfigurationOpt {
+	return func(opts *didConfigOpts) {
+		opts.verificationTimeout = d
+	}
+}
+
+// WithRequireAllMatching requires every domain linkage credential for the DID and domain to have a valid proof.
+// By default, one credential with a valid proof is enough.
+func WithRequireAllMatching() DIDConfigurationOpt {
+	return func(opts *didConfigOpts) {
+		opts.requireAllMatching = true
+	}
+}
+
+// WithRequireSelfIssued requires every domain linkage credential for the DID to be self-issued,
+// i.e. its issuer must equal its subject id. By default, a credential of another issuer is skipped,
+// with this option it fails the verification.
+func WithRequireSelfIssued() DIDConfigurationOpt {
+	return func(opts *didConfigOpts) {
+		opts.requireSelfIssued = true
+	}
+}
+
+// WithProofVerifier delegates the signature check of the domain linkage credential proofs to the verifier,
+// e.g. to verify secp256k1 signatures with an external service. By default, proofs are verified locally.
+func WithProofVerifier(v verifiable.ProofVerifier) DIDConfigurationOpt {
+	return func(opts *didConfigOpts) {
+		opts.proofVerifier = v
+	}
+}
+
+// WithBindVerificationMethodToIssuer requires the verification method of the domain linkage credential proof
+// to belong to the issuer DID, see verifiable.WithBindVerificationMethodToIssuer.
+func WithBindVerificationMethodToIssuer() DIDConfigurationOpt {
+	return func(opts *didConfigOpts) {
+		opts.bindVMToIssuer = true
+	}
+}
+
+// WithAllowedAlgorithms restricts the algorithms of the domain linkage credential proofs to the allowlist:
+// the JWS algorithm of a JWT credential (e.g. EdDSA) or the type of a linked data proof
+// (e.g. Ed25519Signature2018). By default, every supported algorithm is allowed.
+func WithAllowedAlgorithms(algs ...string) DIDConfigurationOpt {
+	return func(opts *didConfigOpts) {
+		opts.allowedAlgorithms = algs
+	}
+}
+
+// WithMaxLinkedDIDs rejects a did configuration with more than n linked_dids entries with ErrTooManyLinkedDIDs,
+// so that a malicious domain can't make the verifier parse and verify an unbounded number of credentials.
+// By default, the number of entries is not limited.
+func WithMaxLinkedDIDs(n int) DIDConfigurationOpt {
+	return func(opts *didConfigOpts) {
+		opts.maxLinkedDIDs = n
+	}
+}
+
 type rawDoc struct {
 	Context    string        `json:"@context,omitempty"`
 	LinkedDIDs []interface{} `json:"linked_dids,omitempty"`
 }
 
 // VerifyDIDAndDomain will verify that there is valid domain linkage credential in did configuration
-// for specified did and domain.
+// for specified did and domain. The linked_dids may mix linked data credentials (objects)
+// and JWT credentials (compact JWT strings).
+//
+// The signature suite of a linked data credential is chosen by its proof type (e.g. Ed25519Signature2018
+// or EcdsaSecp256k1Signature2019) and the algorithm of a JWT credential by its "alg" header (e.g. EdDSA or ES256K).
+// In both cases the public key is resolved from the DID document of the issuer.
 func VerifyDIDAndDomain(didConfig []byte, did, domain string, opts ...DIDConfigurationOpt) error {
+	_, err := VerifyDIDAndDomainWithResult(didConfig, did, domain, opts...)
+
+	return err
+}
+
+// VerifyDIDAndDomainWithResult is like VerifyDIDAndDomain and returns which domain linkage credential
+// satisfied the verification. With WithRequireAllMatching, the result describes the first of the verified
+// credentials.
+func VerifyDIDAndDomainWithResult(didConfig []byte, did, domain string,
+	opts ...DIDConfigurationOpt) (*VerificationResult, error) {
 	// apply options
 	didCfgOpts := getDIDConfigurationOpts(opts)
 
+	// keep the resolved DID document to check the domains it claims
+	recorder := &recordingResolver{resolver: didCfgOpts.didResolver, did: did}
+	didCfgOpts.didResolver = recorder
+
+	didConfig = jsonutil.TrimBOM(didConfig)
+
 	// verify required and allowed properties in did configuration
 	err := verifyDidConfigurationProperties(didConfig)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	raw := rawDoc{}
 
 	err = json.Unmarshal(didConfig, &raw)
 	if err != nil {
-		return fmt.Errorf("JSON unmarshalling of DID configuration bytes failed: %w", err)
+		return nil, fmt.Errorf("JSON unmarshalling of DID configuration bytes failed: %w", err)
+	}
+
+	err = checkRawDoc(&raw, didCfgOpts)
+	if err != nil {
+		return nil, err
 	}
 
 	credOpts := getParseCredentialOptions(true, didCfgOpts)
 
-	credentials, err := getCredentials(raw.LinkedDIDs, did, domain, credOpts...)
+	credentials, err := getCredentials(raw.LinkedDIDs, did, domain, didCfgOpts.requireSelfIssued, credOpts...)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	logger.Debugf("found %d domain linkage credential(s) for DID[%s] and domain[%s]", len(credentials), did, domain)
 
-	for _, credBytes := range credentials {
-		credOpts := getParseCredentialOptions(false, didCfgOpts)
+	if didCfgOpts.requireAllMatching {
+		vc, err := verifyAllCredentials(credentials, did, domain, didCfgOpts)
+		if err != nil {
+			return nil, err
+		}
+
+		warnIfDomainNotClaimed(recorder.doc, did, domain)
 
+		return newVerificationResult(vc)
+	}
+
+	for _, credBytes := range credentials {
 		// this time we are parsing credential with proof check so DID will be resolved
 		// and public key from did will be used to verify proof
-		_, err := verifiable.ParseCredential(credBytes, credOpts...)
+		vc, err := verifyProof(credBytes, didCfgOpts)
 		if err == nil {
 			// we found domain linkage credential with valid proof so all good
-			return nil
+			warnIfDomainNotClaimed(recorder.doc, did, domain)
+
+			return newVerificationResult(vc)
 		}
 
 		// failed to verify credential proof - log info and continue to next one
@@ -116,13 +238,35 @@ func VerifyDIDAndDomain(didConfig []byte, did, domain string, opts ...DIDConfigu
 			did, domain, err.Error())
 	}
 
-	return fmt.Errorf("domain linkage credential(s) with valid proof not found")
+	return nil, ErrNoValidProof
+}
+
+// verifyAllCredentials verifies the proof of every domain linkage credential for the DID and domain
+// and returns the first credential.
+func verifyAllCredentials(credentials [][]byte, did, domain string,
+	opts *didConfigOpts) (*verifiable.Credential, error) {
+	var first *verifiable.Credential
+
+	for i, credBytes := range credentials {
+		vc, err := verifyProof(credBytes, opts)
+		if err != nil {
+			return nil, fmt.Errorf("domain linkage credential %d of %d for DID[%s] and domain[%s] is not valid: %w",
+				i+1, len(credentials), did, domain, err)
+		}
+
+		if first == nil {
+			first = vc
+		}
+	}
+
+	return first, nil
 }
 
 func getDIDConfigurationOpts(opts []DIDConfigurationOpt) *didConfigOpts {
 	didCfgOpts := &didConfigOpts{
-		jsonldDocumentLoader: jsonld.NewDefaultDocumentLoader(http.DefaultClient),
+		jsonldDocumentLoader: ld.NewHTTPDocumentLoader(),
 		didResolver:          vdr.New(vdr.WithVDR(key.New())),
+		allowedContexts:      []string{ContextV0, ContextV1},
 	}
 
 	for _, opt := range opts {
@@ -152,11 +296,40 @@ func verifyDidConfigurationProperties(data []byte) error {
 	return verifyAllowedProperties(didCfgMap, allowedProperties)
 }
 
+// checkRawDoc checks the context and the number of linked DIDs of the did configuration.
+func checkRawDoc(raw *rawDoc, opts *didConfigOpts) error {
+	err := checkContext(raw.Context, opts.allowedContexts)
+	if err != nil {
+		return err
+	}
+
+	if opts.maxLinkedDIDs > 0 && len(raw.LinkedDIDs) > opts.maxLinkedDIDs {
+		return fmt.Errorf("%w: %d entries, the maximum is %d", ErrTooManyLinkedDIDs, len(raw.LinkedDIDs),
+			opts.maxLinkedDIDs)
+	}
+
+	return nil
+}
+
+func checkContext(context string, allowedContexts []string) error {
+	if !contains(context, allowedContexts) {
+		return fmt.Errorf("did configuration @context[%s] is not allowed", context)
+	}
+
+	return nil
+}
+
 func verifyRequiredProperties(values map[string]interface{}, requiredProperties []string) error {
 	for _, key := range requiredProperties {
-		if _, ok := values[key]; !ok {
-			return fmt.Errorf("property '%s' is required", key)
+		if _, ok := values[key]; ok {
+			continue
 		}
+
+		if key == linkedDIDsProperty {
+			return ErrMissingLinkedDIDs
+		}
+
+		return fmt.Errorf("property '%s' is required", key)
 	}
 
 	return nil
@@ -348,12 +521,12 @@ func validateSubject(subject interface{}, did, origin string) error {
 }
 
 func validateOrigin(origin1, origin2 string) error {
-	url1, err := url.Parse(origin1)
+	url1, host1, err := parseOrigin(origin1)
 	if err != nil {
 		return err
 	}
 
-	url2, err := url.Parse(origin2)
+	url2, host2, err := parseOrigin(origin2)
 	if err != nil {
 		return err
 	}
@@ -362,14 +535,77 @@ func validateOrigin(origin1, origin2 string) error {
 	// The protocol (e.g., HTTP or HTTPS)
 	// The port, if available
 	// The host
-	if url1.Host != url2.Host || url1.Scheme != url2.Scheme || url1.Port() != url2.Port() {
-		return fmt.Errorf("origin[%s] and domain origin[%s] are different", origin1, origin2)
+	// The path (e.g. a trailing slash) is not a part of the origin.
+	if host1 != host2 || url1.Scheme != url2.Scheme || url1.Port() != url2.Port() {
+		return &ErrOriginMismatch{Expected: origin2, Got: origin1}
 	}
 
 	return nil
 }
 
-func getCredentials(linkedDIDs []interface{}, did, domain string, opts ...verifiable.CredentialOpt) ([][]byte, error) {
+// NormalizeOrigin returns the origin in the form used for origin comparison: the scheme, the ASCII lower case
+// host and the port, without a path (e.g. "HTTPS://Bücher.example/" is "https://xn--bcher-kva.example").
+// Two origins that are equal for the domain linkage verification have the same normalized form.
+func NormalizeOrigin(origin string) (string, error) {
+	u, host, err := parseOrigin(origin)
+	if err != nil {
+		return "", err
+	}
+
+	if u.Port() != "" {
+		host = net.JoinHostPort(host, u.Port())
+	}
+
+	if u.Scheme == "" {
+		return host, nil
+	}
+
+	return strings.ToLower(u.Scheme) + "://" + host, nil
+}
+
+// parseOrigin parses the origin and returns its URL and the normalized (ASCII, lower case) host.
+// An origin without a scheme (e.g. "identity.foundation") is parsed as a host, and the host is required,
+// otherwise any two origins without a host would match.
+func parseOrigin(origin string) (*url.URL, string, error) {
+	u, err := url.Parse(origin)
+	if err == nil && u.Scheme == "" && u.Host == "" {
+		u, err = url.Parse("//" + origin)
+	}
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	if u.Hostname() == "" {
+		return nil, "", fmt.Errorf("origin[%s] has no host", origin)
+	}
+
+	host, err := asciiHost(u.Hostname())
+	if err != nil {
+		return nil, "", err
+	}
+
+	return u, strings.ToLower(host), nil
+}
+
+// asciiHost converts the host to its ASCII (punycode) form, so that unicode and punycode forms
+// of an internationalized domain name compare equal. Both forms are always normalized to ASCII,
+// so a homograph of a domain (e.g. with a Cyrillic letter) never matches the domain itself.
+func asciiHost(host string) (string, error) {
+	if host == "" {
+		return "", nil
+	}
+
+	asciiHost, err := idna.Lookup.ToASCII(host)
+	if err != nil {
+		return "", fmt.Errorf("invalid origin host[%s]: %w", host, err)
+	}
+
+	return asciiHost, nil
+}
+
+func getCredentials(linkedDIDs []interface{}, did, domain string, requireSelfIssued bool,
+	opts ...verifiable.CredentialOpt) ([][]byte, error) {
 	var credentialsForDIDAndDomain [][]byte
 
 	for _, linkedDID := range linkedDIDs {
@@ -399,6 +635,11 @@ func getCredentials(linkedDIDs []interface{}, did, domain string, opts ...verifi
 		}
 
 		if vc.Issuer.ID != did {
+			if subjectID, _ := verifiable.SubjectID(vc.Subject); requireSelfIssued && subjectID == did { //nolint:errcheck
+				return nil, fmt.Errorf("domain linkage credential for DID[%s] is not self-issued: issuer[%s]",
+					did, vc.Issuer.ID)
+			}
+
 			logger.Infof("skipping credential since issuer[%s] is different from DID[%s]", vc.Issuer.ID, did)
 
 			continue
@@ -416,12 +657,67 @@ func getCredentials(linkedDIDs []interface{}, did, domain string, opts ...verifi
 	}
 
 	if len(credentialsForDIDAndDomain) == 0 {
-		return nil, fmt.Errorf("domain linkage credential(s) not found")
+		return nil, fmt.Errorf("%w for DID[%s] and domain[%s]", ErrNoMatchingCredential, did, domain)
 	}
 
 	return credentialsForDIDAndDomain, nil
 }
 
+// verifyProof parses the domain linkage credential with the proof check.
+func verifyProof(credBytes []byte, opts *didConfigOpts) (*verifiable.Credential, error) {
+	if jwt.IsJWS(string(credBytes)) {
+		err := checkJWTAlgorithm(string(credBytes), opts.didResolver)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return verifiable.ParseCredential(credBytes, getParseCredentialOptions(false, opts)...)
+}
+
+// checkJWTAlgorithm checks that the JWS algorithm of the credential is compatible with the verification method
+// of the "kid" (e.g. ES256K for an EcdsaSecp256k1VerificationKey2019 or a secp256k1 JsonWebKey2020 key),
+// so that the verifier of the key type is chosen.
+func checkJWTAlgorithm(vcJWT string, resolver didResolver) error {
+	jsonWebToken, _, err := jwt.Parse(vcJWT, jwt.WithSignatureVerifier(&noVerifier{}))
+	if err != nil {
+		return fmt.Errorf("parse JWT: %w", err)
+	}
+
+	alg, _ := jsonWebToken.Headers.Algorithm()
+	kid, _ := jsonWebToken.Headers.KeyID()
+
+	didID, _, ok := strings.Cut(kid, "#")
+	if !ok || !strings.HasPrefix(didID, "did:") {
+		return fmt.Errorf("kid %s is not a DID URL", kid)
+	}
+
+	// the verification method is looked up by the fully qualified kid, as e.g. did:web documents
+	// use absolute verification method IDs
+	pubKey, err := verifiable.NewVDRKeyResolver(resolver).PublicKeyFetcher()(didID, kid)
+	if err != nil {
+		return fmt.Errorf("resolve public key of kid %s: %w", kid, err)
+	}
+
+	var algs []string
+
+	if pubKey.JWK != nil {
+		algs, err = jose.AlgsForJWK(pubKey.JWK)
+	} else {
+		algs, err = jose.AlgsForVerificationType(pubKey.Type)
+	}
+
+	if err != nil {
+		return fmt.Errorf("verification method %s: %w", kid, err)
+	}
+
+	if !contains(alg, algs) {
+		return fmt.Errorf("JWS alg %s is not compatible with verification method %s of type %s", alg, kid, pubKey.Type)
+	}
+
+	return nil
+}
+
 // noVerifier is used when no JWT signature verification is needed.
 // To be used with precaution.
 type noVerifier struct{}
@@ -435,15 +731,70 @@ func getParseCredentialOptions(disableProofCheck bool, opts *didConfigOpts) []ve
 
 	credOpts = append(credOpts,
 		verifiable.WithNoCustomSchemaCheck(),
-		verifiable.WithJSONLDDocumentLoader(opts.jsonldDocumentLoader),
+		verifiable.WithJSONLDDocumentLoader(&pinnedContextLoader{loader: opts.jsonldDocumentLoader}),
 		verifiable.WithStrictValidation())
 
 	if disableProofCheck {
 		credOpts = append(credOpts, verifiable.WithDisabledProofCheck())
 	} else {
 		credOpts = append(credOpts,
-			verifiable.WithPublicKeyFetcher(verifiable.NewVDRKeyResolver(opts.didResolver).PublicKeyFetcher()))
+			verifiable.WithPublicKeyFetcher(authorizedKeyFetcher(opts.didResolver, opts.allowedProofPurposes)),
+			verifiable.WithVerificationTimeout(opts.verificationTimeout))
+
+		if v := proofVerifier(opts); v != nil {
+			credOpts = append(credOpts, verifiable.WithProofVerifier(v))
+		}
+
+		if opts.bindVMToIssuer {
+			credOpts = append(credOpts, verifiable.WithBindVerificationMethodToIssuer())
+		}
 	}
 
 	return credOpts
 }
+
+// proofVerifier returns the proof verifier of the options, restricted to the allowed algorithms if any.
+func proofVerifier(opts *didConfigOpts) verifiable.ProofVerifier {
+	if opts.allowedAlgorithms == nil {
+		return opts.proofVerifier
+	}
+
+	var next verifiable.ProofVerifier = verifiable.NewLocalProofVerifier()
+	if opts.proofVerifier != nil {
+		next = opts.proofVerifier
+	}
+
+	return &allowlistProofVerifier{algs: opts.allowedAlgorithms, next: next}
+}
+
+// allowlistProofVerifier rejects the proofs of algorithms which are not allowed.
+type allowlistProofVerifier struct {
+	algs []string
+	next verifiable.ProofVerifier
+}
+
+func (v *allowlistProofVerifier) Verify(alg string, pubKey *verifier.PublicKey, message, signature []byte) error {
+	if !contains(alg, v.algs) {
+		return fmt.Errorf("proof algorithm %s is not allowed", alg)
+	}
+
+	return v.next.Verify(alg, pubKey, message, signature)
+}
+
+// pinnedContextLoader rejects the did configuration v1 context which doesn't match ContextV1Integrity.
+type pinnedContextLoader struct {
+	loader jsonld.DocumentLoader
+}
+
+func (l *pinnedContextLoader) LoadDocument(u string) (*jsonld.RemoteDocument, error) {
+	rd, err := l.loader.LoadDocument(u)
+	if err != nil || u != ContextV1 {
+		return rd, err
+	}
+
+	if err = ld.CheckContextIntegrity(rd, ContextV1Integrity); err != nil {
+		return nil, err
+	}
+
+	return rd, nil
+}
@@ -9,16 +9,27 @@ package didconfig
 import (
 	"crypto/ed25519"
 	"crypto/rand"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	diddoc "github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
 	afgjwt "github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/ld"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/ldcontext"
+	jsonldsig "github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ecdsasecp256k1signature2019"
+	sigverifier "github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/util/signature"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
@@ -27,8 +38,10 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
 	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/key"
 )
 
@@ -66,6 +79,100 @@ func TestParseLinkedData(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("success - BOM and leading whitespace", func(t *testing.T) {
+		err := VerifyDIDAndDomain([]byte("\xEF\xBB\xBF\n "+didCfgLinkedData), testDID, testDomain,
+			WithJSONLDDocumentLoader(loader))
+		require.NoError(t, err)
+	})
+
+	t.Run("success - allowed context", func(t *testing.T) {
+		err := VerifyDIDAndDomain([]byte(didCfgLinkedData), testDID, testDomain,
+			WithJSONLDDocumentLoader(loader), WithAllowedContexts(ContextV1))
+		require.NoError(t, err)
+	})
+
+	t.Run("success - verification within timeout", func(t *testing.T) {
+		err := VerifyDIDAndDomain([]byte(didCfgLinkedData), testDID, testDomain,
+			WithJSONLDDocumentLoader(loader), WithVerificationTimeout(time.Minute))
+		require.NoError(t, err)
+	})
+
+	t.Run("error - verification timeout", func(t *testing.T) {
+		err := VerifyDIDAndDomain([]byte(didCfgLinkedData), testDID, testDomain,
+			WithJSONLDDocumentLoader(loader), WithVerificationTimeout(time.Nanosecond))
+		require.EqualError(t, err, "domain linkage credential(s) with valid proof not found")
+		require.ErrorIs(t, err, ErrNoValidProof)
+
+		diagnosis := Diagnose([]byte(didCfgLinkedData), testDID, testDomain,
+			WithJSONLDDocumentLoader(loader), WithVerificationTimeout(time.Nanosecond))
+		require.False(t, diagnosis.Passed())
+		require.ErrorIs(t, diagnosis.Credentials[0].Proofs[0].Err, verifiable.ErrVerificationTimeout)
+	})
+
+	t.Run("require all matching credentials", func(t *testing.T) {
+		cfg := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal([]byte(didCfgLinkedData), &cfg))
+
+		linkedDIDs, ok := cfg["linked_dids"].([]interface{})
+		require.True(t, ok)
+
+		// the same credential with the issuance date changed after signing
+		tampered := map[string]interface{}{}
+		for k, v := range linkedDIDs[0].(map[string]interface{}) {
+			tampered[k] = v
+		}
+
+		tampered["issuanceDate"] = "2020-12-04T14:08:29-06:00"
+
+		cfg["linked_dids"] = []interface{}{linkedDIDs[0], tampered}
+
+		didCfgBytes, err := json.Marshal(cfg)
+		require.NoError(t, err)
+
+		err = VerifyDIDAndDomain(didCfgBytes, testDID, testDomain, WithJSONLDDocumentLoader(loader))
+		require.NoError(t, err)
+
+		err = VerifyDIDAndDomain(didCfgBytes, testDID, testDomain, WithJSONLDDocumentLoader(loader),
+			WithRequireAllMatching())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "domain linkage credential 2 of 2 for DID["+testDID+"] and domain["+
+			testDomain+"] is not valid")
+
+		err = VerifyDIDAndDomain([]byte(didCfgLinkedData), testDID, testDomain, WithJSONLDDocumentLoader(loader),
+			WithRequireAllMatching())
+		require.NoError(t, err)
+	})
+
+	t.Run("error - tampered did configuration context", func(t *testing.T) {
+		tamperedLoader, err := ldtestutil.DocumentLoader(ldcontext.Document{
+			URL:     ContextV1,
+			Content: json.RawMessage(strings.Replace(didCfgCtxV1, "#origin", "#tampered", 1)),
+		})
+		require.NoError(t, err)
+
+		err = VerifyDIDAndDomain([]byte(didCfgLinkedData), testDID, testDomain,
+			WithJSONLDDocumentLoader(tamperedLoader))
+		require.Error(t, err)
+
+		diagnosis := Diagnose([]byte(didCfgLinkedData), testDID, testDomain, WithJSONLDDocumentLoader(tamperedLoader))
+		require.False(t, diagnosis.Passed())
+
+		checks := diagnosis.Credentials[0].Checks
+		require.Equal(t, CheckParse, checks[0].Name)
+		// JSON-LD processor doesn't keep the cause of the failed context load
+		require.Contains(t, checks[0].Err.Error(), "loading remote context failed")
+		require.Contains(t, checks[0].Err.Error(), ContextV1)
+
+		_, err = (&pinnedContextLoader{loader: tamperedLoader}).LoadDocument(ContextV1)
+		require.ErrorIs(t, err, ld.ErrContextIntegrity)
+	})
+
+	t.Run("error - context not allowed", func(t *testing.T) {
+		err := VerifyDIDAndDomain([]byte(didCfgLinkedData), testDID, testDomain,
+			WithJSONLDDocumentLoader(loader), WithAllowedContexts(ContextV0))
+		require.EqualError(t, err, "did configuration @context["+ContextV1+"] is not allowed")
+	})
+
 	t.Run("success - registry provided", func(t *testing.T) {
 		err := VerifyDIDAndDomain([]byte(didCfgLinkedData), testDID, testDomain,
 			WithJSONLDDocumentLoader(loader),
@@ -73,6 +180,60 @@ func TestParseLinkedData(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("proof verifier provided", func(t *testing.T) {
+		remote := &mockProofVerifier{}
+
+		err := VerifyDIDAndDomain([]byte(didCfgLinkedData), testDID, testDomain,
+			WithJSONLDDocumentLoader(loader),
+			WithProofVerifier(remote))
+		require.NoError(t, err)
+		require.Equal(t, []string{"Ed25519Signature2018"}, remote.algs)
+
+		err = VerifyDIDAndDomain([]byte(didCfgLinkedData), testDID, testDomain,
+			WithJSONLDDocumentLoader(loader),
+			WithProofVerifier(&mockProofVerifier{err: fmt.Errorf("remote verification failed")}),
+			WithRequireAllMatching())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "remote verification failed")
+	})
+
+	t.Run("success - issued with detached JWS proof", func(t *testing.T) {
+		signer, err := newCryptoSigner(kms.ED25519Type)
+		require.NoError(t, err)
+
+		didKey, keyID := fingerprint.CreateDIDKey(signer.PublicKeyBytes())
+
+		vc := &verifiable.Credential{
+			Context: []string{verifiable.ContextURI, ContextV1},
+			Types:   []string{verifiable.VCType, "DomainLinkageCredential"},
+			Issuer:  verifiable.Issuer{ID: didKey},
+			Issued:  util.NewTime(time.Now()),
+			Expired: util.NewTime(time.Now().Add(time.Hour)),
+			Subject: map[string]interface{}{
+				"id":     didKey,
+				"origin": testDomain,
+			},
+		}
+
+		err = vc.AddEd25519Signature2018JWSProof(signer, keyID, time.Now(), jsonldsig.WithDocumentLoader(loader))
+		require.NoError(t, err)
+		require.Len(t, vc.Proofs, 1)
+		require.Equal(t, "Ed25519Signature2018", vc.Proofs[0]["type"])
+		require.Equal(t, "assertionMethod", vc.Proofs[0]["proofPurpose"])
+		// {"alg":"EdDSA","b64":false,"crit":["b64"]} header with detached payload
+		require.True(t, strings.HasPrefix(vc.Proofs[0]["jws"].(string),
+			"eyJhbGciOiJFZERTQSIsImI2NCI6ZmFsc2UsImNyaXQiOlsiYjY0Il19.."))
+
+		didCfgBytes, err := json.Marshal(map[string]interface{}{
+			"@context":    ContextV1,
+			"linked_dids": []interface{}{vc},
+		})
+		require.NoError(t, err)
+
+		err = VerifyDIDAndDomain(didCfgBytes, didKey, testDomain, WithJSONLDDocumentLoader(loader))
+		require.NoError(t, err)
+	})
+
 	t.Run("error - invalid proof", func(t *testing.T) {
 		err := VerifyDIDAndDomain([]byte(didCfgLinkedDataInvalidProof), testDID, testDomain,
 			WithJSONLDDocumentLoader(loader),
@@ -88,15 +249,19 @@ func TestParseLinkedData(t *testing.T) {
 		require.Error(t, err)
 
 		require.Contains(t, err.Error(), "domain linkage credential(s) not found")
+		require.ErrorIs(t, err, ErrNoMatchingCredential)
 	})
 
 	t.Run("error - DIDs do not match", func(t *testing.T) {
+		// the only credential of the configuration is for testDID
 		err := VerifyDIDAndDomain([]byte(didCfgLinkedData), "did:web:different", testDomain,
 			WithJSONLDDocumentLoader(loader),
 			WithVDRegistry(vdr.New(vdr.WithVDR(key.New()))))
 		require.Error(t, err)
 
-		require.Contains(t, err.Error(), "domain linkage credential(s) not found")
+		require.ErrorIs(t, err, ErrNoMatchingCredential)
+		require.EqualError(t, err, "domain linkage credential(s) not found for DID[did:web:different] "+
+			"and domain[https://identity.foundation]")
 	})
 
 	t.Run("error - origin invalid", func(t *testing.T) {
@@ -138,6 +303,7 @@ func TestParseLinkedData(t *testing.T) {
 			WithVDRegistry(vdr.New(vdr.WithVDR(key.New()))))
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "property 'linked_dids' is required")
+		require.ErrorIs(t, err, ErrMissingLinkedDIDs)
 	})
 
 	t.Run("error - unexpected interface for linked DIDs", func(t *testing.T) {
@@ -178,6 +344,380 @@ func TestParseValidJWT(t *testing.T) {
 			WithJSONLDDocumentLoader(loader))
 		require.NoError(t, err)
 	})
+
+	t.Run("error - JWS alg not compatible with verification method", func(t *testing.T) {
+		var cfg map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(didCfgJWT), &cfg))
+
+		linkedDIDs, ok := cfg["linked_dids"].([]interface{})
+		require.True(t, ok)
+
+		jwtParts := strings.Split(linkedDIDs[0].(string), ".")
+		require.Len(t, jwtParts, 3)
+
+		headersBytes, err := base64.RawURLEncoding.DecodeString(jwtParts[0])
+		require.NoError(t, err)
+
+		var headers map[string]interface{}
+		require.NoError(t, json.Unmarshal(headersBytes, &headers))
+
+		headers[jose.HeaderAlgorithm] = jose.AlgES256K
+
+		headersBytes, err = json.Marshal(headers)
+		require.NoError(t, err)
+
+		jwtParts[0] = base64.RawURLEncoding.EncodeToString(headersBytes)
+		cfg["linked_dids"] = []interface{}{strings.Join(jwtParts, ".")}
+
+		cfgBytes, err := json.Marshal(cfg)
+		require.NoError(t, err)
+
+		err = VerifyDIDAndDomain(cfgBytes, testDID, "identity.foundation", WithRequireAllMatching())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "JWS alg ES256K is not compatible with verification method")
+	})
+}
+
+func TestVerifyDIDAndDomainWithResult(t *testing.T) {
+	loader, err := ldtestutil.DocumentLoader(ldcontext.Document{
+		URL:     ContextV1,
+		Content: json.RawMessage(didCfgCtxV1),
+	})
+	require.NoError(t, err)
+
+	t.Run("linked data credential", func(t *testing.T) {
+		result, err := VerifyDIDAndDomainWithResult([]byte(didCfgLinkedData), testDID, testDomain,
+			WithJSONLDDocumentLoader(loader))
+		require.NoError(t, err)
+		require.NotNil(t, result.Credential)
+		require.Equal(t, testDID, result.Issuer)
+		require.Equal(t, testDomain, result.Origin)
+		require.Equal(t, "Ed25519Signature2018", result.ProofType)
+		require.Equal(t, testDID+"#z6MkoTHsgNNrby8JzCNQ1iRLyW5QQ6R8Xuu6AA8igGrMVPUM", result.VerificationMethod)
+	})
+
+	t.Run("JWT credential", func(t *testing.T) {
+		result, err := VerifyDIDAndDomainWithResult([]byte(didCfgJWT), testDID, testJWTDomain,
+			WithJSONLDDocumentLoader(loader))
+		require.NoError(t, err)
+		require.NotEmpty(t, result.Credential.JWT)
+		require.Equal(t, testDID, result.Issuer)
+		require.Equal(t, testJWTDomain, result.Origin)
+		require.Equal(t, "EdDSA", result.ProofType)
+		require.Equal(t, testDID+"#z6MkoTHsgNNrby8JzCNQ1iRLyW5QQ6R8Xuu6AA8igGrMVPUM", result.VerificationMethod)
+	})
+
+	t.Run("error - invalid proof", func(t *testing.T) {
+		result, err := VerifyDIDAndDomainWithResult([]byte(didCfgLinkedDataInvalidProof), testDID, testDomain,
+			WithJSONLDDocumentLoader(loader))
+		require.Error(t, err)
+		require.Nil(t, result)
+	})
+}
+
+func TestParseMixedLinkedDIDs(t *testing.T) {
+	loader, err := ldtestutil.DocumentLoader(ldcontext.Document{
+		URL:     ContextV1,
+		Content: json.RawMessage(didCfgCtxV1),
+	})
+	require.NoError(t, err)
+
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	didKey, keyID := fingerprint.CreateDIDKey(signer.PublicKeyBytes())
+
+	newDLC := func() *verifiable.Credential {
+		return &verifiable.Credential{
+			Context: []string{verifiable.ContextURI, ContextV1},
+			Types:   []string{verifiable.VCType, domainLinkageCredentialType},
+			Issuer:  verifiable.Issuer{ID: didKey},
+			Issued:  util.NewTime(time.Now().Truncate(time.Second)),
+			Expired: util.NewTime(time.Now().Add(time.Hour).Truncate(time.Second)),
+			Subject: []verifiable.Subject{{ID: didKey, CustomFields: map[string]interface{}{"origin": testDomain}}},
+		}
+	}
+
+	// v1 JWT domain linkage credential
+	jwtClaims, err := newDLC().JWTClaims(false)
+	require.NoError(t, err)
+
+	jwtEntry, err := jwtClaims.MarshalJWS(verifiable.EdDSA, signer, keyID)
+	require.NoError(t, err)
+
+	// linked data domain linkage credential
+	ldVC := newDLC()
+
+	err = ldVC.AddEd25519Signature2018JWSProof(signer, keyID, time.Now(), jsonldsig.WithDocumentLoader(loader))
+	require.NoError(t, err)
+
+	ldEntry := ldVC
+
+	// linked data domain linkage credential modified after signing
+	invalidLDVC := *ldVC
+	invalidLDVC.Expired = util.NewTime(ldVC.Expired.Add(time.Hour))
+
+	newDIDConfig := func(t *testing.T, entries ...interface{}) []byte {
+		t.Helper()
+
+		didCfg, err := json.Marshal(map[string]interface{}{
+			contextProperty:    ContextV1,
+			linkedDIDsProperty: entries,
+		})
+		require.NoError(t, err)
+
+		return didCfg
+	}
+
+	t.Run("success - JWT and linked data credentials", func(t *testing.T) {
+		didCfg := newDIDConfig(t, jwtEntry, ldEntry)
+
+		err := VerifyDIDAndDomain(didCfg, didKey, testDomain, WithJSONLDDocumentLoader(loader),
+			WithRequireAllMatching())
+		require.NoError(t, err)
+	})
+
+	t.Run("success - JWT credential after invalid linked data credential", func(t *testing.T) {
+		didCfg := newDIDConfig(t, &invalidLDVC, jwtEntry)
+
+		err := VerifyDIDAndDomain(didCfg, didKey, testDomain, WithJSONLDDocumentLoader(loader))
+		require.NoError(t, err)
+	})
+
+	t.Run("error - invalid linked data credential with require all matching", func(t *testing.T) {
+		didCfg := newDIDConfig(t, jwtEntry, &invalidLDVC)
+
+		err := VerifyDIDAndDomain(didCfg, didKey, testDomain, WithJSONLDDocumentLoader(loader),
+			WithRequireAllMatching())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "domain linkage credential 2 of 2")
+	})
+
+	t.Run("allowed algorithms", func(t *testing.T) {
+		didCfg := newDIDConfig(t, jwtEntry, ldEntry)
+
+		err := VerifyDIDAndDomain(didCfg, didKey, testDomain, WithJSONLDDocumentLoader(loader),
+			WithRequireAllMatching(), WithAllowedAlgorithms(jose.AlgEdDSA, "Ed25519Signature2018"))
+		require.NoError(t, err)
+
+		err = VerifyDIDAndDomain(didCfg, didKey, testDomain, WithJSONLDDocumentLoader(loader),
+			WithRequireAllMatching(), WithAllowedAlgorithms(jose.AlgEdDSA))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "proof algorithm Ed25519Signature2018 is not allowed")
+
+		err = VerifyDIDAndDomain(newDIDConfig(t, jwtEntry), didKey, testDomain, WithJSONLDDocumentLoader(loader),
+			WithAllowedAlgorithms(jose.AlgES256))
+		require.ErrorIs(t, err, ErrNoValidProof)
+	})
+
+	t.Run("max linked DIDs", func(t *testing.T) {
+		didCfg := newDIDConfig(t, jwtEntry, ldEntry)
+
+		err := VerifyDIDAndDomain(didCfg, didKey, testDomain, WithJSONLDDocumentLoader(loader), WithMaxLinkedDIDs(2))
+		require.NoError(t, err)
+
+		err = VerifyDIDAndDomain(didCfg, didKey, testDomain, WithJSONLDDocumentLoader(loader), WithMaxLinkedDIDs(1))
+		require.ErrorIs(t, err, ErrTooManyLinkedDIDs)
+		require.EqualError(t, err, "too many linked DIDs: 2 entries, the maximum is 1")
+
+		diagnosis := Diagnose(didCfg, didKey, testDomain, WithJSONLDDocumentLoader(loader), WithMaxLinkedDIDs(1))
+		require.False(t, diagnosis.Passed())
+		require.Contains(t, diagnosis.Failures(), "parse: too many linked DIDs: 2 entries, the maximum is 1")
+	})
+}
+
+func TestWithBindVerificationMethodToIssuer(t *testing.T) {
+	loader, err := ldtestutil.DocumentLoader(ldcontext.Document{
+		URL:     ContextV1,
+		Content: json.RawMessage(didCfgCtxV1),
+	})
+	require.NoError(t, err)
+
+	victimSigner, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	attackerSigner, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	victimDID, _ := fingerprint.CreateDIDKey(victimSigner.PublicKeyBytes())
+	_, attackerKeyID := fingerprint.CreateDIDKey(attackerSigner.PublicKeyBytes())
+
+	// domain linkage credential of the victim DID signed with the key of the attacker DID
+	vc := &verifiable.Credential{
+		Context: []string{verifiable.ContextURI, ContextV1},
+		Types:   []string{verifiable.VCType, domainLinkageCredentialType},
+		Issuer:  verifiable.Issuer{ID: victimDID},
+		Issued:  util.NewTime(time.Now().Truncate(time.Second)),
+		Expired: util.NewTime(time.Now().Add(time.Hour).Truncate(time.Second)),
+		Subject: []verifiable.Subject{{ID: victimDID, CustomFields: map[string]interface{}{"origin": testDomain}}},
+	}
+
+	err = vc.AddEd25519Signature2018JWSProof(attackerSigner, attackerKeyID, time.Now(),
+		jsonldsig.WithDocumentLoader(loader))
+	require.NoError(t, err)
+
+	didCfg, err := json.Marshal(map[string]interface{}{
+		contextProperty:    ContextV1,
+		linkedDIDsProperty: []interface{}{vc},
+	})
+	require.NoError(t, err)
+
+	t.Run("foreign verification method is accepted by default", func(t *testing.T) {
+		err := VerifyDIDAndDomain(didCfg, victimDID, testDomain, WithJSONLDDocumentLoader(loader))
+		require.NoError(t, err)
+	})
+
+	t.Run("error - foreign verification method is rejected", func(t *testing.T) {
+		err := VerifyDIDAndDomain(didCfg, victimDID, testDomain, WithJSONLDDocumentLoader(loader),
+			WithBindVerificationMethodToIssuer(), WithRequireAllMatching())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "doesn't belong to issuer "+victimDID)
+	})
+}
+
+func TestParseES256K(t *testing.T) {
+	loader, err := ldtestutil.DocumentLoader(ldcontext.Document{
+		URL:     ContextV1,
+		Content: json.RawMessage(didCfgCtxV1),
+	})
+	require.NoError(t, err)
+
+	signer, err := newCryptoSigner(kms.ECDSASecp256k1TypeIEEEP1363)
+	require.NoError(t, err)
+
+	pubKeyJWK, err := jwksupport.JWKFromKey(signer.PublicKey())
+	require.NoError(t, err)
+
+	// secp256k1 key in JWK form, as e.g. in did:ion documents
+	const didID = "did:ion:EiClkZMDxPKqC9c-umQfTkR8vvZ9JPhl_xLDI9Nfk38w5w"
+
+	keyID := didID + "#key-1"
+
+	vm, err := diddoc.NewVerificationMethodFromJWK(keyID, "EcdsaSecp256k1VerificationKey2019", didID, pubKeyJWK)
+	require.NoError(t, err)
+
+	registry := &mockvdr.MockVDRegistry{ResolveValue: &diddoc.Doc{
+		ID:                 didID,
+		VerificationMethod: []diddoc.VerificationMethod{*vm},
+		AssertionMethod:    []diddoc.Verification{*diddoc.NewReferencedVerification(vm, diddoc.AssertionMethod)},
+	}}
+
+	newDLC := func() *verifiable.Credential {
+		return &verifiable.Credential{
+			Context: []string{verifiable.ContextURI, ContextV1},
+			Types:   []string{verifiable.VCType, domainLinkageCredentialType},
+			Issuer:  verifiable.Issuer{ID: didID},
+			Issued:  util.NewTime(time.Now().Truncate(time.Second)),
+			Expired: util.NewTime(time.Now().Add(time.Hour).Truncate(time.Second)),
+			Subject: []verifiable.Subject{{ID: didID, CustomFields: map[string]interface{}{"origin": testDomain}}},
+		}
+	}
+
+	ldVC := newDLC()
+
+	err = ldVC.AddLinkedDataProof(&verifiable.LinkedDataProofContext{
+		SignatureType:           "EcdsaSecp256k1Signature2019",
+		SignatureRepresentation: verifiable.SignatureJWS,
+		Suite:                   ecdsasecp256k1signature2019.New(suite.WithSigner(signer)),
+		VerificationMethod:      keyID,
+	}, jsonldsig.WithDocumentLoader(loader))
+	require.NoError(t, err)
+
+	jwtClaims, err := newDLC().JWTClaims(false)
+	require.NoError(t, err)
+
+	jwtVC, err := jwtClaims.MarshalJWS(verifiable.ECDSASecp256k1, signer, keyID)
+	require.NoError(t, err)
+
+	newDIDConfig := func(t *testing.T, entries ...interface{}) []byte {
+		t.Helper()
+
+		didCfg, err := json.Marshal(map[string]interface{}{
+			contextProperty:    ContextV1,
+			linkedDIDsProperty: entries,
+		})
+		require.NoError(t, err)
+
+		return didCfg
+	}
+
+	t.Run("success - embedded EcdsaSecp256k1Signature2019 proof", func(t *testing.T) {
+		result, err := VerifyDIDAndDomainWithResult(newDIDConfig(t, ldVC), didID, testDomain,
+			WithJSONLDDocumentLoader(loader), WithVDRegistry(registry))
+		require.NoError(t, err)
+		require.Equal(t, "EcdsaSecp256k1Signature2019", result.ProofType)
+		require.Equal(t, keyID, result.VerificationMethod)
+	})
+
+	t.Run("success - ES256K JWT", func(t *testing.T) {
+		result, err := VerifyDIDAndDomainWithResult(newDIDConfig(t, jwtVC), didID, testDomain,
+			WithJSONLDDocumentLoader(loader), WithVDRegistry(registry))
+		require.NoError(t, err)
+		require.Equal(t, "ES256K", result.ProofType)
+		require.Equal(t, keyID, result.VerificationMethod)
+	})
+
+	t.Run("success - both credentials", func(t *testing.T) {
+		err := VerifyDIDAndDomain(newDIDConfig(t, ldVC, jwtVC), didID, testDomain,
+			WithJSONLDDocumentLoader(loader), WithVDRegistry(registry), WithRequireAllMatching())
+		require.NoError(t, err)
+	})
+
+	t.Run("error - signed with another key", func(t *testing.T) {
+		otherSigner, err := newCryptoSigner(kms.ECDSASecp256k1TypeIEEEP1363)
+		require.NoError(t, err)
+
+		otherJWT, err := jwtClaims.MarshalJWS(verifiable.ECDSASecp256k1, otherSigner, keyID)
+		require.NoError(t, err)
+
+		err = VerifyDIDAndDomain(newDIDConfig(t, otherJWT), didID, testDomain,
+			WithJSONLDDocumentLoader(loader), WithVDRegistry(registry))
+		require.ErrorIs(t, err, ErrNoValidProof)
+	})
+}
+
+func TestAuthorizedKeyFetcher(t *testing.T) {
+	const didID = "did:example:123"
+
+	vm := diddoc.NewVerificationMethodFromBytes("#key-1", "Ed25519VerificationKey2018", didID, []byte("key"))
+	otherVM := diddoc.NewVerificationMethodFromBytes("#key-2", "Ed25519VerificationKey2018", didID, []byte("key 2"))
+
+	registry := &mockvdr.MockVDRegistry{ResolveValue: &diddoc.Doc{
+		ID:                 didID,
+		VerificationMethod: []diddoc.VerificationMethod{*vm, *otherVM},
+		AssertionMethod:    []diddoc.Verification{*diddoc.NewReferencedVerification(vm, diddoc.AssertionMethod)},
+	}}
+
+	t.Run("success - verification method ID forms", func(t *testing.T) {
+		authorized := authorizedKeyFetcher(registry, nil)
+
+		for _, keyID := range []string{"#key-1", "key-1", didID + "#key-1"} {
+			pubKey, err := authorized(didID, keyID)
+			require.NoError(t, err, keyID)
+			require.Equal(t, []byte("key"), pubKey.Value)
+		}
+
+		// the key is not referenced by assertionMethod
+		_, err := authorized(didID, "#key-2")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "verification method did:example:123#key-2 is not authorized")
+	})
+
+	t.Run("error - not authorized", func(t *testing.T) {
+		authorized := authorizedKeyFetcher(registry, []diddoc.VerificationRelationship{diddoc.Authentication,
+			diddoc.CapabilityInvocation})
+
+		_, err := authorized(didID, "#key-1")
+		require.EqualError(t, err, "verification method did:example:123#key-1 is not authorized "+
+			"for proof purpose(s) authentication, capabilityInvocation of DID did:example:123")
+	})
+
+	t.Run("error - resolve DID", func(t *testing.T) {
+		authorized := authorizedKeyFetcher(&mockvdr.MockVDRegistry{ResolveErr: errors.New("not found")}, nil)
+
+		_, err := authorized(didID, "#key-1")
+		require.EqualError(t, err, "resolve DID did:example:123: not found")
+	})
 }
 
 func TestIsValidDomainCredentialJWT(t *testing.T) {
@@ -653,6 +1193,87 @@ func TestIsValidDomainLinkageCredential(t *testing.T) {
 	})
 }
 
+func TestValidateOrigin(t *testing.T) {
+	t.Run("success - unicode domain and its punycode origin", func(t *testing.T) {
+		require.NoError(t, validateOrigin("https://bücher.example", "https://xn--bcher-kva.example"))
+		require.NoError(t, validateOrigin("https://xn--bcher-kva.example", "https://bücher.example"))
+		require.NoError(t, validateOrigin("https://Bücher.example:8443", "https://xn--bcher-kva.example:8443"))
+	})
+
+	t.Run("success - host case and trailing slash are ignored", func(t *testing.T) {
+		require.NoError(t, validateOrigin("https://Example.com", "https://example.com/"))
+		require.NoError(t, validateOrigin("https://example.com/", "HTTPS://EXAMPLE.COM"))
+	})
+
+	t.Run("error - homograph of the domain", func(t *testing.T) {
+		// the first letter is Cyrillic
+		err := validateOrigin("https://\u0430pple.com", "https://apple.com")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "are different")
+	})
+
+	t.Run("error - different scheme or port", func(t *testing.T) {
+		require.Error(t, validateOrigin("http://bücher.example", "https://xn--bcher-kva.example"))
+		require.Error(t, validateOrigin("https://bücher.example:8443", "https://xn--bcher-kva.example"))
+	})
+
+	t.Run("error - different host names both values", func(t *testing.T) {
+		err := validateOrigin("https://Example.com", "https://example.org/")
+		require.EqualError(t, err, "origin[https://Example.com] and domain origin[https://example.org/] are different")
+
+		var mismatchErr *ErrOriginMismatch
+
+		require.ErrorAs(t, err, &mismatchErr)
+		require.Equal(t, "https://example.org/", mismatchErr.Expected)
+		require.Equal(t, "https://Example.com", mismatchErr.Got)
+	})
+
+	t.Run("origin without scheme", func(t *testing.T) {
+		require.NoError(t, validateOrigin("Example.com", "example.com/"))
+
+		err := validateOrigin("example.com", "other.com")
+		require.EqualError(t, err, "origin[example.com] and domain origin[other.com] are different")
+
+		err = validateOrigin("example.com", "https://example.com")
+		require.EqualError(t, err, "origin[example.com] and domain origin[https://example.com] are different")
+	})
+
+	t.Run("error - no host", func(t *testing.T) {
+		err := validateOrigin(testDomain, "https://")
+		require.EqualError(t, err, "origin[https://] has no host")
+
+		err = validateOrigin("/", testDomain)
+		require.EqualError(t, err, "origin[/] has no host")
+	})
+
+	t.Run("error - invalid host", func(t *testing.T) {
+		err := validateOrigin("https://xn--a.example", testDomain)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid origin host[xn--a.example]")
+	})
+}
+
+func TestNormalizeOrigin(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		for origin, expected := range map[string]string{
+			"https://example.com":          "https://example.com",
+			"HTTPS://Example.COM/":         "https://example.com",
+			"https://example.com:8443/a/b": "https://example.com:8443",
+			"https://bücher.example":       "https://xn--bcher-kva.example",
+			"Example.com":                  "example.com",
+		} {
+			normalized, err := NormalizeOrigin(origin)
+			require.NoError(t, err)
+			require.Equal(t, expected, normalized, origin)
+		}
+	})
+
+	t.Run("error - no host", func(t *testing.T) {
+		_, err := NormalizeOrigin("https://")
+		require.EqualError(t, err, "origin[https://] has no host")
+	})
+}
+
 func createEdDSAJWS(t *testing.T, cred *verifiable.Credential, signer verifiable.Signer,
 	keyID string, minimize bool) string {
 	t.Helper()
@@ -921,3 +1542,19 @@ var didCfgCtxV1 = `
     }
   ]
 }`
+
+// mockProofVerifier records the verification requests and verifies them locally.
+type mockProofVerifier struct {
+	algs []string
+	err  error
+}
+
+func (v *mockProofVerifier) Verify(alg string, pubKey *sigverifier.PublicKey, message, signature []byte) error {
+	v.algs = append(v.algs, alg)
+
+	if v.err != nil {
+		return v.err
+	}
+
+	return verifiable.NewLocalProofVerifier().Verify(alg, pubKey, message, signature)
+}
@@ -0,0 +1,42 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package didconfig
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrMissingLinkedDIDs is returned if the did configuration has no linked_dids property.
+	ErrMissingLinkedDIDs = fmt.Errorf("property '%s' is required", linkedDIDsProperty)
+
+	// ErrNoMatchingCredential is returned if the did configuration has no valid domain linkage credential
+	// for the DID and domain, e.g. if the domain doesn't link the DID. The returned error wraps it with the DID
+	// and domain, use errors.Is to check for it.
+	ErrNoMatchingCredential = errors.New("domain linkage credential(s) not found")
+
+	// ErrNoValidProof is returned if none of the domain linkage credentials for the DID and domain
+	// has a valid proof.
+	ErrNoValidProof = errors.New("domain linkage credential(s) with valid proof not found")
+
+	// ErrTooManyLinkedDIDs is returned if the did configuration has more linked_dids entries than allowed
+	// by WithMaxLinkedDIDs.
+	ErrTooManyLinkedDIDs = errors.New("too many linked DIDs")
+)
+
+// ErrOriginMismatch is returned if the origin of the domain linkage credential doesn't match the domain
+// the did configuration was requested from.
+type ErrOriginMismatch struct {
+	// Expected is the origin of the domain.
+	Expected string
+	// Got is the origin of the domain linkage credential.
+	Got string
+}
+
+func (e *ErrOriginMismatch) Error() string {
+	return fmt.Sprintf("origin[%s] and domain origin[%s] are different", e.Got, e.Expected)
+}
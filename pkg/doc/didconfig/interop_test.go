@@ -14,6 +14,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/ldcontext"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/ldtestutil"
@@ -61,6 +62,96 @@ func TestInterop(t *testing.T) {
 	})
 }
 
+func TestClaimedDomains(t *testing.T) {
+	t.Run("success - MS interop document", func(t *testing.T) {
+		didDoc, err := did.ParseDocument([]byte(msDoc))
+		require.NoError(t, err)
+
+		domains, err := ClaimedDomains(didDoc)
+		require.NoError(t, err)
+		require.Equal(t, []string{msDomain}, domains)
+	})
+
+	t.Run("success - MS interop resolution", func(t *testing.T) {
+		docResolution, err := did.ParseDocumentResolution([]byte(msResolutionResponse))
+		require.NoError(t, err)
+
+		domains, err := ClaimedDomains(docResolution.DIDDocument)
+		require.NoError(t, err)
+		require.Equal(t, []string{msDomain}, domains)
+	})
+
+	t.Run("success - endpoint forms", func(t *testing.T) {
+		didDoc := &did.Doc{
+			ID: "did:example:123",
+			Service: []did.Service{
+				{
+					ID:              "did:example:123#single",
+					Type:            LinkedDomainsServiceType,
+					ServiceEndpoint: model.NewDIDCommV1Endpoint("https://foo.example.com"),
+				},
+				{
+					ID:              "did:example:123#array",
+					Type:            []interface{}{LinkedDomainsServiceType},
+					ServiceEndpoint: model.NewDIDCoreEndpoint([]interface{}{"https://bar.example.com", "https://foo.example.com"}),
+				},
+				{
+					ID:   "did:example:123#origins",
+					Type: LinkedDomainsServiceType,
+					ServiceEndpoint: model.NewDIDCoreEndpoint(map[string]interface{}{
+						"origins": []interface{}{"https://baz.example.com"},
+					}),
+				},
+				{
+					ID:              "did:example:123#hub",
+					Type:            "IdentityHub",
+					ServiceEndpoint: model.NewDIDCommV1Endpoint("https://hub.example.com"),
+				},
+			},
+		}
+
+		domains, err := ClaimedDomains(didDoc)
+		require.NoError(t, err)
+		require.Equal(t, []string{"https://foo.example.com", "https://bar.example.com", "https://baz.example.com"}, domains)
+	})
+
+	t.Run("success - no linked domains", func(t *testing.T) {
+		domains, err := ClaimedDomains(&did.Doc{ID: "did:example:123"})
+		require.NoError(t, err)
+		require.Empty(t, domains)
+	})
+
+	t.Run("error - invalid origins", func(t *testing.T) {
+		didDoc := &did.Doc{
+			ID: "did:example:123",
+			Service: []did.Service{
+				{
+					ID:   "did:example:123#origins",
+					Type: LinkedDomainsServiceType,
+					ServiceEndpoint: model.NewDIDCoreEndpoint(map[string]interface{}{
+						"origins": []interface{}{1},
+					}),
+				},
+			},
+		}
+
+		domains, err := ClaimedDomains(didDoc)
+		require.EqualError(t, err, "service did:example:123#origins: origin of unexpected type float64")
+		require.Nil(t, domains)
+	})
+
+	t.Run("error - missing endpoint", func(t *testing.T) {
+		didDoc := &did.Doc{
+			ID:      "did:example:123",
+			Service: []did.Service{{ID: "did:example:123#linked", Type: LinkedDomainsServiceType}},
+		}
+
+		domains, err := ClaimedDomains(didDoc)
+		require.EqualError(t, err, "service did:example:123#linked: unsupported service endpoint null")
+		require.Nil(t, domains)
+	})
+}
+
 // ms constants.
 const (
 	// nolint: lll
@@ -0,0 +1,139 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package didconfig
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+)
+
+// LinkedDomainsServiceType is the type of DID document service that lists domains linked to the DID.
+const LinkedDomainsServiceType = "LinkedDomains"
+
+const originsProperty = "origins"
+
+// ClaimedDomains returns all origins that the DID claims in LinkedDomains services of the DID document.
+// The service endpoint can be a single origin, an array of origins or an object with an "origins" array.
+// Claimed domains are not verified against the did configuration.
+func ClaimedDomains(doc *did.Doc) ([]string, error) {
+	var domains []string
+
+	for i := range doc.Service {
+		svc := &doc.Service[i]
+
+		if !isLinkedDomainsService(svc) {
+			continue
+		}
+
+		origins, err := serviceOrigins(svc)
+		if err != nil {
+			return nil, fmt.Errorf("service %s: %w", svc.ID, err)
+		}
+
+		for _, origin := range origins {
+			if !contains(origin, domains) {
+				domains = append(domains, origin)
+			}
+		}
+	}
+
+	return domains, nil
+}
+
+func isLinkedDomainsService(svc *did.Service) bool {
+	switch t := svc.Type.(type) {
+	case string:
+		return t == LinkedDomainsServiceType
+	case []interface{}:
+		for _, v := range t {
+			if v == LinkedDomainsServiceType {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func serviceOrigins(svc *did.Service) ([]string, error) {
+	endpointBytes, err := svc.ServiceEndpoint.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("marshal service endpoint: %w", err)
+	}
+
+	var endpoint interface{}
+
+	err = json.Unmarshal(endpointBytes, &endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal service endpoint: %w", err)
+	}
+
+	if obj, ok := endpoint.(map[string]interface{}); ok {
+		endpoint = obj[originsProperty]
+	}
+
+	switch e := endpoint.(type) {
+	case string:
+		return []string{e}, nil
+	case []interface{}:
+		origins := make([]string, 0, len(e))
+
+		for _, v := range e {
+			origin, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("origin of unexpected type %T", v)
+			}
+
+			origins = append(origins, origin)
+		}
+
+		return origins, nil
+	default:
+		return nil, fmt.Errorf("unsupported service endpoint %s", endpointBytes)
+	}
+}
+
+// recordingResolver records the DID document resolved for the DID during verification.
+type recordingResolver struct {
+	resolver didResolver
+	did      string
+	doc      *did.Doc
+}
+
+func (r *recordingResolver) Resolve(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+	docResolution, err := r.resolver.Resolve(didID, opts...)
+	if err == nil && didID == r.did && docResolution.DIDDocument != nil {
+		r.doc = docResolution.DIDDocument
+	}
+
+	return docResolution, err
+}
+
+// warnIfDomainNotClaimed logs a warning if the verified domain is not claimed by the DID document.
+func warnIfDomainNotClaimed(doc *did.Doc, didID, domain string) {
+	if doc == nil {
+		return
+	}
+
+	domains, err := ClaimedDomains(doc)
+	if err != nil {
+		logger.Warnf("failed to get domains claimed by DID[%s]: %s", didID, err.Error())
+
+		return
+	}
+
+	for _, claimed := range domains {
+		if validateOrigin(claimed, domain) == nil {
+			return
+		}
+	}
+
+	logger.Warnf("domain[%s] is linked to DID[%s] but it is not claimed in DID document LinkedDomains services",
+		domain, didID)
+}
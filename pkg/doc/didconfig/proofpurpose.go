@@ -0,0 +1,103 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package didconfig
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	sigverifier "github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+)
+
+//nolint:gochecknoglobals
+var proofPurposeNames = map[did.VerificationRelationship]string{
+	did.Authentication:       "authentication",
+	did.AssertionMethod:      "assertionMethod",
+	did.CapabilityDelegation: "capabilityDelegation",
+	did.CapabilityInvocation: "capabilityInvocation",
+	did.KeyAgreement:         "keyAgreement",
+}
+
+// WithAllowedProofPurposes sets the verification relationships under which the verification method of the domain
+// linkage credential proof must be authorized in the issuer DID document, e.g. did.AssertionMethod and
+// did.CapabilityDelegation for a domain linkage issuance delegated to another key. By default, the verification
+// method must be authorized under did.AssertionMethod.
+func WithAllowedProofPurposes(purposes ...did.VerificationRelationship) DIDConfigurationOpt {
+	return func(opts *didConfigOpts) {
+		opts.allowedProofPurposes = purposes
+	}
+}
+
+// authorizedKeyFetcher returns the public key fetcher which fetches only the verification methods authorized
+// under one of the proof purposes in the DID document.
+func authorizedKeyFetcher(resolver didResolver, purposes []did.VerificationRelationship) verifiable.PublicKeyFetcher {
+	if len(purposes) == 0 {
+		purposes = []did.VerificationRelationship{did.AssertionMethod}
+	}
+
+	return func(didID, keyID string) (*sigverifier.PublicKey, error) {
+		docResolution, err := resolver.Resolve(didID)
+		if err != nil {
+			return nil, fmt.Errorf("resolve DID %s: %w", didID, err)
+		}
+
+		if !isAuthorized(docResolution.DIDDocument, absoluteVMID(didID, keyID), purposes) {
+			names := make([]string, len(purposes))
+
+			for i, purpose := range purposes {
+				names[i] = proofPurposeNames[purpose]
+			}
+
+			return nil, fmt.Errorf("verification method %s is not authorized for proof purpose(s) %s of DID %s",
+				absoluteVMID(didID, keyID), strings.Join(names, ", "), didID)
+		}
+
+		// the public key is looked up in the same resolution of the DID
+		return verifiable.NewVDRKeyResolver(&resolvedDID{docResolution: docResolution}).PublicKeyFetcher()(didID, keyID)
+	}
+}
+
+// resolvedDID resolves any DID to the already resolved document.
+type resolvedDID struct {
+	docResolution *did.DocResolution
+}
+
+func (r *resolvedDID) Resolve(string, ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+	return r.docResolution, nil
+}
+
+// isAuthorized checks whether the verification method is referenced or embedded by one of the relationships.
+func isAuthorized(doc *did.Doc, vmID string, purposes []did.VerificationRelationship) bool {
+	if doc == nil {
+		return false
+	}
+
+	for _, verifications := range doc.VerificationMethods(purposes...) {
+		for _, verification := range verifications {
+			if absoluteVMID(doc.ID, verification.VerificationMethod.ID) == vmID {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// absoluteVMID returns the absolute ID of the verification method, which can be given as a DID URL,
+// a relative DID URL (#key-1) or a fragment (key-1).
+func absoluteVMID(didID, vmID string) string {
+	switch {
+	case strings.HasPrefix(vmID, "#"):
+		return didID + vmID
+	case !strings.Contains(vmID, "#"):
+		return didID + "#" + vmID
+	default:
+		return vmID
+	}
+}
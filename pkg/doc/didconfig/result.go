@@ -0,0 +1,58 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package didconfig
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+)
+
+// VerificationResult describes the domain linkage credential which satisfied the verification.
+type VerificationResult struct {
+	// Credential is the matched domain linkage credential.
+	Credential *verifiable.Credential
+	// Issuer is the issuer of the credential.
+	Issuer string
+	// Origin is the credentialSubject.origin of the credential.
+	Origin string
+	// ProofType is the type of the verified linked data proof (e.g. Ed25519Signature2018)
+	// or, for a JWT credential, the JWS algorithm (e.g. EdDSA).
+	ProofType string
+	// VerificationMethod is the verification method of the verified proof, the "kid" of a JWT credential.
+	VerificationMethod string
+}
+
+func newVerificationResult(vc *verifiable.Credential) (*VerificationResult, error) {
+	result := &VerificationResult{
+		Credential: vc,
+		Issuer:     vc.Issuer.ID,
+	}
+
+	if subjects, ok := vc.Subject.([]verifiable.Subject); ok && len(subjects) > 0 {
+		result.Origin, _ = subjects[0].CustomFields["origin"].(string) //nolint:errcheck
+	}
+
+	if vc.JWT != "" {
+		jsonWebToken, _, err := jwt.Parse(vc.JWT, jwt.WithSignatureVerifier(&noVerifier{}))
+		if err != nil {
+			return nil, fmt.Errorf("parse JWT: %w", err)
+		}
+
+		result.ProofType, _ = jsonWebToken.Headers.Algorithm()
+		result.VerificationMethod, _ = jsonWebToken.Headers.KeyID()
+
+		return result, nil
+	}
+
+	if len(vc.Proofs) > 0 {
+		result.ProofType, _ = vc.Proofs[0]["type"].(string)                        //nolint:errcheck
+		result.VerificationMethod, _ = vc.Proofs[0]["verificationMethod"].(string) //nolint:errcheck
+	}
+
+	return result, nil
+}
@@ -0,0 +1,246 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jose
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/tink/go/subtle/random"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/aead/subtle"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/composite"
+)
+
+// Key management algorithms wrapping the CEK with a shared symmetric key using AES-GCM key wrapping as per
+// the JWA specification: https://tools.ietf.org/html/rfc7518#section-4.7
+const (
+	// A128GCMKWALG represents key wrapping with AES-GCM using a 128-bit key.
+	A128GCMKWALG = "A128GCMKW"
+	// A192GCMKWALG represents key wrapping with AES-GCM using a 192-bit key.
+	A192GCMKWALG = "A192GCMKW"
+	// A256GCMKWALG represents key wrapping with AES-GCM using a 256-bit key.
+	A256GCMKWALG = "A256GCMKW"
+)
+
+const (
+	gcmKWIVSize  = 12
+	gcmKWTagSize = 16
+)
+
+// SymmetricKey is a shared symmetric key used to wrap the CEK with AES-GCM key wrapping.
+type SymmetricKey struct {
+	// KID identifies the key for the recipient, it's set as the 'kid' header of the JWE if not empty.
+	KID string
+	// Key is a 128, 192 or 256-bit AES key, its size determines the key wrapping algorithm.
+	Key []byte
+}
+
+// SymmetricKeySource returns the shared symmetric key identified by kid (which is empty if the JWE has
+// no 'kid' header) to unwrap the CEK with AES-GCM key wrapping.
+type SymmetricKeySource func(kid string) ([]byte, error)
+
+func gcmKWAlg(keySize int) (string, error) {
+	switch keySize {
+	case subtle.AES128Size:
+		return A128GCMKWALG, nil
+	case subtle.AES192Size:
+		return A192GCMKWALG, nil
+	case subtle.AES256Size:
+		return A256GCMKWALG, nil
+	default:
+		return "", fmt.Errorf("invalid AES-GCM key wrapping key size: %d bytes", keySize)
+	}
+}
+
+func isGCMKWAlg(alg string) bool {
+	switch alg {
+	case A128GCMKWALG, A192GCMKWALG, A256GCMKWALG:
+		return true
+	default:
+		return false
+	}
+}
+
+// wrapCEKWithGCM encrypts the CEK with AES-GCM, it returns the encrypted key, the IV and the authentication tag.
+func wrapCEKWithGCM(cek, key []byte) ([]byte, []byte, []byte, error) {
+	aead, err := newGCMKW(key)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	iv := random.GetRandomBytes(gcmKWIVSize)
+
+	sealed := aead.Seal(nil, iv, cek, nil)
+	tagIdx := len(sealed) - gcmKWTagSize
+
+	return sealed[:tagIdx], iv, sealed[tagIdx:], nil
+}
+
+// unwrapCEKWithGCM decrypts the CEK wrapped with AES-GCM.
+func unwrapCEKWithGCM(encryptedKey, iv, tag, key []byte) ([]byte, error) {
+	aead, err := newGCMKW(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(iv) != gcmKWIVSize {
+		return nil, fmt.Errorf("invalid AES-GCM key wrapping IV size: %d bytes", len(iv))
+	}
+
+	if len(tag) != gcmKWTagSize {
+		return nil, fmt.Errorf("invalid AES-GCM key wrapping tag size: %d bytes", len(tag))
+	}
+
+	sealed := append(append(make([]byte, 0, len(encryptedKey)+len(tag)), encryptedKey...), tag...)
+
+	return aead.Open(nil, iv, sealed, nil)
+}
+
+func newGCMKW(key []byte) (cipher.AEAD, error) {
+	if _, err := gcmKWAlg(len(key)); err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+func (je *JWEEncrypt) encryptWithSymmetricKey(protectedHeaders map[string]interface{},
+	plaintext, aad []byte) (*JSONWebEncryption, error) {
+	cek := je.newCEK()
+
+	encryptedKey, iv, tag, err := wrapCEKWithGCM(cek, je.symmetricKey.Key)
+	if err != nil {
+		return nil, fmt.Errorf("jweencrypt: failed to wrap cek: %w", err)
+	}
+
+	// the key wrapping IV and tag are integrity protected along with the other headers.
+	protectedHeaders[HeaderAlgorithm] = je.kwAlg
+	protectedHeaders[HeaderIV] = base64.RawURLEncoding.EncodeToString(iv)
+	protectedHeaders[HeaderTag] = base64.RawURLEncoding.EncodeToString(tag)
+
+	if je.symmetricKey.KID != "" {
+		protectedHeaders[HeaderKeyID] = je.symmetricKey.KID
+	}
+
+	return je.encryptWithWrappedCEK(protectedHeaders, cek, []*Recipient{{EncryptedKey: string(encryptedKey)}},
+		plaintext, aad)
+}
+
+// encryptWithWrappedCEK encrypts the plaintext with the CEK already wrapped for the recipients by a key management
+// algorithm other than the ECDH key wrapping of the crypto service (e.g. AES-GCM or PBES2 key wrapping).
+func (je *JWEEncrypt) encryptWithWrappedCEK(protectedHeaders map[string]interface{}, cek []byte,
+	recipients []*Recipient, plaintext, aad []byte) (*JSONWebEncryption, error) {
+	encPrimitive, err := je.getECDHEncPrimitive(cek)
+	if err != nil {
+		return nil, fmt.Errorf("jweencrypt: failed to get encryption primitive: %w", err)
+	}
+
+	authData, err := computeAuthData(protectedHeaders, "", aad)
+	if err != nil {
+		return nil, fmt.Errorf("jweencrypt: computeAuthData: marshal error %w", err)
+	}
+
+	serializedEncData, err := encPrimitive.Encrypt(plaintext, authData)
+	if err != nil {
+		return nil, fmt.Errorf("jweencrypt: failed to Encrypt: %w", err)
+	}
+
+	encData := new(composite.EncryptedData)
+
+	err = json.Unmarshal(serializedEncData, encData)
+	if err != nil {
+		return nil, fmt.Errorf("jweencrypt: unmarshal encrypted data failed: %w", err)
+	}
+
+	return getJSONWebEncryption(encData, recipients, protectedHeaders, aad), nil
+}
+
+func (jd *JWEDecrypt) decryptWithSymmetricKey(jwe *JSONWebEncryption) ([]byte, error) {
+	if jd.symmetricKeySource == nil {
+		return nil, errors.New("jwedecrypt: symmetric key source is required for AES-GCM key wrapping")
+	}
+
+	var errs []error
+
+	for _, rec := range jwe.Recipients {
+		cek, err := jd.unwrapCEKWithSymmetricKey(jwe.ProtectedHeaders, rec)
+		if err == nil {
+			return jd.decryptJWE(jwe, cek)
+		}
+
+		errs = append(errs, err)
+	}
+
+	return nil, fmt.Errorf("jwedecrypt: failed to unwrap cek: %v", errs)
+}
+
+func (jd *JWEDecrypt) unwrapCEKWithSymmetricKey(protectedHeaders Headers, rec *Recipient) ([]byte, error) {
+	headers := gcmKWHeaders(protectedHeaders, rec.Header)
+
+	if !isGCMKWAlg(headers.Alg) {
+		return nil, fmt.Errorf("unsupported key wrapping algorithm '%s'", headers.Alg)
+	}
+
+	key, err := jd.symmetricKeySource(headers.KID)
+	if err != nil {
+		return nil, fmt.Errorf("symmetric key for kid '%s': %w", headers.KID, err)
+	}
+
+	if alg, e := gcmKWAlg(len(key)); e != nil || alg != headers.Alg {
+		return nil, fmt.Errorf("symmetric key for kid '%s' can't be used with '%s'", headers.KID, headers.Alg)
+	}
+
+	iv, err := base64.RawURLEncoding.DecodeString(headers.IV)
+	if err != nil {
+		return nil, fmt.Errorf("decode key wrapping iv: %w", err)
+	}
+
+	tag, err := base64.RawURLEncoding.DecodeString(headers.Tag)
+	if err != nil {
+		return nil, fmt.Errorf("decode key wrapping tag: %w", err)
+	}
+
+	return unwrapCEKWithGCM([]byte(rec.EncryptedKey), iv, tag, key)
+}
+
+// gcmKWHeaders returns the AES-GCM key wrapping headers of the recipient, the protected headers take precedence
+// over the per-recipient headers.
+func gcmKWHeaders(protectedHeaders Headers, recHeaders *RecipientHeaders) *RecipientHeaders {
+	headers := &RecipientHeaders{}
+
+	if recHeaders != nil {
+		*headers = *recHeaders
+	}
+
+	if alg, ok := protectedHeaders.Algorithm(); ok {
+		headers.Alg = alg
+	}
+
+	if kid, ok := protectedHeaders.KeyID(); ok {
+		headers.KID = kid
+	}
+
+	if iv, ok := protectedHeaders.stringValue(HeaderIV); ok {
+		headers.IV = iv
+	}
+
+	if tag, ok := protectedHeaders.stringValue(HeaderTag); ok {
+		headers.Tag = tag
+	}
+
+	return headers
+}
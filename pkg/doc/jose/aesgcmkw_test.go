@@ -0,0 +1,208 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jose
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	gojose "github.com/go-jose/go-jose/v3"
+	"github.com/google/tink/go/subtle/random"
+	"github.com/stretchr/testify/require"
+)
+
+// Key wrap using AES-GCM key wrap with AES-CBC-HMAC-SHA2 from RFC 7520 (JOSE Cookbook) section 5.7.
+const (
+	rfc7520GCMKWKey = "qC57l_uxcm7Nm3K-ct4GFjx8tM1U8CZ0NLBvdQstiS8"
+	rfc7520GCMKWKID = "18ec08e1-bfa9-4d95-b205-2b4dd1d4321d"
+
+	rfc7520Plaintext = "You can trust us to stick with you through thick and thin–to the bitter end. " +
+		"And you can trust us to keep any secret of yours–closer than you keep it yourself. " +
+		"But you cannot trust us to let you face trouble alone, and go off without a word. " +
+		"We are your friends, Frodo."
+
+	rfc7520GCMKWCompactJWE = "eyJhbGciOiJBMjU2R0NNS1ciLCJraWQiOiIxOGVjMDhlMS1iZmE5LTRkOTUtYjIwNS0yYjRkZDFkNDMy" +
+		"MWQiLCJ0YWciOiJrZlBkdVZRM1QzSDZ2bmV3dC0ta3N3IiwiaXYiOiJLa1lUMEdYXzJqSGxmcU5fIiwiZW5jIjoiQTEyOENCQy1IUzI1" +
+		"NiJ9" +
+		".lJf3HbOApxMEBkCMOoTnnABxs_CvTWUmZQ2ElLvYNok" +
+		".gz6NjyEFNm_vm8Gj6FwoFQ" +
+		".Jf5p9-ZhJlJy_IQ_byKFmI0Ro7w7G1QiaZpI8OaiVgD8EqoDZHyFKFBupS8iaEeVIgMqWmsuJKuoVgzR3YfzoMd3GxEm3VxNhzWyWtZ" +
+		"KX0gxKdy6HgLvqoGNbZCzLjqcpDiF8q2_62EVAbr2uSc2oaxFmFuIQHLcqAHxy51449xkjZ7ewzZaGV3eFqhpco8o4DijXaG5_7kp3h2c" +
+		"ajRfDgymuxUbWgLqaeNQaJtvJmSMFuEOSAzw9Hdeb6yhdTynCRmu-kqtO5Dec4lT2OMZKpnxc_F1_4yDJFcqb5CiDSmA-psB2k0JtjxAj" +
+		"4UPI61oONK7zzFIu4gBfjJCndsZfdvG7h8wGjV98QhrKEnR7xKZ3KCr0_qR1B-gxpNk3xWU" +
+		".DKW7jrb4WaRSNfbXVPlT5g"
+)
+
+func TestAESGCMKW_RFC7520(t *testing.T) {
+	key, err := base64.RawURLEncoding.DecodeString(rfc7520GCMKWKey)
+	require.NoError(t, err)
+
+	keySource := func(kid string) ([]byte, error) {
+		if kid != rfc7520GCMKWKID {
+			return nil, fmt.Errorf("unknown kid %s", kid)
+		}
+
+		return key, nil
+	}
+
+	jwe, err := Deserialize(rfc7520GCMKWCompactJWE)
+	require.NoError(t, err)
+
+	alg, ok := jwe.ProtectedHeaders.Algorithm()
+	require.True(t, ok)
+	require.Equal(t, A256GCMKWALG, alg)
+
+	plaintext, err := NewJWEDecrypt(nil, nil, nil, WithSymmetricKeySource(keySource)).Decrypt(jwe)
+	require.NoError(t, err)
+	require.Equal(t, rfc7520Plaintext, string(plaintext))
+
+	t.Run("error - modified key wrapping tag", func(t *testing.T) {
+		jwe, err := Deserialize(rfc7520GCMKWCompactJWE)
+		require.NoError(t, err)
+
+		jwe.ProtectedHeaders[HeaderTag] = base64.RawURLEncoding.EncodeToString(make([]byte, gcmKWTagSize))
+
+		_, err = NewJWEDecrypt(nil, nil, nil, WithSymmetricKeySource(keySource)).Decrypt(jwe)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to unwrap cek")
+	})
+}
+
+func TestJWEEncryptWithSymmetricKey(t *testing.T) {
+	plaintext := []byte("secret message")
+	aad := []byte("external aad")
+
+	for _, keySize := range []int{16, 24, 32} {
+		for _, encAlg := range []EncAlg{A256GCM, XC20P, A128CBCHS256, A256CBCHS512} {
+			keySize, encAlg := keySize, encAlg
+
+			t.Run(fmt.Sprintf("%d-byte key with %s", keySize, encAlg), func(t *testing.T) {
+				key := &SymmetricKey{KID: "shared-key", Key: random.GetRandomBytes(uint32(keySize))}
+
+				encrypter, err := NewJWEEncryptWithSymmetricKey(encAlg, "application/didcomm-encrypted+json", "", key)
+				require.NoError(t, err)
+
+				jwe, err := encrypter.EncryptWithAuthData(plaintext, aad)
+				require.NoError(t, err)
+
+				alg, ok := jwe.ProtectedHeaders.Algorithm()
+				require.True(t, ok)
+				require.Equal(t, fmt.Sprintf("A%dGCMKW", keySize*8), alg)
+				require.Contains(t, jwe.ProtectedHeaders, HeaderIV)
+				require.Contains(t, jwe.ProtectedHeaders, HeaderTag)
+
+				serializedJWE, err := jwe.FullSerialize(json.Marshal)
+				require.NoError(t, err)
+
+				jwe, err = Deserialize(serializedJWE)
+				require.NoError(t, err)
+
+				decrypter := NewJWEDecrypt(nil, nil, nil, WithSymmetricKeySource(func(kid string) ([]byte, error) {
+					require.Equal(t, "shared-key", kid)
+
+					return key.Key, nil
+				}))
+
+				decrypted, err := decrypter.Decrypt(jwe)
+				require.NoError(t, err)
+				require.Equal(t, plaintext, decrypted)
+			})
+		}
+	}
+
+	t.Run("compact JWE is decrypted by go-jose", func(t *testing.T) {
+		key := &SymmetricKey{Key: random.GetRandomBytes(32)}
+
+		encrypter, err := NewJWEEncryptWithSymmetricKey(A256GCM, "", "", key)
+		require.NoError(t, err)
+
+		jwe, err := encrypter.Encrypt(plaintext)
+		require.NoError(t, err)
+		require.NotContains(t, jwe.ProtectedHeaders, HeaderKeyID)
+
+		compactJWE, err := jwe.CompactSerialize(json.Marshal)
+		require.NoError(t, err)
+
+		gjJWE, err := gojose.ParseEncrypted(compactJWE)
+		require.NoError(t, err)
+
+		decrypted, err := gjJWE.Decrypt(key.Key)
+		require.NoError(t, err)
+		require.Equal(t, plaintext, decrypted)
+	})
+
+	t.Run("go-jose JWE is decrypted", func(t *testing.T) {
+		key := random.GetRandomBytes(16)
+
+		gjEncrypter, err := gojose.NewEncrypter(gojose.A128CBC_HS256,
+			gojose.Recipient{Algorithm: gojose.A128GCMKW, Key: key, KeyID: "gj-key"}, nil)
+		require.NoError(t, err)
+
+		gjJWE, err := gjEncrypter.Encrypt(plaintext)
+		require.NoError(t, err)
+
+		jwe, err := Deserialize(gjJWE.FullSerialize())
+		require.NoError(t, err)
+
+		decrypted, err := NewJWEDecrypt(nil, nil, nil, WithSymmetricKeySource(func(kid string) ([]byte, error) {
+			require.Equal(t, "gj-key", kid)
+
+			return key, nil
+		})).Decrypt(jwe)
+		require.NoError(t, err)
+		require.Equal(t, plaintext, decrypted)
+	})
+
+	t.Run("error - invalid encrypter parameters", func(t *testing.T) {
+		_, err := NewJWEEncryptWithSymmetricKey(A256GCM, "", "", nil)
+		require.EqualError(t, err, "symmetric key is required")
+
+		_, err = NewJWEEncryptWithSymmetricKey(A256GCM, "", "", &SymmetricKey{Key: []byte("short key")})
+		require.EqualError(t, err, "invalid AES-GCM key wrapping key size: 9 bytes")
+
+		_, err = NewJWEEncryptWithSymmetricKey("invalid", "", "", &SymmetricKey{Key: random.GetRandomBytes(16)})
+		require.EqualError(t, err, "encryption algorithm 'invalid' not supported")
+	})
+
+	t.Run("error - decryption with the wrong key", func(t *testing.T) {
+		encrypter, err := NewJWEEncryptWithSymmetricKey(A256GCM, "", "", &SymmetricKey{Key: random.GetRandomBytes(32)})
+		require.NoError(t, err)
+
+		encryptedJWE, err := encrypter.Encrypt(plaintext)
+		require.NoError(t, err)
+
+		compactJWE, err := encryptedJWE.CompactSerialize(json.Marshal)
+		require.NoError(t, err)
+
+		jwe, err := Deserialize(compactJWE)
+		require.NoError(t, err)
+
+		_, err = NewJWEDecrypt(nil, nil, nil).Decrypt(jwe)
+		require.EqualError(t, err, "jwedecrypt: symmetric key source is required for AES-GCM key wrapping")
+
+		_, err = NewJWEDecrypt(nil, nil, nil, WithSymmetricKeySource(func(string) ([]byte, error) {
+			return random.GetRandomBytes(32), nil
+		})).Decrypt(jwe)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "jwedecrypt: failed to unwrap cek")
+
+		_, err = NewJWEDecrypt(nil, nil, nil, WithSymmetricKeySource(func(string) ([]byte, error) {
+			return random.GetRandomBytes(16), nil
+		})).Decrypt(jwe)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "symmetric key for kid '' can't be used with 'A256GCMKW'")
+
+		_, err = NewJWEDecrypt(nil, nil, nil, WithSymmetricKeySource(func(string) ([]byte, error) {
+			return nil, errors.New("key not found")
+		})).Decrypt(jwe)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "key not found")
+	})
+}
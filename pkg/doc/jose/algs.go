@@ -0,0 +1,114 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jose
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+// JWS algorithms of the signature verifiers (pkg/doc/signature/verifier).
+const (
+	AlgEdDSA  = "EdDSA"
+	AlgES256K = "ES256K"
+	AlgES256  = "ES256"
+	AlgES384  = "ES384"
+	AlgES521  = "ES521"
+	AlgRS256  = "RS256"
+	AlgPS256  = "PS256"
+)
+
+// Other JWS algorithms supported by VerifyWithResolver.
+const (
+	AlgES512 = "ES512"
+	AlgRS384 = "RS384"
+	AlgRS512 = "RS512"
+	AlgPS384 = "PS384"
+	AlgPS512 = "PS512"
+	AlgHS256 = "HS256"
+	AlgHS384 = "HS384"
+	AlgHS512 = "HS512"
+)
+
+// ErrAlgsFromJWK is returned by AlgsForVerificationType for the verification method types whose
+// algorithms depend on the key (e.g. JsonWebKey2020), AlgsForJWK should be used instead.
+var ErrAlgsFromJWK = errors.New("JWS algorithms are derived from the JWK")
+
+//nolint:gochecknoglobals
+var verificationTypeAlgs = map[string][]string{
+	"Ed25519VerificationKey2018":        {AlgEdDSA},
+	"Ed25519VerificationKey2020":        {AlgEdDSA},
+	"EcdsaSecp256k1VerificationKey2019": {AlgES256K},
+	"Secp256k1VerificationKey2018":      {AlgES256K},
+	"EcdsaSecp256r1VerificationKey2019": {AlgES256},
+	"RsaVerificationKey2018":            {AlgRS256, AlgPS256},
+}
+
+//nolint:gochecknoglobals
+var jwkVerificationTypes = map[string]bool{
+	"JsonWebKey2020":         true,
+	"JwsVerificationKey2020": true,
+}
+
+// AlgsForVerificationType returns the JWS algorithms compatible with the DID verification method type,
+// e.g. EdDSA for Ed25519VerificationKey2018 or ES256K for EcdsaSecp256k1VerificationKey2019.
+// For JsonWebKey2020 the algorithms depend on the key, so ErrAlgsFromJWK is returned.
+func AlgsForVerificationType(vmType string) ([]string, error) {
+	if jwkVerificationTypes[vmType] {
+		return nil, fmt.Errorf("verification method type %s: %w", vmType, ErrAlgsFromJWK)
+	}
+
+	algs, ok := verificationTypeAlgs[vmType]
+	if !ok {
+		return nil, fmt.Errorf("no JWS algorithm for verification method type %s", vmType)
+	}
+
+	return append([]string(nil), algs...), nil
+}
+
+// AlgsForJWK returns the JWS algorithms compatible with the key of the JWK, e.g. of a JsonWebKey2020
+// verification method. If the JWK restricts the algorithm ("alg"), only that algorithm is returned.
+func AlgsForJWK(j *jwk.JWK) ([]string, error) {
+	keyType, err := j.KeyType()
+	if err != nil {
+		return nil, fmt.Errorf("JWS algorithms for JWK: %w", err)
+	}
+
+	var algs []string
+
+	switch keyType { //nolint:exhaustive
+	case kms.ED25519Type:
+		algs = []string{AlgEdDSA}
+	case kms.ECDSASecp256k1TypeIEEEP1363:
+		algs = []string{AlgES256K}
+	case kms.ECDSAP256TypeIEEEP1363:
+		algs = []string{AlgES256}
+	case kms.ECDSAP384TypeIEEEP1363:
+		algs = []string{AlgES384}
+	case kms.ECDSAP521TypeIEEEP1363:
+		algs = []string{AlgES521}
+	case kms.RSAPS256Type:
+		algs = []string{AlgRS256, AlgPS256}
+	default:
+		return nil, fmt.Errorf("no JWS algorithm for JWK of key type %s", keyType)
+	}
+
+	if j.Algorithm == "" {
+		return algs, nil
+	}
+
+	for _, alg := range algs {
+		if alg == j.Algorithm {
+			return []string{alg}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("JWK alg %s is not compatible with key type %s", j.Algorithm, keyType)
+}
@@ -0,0 +1,145 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jose
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
+)
+
+func TestAlgsForVerificationType(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		tests := map[string][]string{
+			"Ed25519VerificationKey2018":        {"EdDSA"},
+			"Ed25519VerificationKey2020":        {"EdDSA"},
+			"EcdsaSecp256k1VerificationKey2019": {"ES256K"},
+			"Secp256k1VerificationKey2018":      {"ES256K"},
+			"EcdsaSecp256r1VerificationKey2019": {"ES256"},
+			"RsaVerificationKey2018":            {"RS256", "PS256"},
+		}
+
+		for vmType, expected := range tests {
+			algs, err := AlgsForVerificationType(vmType)
+			require.NoError(t, err, vmType)
+			require.Equal(t, expected, algs, vmType)
+		}
+	})
+
+	t.Run("returned algorithms can be modified", func(t *testing.T) {
+		algs, err := AlgsForVerificationType("Ed25519VerificationKey2018")
+		require.NoError(t, err)
+
+		algs[0] = "none"
+
+		algs, err = AlgsForVerificationType("Ed25519VerificationKey2018")
+		require.NoError(t, err)
+		require.Equal(t, []string{AlgEdDSA}, algs)
+	})
+
+	t.Run("error - algorithms derived from JWK", func(t *testing.T) {
+		for _, vmType := range []string{"JsonWebKey2020", "JwsVerificationKey2020"} {
+			_, err := AlgsForVerificationType(vmType)
+			require.Error(t, err)
+			require.True(t, errors.Is(err, ErrAlgsFromJWK))
+		}
+	})
+
+	t.Run("error - unsupported type", func(t *testing.T) {
+		_, err := AlgsForVerificationType("Bls12381G2Key2020")
+		require.EqualError(t, err, "no JWS algorithm for verification method type Bls12381G2Key2020")
+	})
+}
+
+func TestAlgsForJWK(t *testing.T) {
+	newJWK := func(t *testing.T, key interface{}) *jwk.JWK {
+		t.Helper()
+
+		j, err := jwksupport.JWKFromKey(key)
+		require.NoError(t, err)
+
+		return j
+	}
+
+	edPub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	secp256k1Key, err := btcec.NewPrivateKey(btcec.S256())
+	require.NoError(t, err)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		type algsTest struct {
+			name     string
+			key      interface{}
+			expected []string
+		}
+
+		tests := []algsTest{
+			{name: "Ed25519", key: edPub, expected: []string{"EdDSA"}},
+			{name: "secp256k1", key: &secp256k1Key.ToECDSA().PublicKey, expected: []string{"ES256K"}},
+			{name: "RSA", key: &rsaKey.PublicKey, expected: []string{"RS256", "PS256"}},
+		}
+
+		for _, curve := range []struct {
+			curve elliptic.Curve
+			alg   string
+		}{{elliptic.P256(), "ES256"}, {elliptic.P384(), "ES384"}, {elliptic.P521(), "ES521"}} {
+			key, err := ecdsa.GenerateKey(curve.curve, rand.Reader)
+			require.NoError(t, err)
+
+			tests = append(tests, algsTest{name: curve.curve.Params().Name, key: &key.PublicKey, expected: []string{curve.alg}})
+		}
+
+		for _, tc := range tests {
+			algs, err := AlgsForJWK(newJWK(t, tc.key))
+			require.NoError(t, err, tc.name)
+			require.Equal(t, tc.expected, algs, tc.name)
+		}
+	})
+
+	t.Run("success - JWK alg restricts algorithms", func(t *testing.T) {
+		j := newJWK(t, &rsaKey.PublicKey)
+		j.Algorithm = "PS256"
+
+		algs, err := AlgsForJWK(j)
+		require.NoError(t, err)
+		require.Equal(t, []string{"PS256"}, algs)
+	})
+
+	t.Run("error - JWK alg not compatible with key", func(t *testing.T) {
+		j := newJWK(t, edPub)
+		j.Algorithm = "ES256"
+
+		_, err := AlgsForJWK(j)
+		require.EqualError(t, err, "JWK alg ES256 is not compatible with key type ED25519")
+	})
+
+	t.Run("error - key without JWS algorithm", func(t *testing.T) {
+		_, err := AlgsForJWK(&jwk.JWK{Kty: "OKP", Crv: "X25519"})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "no JWS algorithm for JWK of key type")
+	})
+
+	t.Run("error - unknown key", func(t *testing.T) {
+		_, err := AlgsForJWK(&jwk.JWK{})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "JWS algorithms for JWK")
+	})
+}
@@ -7,10 +7,17 @@ SPDX-License-Identifier: Apache-2.0
 package jose
 
 import (
+	"crypto/aes"
+
+	"golang.org/x/crypto/chacha20poly1305"
+
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/composite/ecdh"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
 )
 
+// JWKSet (JSON Web Key Set) is a JSON data structure that represents a set of JWKs.
+type JWKSet = jwk.JWKSet
+
 // IANA registered JOSE headers (https://tools.ietf.org/html/rfc7515#section-4.1)
 const (
 	// HeaderAlgorithm identifies:
@@ -80,6 +87,19 @@ const (
 
 	// HeaderEPK is used by JWE applications to wrap/unwrap the CEK for a recipient.
 	HeaderEPK = "epk" // JSON
+
+	// HeaderIV is the initialization vector used by JWE applications to wrap/unwrap the CEK with AES-GCM.
+	HeaderIV = "iv" // string
+
+	// HeaderTag is the authentication tag of the CEK wrapped by JWE applications with AES-GCM.
+	HeaderTag = "tag" // string
+
+	// HeaderPBES2Salt is the salt input used by JWE applications to derive the key wrapping the CEK with PBES2.
+	HeaderPBES2Salt = "p2s" // string
+
+	// HeaderPBES2Count is the PBKDF2 iteration count used by JWE applications to derive the key wrapping
+	// the CEK with PBES2.
+	HeaderPBES2Count = "p2c" // number
 )
 
 // Header defined in https://tools.ietf.org/html/rfc7797
@@ -111,6 +131,19 @@ var aeadAlg = map[EncAlg]ecdh.AEADAlg{ //nolint:gochecknoglobals
 	A256CBCHS512: ecdh.AES256CBCHMACSHA512,
 }
 
+const gcmIVSize = 12
+
+// contentEncryptionIVSize is the size of the IV of the content encryption algorithms: a 96-bit nonce for AES-GCM,
+// a 192-bit nonce for XChaCha20-Poly1305 and a 128-bit IV for AES-CBC.
+var contentEncryptionIVSize = map[EncAlg]int{ //nolint:gochecknoglobals
+	A256GCM:      gcmIVSize,
+	XC20P:        chacha20poly1305.NonceSizeX,
+	A128CBCHS256: aes.BlockSize,
+	A192CBCHS384: aes.BlockSize,
+	A256CBCHS384: aes.BlockSize,
+	A256CBCHS512: aes.BlockSize,
+}
+
 // Headers represents JOSE headers.
 type Headers map[string]interface{}
 
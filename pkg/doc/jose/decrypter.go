@@ -34,19 +34,56 @@ type Decrypter interface {
 
 // JWEDecrypt is responsible for decrypting a JWE message and returns its protected plaintext.
 type JWEDecrypt struct {
-	kidResolvers []resolver.KIDResolver
-	crypto       cryptoapi.Crypto
-	kms          kms.KeyManager
+	kidResolvers       []resolver.KIDResolver
+	crypto             cryptoapi.Crypto
+	kms                kms.KeyManager
+	symmetricKeySource SymmetricKeySource
+	password           []byte
+	rsaKeySource       RSAKeySource
+}
+
+// JWEDecryptOpt is an option of JWEDecrypt.
+type JWEDecryptOpt func(jd *JWEDecrypt)
+
+// WithSymmetricKeySource sets the source of the shared symmetric keys used to unwrap the CEK of JWEs
+// encrypted with AES-GCM key wrapping (A128GCMKW, A192GCMKW and A256GCMKW).
+func WithSymmetricKeySource(source SymmetricKeySource) JWEDecryptOpt {
+	return func(jd *JWEDecrypt) {
+		jd.symmetricKeySource = source
+	}
+}
+
+// WithPassword sets the password used to unwrap the CEK of JWEs encrypted with PBES2 password-based
+// key wrapping (PBES2-HS256+A128KW, PBES2-HS384+A192KW and PBES2-HS512+A256KW).
+func WithPassword(password []byte) JWEDecryptOpt {
+	return func(jd *JWEDecrypt) {
+		jd.password = password
+	}
+}
+
+// WithRSAKeySource sets the source of the RSA private keys used to decrypt the CEK of the RSA-OAEP recipients
+// (RSA-OAEP and RSA-OAEP-256) of JWEs encrypted with per-recipient key management algorithms.
+func WithRSAKeySource(source RSAKeySource) JWEDecryptOpt {
+	return func(jd *JWEDecrypt) {
+		jd.rsaKeySource = source
+	}
 }
 
 // NewJWEDecrypt creates a new JWEDecrypt instance to parse and decrypt a JWE message for a given recipient
 // store is needed for Authcrypt only (to fetch sender's pre agreed upon public key), it is not needed for Anoncrypt.
-func NewJWEDecrypt(kidResolvers []resolver.KIDResolver, c cryptoapi.Crypto, k kms.KeyManager) *JWEDecrypt {
-	return &JWEDecrypt{
+func NewJWEDecrypt(kidResolvers []resolver.KIDResolver, c cryptoapi.Crypto, k kms.KeyManager,
+	opts ...JWEDecryptOpt) *JWEDecrypt {
+	jd := &JWEDecrypt{
 		kidResolvers: kidResolvers,
 		crypto:       c,
 		kms:          k,
 	}
+
+	for _, opt := range opts {
+		opt(jd)
+	}
+
+	return jd
 }
 
 func getECDHDecPrimitive(cek []byte, encAlg EncAlg, nistpKW bool) (api.CompositeDecrypt, error) {
@@ -66,6 +103,22 @@ func getECDHDecPrimitive(cek []byte, encAlg EncAlg, nistpKW bool) (api.Composite
 	return ecdh.NewECDHDecrypt(kh)
 }
 
+// DecryptWithAuthData decrypts a deserialized JWE like Decrypt and returns its plaintext and its additional
+// authenticated data (the JWE 'aad' member, nil if the JWE has none). The AAD is authenticated along with
+// the ciphertext, so that it's returned only if the JWE could be decrypted.
+func (jd *JWEDecrypt) DecryptWithAuthData(jwe *JSONWebEncryption) ([]byte, []byte, error) {
+	plaintext, err := jd.Decrypt(jwe)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if jwe.AAD == "" {
+		return plaintext, nil, nil
+	}
+
+	return plaintext, []byte(jwe.AAD), nil
+}
+
 // Decrypt a deserialized JWE, decrypts its protected content and returns plaintext.
 func (jd *JWEDecrypt) Decrypt(jwe *JSONWebEncryption) ([]byte, error) {
 	encAlg, err := jd.validateAndExtractProtectedHeaders(jwe)
@@ -73,6 +126,15 @@ func (jd *JWEDecrypt) Decrypt(jwe *JSONWebEncryption) ([]byte, error) {
 		return nil, fmt.Errorf("jwedecrypt: %w", err)
 	}
 
+	switch kwAlg := keyManagementAlg(jwe); {
+	case isGCMKWAlg(kwAlg):
+		return jd.decryptWithSymmetricKey(jwe)
+	case isPBES2Alg(kwAlg):
+		return jd.decryptWithPassword(jwe)
+	case usesPerRecipientAlgs(jwe):
+		return jd.decryptForRecipients(jwe)
+	}
+
 	var wkOpts []cryptoapi.WrapKeyOpts
 
 	skid, ok := jwe.ProtectedHeaders.SenderKeyID()
@@ -112,6 +174,19 @@ func (jd *JWEDecrypt) Decrypt(jwe *JSONWebEncryption) ([]byte, error) {
 	return jd.decryptJWE(jwe, cek)
 }
 
+// keyManagementAlg returns the algorithm used to encrypt the CEK for the first recipient of the JWE.
+func keyManagementAlg(jwe *JSONWebEncryption) string {
+	if alg, ok := jwe.ProtectedHeaders.Algorithm(); ok {
+		return alg
+	}
+
+	if len(jwe.Recipients) > 0 && jwe.Recipients[0].Header != nil {
+		return jwe.Recipients[0].Header.Alg
+	}
+
+	return ""
+}
+
 func fetchSKIDFromAPU(jwe *JSONWebEncryption) (string, bool) {
 	// for multi-recipients only: check apu in protectedHeaders if it's found for ECDH-1PU, if skid header is empty then
 	// use apu as skid instead.
@@ -212,6 +287,12 @@ func (jd *JWEDecrypt) decryptJWE(jwe *JSONWebEncryption, cek []byte) ([]byte, er
 		return nil, fmt.Errorf("jwedecrypt: failed to get decryption primitive: %w", err)
 	}
 
+	// the content encryption primitive splits the IV from the ciphertext by the size of the IV of the algorithm,
+	// so an IV of another size must be rejected before decryption.
+	if ivSize := contentEncryptionIVSize[EncAlg(encAlg)]; len(jwe.IV) != ivSize {
+		return nil, fmt.Errorf("jwedecrypt: invalid IV size for '%s': %d bytes, expected %d", encAlg, len(jwe.IV), ivSize)
+	}
+
 	encryptedData, err := buildEncryptedData(jwe)
 	if err != nil {
 		return nil, fmt.Errorf("jwedecrypt: failed to build encryptedData for Decrypt(): %w", err)
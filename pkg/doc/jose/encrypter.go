@@ -75,20 +75,45 @@ type JWEEncrypt struct {
 	encTyp         string
 	cty            string
 	crypto         cryptoapi.Crypto
+	symmetricKey   *SymmetricKey
+	password       []byte
+	p2c            int
+	kwAlg          string
+	recipients     []*JWERecipient
+	aad            []byte
+}
+
+// JWEEncryptOpt is an option of JWEEncrypt.
+type JWEEncryptOpt func(je *JWEEncrypt)
+
+// WithAAD sets the additional authenticated data (the JWE 'aad' member) of the JWEs encrypted by Encrypt,
+// e.g. to bind a transaction ID to the ciphertext. The AAD is integrity protected but not encrypted, it's
+// serialized with the JSON serialization only as the compact serialization doesn't support it.
+// EncryptWithAuthData uses the AAD passed to it, or this AAD if it's nil.
+func WithAAD(aad []byte) JWEEncryptOpt {
+	return func(je *JWEEncrypt) {
+		je.aad = aad
+	}
+}
+
+func (je *JWEEncrypt) applyOpts(opts []JWEEncryptOpt) *JWEEncrypt {
+	for _, opt := range opts {
+		opt(je)
+	}
+
+	return je
 }
 
 // NewJWEEncrypt creates a new JWEEncrypt instance to build JWE with recipientsPubKeys
 // senderKID and senderKH are used for Authcrypt (to authenticate the sender), if not set JWEEncrypt assumes Anoncrypt.
 func NewJWEEncrypt(encAlg EncAlg, envelopMediaType, cty, senderKID string, senderKH *keyset.Handle,
-	recipientsPubKeys []*cryptoapi.PublicKey, crypto cryptoapi.Crypto) (*JWEEncrypt, error) {
+	recipientsPubKeys []*cryptoapi.PublicKey, crypto cryptoapi.Crypto, opts ...JWEEncryptOpt) (*JWEEncrypt, error) {
 	if len(recipientsPubKeys) == 0 {
 		return nil, fmt.Errorf("empty recipientsPubKeys list")
 	}
 
-	switch encAlg {
-	case A256GCM, XC20P, A128CBCHS256, A192CBCHS384, A256CBCHS384, A256CBCHS512:
-	default:
-		return nil, fmt.Errorf("encryption algorithm '%s' not supported", encAlg)
+	if err := validateEncAlg(encAlg); err != nil {
+		return nil, err
 	}
 
 	if crypto == nil {
@@ -102,7 +127,7 @@ func NewJWEEncrypt(encAlg EncAlg, envelopMediaType, cty, senderKID string, sende
 		}
 	}
 
-	return &JWEEncrypt{
+	return (&JWEEncrypt{
 		recipientsKeys: recipientsPubKeys,
 		skid:           senderKID,
 		senderKH:       senderKH,
@@ -110,7 +135,107 @@ func NewJWEEncrypt(encAlg EncAlg, envelopMediaType, cty, senderKID string, sende
 		encTyp:         envelopMediaType,
 		cty:            cty,
 		crypto:         crypto,
-	}, nil
+	}).applyOpts(opts), nil
+}
+
+// NewJWEEncryptWithSymmetricKey creates a new JWEEncrypt instance to build JWE with the CEK wrapped by the shared
+// symmetric key using AES-GCM key wrapping. The key wrapping algorithm (A128GCMKW, A192GCMKW or A256GCMKW)
+// depends on the key size.
+func NewJWEEncryptWithSymmetricKey(encAlg EncAlg, envelopMediaType, cty string,
+	key *SymmetricKey, opts ...JWEEncryptOpt) (*JWEEncrypt, error) {
+	if key == nil {
+		return nil, errors.New("symmetric key is required")
+	}
+
+	if err := validateEncAlg(encAlg); err != nil {
+		return nil, err
+	}
+
+	kwAlg, err := gcmKWAlg(len(key.Key))
+	if err != nil {
+		return nil, err
+	}
+
+	return (&JWEEncrypt{
+		encAlg:       encAlg,
+		encTyp:       envelopMediaType,
+		cty:          cty,
+		symmetricKey: key,
+		kwAlg:        kwAlg,
+	}).applyOpts(opts), nil
+}
+
+// NewJWEEncryptWithPassword creates a new JWEEncrypt instance to build JWE with the CEK wrapped by a key derived
+// from the password using PBES2 password-based key wrapping (kwAlg is one of PBES2-HS256+A128KW, PBES2-HS384+A192KW
+// and PBES2-HS512+A256KW). The iterations (p2c) must be between PBES2MinIterations and PBES2MaxIterations.
+func NewJWEEncryptWithPassword(encAlg EncAlg, envelopMediaType, cty string, password []byte,
+	kwAlg string, iterations int, opts ...JWEEncryptOpt) (*JWEEncrypt, error) {
+	if len(password) == 0 {
+		return nil, errors.New("password is required")
+	}
+
+	if err := validateEncAlg(encAlg); err != nil {
+		return nil, err
+	}
+
+	if !isPBES2Alg(kwAlg) {
+		return nil, fmt.Errorf("key wrapping algorithm '%s' is not a PBES2 algorithm", kwAlg)
+	}
+
+	if err := validatePBES2Iterations(iterations); err != nil {
+		return nil, err
+	}
+
+	return (&JWEEncrypt{
+		encAlg:   encAlg,
+		encTyp:   envelopMediaType,
+		cty:      cty,
+		password: password,
+		p2c:      iterations,
+		kwAlg:    kwAlg,
+	}).applyOpts(opts), nil
+}
+
+// NewJWEEncryptWithRecipients creates a new JWEEncrypt instance to build JWE for recipients with their own key
+// management algorithms, e.g. ECDH-ES+A256KW and RSA-OAEP recipients of the same message. The CEK is generated once
+// per message and wrapped for every recipient, the JWE has the JSON serialization with the algorithm, key ID and
+// key agreement parameters in the per-recipient headers. The crypto service wraps the CEK for ECDH-ES recipients.
+func NewJWEEncryptWithRecipients(encAlg EncAlg, envelopMediaType, cty string, recipients []*JWERecipient,
+	crypto cryptoapi.Crypto, opts ...JWEEncryptOpt) (*JWEEncrypt, error) {
+	if len(recipients) == 0 {
+		return nil, errors.New("empty recipients list")
+	}
+
+	if err := validateEncAlg(encAlg); err != nil {
+		return nil, err
+	}
+
+	for i, rec := range recipients {
+		if err := validateJWERecipient(rec); err != nil {
+			return nil, fmt.Errorf("recipient %d: %w", i+1, err)
+		}
+
+		if !isRSAOAEPAlg(rec.Algorithm) && crypto == nil {
+			return nil, errors.New("crypto service is required for ECDH-ES recipients")
+		}
+	}
+
+	return (&JWEEncrypt{
+		encAlg:     encAlg,
+		encTyp:     envelopMediaType,
+		cty:        cty,
+		crypto:     crypto,
+		recipients: recipients,
+	}).applyOpts(opts), nil
+}
+
+func validateEncAlg(encAlg EncAlg) error {
+	switch encAlg {
+	case A256GCM, XC20P, A128CBCHS256, A192CBCHS384, A256CBCHS384, A256CBCHS512:
+		return nil
+	default:
+		return fmt.Errorf("encryption algorithm '%s' not supported", encAlg)
+	}
 }
 
 func (je *JWEEncrypt) getECDHEncPrimitive(cek []byte) (api.CompositeEncrypt, error) {
@@ -136,13 +261,19 @@ func (je *JWEEncrypt) getECDHEncPrimitive(cek []byte) (api.CompositeEncrypt, err
 	return ecdh.NewECDHEncrypt(pubKH)
 }
 
-// Encrypt encrypt plaintext with AAD and returns a JSONWebEncryption instance to serialize a JWE instance.
+// Encrypt encrypt plaintext with the AAD set by WithAAD, if any, and returns a JSONWebEncryption instance
+// to serialize a JWE instance.
 func (je *JWEEncrypt) Encrypt(plaintext []byte) (*JSONWebEncryption, error) {
 	return je.EncryptWithAuthData(plaintext, nil)
 }
 
 // EncryptWithAuthData encrypt plaintext with AAD and returns a JSONWebEncryption instance to serialize a JWE instance.
+// A nil aad falls back to the AAD set by WithAAD.
 func (je *JWEEncrypt) EncryptWithAuthData(plaintext, aad []byte) (*JSONWebEncryption, error) {
+	if aad == nil {
+		aad = je.aad
+	}
+
 	protectedHeaders := map[string]interface{}{
 		HeaderEncryption: je.encAlg,
 		HeaderType:       je.encTyp,
@@ -150,6 +281,18 @@ func (je *JWEEncrypt) EncryptWithAuthData(plaintext, aad []byte) (*JSONWebEncryp
 
 	je.addExtraProtectedHeaders(protectedHeaders)
 
+	if je.symmetricKey != nil {
+		return je.encryptWithSymmetricKey(protectedHeaders, plaintext, aad)
+	}
+
+	if je.password != nil {
+		return je.encryptWithPassword(protectedHeaders, plaintext, aad)
+	}
+
+	if je.recipients != nil {
+		return je.encryptForRecipients(protectedHeaders, plaintext, aad)
+	}
+
 	cek := je.newCEK()
 
 	// creating the crypto primitive requires a pre-built cek
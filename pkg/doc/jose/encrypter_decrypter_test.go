@@ -26,6 +26,7 @@ import (
 	"github.com/google/tink/go/keyset"
 	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
 	"github.com/google/tink/go/subtle"
+	"github.com/google/tink/go/subtle/random"
 	"github.com/stretchr/testify/require"
 
 	ecdhpb "github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/proto/ecdh_aead_go_proto"
@@ -1083,6 +1084,244 @@ func getPrintedX25519PubKey(t *testing.T, pubKeyType *cryptoapi.PublicKey) strin
 	return strings.Replace(jwkStr, "Ed25519", "X25519", 1)
 }
 
+func TestJWEEncryptWithAAD(t *testing.T) {
+	pt := []byte("Test secret message")
+	aad := []byte("transaction-id:7f3c2a")
+
+	recECKeys, recKHs, _, _ := createRecipients(t, 2)
+
+	c, k := createCryptoAndKMSServices(t, recKHs)
+
+	for _, nbRec := range []int{1, 2} {
+		nbRec := nbRec
+
+		t.Run(fmt.Sprintf("round trip with %d recipient(s)", nbRec), func(t *testing.T) {
+			jweEncrypter, err := ariesjose.NewJWEEncrypt(ariesjose.A256GCM, EnvelopeEncodingType,
+				DIDCommContentEncodingType, "", nil, recECKeys[:nbRec], c, ariesjose.WithAAD(aad))
+			require.NoError(t, err)
+
+			jwe, err := jweEncrypter.Encrypt(pt)
+			require.NoError(t, err)
+			require.Equal(t, string(aad), jwe.AAD)
+
+			serializedJWE, err := jwe.FullSerialize(json.Marshal)
+			require.NoError(t, err)
+			require.Contains(t, serializedJWE, `"aad":"`+base64.RawURLEncoding.EncodeToString(aad)+`"`)
+
+			_, err = jwe.CompactSerialize(json.Marshal)
+			require.Error(t, err)
+
+			localJWE, err := ariesjose.Deserialize(serializedJWE)
+			require.NoError(t, err)
+
+			msg, decryptedAAD, err := ariesjose.NewJWEDecrypt(nil, c, k).DecryptWithAuthData(localJWE)
+			require.NoError(t, err)
+			require.Equal(t, pt, msg)
+			require.Equal(t, aad, decryptedAAD)
+
+			// the AAD is authenticated by go-jose too
+			gjJWE, err := jose.ParseEncrypted(serializedJWE)
+			require.NoError(t, err)
+			require.Equal(t, aad, gjJWE.GetAuthData())
+
+			t.Run("error - tampered AAD", func(t *testing.T) {
+				tamperedJWE, err := ariesjose.Deserialize(serializedJWE)
+				require.NoError(t, err)
+
+				tamperedJWE.AAD = "transaction-id:000000"
+
+				_, _, err = ariesjose.NewJWEDecrypt(nil, c, k).DecryptWithAuthData(tamperedJWE)
+				require.Error(t, err)
+
+				tamperedJWE.AAD = ""
+
+				_, err = ariesjose.NewJWEDecrypt(nil, c, k).Decrypt(tamperedJWE)
+				require.Error(t, err)
+			})
+		})
+	}
+
+	t.Run("AAD of EncryptWithAuthData takes precedence", func(t *testing.T) {
+		jweEncrypter, err := ariesjose.NewJWEEncryptWithSymmetricKey(ariesjose.A256GCM, "", "",
+			&ariesjose.SymmetricKey{KID: "shared-key", Key: random.GetRandomBytes(32)}, ariesjose.WithAAD(aad))
+		require.NoError(t, err)
+
+		jwe, err := jweEncrypter.EncryptWithAuthData(pt, []byte("other aad"))
+		require.NoError(t, err)
+		require.Equal(t, "other aad", jwe.AAD)
+	})
+
+	t.Run("no AAD", func(t *testing.T) {
+		jweEncrypter, err := ariesjose.NewJWEEncrypt(ariesjose.A256GCM, EnvelopeEncodingType,
+			DIDCommContentEncodingType, "", nil, recECKeys[:1], c)
+		require.NoError(t, err)
+
+		jwe, err := jweEncrypter.Encrypt(pt)
+		require.NoError(t, err)
+
+		serializedJWE, err := jwe.CompactSerialize(json.Marshal)
+		require.NoError(t, err)
+
+		localJWE, err := ariesjose.Deserialize(serializedJWE)
+		require.NoError(t, err)
+
+		msg, decryptedAAD, err := ariesjose.NewJWEDecrypt(nil, c, k).DecryptWithAuthData(localJWE)
+		require.NoError(t, err)
+		require.Equal(t, pt, msg)
+		require.Nil(t, decryptedAAD)
+	})
+}
+
+func TestJWEEncryptWithRecipients(t *testing.T) {
+	pt := []byte("Test secret message")
+
+	recECKeys, recKHs, recKIDs, _ := createRecipients(t, 2)
+
+	c, _ := createCryptoAndKMSServices(t, recKHs)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	rsa256Key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	rsaKeys := map[string]*rsa.PrivateKey{"rsa-1": rsaKey, "rsa-256": rsa256Key}
+
+	rsaKeySource := func(kid string) (*rsa.PrivateKey, error) {
+		key, ok := rsaKeys[kid]
+		if !ok {
+			return nil, fmt.Errorf("key %s not found", kid)
+		}
+
+		return key, nil
+	}
+
+	recipients := []*ariesjose.JWERecipient{
+		{Algorithm: ariesjose.ECDHESA256KWALG, PublicKey: recECKeys[0]},
+		{Algorithm: ariesjose.ECDHESA256KWALG, PublicKey: recECKeys[1]},
+		{Algorithm: ariesjose.RSAOAEPALG, KID: "rsa-1", RSAPublicKey: &rsaKey.PublicKey},
+		{Algorithm: ariesjose.RSAOAEP256ALG, KID: "rsa-256", RSAPublicKey: &rsa256Key.PublicKey},
+	}
+
+	jweEncrypter, err := ariesjose.NewJWEEncryptWithRecipients(ariesjose.A256GCM, EnvelopeEncodingType,
+		DIDCommContentEncodingType, recipients, c)
+	require.NoError(t, err)
+
+	jwe, err := jweEncrypter.Encrypt(pt)
+	require.NoError(t, err)
+	require.Len(t, jwe.Recipients, len(recipients))
+
+	_, ok := jwe.ProtectedHeaders.Algorithm()
+	require.False(t, ok)
+
+	for i, rec := range jwe.Recipients {
+		require.Equal(t, recipients[i].Algorithm, rec.Header.Alg)
+		require.NotEmpty(t, rec.EncryptedKey)
+	}
+
+	require.Equal(t, recKIDs[0], jwe.Recipients[0].Header.KID)
+	require.NotEmpty(t, jwe.Recipients[0].Header.EPK)
+	require.Equal(t, "rsa-1", jwe.Recipients[2].Header.KID)
+	require.Empty(t, jwe.Recipients[2].Header.EPK)
+
+	serializedJWE, err := jwe.FullSerialize(json.Marshal)
+	require.NoError(t, err)
+	require.Contains(t, serializedJWE, `"recipients":[`)
+
+	_, err = jwe.CompactSerialize(json.Marshal)
+	require.Error(t, err)
+
+	t.Run("decrypt as an RSA-OAEP recipient", func(t *testing.T) {
+		localJWE, err := ariesjose.Deserialize(serializedJWE)
+		require.NoError(t, err)
+
+		recipientKeys := map[string]*rsa.PrivateKey{"rsa-256": rsa256Key}
+
+		msg, err := ariesjose.NewJWEDecrypt(nil, nil, nil,
+			ariesjose.WithRSAKeySource(func(kid string) (*rsa.PrivateKey, error) {
+				key, ok := recipientKeys[kid]
+				if !ok {
+					return nil, fmt.Errorf("key %s not found", kid)
+				}
+
+				return key, nil
+			})).Decrypt(localJWE)
+		require.NoError(t, err)
+		require.Equal(t, pt, msg)
+	})
+
+	t.Run("decrypt as an ECDH-ES recipient", func(t *testing.T) {
+		localJWE, err := ariesjose.Deserialize(serializedJWE)
+		require.NoError(t, err)
+
+		// the KMS has the key of the 2nd recipient only.
+		c2, k2 := createCryptoAndKMSServices(t, map[string]*keyset.Handle{recKIDs[1]: recKHs[recKIDs[1]]})
+
+		msg, err := ariesjose.NewJWEDecrypt(nil, c2, k2).Decrypt(localJWE)
+		require.NoError(t, err)
+		require.Equal(t, pt, msg)
+	})
+
+	t.Run("decrypt RSA-OAEP recipient with go-jose", func(t *testing.T) {
+		gjJWE, err := jose.ParseEncrypted(serializedJWE)
+		require.NoError(t, err)
+
+		idx, _, msg, err := gjJWE.DecryptMulti(rsaKey)
+		require.NoError(t, err)
+		require.Equal(t, 2, idx)
+		require.Equal(t, pt, msg)
+	})
+
+	t.Run("single recipient", func(t *testing.T) {
+		jweEncrypter, err := ariesjose.NewJWEEncryptWithRecipients(ariesjose.A256GCM, "", "",
+			recipients[3:], nil)
+		require.NoError(t, err)
+
+		jwe, err := jweEncrypter.Encrypt(pt)
+		require.NoError(t, err)
+
+		serializedJWE, err := jwe.FullSerialize(json.Marshal)
+		require.NoError(t, err)
+
+		localJWE, err := ariesjose.Deserialize(serializedJWE)
+		require.NoError(t, err)
+
+		msg, err := ariesjose.NewJWEDecrypt(nil, nil, nil, ariesjose.WithRSAKeySource(rsaKeySource)).Decrypt(localJWE)
+		require.NoError(t, err)
+		require.Equal(t, pt, msg)
+	})
+
+	t.Run("error - no key of any recipient", func(t *testing.T) {
+		localJWE, err := ariesjose.Deserialize(serializedJWE)
+		require.NoError(t, err)
+
+		_, err = ariesjose.NewJWEDecrypt(nil, nil, nil).Decrypt(localJWE)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "jwedecrypt: failed to unwrap cek")
+		require.Contains(t, err.Error(), "RSA key source is required for RSA-OAEP key wrapping")
+	})
+
+	t.Run("error - invalid recipients", func(t *testing.T) {
+		_, err := ariesjose.NewJWEEncryptWithRecipients(ariesjose.A256GCM, "", "", nil, c)
+		require.EqualError(t, err, "empty recipients list")
+
+		_, err = ariesjose.NewJWEEncryptWithRecipients(ariesjose.A256GCM, "", "",
+			[]*ariesjose.JWERecipient{{Algorithm: "RSA1_5", RSAPublicKey: &rsaKey.PublicKey}}, c)
+		require.EqualError(t, err, "recipient 1: key management algorithm 'RSA1_5' not supported")
+
+		_, err = ariesjose.NewJWEEncryptWithRecipients(ariesjose.A256GCM, "", "",
+			[]*ariesjose.JWERecipient{{Algorithm: ariesjose.RSAOAEPALG}}, c)
+		require.EqualError(t, err, "recipient 1: RSA public key is required for 'RSA-OAEP'")
+
+		_, err = ariesjose.NewJWEEncryptWithRecipients(ariesjose.A256GCM, "", "",
+			[]*ariesjose.JWERecipient{{Algorithm: ariesjose.ECDHESXC20PKWALG, PublicKey: recECKeys[0]}}, c)
+		require.EqualError(t, err, "recipient 1: 'ECDH-ES+XC20PKW' requires a key of type OKP, got EC")
+
+		_, err = ariesjose.NewJWEEncryptWithRecipients(ariesjose.A256GCM, "", "", recipients, nil)
+		require.EqualError(t, err, "crypto service is required for ECDH-ES recipients")
+	})
+}
+
 func TestFailNewJWEEncrypt(t *testing.T) {
 	c, err := tinkcrypto.New()
 	require.NoError(t, err)
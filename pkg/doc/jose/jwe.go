@@ -199,7 +199,8 @@ func (e *JSONWebEncryption) prepareRecipients(marshal marshalFunc) (json.RawMess
 }
 
 // CompactSerialize serializes the given JWE into a compact, URL-safe string as defined in
-// https://tools.ietf.org/html/rfc7516#section-7.1.
+// https://tools.ietf.org/html/rfc7516#section-7.1. Only a JWE with a single recipient and without
+// unprotected headers, per-recipient headers and AAD can be serialized in the compact form.
 func (e *JSONWebEncryption) CompactSerialize(marshal marshalFunc) (string, error) {
 	if e.ProtectedHeaders == nil {
 		return "", errProtectedHeaderMissing
@@ -811,6 +811,34 @@ func TestDeserialize(t *testing.T) {
 			require.NoError(t, err)
 			require.Equal(t, expectedSerializedCompactJWE, reserializedJWE)
 		})
+		t.Run("Success - compact serialization round trip", func(t *testing.T) {
+			deserializedJWE, err := Deserialize(exampleRealCompactJWE)
+			require.NoError(t, err)
+
+			reserializedJWE, err := deserializedJWE.CompactSerialize(json.Marshal)
+			require.NoError(t, err)
+			require.Equal(t, exampleRealCompactJWE, reserializedJWE)
+
+			jwe := JSONWebEncryption{
+				ProtectedHeaders: Headers{"protectedheader1": "protectedtestvalue1"},
+				Recipients:       []*Recipient{{EncryptedKey: "TestKey"}},
+				IV:               "TestIV",
+				Ciphertext:       "TestCipherText",
+				Tag:              "TestTag",
+			}
+
+			compactJWE, err := jwe.CompactSerialize(json.Marshal)
+			require.NoError(t, err)
+
+			deserializedJWE, err = Deserialize(compactJWE)
+			require.NoError(t, err)
+			require.Equal(t, jwe.ProtectedHeaders, deserializedJWE.ProtectedHeaders)
+			require.Len(t, deserializedJWE.Recipients, 1)
+			require.Equal(t, "TestKey", deserializedJWE.Recipients[0].EncryptedKey)
+			require.Equal(t, jwe.IV, deserializedJWE.IV)
+			require.Equal(t, jwe.Ciphertext, deserializedJWE.Ciphertext)
+			require.Equal(t, jwe.Tag, deserializedJWE.Tag)
+		})
 		t.Run("Invalid compact JWE - wrong number of parts", func(t *testing.T) {
 			deserializedJWE, err := Deserialize("")
 			require.Equal(t, errWrongNumberOfCompactJWEParts, err)
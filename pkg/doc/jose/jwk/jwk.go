@@ -15,3 +15,6 @@ type JWK = jwk.JWK
 
 // ErrInvalidKey is returned when passed JWK is invalid.
 var ErrInvalidKey = jwk.ErrInvalidKey
+
+// ErrInconsistentKeyUsage is returned when "use" and "key_ops" of the parsed JWK contradict each other.
+var ErrInconsistentKeyUsage = jwk.ErrInconsistentKeyUsage
@@ -0,0 +1,24 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jwk
+
+// JWKSet (JSON Web Key Set) is a JSON data structure that represents a set of JWKs
+// (https://tools.ietf.org/html/rfc7517#section-5).
+type JWKSet struct {
+	Keys []*JWK `json:"keys"`
+}
+
+// Key returns the first key of the set with the given key ID ("kid").
+func (s *JWKSet) Key(kid string) (*JWK, bool) {
+	for _, key := range s.Keys {
+		if key.KeyID == kid {
+			return key, true
+		}
+	}
+
+	return nil, false
+}
@@ -130,6 +130,55 @@ func NewJWS(protectedHeaders, unprotectedHeaders Headers, payload []byte, signer
 	return jws, nil
 }
 
+// SignDetached signs the payload and returns a compact JWS with unencoded detached payload as per RFC 7797
+// (https://tools.ietf.org/html/rfc7797): the "b64" header is false, it's listed in the "crit" header and
+// the payload segment is empty ("<header>..<signature>"). The protected headers are merged with the headers
+// of the signer. The JWS is verified with VerifyDetached given the same payload.
+func SignDetached(payload []byte, protectedHeaders Headers, signer Signer) (string, error) {
+	headers := make(Headers, len(protectedHeaders))
+
+	for k, v := range protectedHeaders {
+		headers[k] = v
+	}
+
+	crit, err := criticalHeaders(headers)
+	if err != nil {
+		return "", err
+	}
+
+	if !containsString(crit, HeaderB64Payload) {
+		crit = append(crit, HeaderB64Payload)
+	}
+
+	headers[HeaderB64Payload] = false
+	headers[HeaderCritical] = crit
+
+	jws, err := NewJWS(headers, nil, payload, signer)
+	if err != nil {
+		return "", err
+	}
+
+	return jws.SerializeCompact(true)
+}
+
+// VerifyDetached verifies a compact JWS with detached payload, e.g. created by SignDetached: the signing input
+// is reconstructed from the protected header of the JWS and the payload, which is used as is if the "b64" header
+// is false or base64url encoded otherwise. The payload segment of the JWS must be empty, and the "crit" header
+// may list only "b64", as other critical extensions are not supported.
+func VerifyDetached(jws string, payload []byte, verifier SignatureVerifier,
+	opts ...JWSParseOpt) (*JSONWebSignature, error) {
+	pOpts := &jwsParseOpts{}
+
+	for _, opt := range opts {
+		opt(pOpts)
+	}
+
+	pOpts.detachedPayload = payload
+	pOpts.requireDetached = true
+
+	return parseCompacted(jws, verifier, pOpts)
+}
+
 // SerializeCompact makes JWS Compact Serialization (https://tools.ietf.org/html/rfc7515#section-7.1)
 func (s JSONWebSignature) SerializeCompact(detached bool) (string, error) {
 	byteHeaders, err := json.Marshal(s.joseHeaders)
@@ -200,6 +249,8 @@ func sign(joseHeaders Headers, payload []byte, signer Signer) ([]byte, error) {
 // jwsParseOpts holds options for the JWS Parsing.
 type jwsParseOpts struct {
 	detachedPayload []byte
+	maxHeaderBytes  int
+	requireDetached bool
 }
 
 // JWSParseOpt is the JWS Parser option.
@@ -212,6 +263,14 @@ func WithJWSDetachedPayload(payload []byte) JWSParseOpt {
 	}
 }
 
+// WithMaxHeaderBytes limits the size of the decoded protected header of compact JWS to n bytes.
+// A header exceeding the limit is rejected before it is decoded. Zero or negative n means no limit.
+func WithMaxHeaderBytes(n int) JWSParseOpt {
+	return func(opts *jwsParseOpts) {
+		opts.maxHeaderBytes = n
+	}
+}
+
 // ParseJWS parses serialized JWS. Currently only JWS Compact Serialization parsing is supported.
 func ParseJWS(jws string, verifier SignatureVerifier, opts ...JWSParseOpt) (*JSONWebSignature, error) {
 	pOpts := &jwsParseOpts{}
@@ -237,16 +296,27 @@ func IsCompactJWS(s string) bool {
 }
 
 func parseCompacted(jwsCompact string, verifier SignatureVerifier, opts *jwsParseOpts) (*JSONWebSignature, error) {
-	parts := strings.Split(jwsCompact, ".")
+	// split into at most one more part than expected to not allocate for an arbitrary number of segments
+	parts := strings.SplitN(jwsCompact, ".", jwsPartsCount+1)
 	if len(parts) != jwsPartsCount {
 		return nil, errors.New("invalid JWS compact format")
 	}
 
+	if opts.maxHeaderBytes > 0 && len(parts[jwsHeaderPart]) > base64.RawURLEncoding.EncodedLen(opts.maxHeaderBytes) {
+		return nil, fmt.Errorf("protected header exceeds %d bytes", opts.maxHeaderBytes)
+	}
+
 	joseHeaders, err := parseCompactedHeaders(parts)
 	if err != nil {
 		return nil, err
 	}
 
+	if opts.requireDetached {
+		if err = checkDetachedJWS(joseHeaders, parts[jwsPayloadPart]); err != nil {
+			return nil, err
+		}
+	}
+
 	payload, err := parseCompactedPayload(parts[jwsPayloadPart], opts)
 	if err != nil {
 		return nil, err
@@ -341,6 +411,66 @@ func signingInput(headers Headers, header string, payload []byte) ([]byte, error
 	return []byte(fmt.Sprintf("%s.%s", headersStr, payloadStr)), nil
 }
 
+// checkDetachedJWS checks that the payload of the JWS is detached and its critical headers are supported.
+func checkDetachedJWS(headers Headers, jwsPayload string) error {
+	if jwsPayload != "" {
+		return errors.New("JWS payload is not detached")
+	}
+
+	crit, err := criticalHeaders(headers)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range crit {
+		if name != HeaderB64Payload {
+			return fmt.Errorf("unsupported critical JWS header %s", name)
+		}
+	}
+
+	// the b64 header must be understood to compute the signing input (https://tools.ietf.org/html/rfc7797#section-6)
+	if b64, ok := headers[HeaderB64Payload].(bool); ok && !b64 && !containsString(crit, HeaderB64Payload) {
+		return fmt.Errorf("%s JWS header must be listed in %s header", HeaderB64Payload, HeaderCritical)
+	}
+
+	return nil
+}
+
+// criticalHeaders returns the names of the headers listed in the "crit" header.
+func criticalHeaders(headers Headers) ([]string, error) {
+	switch crit := headers[HeaderCritical].(type) {
+	case nil:
+		return nil, nil
+	case []string:
+		return append([]string{}, crit...), nil
+	case []interface{}:
+		names := make([]string, 0, len(crit))
+
+		for _, v := range crit {
+			name, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("invalid %s JWS header", HeaderCritical)
+			}
+
+			names = append(names, name)
+		}
+
+		return names, nil
+	default:
+		return nil, fmt.Errorf("invalid %s JWS header", HeaderCritical)
+	}
+}
+
+func containsString(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+
+	return false
+}
+
 func checkJWSHeaders(headers Headers) error {
 	if _, ok := headers[HeaderAlgorithm]; !ok {
 		return fmt.Errorf("%s JWS header is not defined", HeaderAlgorithm)
@@ -0,0 +1,197 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jose
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
+)
+
+// KeyResolver returns the key verifying a JWS from its headers, e.g. the key identified by the 'kid' header
+// in a JWKS. As the key is resolved per JWS, the JWS signed before a key rotation still verify with the key
+// which was valid when they were signed.
+//
+// The key is one of:
+//   - ed25519.PublicKey for EdDSA,
+//   - *ecdsa.PublicKey for ES256, ES384, ES512 (or ES521) and ES256K,
+//   - *rsa.PublicKey for RS256, RS384, RS512, PS256, PS384 and PS512,
+//   - []byte, the shared secret for HS256, HS384 and HS512,
+//   - *jwk.JWK holding one of the above keys (its 'alg' must match the JWS 'alg' if set),
+//   - SignatureVerifier, e.g. for keys kept in a KMS.
+type KeyResolver func(headers Headers) (interface{}, error)
+
+const bitsPerByte = 8
+
+//nolint:gochecknoglobals
+var ecdsaAlgs = map[string]struct {
+	curve string
+	hash  crypto.Hash
+}{
+	AlgES256:  {curve: "P-256", hash: crypto.SHA256},
+	AlgES384:  {curve: "P-384", hash: crypto.SHA384},
+	AlgES512:  {curve: "P-521", hash: crypto.SHA512},
+	AlgES521:  {curve: "P-521", hash: crypto.SHA512},
+	AlgES256K: {curve: "secp256k1", hash: crypto.SHA256},
+}
+
+//nolint:gochecknoglobals
+var rsaAlgs = map[string]struct {
+	pss  bool
+	hash crypto.Hash
+}{
+	AlgRS256: {hash: crypto.SHA256},
+	AlgRS384: {hash: crypto.SHA384},
+	AlgRS512: {hash: crypto.SHA512},
+	AlgPS256: {pss: true, hash: crypto.SHA256},
+	AlgPS384: {pss: true, hash: crypto.SHA384},
+	AlgPS512: {pss: true, hash: crypto.SHA512},
+}
+
+//nolint:gochecknoglobals
+var hmacAlgs = map[string]crypto.Hash{
+	AlgHS256: crypto.SHA256,
+	AlgHS384: crypto.SHA384,
+	AlgHS512: crypto.SHA512,
+}
+
+// VerifyWithResolver parses the compact JWS and verifies its signature with the key returned by resolve
+// for the JWS headers (see KeyResolver for the supported keys). The JWS is rejected if the key can't be resolved
+// or if it can't be used with the 'alg' header of the JWS.
+func VerifyWithResolver(jws string, resolve KeyResolver, opts ...JWSParseOpt) (*JSONWebSignature, error) {
+	if resolve == nil {
+		return nil, errors.New("key resolver is required")
+	}
+
+	verifier := SignatureVerifierFunc(func(joseHeaders Headers, payload, signingInput, signature []byte) error {
+		key, err := resolve(joseHeaders)
+		if err != nil {
+			return fmt.Errorf("resolve JWS verification key: %w", err)
+		}
+
+		return verifyWithKey(key, joseHeaders, payload, signingInput, signature)
+	})
+
+	return ParseJWS(jws, verifier, opts...)
+}
+
+func verifyWithKey(key interface{}, joseHeaders Headers, payload, signingInput, signature []byte) error {
+	alg, ok := joseHeaders.Algorithm()
+	if !ok {
+		return errors.New("'alg' JOSE header is not present")
+	}
+
+	switch k := key.(type) {
+	case SignatureVerifier:
+		return k.Verify(joseHeaders, payload, signingInput, signature)
+	case *jwk.JWK:
+		if k.Algorithm != "" && k.Algorithm != alg {
+			return fmt.Errorf("JWK alg %s doesn't match JWS alg %s", k.Algorithm, alg)
+		}
+
+		return verifyWithKey(k.Key, joseHeaders, payload, signingInput, signature)
+	case ed25519.PublicKey:
+		return verifyEd25519(k, alg, signingInput, signature)
+	case *ecdsa.PublicKey:
+		return verifyECDSA(k, alg, signingInput, signature)
+	case *rsa.PublicKey:
+		return verifyRSA(k, alg, signingInput, signature)
+	case []byte:
+		return verifyHMAC(k, alg, signingInput, signature)
+	default:
+		return fmt.Errorf("unsupported JWS verification key type %T", key)
+	}
+}
+
+func verifyEd25519(key ed25519.PublicKey, alg string, signingInput, signature []byte) error {
+	if alg != AlgEdDSA {
+		return fmt.Errorf("ed25519 key can't be used with alg %s", alg)
+	}
+
+	if len(key) != ed25519.PublicKeySize {
+		return errors.New("invalid ed25519 public key size")
+	}
+
+	if !ed25519.Verify(key, signingInput, signature) {
+		return errors.New("invalid ed25519 signature")
+	}
+
+	return nil
+}
+
+func verifyECDSA(key *ecdsa.PublicKey, alg string, signingInput, signature []byte) error {
+	params, ok := ecdsaAlgs[alg]
+	if !ok || key.Curve == nil || key.Curve.Params().Name != params.curve {
+		return fmt.Errorf("ECDSA key can't be used with alg %s", alg)
+	}
+
+	keySize := (key.Curve.Params().BitSize + bitsPerByte - 1) / bitsPerByte
+
+	// the signature is the concatenation of R and S, each padded to the key size.
+	if len(signature) != 2*keySize {
+		return errors.New("invalid ECDSA signature size")
+	}
+
+	r := new(big.Int).SetBytes(signature[:keySize])
+	s := new(big.Int).SetBytes(signature[keySize:])
+
+	if !ecdsa.Verify(key, digest(params.hash, signingInput), r, s) {
+		return errors.New("invalid ECDSA signature")
+	}
+
+	return nil
+}
+
+func verifyRSA(key *rsa.PublicKey, alg string, signingInput, signature []byte) error {
+	params, ok := rsaAlgs[alg]
+	if !ok {
+		return fmt.Errorf("RSA key can't be used with alg %s", alg)
+	}
+
+	hashed := digest(params.hash, signingInput)
+
+	if params.pss {
+		return rsa.VerifyPSS(key, params.hash, hashed, signature,
+			&rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+	}
+
+	return rsa.VerifyPKCS1v15(key, params.hash, hashed, signature)
+}
+
+func verifyHMAC(key []byte, alg string, signingInput, signature []byte) error {
+	hash, ok := hmacAlgs[alg]
+	if !ok {
+		return fmt.Errorf("shared secret can't be used with alg %s", alg)
+	}
+
+	if len(key) == 0 {
+		return errors.New("empty HMAC key")
+	}
+
+	mac := hmac.New(hash.New, key)
+	mac.Write(signingInput) //nolint:errcheck,gosec // hash.Hash never returns an error
+
+	if !hmac.Equal(mac.Sum(nil), signature) {
+		return errors.New("invalid HMAC signature")
+	}
+
+	return nil
+}
+
+func digest(hash crypto.Hash, data []byte) []byte {
+	h := hash.New()
+	h.Write(data) //nolint:errcheck,gosec // hash.Hash never returns an error
+
+	return h.Sum(nil)
+}
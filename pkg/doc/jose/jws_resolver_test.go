@@ -0,0 +1,255 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jose
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	gojose "github.com/go-jose/go-jose/v3"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
+)
+
+func TestVerifyWithResolver(t *testing.T) {
+	payload := []byte("payload")
+
+	t.Run("key rotation", func(t *testing.T) {
+		oldPub, oldPriv, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		newPub, newPriv, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		keys := map[string]interface{}{"key-1": oldPub, "key-2": newPub}
+
+		resolve := func(headers Headers) (interface{}, error) {
+			kid, _ := headers.KeyID()
+
+			key, ok := keys[kid]
+			if !ok {
+				return nil, fmt.Errorf("key %s not found", kid)
+			}
+
+			return key, nil
+		}
+
+		// signed before the rotation.
+		oldJWS := signJWSWithKey(t, AlgEdDSA, "key-1", payload, oldPriv)
+		// signed after the rotation.
+		newJWS := signJWSWithKey(t, AlgEdDSA, "key-2", payload, newPriv)
+
+		jws, err := VerifyWithResolver(oldJWS, resolve)
+		require.NoError(t, err)
+		require.Equal(t, payload, jws.Payload)
+
+		jws, err = VerifyWithResolver(newJWS, resolve)
+		require.NoError(t, err)
+		require.Equal(t, payload, jws.Payload)
+
+		// signed with the new key but claiming the old key.
+		_, err = VerifyWithResolver(signJWSWithKey(t, AlgEdDSA, "key-1", payload, newPriv), resolve)
+		require.EqualError(t, err, "invalid ed25519 signature")
+
+		_, err = VerifyWithResolver(signJWSWithKey(t, AlgEdDSA, "key-3", payload, newPriv), resolve)
+		require.EqualError(t, err, "resolve JWS verification key: key key-3 not found")
+	})
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	ec384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+
+	ec521Key, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
+	require.NoError(t, err)
+
+	secp256k1Key, err := ecdsa.GenerateKey(btcec.S256(), rand.Reader)
+	require.NoError(t, err)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	hmacKey, err := base64.RawURLEncoding.DecodeString(rfc7797Key)
+	require.NoError(t, err)
+
+	tests := []struct {
+		alg        string
+		signingKey interface{}
+		key        interface{}
+	}{
+		{alg: AlgES256, signingKey: ecKey, key: &ecKey.PublicKey},
+		{alg: AlgES384, signingKey: ec384Key, key: &ec384Key.PublicKey},
+		{alg: AlgES512, signingKey: ec521Key, key: &ec521Key.PublicKey},
+		{alg: AlgES256K, signingKey: secp256k1Key, key: &secp256k1Key.PublicKey},
+		{alg: AlgRS256, signingKey: rsaKey, key: &rsaKey.PublicKey},
+		{alg: AlgPS512, signingKey: rsaKey, key: &rsaKey.PublicKey},
+		{alg: AlgHS256, signingKey: hmacKey, key: hmacKey},
+		{
+			alg:        AlgES256,
+			signingKey: ecKey,
+			key:        &jwk.JWK{JSONWebKey: gojose.JSONWebKey{Key: &ecKey.PublicKey, Algorithm: AlgES256}},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(fmt.Sprintf("%s with %T", tc.alg, tc.key), func(t *testing.T) {
+			jws := signJWSWithKey(t, tc.alg, "kid", payload, tc.signingKey)
+
+			var resolvedHeaders Headers
+
+			parsedJWS, err := VerifyWithResolver(jws, func(headers Headers) (interface{}, error) {
+				resolvedHeaders = headers
+
+				return tc.key, nil
+			})
+			require.NoError(t, err)
+			require.Equal(t, payload, parsedJWS.Payload)
+			require.Equal(t, Headers{HeaderAlgorithm: tc.alg, HeaderKeyID: "kid"}, resolvedHeaders)
+		})
+	}
+
+	t.Run("signature verifier", func(t *testing.T) {
+		jws := signJWSWithKey(t, AlgHS256, "kid", payload, hmacKey)
+
+		_, err := VerifyWithResolver(jws, func(Headers) (interface{}, error) {
+			return &hs256Verifier{key: hmacKey}, nil
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("detached payload", func(t *testing.T) {
+		_, err := VerifyWithResolver(rfc7797JWS, func(Headers) (interface{}, error) {
+			return hmacKey, nil
+		}, WithJWSDetachedPayload([]byte(rfc7797Payload)))
+		require.NoError(t, err)
+	})
+
+	t.Run("error - key can't be used with alg", func(t *testing.T) {
+		tests := []struct {
+			alg    string
+			key    interface{}
+			errMsg string
+		}{
+			{alg: AlgES384, key: &ecKey.PublicKey, errMsg: "ECDSA key can't be used with alg ES384"},
+			{alg: AlgES256K, key: &ecKey.PublicKey, errMsg: "ECDSA key can't be used with alg ES256K"},
+			{alg: AlgHS256, key: &rsaKey.PublicKey, errMsg: "RSA key can't be used with alg HS256"},
+			{alg: AlgES256, key: ed25519.PublicKey(make([]byte, ed25519.PublicKeySize)),
+				errMsg: "ed25519 key can't be used with alg ES256"},
+			{alg: AlgRS256, key: hmacKey, errMsg: "shared secret can't be used with alg RS256"},
+			{alg: AlgES256, key: &jwk.JWK{JSONWebKey: gojose.JSONWebKey{Key: &ecKey.PublicKey, Algorithm: AlgES384}},
+				errMsg: "JWK alg ES384 doesn't match JWS alg ES256"},
+			{alg: AlgES256, key: ecKey, errMsg: "unsupported JWS verification key type *ecdsa.PrivateKey"},
+		}
+
+		jws := signJWSWithKey(t, AlgES256, "kid", payload, ecKey)
+
+		for _, tc := range tests {
+			verifier := SignatureVerifierFunc(func(joseHeaders Headers, payload, signingInput, signature []byte) error {
+				return verifyWithKey(tc.key, Headers{HeaderAlgorithm: tc.alg}, payload, signingInput, signature)
+			})
+
+			_, err := ParseJWS(jws, verifier)
+			require.EqualError(t, err, tc.errMsg)
+		}
+	})
+
+	t.Run("error - invalid signature", func(t *testing.T) {
+		otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		_, err = VerifyWithResolver(signJWSWithKey(t, AlgES256, "kid", payload, otherKey),
+			func(Headers) (interface{}, error) { return &ecKey.PublicKey, nil })
+		require.EqualError(t, err, "invalid ECDSA signature")
+
+		_, err = VerifyWithResolver(signJWSWithKey(t, AlgHS256, "kid", payload, []byte("other key")),
+			func(Headers) (interface{}, error) { return hmacKey, nil })
+		require.EqualError(t, err, "invalid HMAC signature")
+
+		_, err = VerifyWithResolver(signJWSWithKey(t, AlgRS256, "kid", payload, rsaKey),
+			func(Headers) (interface{}, error) { return []byte{}, nil })
+		require.Error(t, err)
+	})
+
+	t.Run("error - missing resolver", func(t *testing.T) {
+		_, err := VerifyWithResolver("a.b.c", nil)
+		require.EqualError(t, err, "key resolver is required")
+	})
+
+	t.Run("error - resolver fails", func(t *testing.T) {
+		_, err := VerifyWithResolver(signJWSWithKey(t, AlgHS256, "kid", payload, hmacKey),
+			func(Headers) (interface{}, error) { return nil, errors.New("resolver error") })
+		require.EqualError(t, err, "resolve JWS verification key: resolver error")
+	})
+}
+
+// signJWSWithKey returns the compact JWS of the payload signed with the private key (or shared secret) for alg.
+func signJWSWithKey(t *testing.T, alg, kid string, payload []byte, key interface{}) string {
+	t.Helper()
+
+	jws, err := NewJWS(Headers{HeaderAlgorithm: alg, HeaderKeyID: kid}, nil, payload,
+		&keySigner{alg: alg, key: key})
+	require.NoError(t, err)
+
+	compactJWS, err := jws.SerializeCompact(false)
+	require.NoError(t, err)
+
+	return compactJWS
+}
+
+type keySigner struct {
+	alg string
+	key interface{}
+}
+
+func (s *keySigner) Sign(data []byte) ([]byte, error) {
+	switch key := s.key.(type) {
+	case ed25519.PrivateKey:
+		return ed25519.Sign(key, data), nil
+	case *ecdsa.PrivateKey:
+		r, ss, err := ecdsa.Sign(rand.Reader, key, digest(ecdsaAlgs[s.alg].hash, data))
+		if err != nil {
+			return nil, err
+		}
+
+		keySize := (key.Curve.Params().BitSize + bitsPerByte - 1) / bitsPerByte
+		signature := make([]byte, 2*keySize)
+
+		r.FillBytes(signature[:keySize])
+		ss.FillBytes(signature[keySize:])
+
+		return signature, nil
+	case *rsa.PrivateKey:
+		params := rsaAlgs[s.alg]
+
+		if params.pss {
+			return rsa.SignPSS(rand.Reader, key, params.hash, digest(params.hash, data),
+				&rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+		}
+
+		return rsa.SignPKCS1v15(rand.Reader, key, params.hash, digest(params.hash, data))
+	case []byte:
+		return (&hs256Signer{key: key}).Sign(data)
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", s.key)
+	}
+}
+
+func (s *keySigner) Headers() Headers {
+	return nil
+}
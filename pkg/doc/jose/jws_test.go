@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package jose
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
 	"encoding/base64"
 	"errors"
 	"fmt"
@@ -245,6 +247,210 @@ func TestParseJWS(t *testing.T) {
 	require.Nil(t, parsedJWS)
 }
 
+func TestParseJWS_WithMaxHeaderBytes(t *testing.T) {
+	jws, err := NewJWS(Headers{"alg": "EdSDA", "typ": "JWT"}, nil, []byte("payload"),
+		&testSigner{
+			headers:   Headers{"alg": "dummy"},
+			signature: []byte("signature"),
+		})
+	require.NoError(t, err)
+
+	jwsCompact, err := jws.SerializeCompact(false)
+	require.NoError(t, err)
+
+	parts := strings.Split(jwsCompact, ".")
+
+	headerBytes, err := base64.RawURLEncoding.DecodeString(parts[0])
+	require.NoError(t, err)
+
+	t.Run("header within the limit", func(t *testing.T) {
+		parsedJWS, err := ParseJWS(jwsCompact, &testVerifier{}, WithMaxHeaderBytes(len(headerBytes)))
+		require.NoError(t, err)
+		require.Equal(t, jws, parsedJWS)
+	})
+
+	t.Run("oversized header", func(t *testing.T) {
+		parsedJWS, err := ParseJWS(jwsCompact, &testVerifier{}, WithMaxHeaderBytes(len(headerBytes)-3))
+		require.EqualError(t, err, fmt.Sprintf("protected header exceeds %d bytes", len(headerBytes)-3))
+		require.Nil(t, parsedJWS)
+	})
+
+	t.Run("oversized header is rejected before decoding", func(t *testing.T) {
+		oversized := strings.Repeat("!", 1024) + "." + parts[1] + "." + parts[2]
+
+		parsedJWS, err := ParseJWS(oversized, &testVerifier{}, WithMaxHeaderBytes(64))
+		require.EqualError(t, err, "protected header exceeds 64 bytes")
+		require.Nil(t, parsedJWS)
+	})
+
+	t.Run("malformed segment count", func(t *testing.T) {
+		for _, s := range []string{
+			parts[0],
+			parts[0] + "." + parts[1],
+			jwsCompact + "." + parts[2],
+			strings.Repeat(".", 1000),
+		} {
+			parsedJWS, err := ParseJWS(s, &testVerifier{}, WithMaxHeaderBytes(len(headerBytes)))
+			require.EqualError(t, err, "invalid JWS compact format")
+			require.Nil(t, parsedJWS)
+		}
+	})
+}
+
+// Unencoded detached payload example of RFC 7797 section 4.2, signed with the HS256 key of RFC 7515 appendix A.1.
+const (
+	rfc7797Key     = "AyM1SysPpbyDfgZld3umj1qzKObwVMkoqQ-EstJQLr_T-1qS0gZH75aKtMN3Yj0iPS4hcgUuTwjAzZr1Z9CAow"
+	rfc7797Payload = "$.02"
+	rfc7797JWS     = "eyJhbGciOiJIUzI1NiIsImI2NCI6ZmFsc2UsImNyaXQiOlsiYjY0Il19" +
+		"..A5dxf2s96_n5FLueVuW1Z_vh161FwXZC4YLPff6dmDY"
+)
+
+func TestSignDetached(t *testing.T) {
+	key, err := base64.RawURLEncoding.DecodeString(rfc7797Key)
+	require.NoError(t, err)
+
+	t.Run("RFC 7797 example", func(t *testing.T) {
+		jws, err := SignDetached([]byte(rfc7797Payload), nil, &hs256Signer{key: key})
+		require.NoError(t, err)
+		require.Equal(t, rfc7797JWS, jws)
+	})
+
+	t.Run("critical headers are merged", func(t *testing.T) {
+		jws, err := SignDetached([]byte(rfc7797Payload), Headers{HeaderCritical: []interface{}{"exp"}, "exp": 1},
+			&hs256Signer{key: key})
+		require.NoError(t, err)
+
+		parts := strings.Split(jws, ".")
+		require.Len(t, parts, 3)
+		require.Empty(t, parts[1])
+
+		headers, err := parseCompactedHeaders(parts)
+		require.NoError(t, err)
+		require.Equal(t, false, headers[HeaderB64Payload])
+		require.Equal(t, []interface{}{"exp", HeaderB64Payload}, headers[HeaderCritical])
+
+		// the exp critical header is not supported by VerifyDetached
+		_, err = VerifyDetached(jws, []byte(rfc7797Payload), &hs256Verifier{key: key})
+		require.EqualError(t, err, "unsupported critical JWS header exp")
+	})
+
+	t.Run("error - invalid crit header", func(t *testing.T) {
+		_, err := SignDetached([]byte(rfc7797Payload), Headers{HeaderCritical: "b64"}, &hs256Signer{key: key})
+		require.EqualError(t, err, "invalid crit JWS header")
+	})
+
+	t.Run("error - sign", func(t *testing.T) {
+		_, err := SignDetached([]byte(rfc7797Payload), nil, &testSigner{
+			headers: Headers{"alg": "dummy"},
+			err:     errors.New("sign error"),
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "sign error")
+	})
+}
+
+func TestVerifyDetached(t *testing.T) {
+	key, err := base64.RawURLEncoding.DecodeString(rfc7797Key)
+	require.NoError(t, err)
+
+	t.Run("RFC 7797 example", func(t *testing.T) {
+		jws, err := VerifyDetached(rfc7797JWS, []byte(rfc7797Payload), &hs256Verifier{key: key})
+		require.NoError(t, err)
+		require.Equal(t, []byte(rfc7797Payload), jws.Payload)
+
+		alg, ok := jws.ProtectedHeaders.Algorithm()
+		require.True(t, ok)
+		require.Equal(t, "HS256", alg)
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		payload := []byte(`{"proof options and document digests": "with . and non-base64url characters"}`)
+
+		jws, err := SignDetached(payload, Headers{HeaderKeyID: "key-1"}, &hs256Signer{key: key})
+		require.NoError(t, err)
+
+		_, err = VerifyDetached(jws, payload, &hs256Verifier{key: key})
+		require.NoError(t, err)
+
+		_, err = VerifyDetached(jws, []byte("other payload"), &hs256Verifier{key: key})
+		require.EqualError(t, err, "invalid HS256 signature")
+	})
+
+	t.Run("base64url encoded detached payload", func(t *testing.T) {
+		jws, err := NewJWS(Headers{"alg": "HS256"}, nil, []byte(rfc7797Payload), &hs256Signer{key: key})
+		require.NoError(t, err)
+
+		jwsDetached, err := jws.SerializeCompact(true)
+		require.NoError(t, err)
+
+		_, err = VerifyDetached(jwsDetached, []byte(rfc7797Payload), &hs256Verifier{key: key})
+		require.NoError(t, err)
+	})
+
+	t.Run("error - payload is not detached", func(t *testing.T) {
+		jws, err := NewJWS(Headers{"alg": "HS256"}, nil, []byte(rfc7797Payload), &hs256Signer{key: key})
+		require.NoError(t, err)
+
+		jwsCompact, err := jws.SerializeCompact(false)
+		require.NoError(t, err)
+
+		_, err = VerifyDetached(jwsCompact, []byte(rfc7797Payload), &hs256Verifier{key: key})
+		require.EqualError(t, err, "JWS payload is not detached")
+	})
+
+	t.Run("error - b64 header not critical", func(t *testing.T) {
+		jws, err := NewJWS(Headers{"alg": "HS256", HeaderB64Payload: false}, nil, []byte(rfc7797Payload),
+			&hs256Signer{key: key})
+		require.NoError(t, err)
+
+		jwsDetached, err := jws.SerializeCompact(true)
+		require.NoError(t, err)
+
+		_, err = VerifyDetached(jwsDetached, []byte(rfc7797Payload), &hs256Verifier{key: key})
+		require.EqualError(t, err, "b64 JWS header must be listed in crit header")
+	})
+
+	t.Run("error - invalid JWS", func(t *testing.T) {
+		_, err := VerifyDetached("two_parts.only", nil, &hs256Verifier{key: key})
+		require.EqualError(t, err, "invalid JWS compact format")
+
+		invalidCrit := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","crit":[1]}`))
+
+		_, err = VerifyDetached(invalidCrit+"..", nil, &hs256Verifier{key: key})
+		require.EqualError(t, err, "invalid crit JWS header")
+	})
+}
+
+type hs256Signer struct {
+	key []byte
+}
+
+func (s *hs256Signer) Sign(data []byte) ([]byte, error) {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write(data)
+
+	return mac.Sum(nil), nil
+}
+
+func (s *hs256Signer) Headers() Headers {
+	return Headers{HeaderAlgorithm: "HS256"}
+}
+
+type hs256Verifier struct {
+	key []byte
+}
+
+func (v *hs256Verifier) Verify(_ Headers, _, signingInput, signature []byte) error {
+	mac := hmac.New(sha256.New, v.key)
+	mac.Write(signingInput)
+
+	if !hmac.Equal(mac.Sum(nil), signature) {
+		return errors.New("invalid HS256 signature")
+	}
+
+	return nil
+}
+
 func TestIsCompactJWS(t *testing.T) {
 	require.True(t, IsCompactJWS("a.b.c"))
 	require.False(t, IsCompactJWS("a.b"))
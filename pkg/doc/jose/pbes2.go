@@ -0,0 +1,189 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jose
+
+import (
+	"crypto/aes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"math"
+
+	josecipher "github.com/go-jose/go-jose/v3/cipher"
+	"github.com/google/tink/go/subtle/random"
+	"golang.org/x/crypto/pbkdf2"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/aead/subtle"
+)
+
+// Key management algorithms wrapping the CEK with AES key wrap using a key derived from a password with PBKDF2
+// as per the JWA specification: https://tools.ietf.org/html/rfc7518#section-4.8
+const (
+	// PBES2HS256A128KWALG represents PBES2 with HMAC SHA-256 and A128KW wrapping.
+	PBES2HS256A128KWALG = "PBES2-HS256+A128KW"
+	// PBES2HS384A192KWALG represents PBES2 with HMAC SHA-384 and A192KW wrapping.
+	PBES2HS384A192KWALG = "PBES2-HS384+A192KW"
+	// PBES2HS512A256KWALG represents PBES2 with HMAC SHA-512 and A256KW wrapping.
+	PBES2HS512A256KWALG = "PBES2-HS512+A256KW"
+)
+
+const (
+	// PBES2MinIterations is the minimum PBKDF2 iteration count (p2c) as recommended by the JWA specification.
+	PBES2MinIterations = 1000
+	// PBES2MaxIterations is the maximum PBKDF2 iteration count (p2c), a larger count of a JWE is rejected
+	// to prevent denial of service by an attacker-chosen count.
+	PBES2MaxIterations = 1000000
+
+	pbes2SaltSize = 16
+	// pbes2MinSaltSize is the minimum size of the salt input (p2s) required by the JWA specification.
+	pbes2MinSaltSize = 8
+)
+
+type pbes2Params struct {
+	hash    func() hash.Hash
+	keySize int
+}
+
+//nolint:gochecknoglobals
+var pbes2Algs = map[string]pbes2Params{
+	PBES2HS256A128KWALG: {hash: sha256.New, keySize: subtle.AES128Size},
+	PBES2HS384A192KWALG: {hash: sha512.New384, keySize: subtle.AES192Size},
+	PBES2HS512A256KWALG: {hash: sha512.New, keySize: subtle.AES256Size},
+}
+
+func isPBES2Alg(alg string) bool {
+	_, ok := pbes2Algs[alg]
+
+	return ok
+}
+
+func validatePBES2Iterations(iterations int) error {
+	if iterations < PBES2MinIterations || iterations > PBES2MaxIterations {
+		return fmt.Errorf("PBES2 iteration count %d is out of range [%d, %d]",
+			iterations, PBES2MinIterations, PBES2MaxIterations)
+	}
+
+	return nil
+}
+
+// derivePBES2Key derives the key wrapping the CEK from the password, the salt input (p2s) and the iteration count
+// (p2c). The PBKDF2 salt is the UTF-8 encoded algorithm, a zero byte and the salt input.
+func derivePBES2Key(alg string, password, saltInput []byte, iterations int) []byte {
+	params := pbes2Algs[alg]
+
+	salt := make([]byte, 0, len(alg)+1+len(saltInput))
+	salt = append(salt, alg...)
+	salt = append(salt, 0)
+	salt = append(salt, saltInput...)
+
+	return pbkdf2.Key(password, salt, iterations, params.keySize, params.hash)
+}
+
+func (je *JWEEncrypt) encryptWithPassword(protectedHeaders map[string]interface{},
+	plaintext, aad []byte) (*JSONWebEncryption, error) {
+	cek := je.newCEK()
+
+	saltInput := random.GetRandomBytes(pbes2SaltSize)
+
+	block, err := aes.NewCipher(derivePBES2Key(je.kwAlg, je.password, saltInput, je.p2c))
+	if err != nil {
+		return nil, fmt.Errorf("jweencrypt: failed to wrap cek: %w", err)
+	}
+
+	encryptedKey, err := josecipher.KeyWrap(block, cek)
+	if err != nil {
+		return nil, fmt.Errorf("jweencrypt: failed to wrap cek: %w", err)
+	}
+
+	protectedHeaders[HeaderAlgorithm] = je.kwAlg
+	protectedHeaders[HeaderPBES2Salt] = base64.RawURLEncoding.EncodeToString(saltInput)
+	protectedHeaders[HeaderPBES2Count] = je.p2c
+
+	return je.encryptWithWrappedCEK(protectedHeaders, cek, []*Recipient{{EncryptedKey: string(encryptedKey)}},
+		plaintext, aad)
+}
+
+// decryptWithPassword decrypts a JWE with the CEK wrapped with PBES2, the PBES2 headers must be protected.
+func (jd *JWEDecrypt) decryptWithPassword(jwe *JSONWebEncryption) ([]byte, error) {
+	if len(jd.password) == 0 {
+		return nil, errors.New("jwedecrypt: password is required for PBES2 key wrapping")
+	}
+
+	if len(jwe.Recipients) != 1 {
+		return nil, errors.New("jwedecrypt: PBES2 key wrapping is supported for a single recipient only")
+	}
+
+	cek, err := jd.unwrapCEKWithPassword(jwe.ProtectedHeaders, []byte(jwe.Recipients[0].EncryptedKey))
+	if err != nil {
+		return nil, fmt.Errorf("jwedecrypt: failed to unwrap cek: %w", err)
+	}
+
+	return jd.decryptJWE(jwe, cek)
+}
+
+func (jd *JWEDecrypt) unwrapCEKWithPassword(protectedHeaders Headers, encryptedKey []byte) ([]byte, error) {
+	alg, _ := protectedHeaders.Algorithm()
+
+	p2s, ok := protectedHeaders.stringValue(HeaderPBES2Salt)
+	if !ok {
+		return nil, errors.New("missing 'p2s' header")
+	}
+
+	saltInput, err := base64.RawURLEncoding.DecodeString(p2s)
+	if err != nil {
+		return nil, fmt.Errorf("decode 'p2s' header: %w", err)
+	}
+
+	if len(saltInput) < pbes2MinSaltSize {
+		return nil, fmt.Errorf("'p2s' header of %d bytes is shorter than %d bytes", len(saltInput), pbes2MinSaltSize)
+	}
+
+	p2c, err := pbes2Count(protectedHeaders[HeaderPBES2Count])
+	if err != nil {
+		return nil, err
+	}
+
+	// the iteration count is checked before the key derivation, which is as costly as the count.
+	if err = validatePBES2Iterations(p2c); err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(derivePBES2Key(alg, jd.password, saltInput, p2c))
+	if err != nil {
+		return nil, err
+	}
+
+	return josecipher.KeyUnwrap(block, encryptedKey)
+}
+
+func pbes2Count(p2c interface{}) (int, error) {
+	switch count := p2c.(type) {
+	case int:
+		return count, nil
+	case float64:
+		if count != math.Trunc(count) || count < 0 || count > math.MaxInt32 {
+			return 0, fmt.Errorf("invalid 'p2c' header: %v", count)
+		}
+
+		return int(count), nil
+	case json.Number:
+		n, err := count.Int64()
+		if err != nil {
+			return 0, fmt.Errorf("invalid 'p2c' header: %w", err)
+		}
+
+		return int(n), nil
+	case nil:
+		return 0, errors.New("missing 'p2c' header")
+	default:
+		return 0, fmt.Errorf("invalid 'p2c' header type: %T", p2c)
+	}
+}
@@ -0,0 +1,191 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jose
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	gojose "github.com/go-jose/go-jose/v3"
+	"github.com/stretchr/testify/require"
+)
+
+// Key wrap using PBES2-AES-KeyWrap with AES-CBC-HMAC-SHA2 from RFC 7520 (JOSE Cookbook) section 5.3.
+const (
+	rfc7520Password = "entrap_o–peter_long–credit_tun"
+
+	rfc7520PBES2Plaintext = `{"keys":[{"kty":"oct","kid":"77c7e2b8-6e13-45cf-8672-617b5b45243a","use":"enc",` +
+		`"alg":"A128GCM","k":"XctOhJAkA-pD9Lh7ZgW_2A"},{"kty":"oct","kid":"81b20965-8332-43d9-a468-82160ad91ac8",` +
+		`"use":"enc","alg":"A128KW","k":"GZy6sIZ6wl9NJOKB-jnmVQ"},{"kty":"oct",` +
+		`"kid":"18ec08e1-bfa9-4d95-b205-2b4dd1d4321d","use":"enc","alg":"A256GCMKW",` +
+		`"k":"qC57l_uxcm7Nm3K-ct4GFjx8tM1U8CZ0NLBvdQstiS8"}]}`
+
+	rfc7520PBES2CompactJWE = "eyJhbGciOiJQQkVTMi1IUzUxMitBMjU2S1ciLCJwMnMiOiI4UTFTemluYXNSM3hjaFl6NlpaY0hBIiwicDJj" +
+		"Ijo4MTkyLCJjdHkiOiJqd2stc2V0K2pzb24iLCJlbmMiOiJBMTI4Q0JDLUhTMjU2In0" +
+		".d3qNhUWfqheyPp4H8sjOWsDYajoej4c5Je6rlUtFPWdgtURtmeDV1g" +
+		".VBiCzVHNoLiR3F4V82uoTQ" +
+		".23i-Tb1AV4n0WKVSSgcQrdg6GRqsUKxjruHXYsTHAJLZ2nsnGIX86vMXqIi6IRsfywCRFzLxEcZBRnTvG3nhzPk0GDD7FMyXhUHpDjEYCNA" +
+		"_XOmzg8yZR9oyjo6lTF6si4q9FZ2EhzgFQCLO_6h5EVg3vR75_hkBsnuoqoM3dwejXBtIodN84PeqMb6asmas_dpSsz7H10fC5ni9xIz424" +
+		"givB1YLldF6exVmL93R3fOoOJbmk2GBQZL_SEGllv2cQsBgeprARsaQ7Bq99tT80coH8ItBjgV08AtzXFFsx9qKvC982KLKdPQMTlVJKkqtV4" +
+		"Ru5LEVpBZXBnZrtViSOgyg6AiuwaS-rCrcD_ePOGSuxvgtrokAKYPqmXUeRdjFJwafkYEkiuDCV9vWGAi1DH2xTafhJwcmywIyzi4BqRpmdn_" +
+		"N-zl5tuJYyuvKhjKv6ihbsV_k1hJGPGAxJ6wUpmwC4PTQ2izEm0TuSE8oMKdTw8V3kobXZ77ulMwDs4p" +
+		".0HlwodAhOCILG5SQ2LQ9dg"
+)
+
+func TestPBES2_RFC7520(t *testing.T) {
+	jwe, err := Deserialize(rfc7520PBES2CompactJWE)
+	require.NoError(t, err)
+
+	alg, ok := jwe.ProtectedHeaders.Algorithm()
+	require.True(t, ok)
+	require.Equal(t, PBES2HS512A256KWALG, alg)
+
+	plaintext, err := NewJWEDecrypt(nil, nil, nil, WithPassword([]byte(rfc7520Password))).Decrypt(jwe)
+	require.NoError(t, err)
+	require.Equal(t, rfc7520PBES2Plaintext, string(plaintext))
+
+	t.Run("error - wrong password", func(t *testing.T) {
+		_, err := NewJWEDecrypt(nil, nil, nil, WithPassword([]byte("wrong password"))).Decrypt(jwe)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "jwedecrypt: failed to unwrap cek")
+	})
+
+	t.Run("error - no password", func(t *testing.T) {
+		_, err := NewJWEDecrypt(nil, nil, nil).Decrypt(jwe)
+		require.EqualError(t, err, "jwedecrypt: password is required for PBES2 key wrapping")
+	})
+}
+
+func TestJWEEncryptWithPassword(t *testing.T) {
+	password := []byte("correct horse battery staple")
+	plaintext := []byte("secret message")
+
+	for _, kwAlg := range []string{PBES2HS256A128KWALG, PBES2HS384A192KWALG, PBES2HS512A256KWALG} {
+		for _, encAlg := range []EncAlg{A256GCM, A128CBCHS256} {
+			kwAlg, encAlg := kwAlg, encAlg
+
+			t.Run(fmt.Sprintf("%s with %s", kwAlg, encAlg), func(t *testing.T) {
+				encrypter, err := NewJWEEncryptWithPassword(encAlg, "", "", password, kwAlg, PBES2MinIterations)
+				require.NoError(t, err)
+
+				encryptedJWE, err := encrypter.EncryptWithAuthData(plaintext, []byte("external aad"))
+				require.NoError(t, err)
+				require.Equal(t, kwAlg, encryptedJWE.ProtectedHeaders[HeaderAlgorithm])
+				require.Equal(t, PBES2MinIterations, encryptedJWE.ProtectedHeaders[HeaderPBES2Count])
+				require.Contains(t, encryptedJWE.ProtectedHeaders, HeaderPBES2Salt)
+
+				serializedJWE, err := encryptedJWE.FullSerialize(json.Marshal)
+				require.NoError(t, err)
+
+				jwe, err := Deserialize(serializedJWE)
+				require.NoError(t, err)
+
+				decrypted, err := NewJWEDecrypt(nil, nil, nil, WithPassword(password)).Decrypt(jwe)
+				require.NoError(t, err)
+				require.Equal(t, plaintext, decrypted)
+			})
+		}
+	}
+
+	t.Run("compact JWE is decrypted by go-jose", func(t *testing.T) {
+		encrypter, err := NewJWEEncryptWithPassword(A256GCM, "", "", password, PBES2HS256A128KWALG, 4096)
+		require.NoError(t, err)
+
+		jwe, err := encrypter.Encrypt(plaintext)
+		require.NoError(t, err)
+
+		compactJWE, err := jwe.CompactSerialize(json.Marshal)
+		require.NoError(t, err)
+
+		gjJWE, err := gojose.ParseEncrypted(compactJWE)
+		require.NoError(t, err)
+
+		decrypted, err := gjJWE.Decrypt(password)
+		require.NoError(t, err)
+		require.Equal(t, plaintext, decrypted)
+	})
+
+	t.Run("go-jose JWE is decrypted", func(t *testing.T) {
+		gjEncrypter, err := gojose.NewEncrypter(gojose.A256GCM,
+			gojose.Recipient{Algorithm: gojose.PBES2_HS384_A192KW, Key: password, PBES2Count: 4096}, nil)
+		require.NoError(t, err)
+
+		gjJWE, err := gjEncrypter.Encrypt(plaintext)
+		require.NoError(t, err)
+
+		jwe, err := Deserialize(gjJWE.FullSerialize())
+		require.NoError(t, err)
+
+		decrypted, err := NewJWEDecrypt(nil, nil, nil, WithPassword(password)).Decrypt(jwe)
+		require.NoError(t, err)
+		require.Equal(t, plaintext, decrypted)
+	})
+
+	t.Run("error - invalid encrypter parameters", func(t *testing.T) {
+		_, err := NewJWEEncryptWithPassword(A256GCM, "", "", nil, PBES2HS256A128KWALG, PBES2MinIterations)
+		require.EqualError(t, err, "password is required")
+
+		_, err = NewJWEEncryptWithPassword("invalid", "", "", password, PBES2HS256A128KWALG, PBES2MinIterations)
+		require.EqualError(t, err, "encryption algorithm 'invalid' not supported")
+
+		_, err = NewJWEEncryptWithPassword(A256GCM, "", "", password, A256GCMKWALG, PBES2MinIterations)
+		require.EqualError(t, err, "key wrapping algorithm 'A256GCMKW' is not a PBES2 algorithm")
+
+		_, err = NewJWEEncryptWithPassword(A256GCM, "", "", password, PBES2HS256A128KWALG, PBES2MinIterations-1)
+		require.EqualError(t, err, "PBES2 iteration count 999 is out of range [1000, 1000000]")
+
+		_, err = NewJWEEncryptWithPassword(A256GCM, "", "", password, PBES2HS256A128KWALG, PBES2MaxIterations+1)
+		require.EqualError(t, err, "PBES2 iteration count 1000001 is out of range [1000, 1000000]")
+	})
+
+	t.Run("error - invalid PBES2 headers", func(t *testing.T) {
+		decrypter := NewJWEDecrypt(nil, nil, nil, WithPassword(password))
+
+		for _, tc := range []struct {
+			name   string
+			header string
+			value  interface{}
+			err    string
+		}{
+			{name: "attacker-chosen iteration count", header: HeaderPBES2Count, value: float64(1 << 30),
+				err: "PBES2 iteration count 1073741824 is out of range"},
+			{name: "fractional iteration count", header: HeaderPBES2Count, value: 1000.5,
+				err: "invalid 'p2c' header: 1000.5"},
+			{name: "iteration count of invalid type", header: HeaderPBES2Count, value: "1000",
+				err: "invalid 'p2c' header type: string"},
+			{name: "missing iteration count", header: HeaderPBES2Count, err: "missing 'p2c' header"},
+			{name: "missing salt", header: HeaderPBES2Salt, err: "missing 'p2s' header"},
+			{name: "salt not base64-encoded", header: HeaderPBES2Salt, value: "not base64-encoded",
+				err: "decode 'p2s' header"},
+			{name: "empty salt", header: HeaderPBES2Salt, value: "",
+				err: "'p2s' header of 0 bytes is shorter than 8 bytes"},
+			{name: "1-byte salt", header: HeaderPBES2Salt, value: base64.RawURLEncoding.EncodeToString([]byte{1}),
+				err: "'p2s' header of 1 bytes is shorter than 8 bytes"},
+			{name: "7-byte salt", header: HeaderPBES2Salt, value: base64.RawURLEncoding.EncodeToString(make([]byte, 7)),
+				err: "'p2s' header of 7 bytes is shorter than 8 bytes"},
+		} {
+			tc := tc
+
+			t.Run(tc.name, func(t *testing.T) {
+				jwe, err := Deserialize(rfc7520PBES2CompactJWE)
+				require.NoError(t, err)
+
+				if tc.value == nil {
+					delete(jwe.ProtectedHeaders, tc.header)
+				} else {
+					jwe.ProtectedHeaders[tc.header] = tc.value
+				}
+
+				_, err = decrypter.Decrypt(jwe)
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.err)
+			})
+		}
+	})
+}
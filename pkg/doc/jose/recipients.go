@@ -0,0 +1,216 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jose
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1" //nolint:gosec // SHA-1 is mandated by RSA-OAEP
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"hash"
+
+	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
+)
+
+// Key management algorithms of the recipients of a JWE encrypted with per-recipient algorithms.
+const (
+	// ECDHESA256KWALG represents ECDH-ES key agreement with A256KW wrapping of the CEK for an EC key.
+	ECDHESA256KWALG = "ECDH-ES+A256KW"
+	// ECDHESXC20PKWALG represents ECDH-ES key agreement with XC20P wrapping of the CEK for an OKP (X25519) key.
+	ECDHESXC20PKWALG = "ECDH-ES+XC20PKW"
+	// RSAOAEPALG represents RSAES OAEP encryption of the CEK with SHA-1 and MGF1 with SHA-1.
+	RSAOAEPALG = "RSA-OAEP"
+	// RSAOAEP256ALG represents RSAES OAEP encryption of the CEK with SHA-256 and MGF1 with SHA-256.
+	RSAOAEP256ALG = "RSA-OAEP-256"
+)
+
+// JWERecipient is a recipient of a JWE encrypted with per-recipient key management algorithms.
+type JWERecipient struct {
+	// Algorithm is the key management algorithm of the recipient: ECDHESA256KWALG or ECDHESXC20PKWALG
+	// with PublicKey, RSAOAEPALG or RSAOAEP256ALG with RSAPublicKey.
+	Algorithm string
+	// KID is set as the 'kid' header of the recipient, the KID of the PublicKey is used if it's empty.
+	KID string
+	// PublicKey is the EC or OKP key of an ECDH-ES recipient.
+	PublicKey *cryptoapi.PublicKey
+	// RSAPublicKey is the key of an RSA-OAEP recipient.
+	RSAPublicKey *rsa.PublicKey
+}
+
+// RSAKeySource returns the RSA private key identified by kid (the 'kid' header of the recipient, which may be empty)
+// to decrypt the CEK of an RSA-OAEP recipient.
+type RSAKeySource func(kid string) (*rsa.PrivateKey, error)
+
+func isRSAOAEPAlg(alg string) bool {
+	return alg == RSAOAEPALG || alg == RSAOAEP256ALG
+}
+
+func rsaOAEPHash(alg string) hash.Hash {
+	if alg == RSAOAEP256ALG {
+		return sha256.New()
+	}
+
+	return sha1.New() //nolint:gosec
+}
+
+func validateJWERecipient(rec *JWERecipient) error {
+	switch rec.Algorithm {
+	case ECDHESA256KWALG, ECDHESXC20PKWALG:
+		if rec.PublicKey == nil {
+			return fmt.Errorf("public key is required for '%s'", rec.Algorithm)
+		}
+
+		if keyType := ecdhKeyType(rec.Algorithm); rec.PublicKey.Type != keyType {
+			return fmt.Errorf("'%s' requires a key of type %s, got %s", rec.Algorithm, keyType, rec.PublicKey.Type)
+		}
+	case RSAOAEPALG, RSAOAEP256ALG:
+		if rec.RSAPublicKey == nil {
+			return fmt.Errorf("RSA public key is required for '%s'", rec.Algorithm)
+		}
+	default:
+		return fmt.Errorf("key management algorithm '%s' not supported", rec.Algorithm)
+	}
+
+	return nil
+}
+
+func ecdhKeyType(alg string) string {
+	if alg == ECDHESXC20PKWALG {
+		return "OKP"
+	}
+
+	return "EC"
+}
+
+// encryptForRecipients encrypts the plaintext with a single CEK wrapped for every recipient with its own algorithm.
+// The recipient headers are never merged into the protected headers, so the JWE has the JSON serialization.
+func (je *JWEEncrypt) encryptForRecipients(protectedHeaders map[string]interface{},
+	plaintext, aad []byte) (*JSONWebEncryption, error) {
+	cek := je.newCEK()
+
+	recipients := make([]*Recipient, 0, len(je.recipients))
+
+	for i, rec := range je.recipients {
+		recipient, err := je.wrapCEKForRecipient(cek, rec)
+		if err != nil {
+			return nil, fmt.Errorf("jweencrypt: failed to wrap cek for recipient %d: %w", i+1, err)
+		}
+
+		recipients = append(recipients, recipient)
+	}
+
+	return je.encryptWithWrappedCEK(protectedHeaders, cek, recipients, plaintext, aad)
+}
+
+func (je *JWEEncrypt) wrapCEKForRecipient(cek []byte, rec *JWERecipient) (*Recipient, error) {
+	if isRSAOAEPAlg(rec.Algorithm) {
+		encryptedKey, err := rsa.EncryptOAEP(rsaOAEPHash(rec.Algorithm), rand.Reader, rec.RSAPublicKey, cek, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Recipient{
+			EncryptedKey: string(encryptedKey),
+			Header:       &RecipientHeaders{Alg: rec.Algorithm, KID: rec.KID},
+		}, nil
+	}
+
+	var wrapOpts []cryptoapi.WrapKeyOpts
+
+	if rec.Algorithm == ECDHESXC20PKWALG {
+		wrapOpts = append(wrapOpts, cryptoapi.WithXC20PKW())
+	}
+
+	kek, err := je.crypto.WrapKey(cek, []byte{}, []byte{}, rec.PublicKey, wrapOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	je.encodeAPUAPV(kek)
+
+	headers, err := buildRecipientHeaders(kek, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if rec.KID != "" {
+		headers.KID = rec.KID
+	}
+
+	return &Recipient{
+		EncryptedKey: string(kek.EncryptedCEK),
+		Header:       headers,
+	}, nil
+}
+
+// usesPerRecipientAlgs returns true if the JWE has no common key management algorithm and its recipients
+// have their own algorithms (e.g. RSA-OAEP and ECDH-ES recipients) or a single recipient has its own headers.
+func usesPerRecipientAlgs(jwe *JSONWebEncryption) bool {
+	if _, ok := jwe.ProtectedHeaders.Algorithm(); ok {
+		return false
+	}
+
+	for _, rec := range jwe.Recipients {
+		if rec.Header != nil && (isRSAOAEPAlg(rec.Header.Alg) || len(jwe.Recipients) == 1) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// decryptForRecipients decrypts a JWE with per-recipient key management algorithms with the CEK of the first
+// recipient whose key is available: RSA-OAEP keys are fetched from the RSA key source and ECDH-ES keys
+// from the KMS.
+func (jd *JWEDecrypt) decryptForRecipients(jwe *JSONWebEncryption) ([]byte, error) {
+	var errs []error
+
+	for i, rec := range jwe.Recipients {
+		if rec.Header == nil {
+			errs = append(errs, fmt.Errorf("recipient %d: missing recipient headers", i+1))
+
+			continue
+		}
+
+		cek, err := jd.unwrapCEKForRecipient(rec)
+		if err == nil {
+			return jd.decryptJWE(jwe, cek)
+		}
+
+		errs = append(errs, fmt.Errorf("recipient %d: %w", i+1, err))
+	}
+
+	return nil, fmt.Errorf("jwedecrypt: failed to unwrap cek: %v", errs)
+}
+
+func (jd *JWEDecrypt) unwrapCEKForRecipient(rec *Recipient) ([]byte, error) {
+	if isRSAOAEPAlg(rec.Header.Alg) {
+		if jd.rsaKeySource == nil {
+			return nil, errors.New("RSA key source is required for RSA-OAEP key wrapping")
+		}
+
+		key, err := jd.rsaKeySource(rec.Header.KID)
+		if err != nil {
+			return nil, fmt.Errorf("RSA key for kid '%s': %w", rec.Header.KID, err)
+		}
+
+		return rsa.DecryptOAEP(rsaOAEPHash(rec.Header.Alg), nil, key, []byte(rec.EncryptedKey), nil)
+	}
+
+	if jd.kms == nil || jd.crypto == nil {
+		return nil, fmt.Errorf("crypto and KMS are required for '%s' key wrapping", rec.Header.Alg)
+	}
+
+	recWK, err := createRecWK(rec.Header, []byte(rec.EncryptedKey))
+	if err != nil {
+		return nil, err
+	}
+
+	return jd.unwrapCEK([]*cryptoapi.RecipientWrappedKey{recWK})
+}
@@ -2,6 +2,8 @@ package jose
 
 import (
 	"crypto/elliptic"
+	"errors"
+	"math/big"
 
 	"github.com/ethereum/go-ethereum/crypto/secp256k1"
 )
@@ -27,3 +29,83 @@ func (BitCurve *S256Curve) Params() *elliptic.CurveParams {
 		Name:    "secp256k1",
 	}
 }
+
+// DecompressPoint computes the y coordinate of the point of the curve with the x coordinate
+// and the parity yBit (0 for even y, 1 for odd y) of a compressed public key.
+func (BitCurve *S256Curve) DecompressPoint(x *big.Int, yBit uint) (*big.Int, *big.Int, error) {
+	if yBit > 1 {
+		return nil, nil, errors.New("invalid y bit")
+	}
+
+	if x == nil || x.Sign() < 0 || x.Cmp(BitCurve.P) >= 0 {
+		return nil, nil, errors.New("invalid x coordinate")
+	}
+
+	// y² = x³ + b (mod p)
+	ySquared := new(big.Int).Exp(x, big.NewInt(3), BitCurve.P) //nolint:gomnd
+	ySquared.Add(ySquared, BitCurve.B)
+	ySquared.Mod(ySquared, BitCurve.P)
+
+	y := new(big.Int).ModSqrt(ySquared, BitCurve.P)
+	if y == nil {
+		return nil, nil, errors.New("x coordinate is not on the curve")
+	}
+
+	if y.Bit(0) != yBit {
+		y.Sub(BitCurve.P, y)
+	}
+
+	if !BitCurve.IsOnCurve(x, y) {
+		return nil, nil, errors.New("point is not on the curve")
+	}
+
+	return new(big.Int).Set(x), y, nil
+}
+
+// ScalarMult returns k*(Bx,By) with the constant-time scalar multiplication of libsecp256k1, k is a big-endian
+// scalar reduced modulo the curve order. As with crypto/elliptic, (0,0) is the point at infinity: it is
+// returned only for the point at infinity or k ≡ 0 (mod N), and ScalarMult panics on a point not on the curve.
+func (BitCurve *S256Curve) ScalarMult(Bx, By *big.Int, k []byte) (*big.Int, *big.Int) {
+	if Bx.Sign() == 0 && By.Sign() == 0 {
+		return new(big.Int), new(big.Int)
+	}
+
+	if Bx.Sign() < 0 || By.Sign() < 0 || Bx.Cmp(BitCurve.P) >= 0 || By.Cmp(BitCurve.P) >= 0 ||
+		!BitCurve.IsOnCurve(Bx, By) {
+		panic("jose: S256 ScalarMult was called on an invalid point")
+	}
+
+	scalar := BitCurve.normalizeScalar(k)
+	if scalar == nil {
+		return new(big.Int), new(big.Int)
+	}
+
+	x, y := BitCurve.BitCurve.ScalarMult(Bx, By, scalar)
+	if x == nil {
+		panic("jose: S256 ScalarMult rejected normalized scalar")
+	}
+
+	return x, y
+}
+
+// ScalarBaseMult returns k*G, where G is the base point of the curve, see ScalarMult.
+func (BitCurve *S256Curve) ScalarBaseMult(k []byte) (*big.Int, *big.Int) {
+	return BitCurve.ScalarMult(BitCurve.Gx, BitCurve.Gy, k)
+}
+
+// normalizeScalar returns k reduced modulo the curve order as a 32-byte big-endian scalar,
+// nil if k ≡ 0 (mod N).
+func (BitCurve *S256Curve) normalizeScalar(k []byte) []byte {
+	byteSize := (BitCurve.N.BitLen() + 7) / 8 //nolint:gomnd
+
+	s := new(big.Int).SetBytes(k)
+	if len(k) > byteSize || s.Cmp(BitCurve.N) >= 0 {
+		s.Mod(s, BitCurve.N)
+	}
+
+	if s.Sign() == 0 {
+		return nil
+	}
+
+	return s.FillBytes(make([]byte, byteSize))
+}
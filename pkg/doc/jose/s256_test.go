@@ -1,6 +1,9 @@
 package jose
 
 import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -12,3 +15,159 @@ func TestS256Curve(t *testing.T) {
 	require.EqualValues(t, "secp256k1", curve.Params().Name)
 	require.EqualValues(t, "7", curve.Params().B.String())
 }
+
+func TestS256Curve_DecompressPoint(t *testing.T) {
+	curve := S256().(*S256Curve)
+
+	privKey, err := ecdsa.GenerateKey(curve, rand.Reader)
+	require.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		x, y, err := curve.DecompressPoint(privKey.X, privKey.Y.Bit(0))
+		require.NoError(t, err)
+		require.Zero(t, privKey.X.Cmp(x))
+		require.Zero(t, privKey.Y.Cmp(y))
+
+		// the other point with the same x
+		x, y, err = curve.DecompressPoint(privKey.X, 1-privKey.Y.Bit(0))
+		require.NoError(t, err)
+		require.Zero(t, privKey.X.Cmp(x))
+		require.Zero(t, new(big.Int).Sub(curve.P, privKey.Y).Cmp(y))
+	})
+
+	t.Run("success - generator point", func(t *testing.T) {
+		x, y, err := curve.DecompressPoint(curve.Gx, curve.Gy.Bit(0))
+		require.NoError(t, err)
+		require.Zero(t, curve.Gx.Cmp(x))
+		require.Zero(t, curve.Gy.Cmp(y))
+	})
+
+	t.Run("error - invalid y bit", func(t *testing.T) {
+		_, _, err := curve.DecompressPoint(privKey.X, 2)
+		require.EqualError(t, err, "invalid y bit")
+	})
+
+	t.Run("error - invalid x coordinate", func(t *testing.T) {
+		_, _, err := curve.DecompressPoint(nil, 0)
+		require.EqualError(t, err, "invalid x coordinate")
+
+		_, _, err = curve.DecompressPoint(curve.P, 0)
+		require.EqualError(t, err, "invalid x coordinate")
+
+		_, _, err = curve.DecompressPoint(big.NewInt(-1), 0)
+		require.EqualError(t, err, "invalid x coordinate")
+	})
+
+	t.Run("error - x coordinate is not on the curve", func(t *testing.T) {
+		// x³ + 7 = 132 is not a quadratic residue modulo p
+		_, _, err := curve.DecompressPoint(big.NewInt(5), 0)
+		require.EqualError(t, err, "x coordinate is not on the curve")
+	})
+}
+
+func TestS256Curve_ScalarMult(t *testing.T) {
+	curve := S256().(*S256Curve)
+
+	fromHex := func(t *testing.T, s string) *big.Int {
+		t.Helper()
+
+		n, ok := new(big.Int).SetString(s, 16)
+		require.True(t, ok)
+
+		return n
+	}
+
+	// multiples of the base point of secp256k1
+	vectors := []struct {
+		k    *big.Int
+		x, y string
+	}{
+		{
+			k: big.NewInt(1),
+			x: "79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798",
+			y: "483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8",
+		},
+		{
+			k: big.NewInt(2),
+			x: "c6047f9441ed7d6d3045406e95c07cd85c778e4b8cef3ca7abac09b95c709ee5",
+			y: "1ae168fea63dc339a3c58419466ceaeef7f632653266d0e1236431a950cfe52a",
+		},
+		{
+			k: big.NewInt(3),
+			x: "f9308a019258c31049344f85f89d5229b531c845836f99b08601f113bce036f9",
+			y: "388f7b0f632de8140fe337e62a37f3566500a99934c2231b6cb9fd7584b8e672",
+		},
+		{
+			k: new(big.Int).Sub(curve.N, big.NewInt(1)),
+			x: "79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798",
+			y: "b7c52588d95c3b9aa25b0403f1eef75702e84bb7597aabe663b82f6f04ef2777",
+		},
+	}
+
+	t.Run("base point multiples", func(t *testing.T) {
+		for _, v := range vectors {
+			x, y := curve.ScalarBaseMult(v.k.Bytes())
+			require.Zero(t, fromHex(t, v.x).Cmp(x), "x of %s*G", v.k)
+			require.Zero(t, fromHex(t, v.y).Cmp(y), "y of %s*G", v.k)
+
+			x, y = curve.ScalarMult(curve.Gx, curve.Gy, v.k.Bytes())
+			require.Zero(t, fromHex(t, v.x).Cmp(x), "x of %s*G", v.k)
+			require.Zero(t, fromHex(t, v.y).Cmp(y), "y of %s*G", v.k)
+		}
+	})
+
+	t.Run("scalar is reduced modulo the order", func(t *testing.T) {
+		// (N+2)*G = 2*G, with a 32-byte and a 33-byte scalar
+		k := new(big.Int).Add(curve.N, big.NewInt(2))
+
+		x, y := curve.ScalarBaseMult(k.Bytes())
+		require.Zero(t, fromHex(t, vectors[1].x).Cmp(x))
+		require.Zero(t, fromHex(t, vectors[1].y).Cmp(y))
+
+		x, y = curve.ScalarBaseMult(append([]byte{0}, k.Bytes()...))
+		require.Zero(t, fromHex(t, vectors[1].x).Cmp(x))
+		require.Zero(t, fromHex(t, vectors[1].y).Cmp(y))
+
+		x, y = curve.ScalarBaseMult(new(big.Int).Add(k, curve.N).Bytes())
+		require.Zero(t, fromHex(t, vectors[1].x).Cmp(x))
+		require.Zero(t, fromHex(t, vectors[1].y).Cmp(y))
+	})
+
+	t.Run("ECDH shared secret", func(t *testing.T) {
+		priv1, err := ecdsa.GenerateKey(curve, rand.Reader)
+		require.NoError(t, err)
+
+		priv2, err := ecdsa.GenerateKey(curve, rand.Reader)
+		require.NoError(t, err)
+
+		x1, y1 := curve.ScalarMult(priv2.X, priv2.Y, priv1.D.Bytes())
+		x2, y2 := curve.ScalarMult(priv1.X, priv1.Y, priv2.D.Bytes())
+		require.Zero(t, x1.Cmp(x2))
+		require.Zero(t, y1.Cmp(y2))
+		require.True(t, curve.IsOnCurve(x1, y1))
+	})
+
+	t.Run("point at infinity", func(t *testing.T) {
+		for _, k := range [][]byte{nil, {0}, curve.N.Bytes(), new(big.Int).Lsh(curve.N, 8).Bytes()} {
+			x, y := curve.ScalarBaseMult(k)
+			require.Zero(t, x.Sign())
+			require.Zero(t, y.Sign())
+		}
+
+		x, y := curve.ScalarMult(new(big.Int), new(big.Int), []byte{1})
+		require.Zero(t, x.Sign())
+		require.Zero(t, y.Sign())
+	})
+
+	t.Run("invalid point", func(t *testing.T) {
+		for _, p := range [][2]*big.Int{
+			{curve.Gx, new(big.Int).Add(curve.Gy, big.NewInt(1))},
+			{new(big.Int).Add(curve.Gx, curve.P), curve.Gy},
+			{new(big.Int).Neg(curve.Gx), curve.Gy},
+		} {
+			require.PanicsWithValue(t, "jose: S256 ScalarMult was called on an invalid point", func() {
+				curve.ScalarMult(p[0], p[1], []byte{1})
+			})
+		}
+	})
+}
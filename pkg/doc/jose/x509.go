@@ -0,0 +1,197 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jose
+
+import (
+	"bytes"
+	"crypto/sha1" //nolint:gosec
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
+)
+
+// x509Opts holds options for the X.509 certificate chain processing.
+type x509Opts struct {
+	roots *x509.CertPool
+}
+
+// X509Opt is the X.509 certificate chain option.
+type X509Opt func(opts *x509Opts)
+
+// WithX509Roots option enables verification of the "x5c" certificate chain against the given root certificates.
+// Without this option the chain is only parsed and the public key is taken from the leaf certificate as is.
+func WithX509Roots(roots *x509.CertPool) X509Opt {
+	return func(opts *x509Opts) {
+		opts.roots = roots
+	}
+}
+
+// X509CertificateChain parses the "x5c" header (https://tools.ietf.org/html/rfc7515#section-4.1.6).
+// The first certificate of the returned chain is the leaf one containing the key.
+func (h Headers) X509CertificateChain() ([]*x509.Certificate, error) {
+	raw, ok := h[HeaderX509CertificateChain]
+	if !ok {
+		return nil, fmt.Errorf("%s JOSE header is not present", HeaderX509CertificateChain)
+	}
+
+	rawCerts, ok := raw.([]interface{})
+	if !ok || len(rawCerts) == 0 {
+		return nil, fmt.Errorf("%s JOSE header is not a non-empty array", HeaderX509CertificateChain)
+	}
+
+	certs := make([]*x509.Certificate, len(rawCerts))
+
+	for i, rawCert := range rawCerts {
+		b64Cert, ok := rawCert.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s certificate %d is not a string", HeaderX509CertificateChain, i)
+		}
+
+		// x5c values are base64 (not base64url) encoded DER certificates
+		der, err := base64.StdEncoding.DecodeString(b64Cert)
+		if err != nil {
+			return nil, fmt.Errorf("decode %s certificate %d: %w", HeaderX509CertificateChain, i, err)
+		}
+
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s certificate %d: %w", HeaderX509CertificateChain, i, err)
+		}
+
+		certs[i] = cert
+	}
+
+	return certs, nil
+}
+
+// X509PublicKey extracts the public key from the leaf certificate of the "x5c" header.
+// If "x5t" or "x5t#S256" headers are present, they must match the thumbprint of the leaf certificate.
+// The certificate chain is verified if WithX509Roots option is passed.
+func (h Headers) X509PublicKey(opts ...X509Opt) (*jwk.JWK, error) {
+	xOpts := &x509Opts{}
+
+	for _, opt := range opts {
+		opt(xOpts)
+	}
+
+	certs, err := h.X509CertificateChain()
+	if err != nil {
+		return nil, err
+	}
+
+	leaf := certs[0]
+
+	err = h.checkX509Thumbprints(leaf)
+	if err != nil {
+		return nil, err
+	}
+
+	if xOpts.roots != nil {
+		err = verifyX509Chain(certs, xOpts.roots)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	pubKey, err := jwksupport.JWKFromKey(leaf.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("create JWK from %s leaf certificate: %w", HeaderX509CertificateChain, err)
+	}
+
+	pubKey.Certificates = certs
+
+	return pubKey, nil
+}
+
+func (h Headers) checkX509Thumbprints(leaf *x509.Certificate) error {
+	sha256Sum := sha256.Sum256(leaf.Raw)
+
+	err := h.checkX509Thumbprint(HeaderX509CertificateDigestSha256, sha256Sum[:])
+	if err != nil {
+		return err
+	}
+
+	sha1Sum := sha1.Sum(leaf.Raw) //nolint:gosec
+
+	return h.checkX509Thumbprint(HeaderX509CertificateDigestSha1, sha1Sum[:])
+}
+
+func (h Headers) checkX509Thumbprint(header string, digest []byte) error {
+	if _, ok := h[header]; !ok {
+		return nil
+	}
+
+	thumbprint, ok := h.stringValue(header)
+	if !ok {
+		return fmt.Errorf("%s JOSE header is not a string", header)
+	}
+
+	expected, err := base64.RawURLEncoding.DecodeString(thumbprint)
+	if err != nil {
+		return fmt.Errorf("decode %s JOSE header: %w", header, err)
+	}
+
+	if !bytes.Equal(expected, digest) {
+		return fmt.Errorf("%s JOSE header does not match %s leaf certificate", header, HeaderX509CertificateChain)
+	}
+
+	return nil
+}
+
+func verifyX509Chain(certs []*x509.Certificate, roots *x509.CertPool) error {
+	intermediates := x509.NewCertPool()
+
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	_, err := certs[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		return fmt.Errorf("verify %s certificate chain: %w", HeaderX509CertificateChain, err)
+	}
+
+	return nil
+}
+
+// X509KeyVerifierFunc verifies JWS signature using the public key taken from the "x5c" header.
+type X509KeyVerifierFunc func(pubKey *jwk.JWK, joseHeaders Headers, signingInput, signature []byte) error
+
+// X509SignatureVerifier is a SignatureVerifier which takes the public key from the "x5c" JOSE header
+// instead of resolving it by "kid".
+type X509SignatureVerifier struct {
+	keyVerifier X509KeyVerifierFunc
+	opts        []X509Opt
+}
+
+// NewX509SignatureVerifier creates a new X509SignatureVerifier. The keyVerifier makes the actual
+// signature verification with the public key extracted from the leaf certificate.
+func NewX509SignatureVerifier(keyVerifier X509KeyVerifierFunc, opts ...X509Opt) *X509SignatureVerifier {
+	return &X509SignatureVerifier{keyVerifier: keyVerifier, opts: opts}
+}
+
+// Verify verifies JWS signature.
+func (v *X509SignatureVerifier) Verify(joseHeaders Headers, _, signingInput, signature []byte) error {
+	if v.keyVerifier == nil {
+		return errors.New("x509 key verifier is not defined")
+	}
+
+	pubKey, err := joseHeaders.X509PublicKey(v.opts...)
+	if err != nil {
+		return err
+	}
+
+	return v.keyVerifier(pubKey, joseHeaders, signingInput, signature)
+}
@@ -0,0 +1,291 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jose
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha1" //nolint:gosec
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
+)
+
+const p256Size = 32
+
+func TestX509SignatureVerifier(t *testing.T) {
+	rootKey, root := newTestCertificate(t, "root", nil, nil)
+	leafKey, leaf := newTestCertificate(t, "leaf", root, rootKey)
+	_, otherRoot := newTestCertificate(t, "other root", nil, nil)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(root)
+
+	x5c := []interface{}{
+		base64.StdEncoding.EncodeToString(leaf.Raw),
+		base64.StdEncoding.EncodeToString(root.Raw),
+	}
+
+	thumbprint := sha256.Sum256(leaf.Raw)
+	x5tS256 := base64.RawURLEncoding.EncodeToString(thumbprint[:])
+
+	jwsCompact := signES256(t, leafKey, Headers{
+		HeaderAlgorithm:                   "ES256",
+		HeaderX509CertificateChain:        x5c,
+		HeaderX509CertificateDigestSha256: x5tS256,
+	})
+
+	t.Run("success - key from x5c", func(t *testing.T) {
+		jws, err := ParseJWS(jwsCompact, NewX509SignatureVerifier(verifyES256))
+		require.NoError(t, err)
+		require.Equal(t, []byte("payload"), jws.Payload)
+	})
+
+	t.Run("success - key from x5c with verified chain", func(t *testing.T) {
+		jws, err := ParseJWS(jwsCompact, NewX509SignatureVerifier(verifyES256, WithX509Roots(roots)))
+		require.NoError(t, err)
+		require.Equal(t, []byte("payload"), jws.Payload)
+	})
+
+	t.Run("error - chain is not trusted", func(t *testing.T) {
+		otherRoots := x509.NewCertPool()
+		otherRoots.AddCert(otherRoot)
+
+		_, err := ParseJWS(jwsCompact, NewX509SignatureVerifier(verifyES256, WithX509Roots(otherRoots)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "verify x5c certificate chain")
+	})
+
+	t.Run("error - signed by other key", func(t *testing.T) {
+		otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		otherJWS := signES256(t, otherKey, Headers{
+			HeaderAlgorithm:            "ES256",
+			HeaderX509CertificateChain: x5c,
+		})
+
+		_, err = ParseJWS(otherJWS, NewX509SignatureVerifier(verifyES256, WithX509Roots(roots)))
+		require.EqualError(t, err, "ecdsa: invalid signature")
+	})
+
+	t.Run("error - key verifier is not defined", func(t *testing.T) {
+		_, err := ParseJWS(jwsCompact, NewX509SignatureVerifier(nil))
+		require.EqualError(t, err, "x509 key verifier is not defined")
+	})
+}
+
+func TestHeaders_X509PublicKey(t *testing.T) {
+	rootKey, root := newTestCertificate(t, "root", nil, nil)
+	_, leaf := newTestCertificate(t, "leaf", root, rootKey)
+
+	x5c := []interface{}{base64.StdEncoding.EncodeToString(leaf.Raw)}
+
+	sha256Sum := sha256.Sum256(leaf.Raw)
+	sha1Sum := sha1.Sum(leaf.Raw) //nolint:gosec
+
+	t.Run("success - thumbprints match", func(t *testing.T) {
+		pubKey, err := Headers{
+			HeaderX509CertificateChain:        x5c,
+			HeaderX509CertificateDigestSha256: base64.RawURLEncoding.EncodeToString(sha256Sum[:]),
+			HeaderX509CertificateDigestSha1:   base64.RawURLEncoding.EncodeToString(sha1Sum[:]),
+		}.X509PublicKey()
+		require.NoError(t, err)
+		require.Equal(t, leaf.PublicKey, pubKey.Key)
+		require.Equal(t, "EC", pubKey.Kty)
+		require.Len(t, pubKey.Certificates, 1)
+	})
+
+	t.Run("success - intermediate from x5c chain", func(t *testing.T) {
+		intermediateKey, intermediate := newTestCertificate(t, "intermediate", root, rootKey)
+		_, leafOfIntermediate := newTestCertificate(t, "leaf", intermediate, intermediateKey)
+
+		roots := x509.NewCertPool()
+		roots.AddCert(root)
+
+		pubKey, err := Headers{
+			HeaderX509CertificateChain: []interface{}{
+				base64.StdEncoding.EncodeToString(leafOfIntermediate.Raw),
+				base64.StdEncoding.EncodeToString(intermediate.Raw),
+			},
+		}.X509PublicKey(WithX509Roots(roots))
+		require.NoError(t, err)
+		require.Equal(t, leafOfIntermediate.PublicKey, pubKey.Key)
+	})
+
+	t.Run("error - x5t#S256 mismatch", func(t *testing.T) {
+		_, err := Headers{
+			HeaderX509CertificateChain:        x5c,
+			HeaderX509CertificateDigestSha256: base64.RawURLEncoding.EncodeToString(sha1Sum[:]),
+		}.X509PublicKey()
+		require.EqualError(t, err, "x5t#S256 JOSE header does not match x5c leaf certificate")
+	})
+
+	t.Run("error - x5t mismatch", func(t *testing.T) {
+		_, err := Headers{
+			HeaderX509CertificateChain:      x5c,
+			HeaderX509CertificateDigestSha1: base64.RawURLEncoding.EncodeToString(sha256Sum[:]),
+		}.X509PublicKey()
+		require.EqualError(t, err, "x5t JOSE header does not match x5c leaf certificate")
+	})
+
+	t.Run("error - invalid thumbprint", func(t *testing.T) {
+		_, err := Headers{
+			HeaderX509CertificateChain:        x5c,
+			HeaderX509CertificateDigestSha256: 777,
+		}.X509PublicKey()
+		require.EqualError(t, err, "x5t#S256 JOSE header is not a string")
+
+		_, err = Headers{
+			HeaderX509CertificateChain:        x5c,
+			HeaderX509CertificateDigestSha256: "!!!",
+		}.X509PublicKey()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decode x5t#S256 JOSE header")
+	})
+
+	t.Run("error - invalid x5c", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			headers Headers
+			err     string
+		}{
+			{name: "missing", headers: Headers{}, err: "x5c JOSE header is not present"},
+			{
+				name:    "not an array",
+				headers: Headers{HeaderX509CertificateChain: "cert"},
+				err:     "x5c JOSE header is not a non-empty array",
+			},
+			{
+				name:    "empty",
+				headers: Headers{HeaderX509CertificateChain: []interface{}{}},
+				err:     "x5c JOSE header is not a non-empty array",
+			},
+			{
+				name:    "not a string",
+				headers: Headers{HeaderX509CertificateChain: []interface{}{777}},
+				err:     "x5c certificate 0 is not a string",
+			},
+			{
+				name:    "not base64",
+				headers: Headers{HeaderX509CertificateChain: []interface{}{"!!!"}},
+				err:     "decode x5c certificate 0",
+			},
+			{
+				name:    "not a certificate",
+				headers: Headers{HeaderX509CertificateChain: []interface{}{"Y2VydA=="}},
+				err:     "parse x5c certificate 0",
+			},
+		}
+
+		for _, tc := range tests {
+			tc := tc
+
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := tc.headers.X509PublicKey()
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.err)
+			})
+		}
+	})
+}
+
+func newTestCertificate(t *testing.T, name string, parent *x509.Certificate,
+	parentKey *ecdsa.PrivateKey) (*ecdsa.PrivateKey, *x509.Certificate) {
+	t.Helper()
+
+	privKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  name != "leaf",
+	}
+
+	if parent == nil {
+		parent, parentKey = template, privKey
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &privKey.PublicKey, parentKey)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return privKey, cert
+}
+
+type es256Signer struct {
+	privKey *ecdsa.PrivateKey
+	headers Headers
+}
+
+func (s es256Signer) Sign(data []byte) ([]byte, error) {
+	digest := sha256.Sum256(data)
+
+	r, sig, err := ecdsa.Sign(rand.Reader, s.privKey, digest[:])
+	if err != nil {
+		return nil, err
+	}
+
+	signature := make([]byte, 2*p256Size)
+	r.FillBytes(signature[:p256Size])
+	sig.FillBytes(signature[p256Size:])
+
+	return signature, nil
+}
+
+func (s es256Signer) Headers() Headers {
+	return s.headers
+}
+
+func signES256(t *testing.T, privKey *ecdsa.PrivateKey, headers Headers) string {
+	t.Helper()
+
+	jws, err := NewJWS(nil, nil, []byte("payload"), es256Signer{privKey: privKey, headers: headers})
+	require.NoError(t, err)
+
+	jwsCompact, err := jws.SerializeCompact(false)
+	require.NoError(t, err)
+
+	return jwsCompact
+}
+
+func verifyES256(pubKey *jwk.JWK, _ Headers, signingInput, signature []byte) error {
+	ecdsaPubKey, ok := pubKey.Key.(*ecdsa.PublicKey)
+	if !ok || len(signature) != 2*p256Size {
+		return errors.New("ecdsa: invalid public key or signature")
+	}
+
+	digest := sha256.Sum256(signingInput)
+	r := new(big.Int).SetBytes(signature[:p256Size])
+	s := new(big.Int).SetBytes(signature[p256Size:])
+
+	if !ecdsa.Verify(ecdsaPubKey, digest[:], r, s) {
+		return errors.New("ecdsa: invalid signature")
+	}
+
+	return nil
+}
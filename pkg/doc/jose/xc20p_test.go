@@ -0,0 +1,140 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jose
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// XC20P JWE built with the XChaCha20-Poly1305 implementation of golang.org/x/crypto, the CEK is wrapped with
+// A256GCMKW. The CEK, nonce and plaintext are the ones of draft-irtf-cfrg-xchacha-03 section A.3.1, so that
+// the ciphertext is the one of the draft (the tag differs as the AAD is the JWE protected header).
+const (
+	xc20pKEK       = "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"
+	xc20pKID       = "xc20p-test-key"
+	xc20pPlaintext = "Ladies and Gentlemen of the class of '99: If I could offer you only one tip for the future, " +
+		"sunscreen would be it."
+
+	xc20pCompactJWE = "eyJhbGciOiJBMjU2R0NNS1ciLCJlbmMiOiJYQzIwUCIsIml2Ijoib0tHaW82U2xwcWVvcWFxciIsImtpZCI6Inhj" +
+		"MjBwLXRlc3Qta2V5IiwidGFnIjoiem5YeHFSUTFqbWJsWkJQbWJIaDFlQSJ9" +
+		".Zpn-rsFOhDjq7A1Yi_dOUeA9y4MGItT7BJe8HeM2654" +
+		".QEFCQ0RFRkdISUpLTE1OT1BRUlNUVVZX" +
+		".vW0XnT6D1DuVdleUk8DpOVcqFwAlK_rMvtKQLCE5bLtzHH8bC0qmRAvzqC9O2n45rmTGcIxUwhbLlrcuEhO0Ui-Mm6QNtdlFsRtpuYLB" +
+		"u54_P6wrw2lIj3ayODVl0__5IflmTJdjfal2iBL2FcaLE7Uu" +
+		".cHBHlAzltAnj28BP-lAqRw"
+)
+
+func xc20pKeySource(t *testing.T) SymmetricKeySource {
+	t.Helper()
+
+	kek, err := hex.DecodeString(xc20pKEK)
+	require.NoError(t, err)
+
+	return func(kid string) ([]byte, error) {
+		require.Equal(t, xc20pKID, kid)
+
+		return kek, nil
+	}
+}
+
+func TestXC20P_Decrypt(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		jwe, err := Deserialize(xc20pCompactJWE)
+		require.NoError(t, err)
+		require.Len(t, jwe.IV, chacha20poly1305.NonceSizeX)
+
+		pt, err := NewJWEDecrypt(nil, nil, nil, WithSymmetricKeySource(xc20pKeySource(t))).Decrypt(jwe)
+		require.NoError(t, err)
+		require.Equal(t, xc20pPlaintext, string(pt))
+	})
+
+	t.Run("error - invalid IV size", func(t *testing.T) {
+		for _, ivSize := range []int{0, gcmIVSize, chacha20poly1305.NonceSizeX - 1, chacha20poly1305.NonceSizeX + 1} {
+			jwe, err := Deserialize(xc20pCompactJWE)
+			require.NoError(t, err)
+
+			// keep the original nonce bytes so that only the IV size is wrong.
+			jwe.IV = (jwe.IV + "\x00")[:ivSize]
+
+			_, err = NewJWEDecrypt(nil, nil, nil, WithSymmetricKeySource(xc20pKeySource(t))).Decrypt(jwe)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "jwedecrypt: invalid IV size for 'XC20P'")
+		}
+	})
+
+	t.Run("error - tampered ciphertext", func(t *testing.T) {
+		jwe, err := Deserialize(xc20pCompactJWE)
+		require.NoError(t, err)
+
+		ct := []byte(jwe.Ciphertext)
+		ct[0] ^= 1
+		jwe.Ciphertext = string(ct)
+
+		_, err = NewJWEDecrypt(nil, nil, nil, WithSymmetricKeySource(xc20pKeySource(t))).Decrypt(jwe)
+		require.Error(t, err)
+	})
+}
+
+func TestXC20P_Encrypt(t *testing.T) {
+	kek, err := hex.DecodeString(xc20pKEK)
+	require.NoError(t, err)
+
+	jweEncrypter, err := NewJWEEncryptWithSymmetricKey(XC20P, "", "", &SymmetricKey{KID: xc20pKID, Key: kek})
+	require.NoError(t, err)
+
+	jwe, err := jweEncrypter.Encrypt([]byte(xc20pPlaintext))
+	require.NoError(t, err)
+	require.Len(t, jwe.IV, chacha20poly1305.NonceSizeX)
+
+	serializedJWE, err := jwe.CompactSerialize(json.Marshal)
+	require.NoError(t, err)
+
+	t.Run("decrypt with golang.org/x/crypto", func(t *testing.T) {
+		parsedJWE, err := Deserialize(serializedJWE)
+		require.NoError(t, err)
+
+		iv, err := base64.RawURLEncoding.DecodeString(parsedJWE.ProtectedHeaders[HeaderIV].(string))
+		require.NoError(t, err)
+
+		tag, err := base64.RawURLEncoding.DecodeString(parsedJWE.ProtectedHeaders[HeaderTag].(string))
+		require.NoError(t, err)
+
+		block, err := aes.NewCipher(kek)
+		require.NoError(t, err)
+
+		gcm, err := cipher.NewGCM(block)
+		require.NoError(t, err)
+
+		cek, err := gcm.Open(nil, iv, []byte(parsedJWE.Recipients[0].EncryptedKey+string(tag)), nil)
+		require.NoError(t, err)
+
+		aead, err := chacha20poly1305.NewX(cek)
+		require.NoError(t, err)
+
+		pt, err := aead.Open(nil, []byte(parsedJWE.IV), []byte(parsedJWE.Ciphertext+parsedJWE.Tag),
+			[]byte(parsedJWE.OrigProtectedHders))
+		require.NoError(t, err)
+		require.Equal(t, xc20pPlaintext, string(pt))
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		parsedJWE, err := Deserialize(serializedJWE)
+		require.NoError(t, err)
+
+		pt, err := NewJWEDecrypt(nil, nil, nil, WithSymmetricKeySource(xc20pKeySource(t))).Decrypt(parsedJWE)
+		require.NoError(t, err)
+		require.Equal(t, xc20pPlaintext, string(pt))
+	})
+}
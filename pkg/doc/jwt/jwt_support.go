@@ -8,10 +8,13 @@ package jwt
 
 import (
 	"crypto"
+	"crypto/ecdsa"
 	"crypto/ed25519"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
 	"errors"
+	"math/big"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
 )
@@ -138,6 +141,116 @@ func (v RS256Verifier) Verify(joseHeaders jose.Headers, _, signingInput, signatu
 	return rsa.VerifyPKCS1v15(v.pubKey, crypto.SHA256, hashed, signature)
 }
 
+// ES256Signer is a Jose compliant signer.
+type ES256Signer struct {
+	privKey       *ecdsa.PrivateKey
+	headers       map[string]interface{}
+	deterministic bool
+}
+
+// NewES256Signer returns a Jose compliant signer that can be passed as a signer to jwt.NewSigned().
+func NewES256Signer(privKey *ecdsa.PrivateKey, headers map[string]interface{}) *ES256Signer {
+	return &ES256Signer{
+		privKey: privKey,
+		headers: prepareJWSHeaders(headers, signatureES256),
+	}
+}
+
+// NewDeterministicES256Signer returns a Jose compliant signer with the deterministic nonce of RFC 6979,
+// the same key and data always give the same signature (e.g. for reproducible test vectors and fixtures).
+// The signature is computed with math/big arithmetic, which is not constant time.
+func NewDeterministicES256Signer(privKey *ecdsa.PrivateKey, headers map[string]interface{}) *ES256Signer {
+	return &ES256Signer{
+		privKey:       privKey,
+		headers:       prepareJWSHeaders(headers, signatureES256),
+		deterministic: true,
+	}
+}
+
+// Sign data. The signature is in IEEE P1363 (R || S) format as required by JWS.
+func (s ES256Signer) Sign(data []byte) ([]byte, error) {
+	hashed := sha256Sum(data)
+
+	var (
+		r, sig *big.Int
+		err    error
+	)
+
+	if s.deterministic {
+		r, sig, err = signRFC6979(s.privKey, hashed)
+	} else {
+		r, sig, err = ecdsa.Sign(rand.Reader, s.privKey, hashed)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	keySize := (s.privKey.Curve.Params().BitSize + 7) / 8 //nolint:gomnd
+
+	signature := make([]byte, 2*keySize)
+	r.FillBytes(signature[:keySize])
+	sig.FillBytes(signature[keySize:])
+
+	return signature, nil
+}
+
+// Headers returns the signer's headers map.
+func (s ES256Signer) Headers() jose.Headers {
+	return s.headers
+}
+
+// ES256Verifier is a Jose compliant verifier.
+type ES256Verifier struct {
+	pubKey *ecdsa.PublicKey
+}
+
+// NewES256Verifier returns a Jose compliant verifier that can be passed as a verifier option to jwt.Parse().
+func NewES256Verifier(pubKey *ecdsa.PublicKey) *ES256Verifier {
+	return &ES256Verifier{pubKey: pubKey}
+}
+
+// Verify signingInput against the signature. It also validates that joseHeaders includes the right alg.
+func (v ES256Verifier) Verify(joseHeaders jose.Headers, _, signingInput, signature []byte) error {
+	alg, ok := joseHeaders.Algorithm()
+	if !ok {
+		return errors.New("alg is not defined")
+	}
+
+	if alg != signatureES256 {
+		return errors.New("alg is not ES256")
+	}
+
+	// a key of other curve would make ES256 verify signatures of a different algorithm
+	if v.pubKey == nil || v.pubKey.Curve == nil || v.pubKey.Curve.Params().Name != elliptic.P256().Params().Name {
+		return errors.New("ECDSA key can't be used with alg ES256")
+	}
+
+	keySize := (v.pubKey.Curve.Params().BitSize + 7) / 8 //nolint:gomnd
+
+	if len(signature) != 2*keySize {
+		return errors.New("invalid signature size")
+	}
+
+	r := new(big.Int).SetBytes(signature[:keySize])
+	s := new(big.Int).SetBytes(signature[keySize:])
+
+	if !ecdsa.Verify(v.pubKey, sha256Sum(signingInput), r, s) {
+		return errors.New("signature doesn't match")
+	}
+
+	return nil
+}
+
+func sha256Sum(data []byte) []byte {
+	hash := crypto.SHA256.New()
+
+	// hash.Write never returns an error.
+	_, _ = hash.Write(data) //nolint:errcheck
+
+	return hash.Sum(nil)
+}
+
 func verifyEd25519(jws string, pubKey ed25519.PublicKey) error {
 	v, err := NewEd25519Verifier(pubKey)
 	if err != nil {
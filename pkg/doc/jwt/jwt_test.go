@@ -7,11 +7,15 @@ SPDX-License-Identifier: Apache-2.0
 package jwt
 
 import (
+	"crypto/ecdsa"
 	"crypto/ed25519"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
 	"encoding/base64"
+	"encoding/hex"
 	"fmt"
+	"math/big"
 	"strings"
 	"testing"
 	"time"
@@ -74,6 +78,77 @@ func TestNewSigned(t *testing.T) {
 		err = verifyRS256(jws, pubKey)
 		r.NoError(err)
 	})
+
+	t.Run("Create JWS signed by ES256", func(t *testing.T) {
+		r := require.New(t)
+
+		privKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		r.NoError(err)
+
+		pubKey := &privKey.PublicKey
+
+		token, err := NewSigned(claims, nil, NewES256Signer(privKey, nil))
+		r.NoError(err)
+		jws, err := token.Serialize(false)
+		require.NoError(t, err)
+
+		var parsedClaims CustomClaim
+		err = verifyES256ViaGoJose(jws, pubKey, &parsedClaims)
+		r.NoError(err)
+		r.Equal(*claims, parsedClaims)
+
+		_, _, err = Parse(jws, WithSignatureVerifier(NewES256Verifier(pubKey)))
+		r.NoError(err)
+	})
+}
+
+func TestES256Verifier_Verify(t *testing.T) {
+	privKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	signer := NewES256Signer(privKey, nil)
+	v := NewES256Verifier(&privKey.PublicKey)
+
+	msg := []byte("test message")
+
+	signature, err := signer.Sign(msg)
+	require.NoError(t, err)
+	require.Len(t, signature, 64)
+
+	t.Run("success", func(t *testing.T) {
+		require.NoError(t, v.Verify(signer.Headers(), nil, msg, signature))
+	})
+
+	t.Run("error - alg is not defined", func(t *testing.T) {
+		err := v.Verify(jose.Headers{}, nil, msg, signature)
+		require.EqualError(t, err, "alg is not defined")
+	})
+
+	t.Run("error - alg is not ES256", func(t *testing.T) {
+		err := v.Verify(jose.Headers{jose.HeaderAlgorithm: "EdDSA"}, nil, msg, signature)
+		require.EqualError(t, err, "alg is not ES256")
+	})
+
+	t.Run("error - invalid signature size", func(t *testing.T) {
+		err := v.Verify(signer.Headers(), nil, msg, signature[1:])
+		require.EqualError(t, err, "invalid signature size")
+	})
+
+	t.Run("error - signature doesn't match", func(t *testing.T) {
+		err := v.Verify(signer.Headers(), nil, []byte("other message"), signature)
+		require.EqualError(t, err, "signature doesn't match")
+	})
+
+	t.Run("error - key is not P-256", func(t *testing.T) {
+		p384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+		require.NoError(t, err)
+
+		p384Signature, err := NewES256Signer(p384Key, nil).Sign(msg)
+		require.NoError(t, err)
+
+		err = NewES256Verifier(&p384Key.PublicKey).Verify(signer.Headers(), nil, msg, p384Signature)
+		require.EqualError(t, err, "ECDSA key can't be used with alg ES256")
+	})
 }
 
 func TestNewUnsecured(t *testing.T) {
@@ -515,6 +590,93 @@ func verifyRS256ViaGoJose(jws string, pubKey *rsa.PublicKey, claims interface{})
 	return nil
 }
 
+func verifyES256ViaGoJose(jws string, pubKey *ecdsa.PublicKey, claims interface{}) error {
+	jwtToken, err := jwt.ParseSigned(jws)
+	if err != nil {
+		return fmt.Errorf("parse VC from signed JWS: %w", err)
+	}
+
+	if err = jwtToken.Claims(pubKey, claims); err != nil {
+		return fmt.Errorf("verify JWT signature: %w", err)
+	}
+
+	return nil
+}
+
+// RFC 6979 appendix A.2.5 (ECDSA, 256 bits (prime field), SHA-256).
+const (
+	rfc6979PrivKey = "c9afa9d845ba75166b5c215767b1d6934e50c3db36e89b127b8a622b120f6721"
+	rfc6979PubKeyX = "60fed4ba255a9d31c961eb74c6356d68c049b8923b61fa6ce669622e60f29fb6"
+	rfc6979PubKeyY = "7903fe1008b8bc99a41ae9e95628bc64f2f1b20c2d7e9f5177a3c294d4462299"
+)
+
+func TestNewDeterministicES256Signer(t *testing.T) {
+	d, ok := new(big.Int).SetString(rfc6979PrivKey, 16)
+	require.True(t, ok)
+
+	x, ok := new(big.Int).SetString(rfc6979PubKeyX, 16)
+	require.True(t, ok)
+
+	y, ok := new(big.Int).SetString(rfc6979PubKeyY, 16)
+	require.True(t, ok)
+
+	privKey := &ecdsa.PrivateKey{PublicKey: ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}, D: d}
+	signer := NewDeterministicES256Signer(privKey, nil)
+
+	t.Run("RFC 6979 test vectors", func(t *testing.T) {
+		tests := []struct {
+			msg       string
+			signature string
+		}{
+			{
+				msg: "sample",
+				signature: "efd48b2aacb6a8fd1140dd9cd45e81d69d2c877b56aaf991c34d0ea84eaf3716" +
+					"f7cb1c942d657c41d436c7a1b6e29f65f3e900dbb9aff4064dc4ab2f843acda8",
+			},
+			{
+				msg: "test",
+				signature: "f1abb023518351cd71d881567b1ea663ed3efcf6c5132b354f28d3b0b7d38367" +
+					"019f4113742a2b14bd25926b49c649155f267e60d3814b4c0cc84250e46f0083",
+			},
+		}
+
+		for _, tc := range tests {
+			signature, err := signer.Sign([]byte(tc.msg))
+			require.NoError(t, err)
+			require.Equal(t, tc.signature, hex.EncodeToString(signature))
+
+			require.NoError(t, NewES256Verifier(&privKey.PublicKey).Verify(signer.Headers(), nil,
+				[]byte(tc.msg), signature))
+		}
+	})
+
+	t.Run("reproducible JWT", func(t *testing.T) {
+		token, err := NewSigned(createClaims(), nil, signer)
+		require.NoError(t, err)
+
+		jws, err := token.Serialize(false)
+		require.NoError(t, err)
+
+		token2, err := NewSigned(createClaims(), nil, NewDeterministicES256Signer(privKey, nil))
+		require.NoError(t, err)
+
+		jws2, err := token2.Serialize(false)
+		require.NoError(t, err)
+
+		require.Equal(t, jws, jws2)
+		require.NoError(t, verifyES256ViaGoJose(jws, &privKey.PublicKey, createClaims()))
+	})
+
+	t.Run("error - key is not of 256 bits curve", func(t *testing.T) {
+		p384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+		require.NoError(t, err)
+
+		signature, err := NewDeterministicES256Signer(p384Key, nil).Sign([]byte("test"))
+		require.EqualError(t, err, "deterministic ECDSA signature requires a 256 bits curve")
+		require.Nil(t, signature)
+	})
+}
+
 func getUnmarshallableMap() map[string]interface{} {
 	return map[string]interface{}{"error": map[chan int]interface{}{make(chan int): 6}}
 }
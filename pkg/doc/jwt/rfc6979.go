@@ -0,0 +1,100 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jwt
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"math/big"
+)
+
+// signRFC6979 signs the SHA-256 hash with the deterministic nonce of RFC 6979 section 3.2.
+func signRFC6979(privKey *ecdsa.PrivateKey, hash []byte) (*big.Int, *big.Int, error) {
+	params := privKey.Curve.Params()
+	n := params.N
+
+	if n.BitLen() != 8*sha256.Size {
+		return nil, nil, errors.New("deterministic ECDSA signature requires a 256 bits curve")
+	}
+
+	e := new(big.Int).SetBytes(hash)
+	nonces := newRFC6979Nonces(privKey.D, n, hash)
+
+	for {
+		k := nonces.next()
+
+		r, _ := privKey.Curve.ScalarBaseMult(k.Bytes()) //nolint:staticcheck // the nonce is not random
+		r.Mod(r, n)
+
+		if r.Sign() == 0 {
+			continue
+		}
+
+		s := new(big.Int).Mul(privKey.D, r)
+		s.Add(s, e)
+		s.Mul(s, new(big.Int).ModInverse(k, n))
+		s.Mod(s, n)
+
+		if s.Sign() != 0 {
+			return r, s, nil
+		}
+	}
+}
+
+// rfc6979Nonces generates the nonce candidates of RFC 6979 section 3.2 for a 256 bits curve order and SHA-256.
+type rfc6979Nonces struct {
+	n     *big.Int
+	k, v  []byte
+	first bool
+}
+
+func newRFC6979Nonces(d, n *big.Int, hash []byte) *rfc6979Nonces {
+	x := d.FillBytes(make([]byte, sha256.Size))
+	h1 := new(big.Int).Mod(new(big.Int).SetBytes(hash), n).FillBytes(make([]byte, sha256.Size))
+
+	v := bytes.Repeat([]byte{0x01}, sha256.Size)
+	k := make([]byte, sha256.Size)
+
+	k = hmacSHA256(k, v, []byte{0x00}, x, h1)
+	v = hmacSHA256(k, v)
+	k = hmacSHA256(k, v, []byte{0x01}, x, h1)
+	v = hmacSHA256(k, v)
+
+	return &rfc6979Nonces{n: n, k: k, v: v, first: true}
+}
+
+// next returns the next nonce candidate in [1, n-1].
+func (g *rfc6979Nonces) next() *big.Int {
+	for {
+		if !g.first {
+			g.k = hmacSHA256(g.k, g.v, []byte{0x00})
+			g.v = hmacSHA256(g.k, g.v)
+		}
+
+		g.first = false
+		g.v = hmacSHA256(g.k, g.v)
+
+		nonce := new(big.Int).SetBytes(g.v)
+		if nonce.Sign() > 0 && nonce.Cmp(g.n) < 0 {
+			return nonce
+		}
+	}
+}
+
+func hmacSHA256(key []byte, data ...[]byte) []byte {
+	h := hmac.New(sha256.New, key)
+
+	for _, d := range data {
+		// hash.Write never returns an error.
+		_, _ = h.Write(d) //nolint:errcheck
+	}
+
+	return h.Sum(nil)
+}
@@ -28,6 +28,9 @@ const (
 
 	// signatureRS256 defines RS256 alg.
 	signatureRS256 = "RS256"
+
+	// signatureES256 defines ES256 alg.
+	signatureES256 = "ES256"
 )
 
 // KeyResolver resolves public key based on what and kid.
@@ -7,10 +7,22 @@ SPDX-License-Identifier: Apache-2.0
 package ld
 
 import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/bluele/gcache"
 	jsonld "github.com/piprate/json-gold/ld"
+	"golang.org/x/time/rate"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/ldcontext"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/ldcontext/embed"
@@ -21,6 +33,18 @@ import (
 // ErrContextNotFound is returned when JSON-LD context document is not found in the underlying storage.
 var ErrContextNotFound = errors.New("context not found")
 
+// ErrLoadTimeout is returned when the remote JSON-LD context document is not loaded within the load timeout.
+var ErrLoadTimeout = errors.New("context load timeout")
+
+// ErrContextIntegrity is returned when the JSON-LD context document doesn't match its pinned integrity hash.
+var ErrContextIntegrity = errors.New("context integrity check failed")
+
+// integrityPrefix is the prefix of the Subresource Integrity (SRI) style hash of the context document.
+const integrityPrefix = "sha256-"
+
+// maxHostLimiters bounds the number of per-host rate limiters, the least recently used one is evicted.
+const maxHostLimiters = 1024
+
 // provider contains dependencies for the JSON-LD document loader.
 type provider interface {
 	JSONLDContextStore() ld.ContextStore
@@ -31,6 +55,33 @@ type provider interface {
 type DocumentLoader struct {
 	store                ld.ContextStore
 	remoteDocumentLoader jsonld.DocumentLoader
+	loadTimeout          time.Duration
+
+	hostRateLimit rate.Limit
+	hostBurst     int
+
+	// integrity maps context URL to its pinned integrity hash
+	integrity map[string]string
+
+	// cache holds the fetched remote documents if their number is bounded, otherwise they are saved in the store
+	cache gcache.Cache
+
+	hits      uint64
+	misses    uint64
+	evictions uint64
+
+	// hostLimiters holds the rate limiters of the recently used hosts, as the hosts come from untrusted contexts
+	hostLimiters gcache.Cache
+
+	mu       sync.Mutex
+	inflight map[string]*inflightLoad
+}
+
+// inflightLoad is a remote document load shared by the concurrent loads of the same URL.
+type inflightLoad struct {
+	done chan struct{}
+	rd   *jsonld.RemoteDocument
+	err  error
 }
 
 // NewDocumentLoader returns a new DocumentLoader instance.
@@ -48,6 +99,16 @@ func NewDocumentLoader(ctx provider, opts ...DocumentLoaderOpts) (*DocumentLoade
 		opts[i](loaderOpts)
 	}
 
+	if loaderOpts.hostRateLimit > 0 && loaderOpts.hostBurst < 1 {
+		return nil, fmt.Errorf("host rate limit burst %d must be at least 1", loaderOpts.hostBurst)
+	}
+
+	for u, integrity := range loaderOpts.integrity {
+		if err := validateIntegrity(integrity); err != nil {
+			return nil, fmt.Errorf("context integrity of %s: %w", u, err)
+		}
+	}
+
 	contexts, err := prepareContexts(ctx.JSONLDRemoteProviderStore(), loaderOpts)
 	if err != nil {
 		return nil, fmt.Errorf("get contexts: %w", err)
@@ -59,10 +120,28 @@ func NewDocumentLoader(ctx provider, opts ...DocumentLoaderOpts) (*DocumentLoade
 		return nil, fmt.Errorf("import contexts: %w", err)
 	}
 
-	return &DocumentLoader{
+	loader := &DocumentLoader{
 		store:                store,
 		remoteDocumentLoader: loaderOpts.remoteDocumentLoader,
-	}, nil
+		loadTimeout:          loaderOpts.loadTimeout,
+		hostRateLimit:        loaderOpts.hostRateLimit,
+		hostBurst:            loaderOpts.hostBurst,
+		integrity:            loaderOpts.integrity,
+	}
+
+	if loaderOpts.maxCachedContexts > 0 {
+		loader.cache = gcache.New(loaderOpts.maxCachedContexts).LRU().
+			EvictedFunc(func(interface{}, interface{}) {
+				atomic.AddUint64(&loader.evictions, 1)
+			}).
+			Build()
+	}
+
+	if loaderOpts.hostRateLimit != 0 {
+		loader.hostLimiters = gcache.New(maxHostLimiters).LRU().Build()
+	}
+
+	return loader, nil
 }
 
 func prepareContexts(providerStore ld.RemoteProviderStore, opts *documentLoaderOpts) ([]ldcontext.Document, error) {
@@ -99,12 +178,14 @@ func prepareContexts(providerStore ld.RemoteProviderStore, opts *documentLoaderO
 // LoadDocument resolves JSON-LD context document by document URL (u) either from storage or from remote URL.
 // If document is not found in the storage and remote DocumentLoader is not specified, ErrContextNotFound is returned.
 func (l *DocumentLoader) LoadDocument(u string) (*jsonld.RemoteDocument, error) {
-	rd, err := l.store.Get(u)
+	rd, err := l.getDocument(u)
 	if err != nil {
 		if !errors.Is(err, storage.ErrDataNotFound) {
 			return nil, fmt.Errorf("load document: %w", err)
 		}
 
+		atomic.AddUint64(&l.misses, 1)
+
 		if l.remoteDocumentLoader == nil { // fetching from the remote URL is disabled
 			return nil, ErrContextNotFound
 		}
@@ -112,15 +193,170 @@ func (l *DocumentLoader) LoadDocument(u string) (*jsonld.RemoteDocument, error)
 		return l.loadDocumentFromURL(u)
 	}
 
+	atomic.AddUint64(&l.hits, 1)
+
+	if err = l.checkIntegrity(u, rd); err != nil {
+		return nil, err
+	}
+
 	return rd, nil
 }
 
+// checkIntegrity checks the document against the integrity hash pinned for its URL, if any.
+func (l *DocumentLoader) checkIntegrity(u string, rd *jsonld.RemoteDocument) error {
+	expected, ok := l.integrity[u]
+	if !ok {
+		return nil
+	}
+
+	return CheckContextIntegrity(rd, expected)
+}
+
+// ContextIntegrity returns the integrity hash of the JSON-LD context document in the form sha256-<base64>.
+// The hash is computed over the compact JSON serialization of the document with object keys sorted,
+// so it doesn't depend on the formatting of the document served by the remote URL.
+func ContextIntegrity(rd *jsonld.RemoteDocument) (string, error) {
+	var buf bytes.Buffer
+
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+
+	if err := enc.Encode(rd.Document); err != nil {
+		return "", fmt.Errorf("marshal context document: %w", err)
+	}
+
+	hash := sha256.Sum256(bytes.TrimSuffix(buf.Bytes(), []byte("\n")))
+
+	return integrityPrefix + base64.StdEncoding.EncodeToString(hash[:]), nil
+}
+
+// CheckContextIntegrity checks that the JSON-LD context document matches the integrity hash
+// in the form sha256-<base64>, see ContextIntegrity.
+func CheckContextIntegrity(rd *jsonld.RemoteDocument, expected string) error {
+	if err := validateIntegrity(expected); err != nil {
+		return err
+	}
+
+	actual, err := ContextIntegrity(rd)
+	if err != nil {
+		return err
+	}
+
+	if actual != expected {
+		return fmt.Errorf("%w: %s has integrity %s, expected %s", ErrContextIntegrity, rd.DocumentURL, actual, expected)
+	}
+
+	return nil
+}
+
+func validateIntegrity(integrity string) error {
+	if !strings.HasPrefix(integrity, integrityPrefix) {
+		return fmt.Errorf("integrity %s is not a %s hash", integrity, strings.TrimSuffix(integrityPrefix, "-"))
+	}
+
+	hash, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(integrity, integrityPrefix))
+	if err != nil || len(hash) != sha256.Size {
+		return fmt.Errorf("integrity %s is not a base64 encoded SHA-256 hash", integrity)
+	}
+
+	return nil
+}
+
+// getDocument gets the document from the store or from the cache of fetched remote documents.
+func (l *DocumentLoader) getDocument(u string) (*jsonld.RemoteDocument, error) {
+	rd, err := l.store.Get(u)
+	if err == nil || !errors.Is(err, storage.ErrDataNotFound) || l.cache == nil {
+		return rd, err
+	}
+
+	v, cacheErr := l.cache.Get(u)
+	if cacheErr != nil {
+		return nil, err
+	}
+
+	return v.(*jsonld.RemoteDocument), nil //nolint:forcetypeassert
+}
+
+// Stats holds the counters of the document loader.
+type Stats struct {
+	// Hits is the number of documents found in the store or in the cache of fetched remote documents.
+	Hits uint64
+	// Misses is the number of documents not found in the store and in the cache.
+	Misses uint64
+	// Evictions is the number of fetched remote documents evicted from the cache.
+	Evictions uint64
+}
+
+// Stats returns the counters of the document loader.
+func (l *DocumentLoader) Stats() Stats {
+	return Stats{
+		Hits:      atomic.LoadUint64(&l.hits),
+		Misses:    atomic.LoadUint64(&l.misses),
+		Evictions: atomic.LoadUint64(&l.evictions),
+	}
+}
+
+// loadDocumentFromURL fetches the document from the remote URL. Concurrent loads of the same URL share one fetch.
 func (l *DocumentLoader) loadDocumentFromURL(u string) (*jsonld.RemoteDocument, error) {
-	rd, err := l.remoteDocumentLoader.LoadDocument(u)
+	l.mu.Lock()
+
+	if load, ok := l.inflight[u]; ok {
+		l.mu.Unlock()
+
+		<-load.done
+
+		return load.rd, load.err
+	}
+
+	if l.inflight == nil {
+		l.inflight = make(map[string]*inflightLoad)
+	}
+
+	load := &inflightLoad{done: make(chan struct{})}
+	l.inflight[u] = load
+
+	l.mu.Unlock()
+
+	// the document might have been saved by the load which has just finished
+	if rd, err := l.getDocument(u); err == nil {
+		load.rd = rd
+	} else {
+		load.rd, load.err = l.fetchAndSaveDocument(u)
+	}
+
+	l.mu.Lock()
+	delete(l.inflight, u)
+	l.mu.Unlock()
+
+	close(load.done)
+
+	return load.rd, load.err
+}
+
+func (l *DocumentLoader) fetchAndSaveDocument(u string) (*jsonld.RemoteDocument, error) {
+	err := l.waitHostRateLimit(u)
+	if err != nil {
+		return nil, fmt.Errorf("load remote context document: %w", err)
+	}
+
+	rd, err := l.loadRemoteDocument(u)
 	if err != nil {
 		return nil, fmt.Errorf("load remote context document: %w", err)
 	}
 
+	// the tampered document is neither cached nor saved
+	if err = l.checkIntegrity(u, rd); err != nil {
+		return nil, err
+	}
+
+	if l.cache != nil {
+		if err = l.cache.Set(u, rd); err != nil {
+			return nil, fmt.Errorf("cache loaded document: %w", err)
+		}
+
+		return rd, nil
+	}
+
 	if err = l.store.Put(u, rd); err != nil {
 		return nil, fmt.Errorf("save loaded document: %w", err)
 	}
@@ -128,10 +364,99 @@ func (l *DocumentLoader) loadDocumentFromURL(u string) (*jsonld.RemoteDocument,
 	return rd, nil
 }
 
+// waitHostRateLimit blocks until the rate limit of the URL host allows one more fetch.
+// The wait is bounded by the load timeout if it is set.
+func (l *DocumentLoader) waitHostRateLimit(u string) error {
+	if l.hostRateLimit == 0 {
+		return nil
+	}
+
+	var host string
+
+	if parsed, err := url.Parse(u); err == nil {
+		host = parsed.Host
+	}
+
+	limiter, err := l.hostLimiter(host)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	if l.loadTimeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, l.loadTimeout)
+		defer cancel()
+	}
+
+	if err := limiter.Wait(ctx); err != nil {
+		return fmt.Errorf("%w: %s not loaded within %s due to rate limit of host %s: %s",
+			ErrLoadTimeout, u, l.loadTimeout, host, err.Error())
+	}
+
+	return nil
+}
+
+// hostLimiter returns the rate limiter of the host. The limiter of a host evicted from the bounded set of limiters
+// is created again with a full burst.
+func (l *DocumentLoader) hostLimiter(host string) (*rate.Limiter, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if v, err := l.hostLimiters.Get(host); err == nil {
+		return v.(*rate.Limiter), nil //nolint:forcetypeassert
+	}
+
+	limiter := rate.NewLimiter(l.hostRateLimit, l.hostBurst)
+
+	if err := l.hostLimiters.Set(host, limiter); err != nil {
+		return nil, fmt.Errorf("set rate limiter of host %s: %w", host, err)
+	}
+
+	return limiter, nil
+}
+
+// loadRemoteDocument loads the document with the remote loader, bounded by the load timeout if it is set.
+// The remote loader has no cancellation, so the timed out fetch is left to finish in the background.
+func (l *DocumentLoader) loadRemoteDocument(u string) (*jsonld.RemoteDocument, error) {
+	if l.loadTimeout <= 0 {
+		return l.remoteDocumentLoader.LoadDocument(u)
+	}
+
+	type result struct {
+		rd  *jsonld.RemoteDocument
+		err error
+	}
+
+	ch := make(chan result, 1)
+
+	go func() {
+		rd, err := l.remoteDocumentLoader.LoadDocument(u)
+		ch <- result{rd: rd, err: err}
+	}()
+
+	timer := time.NewTimer(l.loadTimeout)
+	defer timer.Stop()
+
+	select {
+	case r := <-ch:
+		return r.rd, r.err
+	case <-timer.C:
+		return nil, fmt.Errorf("%w: %s not loaded within %s", ErrLoadTimeout, u, l.loadTimeout)
+	}
+}
+
 type documentLoaderOpts struct {
 	remoteDocumentLoader jsonld.DocumentLoader
 	extraContexts        []ldcontext.Document
 	remoteProviders      []RemoteProvider
+	loadTimeout          time.Duration
+	hostRateLimit        rate.Limit
+	hostBurst            int
+	maxCachedContexts    int
+	integrity            map[string]string
 }
 
 // DocumentLoaderOpts configures DocumentLoader during creation.
@@ -145,6 +470,45 @@ func WithRemoteDocumentLoader(loader jsonld.DocumentLoader) DocumentLoaderOpts {
 	}
 }
 
+// WithLoadTimeout bounds each fetch of the context document with the remote loader.
+// ErrLoadTimeout is returned if the document is not loaded in time. By default, there is no timeout.
+func WithLoadTimeout(d time.Duration) DocumentLoaderOpts {
+	return func(opts *documentLoaderOpts) {
+		opts.loadTimeout = d
+	}
+}
+
+// WithHostRateLimit limits the rate of context document fetches with the remote loader per URL host,
+// allowing bursts of up to burst fetches (at least 1 if limit is positive). The wait for the rate limit counts
+// towards the load timeout. The limiters of the 1024 most recently used hosts are kept. By default, there is
+// no limit.
+func WithHostRateLimit(limit rate.Limit, burst int) DocumentLoaderOpts {
+	return func(opts *documentLoaderOpts) {
+		opts.hostRateLimit = limit
+		opts.hostBurst = burst
+	}
+}
+
+// WithMaxCachedContexts bounds the number of context documents fetched with the remote loader to n.
+// The fetched documents are kept in memory instead of the underlying storage, and the least recently used
+// document is evicted when the bound is exceeded. Embedded, extra and remote provider contexts are never evicted.
+// By default, the fetched documents are saved in the underlying storage without a bound.
+func WithMaxCachedContexts(n int) DocumentLoaderOpts {
+	return func(opts *documentLoaderOpts) {
+		opts.maxCachedContexts = n
+	}
+}
+
+// WithContextIntegrity pins the Subresource Integrity (SRI) style hashes of context documents, mapping
+// context URL to the expected hash in the form sha256-<base64> (see ContextIntegrity).
+// A pinned context document which doesn't match its hash is rejected with ErrContextIntegrity,
+// whether it is fetched from the remote URL or found in the underlying storage.
+func WithContextIntegrity(integrity map[string]string) DocumentLoaderOpts {
+	return func(opts *documentLoaderOpts) {
+		opts.integrity = integrity
+	}
+}
+
 // WithExtraContexts sets the extra contexts (in addition to embedded) for preloading into the underlying storage.
 func WithExtraContexts(contexts ...ldcontext.Document) DocumentLoaderOpts {
 	return func(opts *documentLoaderOpts) {
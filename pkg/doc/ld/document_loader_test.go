@@ -7,13 +7,22 @@ SPDX-License-Identifier: Apache-2.0
 package ld_test
 
 import (
+	"crypto/sha256"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	jsonld "github.com/piprate/json-gold/ld"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/ld"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/ldcontext"
@@ -133,6 +142,14 @@ func TestNewDocumentLoader(t *testing.T) {
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "import contexts")
 	})
+
+	t.Run("Fail with host rate limit burst less than 1", func(t *testing.T) {
+		loader, err := ld.NewDocumentLoader(createMockProvider(), ld.WithHostRateLimit(rate.Every(time.Second), 0))
+
+		require.Nil(t, loader)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "host rate limit burst 0 must be at least 1")
+	})
 }
 
 func TestLoadDocument(t *testing.T) {
@@ -227,6 +244,58 @@ func TestLoadDocument(t *testing.T) {
 		require.Contains(t, err.Error(), "load remote context document")
 	})
 
+	t.Run("Fetch remote context document within load timeout", func(t *testing.T) {
+		store := mockldstore.NewMockContextStore()
+		store.Store.ErrGet = storage.ErrDataNotFound
+
+		loader, err := ld.NewDocumentLoader(createMockProvider(withContextStore(store)),
+			ld.WithRemoteDocumentLoader(&mockRemoteDocumentLoader{}), ld.WithLoadTimeout(time.Minute))
+		require.NotNil(t, loader)
+		require.NoError(t, err)
+
+		rd, err := loader.LoadDocument("https://example.com/context.jsonld")
+
+		require.NotNil(t, rd)
+		require.NoError(t, err)
+	})
+
+	t.Run("Fail to load remote context document from slow server", func(t *testing.T) {
+		release := make(chan struct{})
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-release:
+			case <-r.Context().Done():
+			}
+
+			w.Header().Set("Content-Type", "application/ld+json")
+			_, _ = w.Write([]byte(sampleJSONLDContext)) //nolint:errcheck
+		}))
+
+		defer server.Close()
+		defer close(release)
+
+		store := mockldstore.NewMockContextStore()
+		store.Store.ErrGet = storage.ErrDataNotFound
+
+		loader, err := ld.NewDocumentLoader(createMockProvider(withContextStore(store)),
+			ld.WithRemoteDocumentLoader(jsonld.NewDefaultDocumentLoader(http.DefaultClient)),
+			ld.WithLoadTimeout(50*time.Millisecond))
+		require.NotNil(t, loader)
+		require.NoError(t, err)
+
+		start := time.Now()
+
+		rd, err := loader.LoadDocument(server.URL + "/context.jsonld")
+
+		require.Nil(t, rd)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, ld.ErrLoadTimeout))
+		require.Contains(t, err.Error(), "load remote context document: context load timeout")
+		require.Less(t, time.Since(start), 5*time.Second)
+		require.NotContains(t, store.Store.Store, server.URL+"/context.jsonld")
+	})
+
 	t.Run("Fail to save fetched remote document", func(t *testing.T) {
 		store := mockldstore.NewMockContextStore()
 
@@ -246,6 +315,273 @@ func TestLoadDocument(t *testing.T) {
 	})
 }
 
+func TestLoadDocumentConcurrently(t *testing.T) {
+	t.Run("Concurrent loads of the same context share one fetch", func(t *testing.T) {
+		const loads = 20
+
+		store := mockldstore.NewMockContextStore()
+		remoteLoader := &countingRemoteDocumentLoader{delay: 50 * time.Millisecond}
+
+		loader, err := ld.NewDocumentLoader(createMockProvider(withContextStore(store)),
+			ld.WithRemoteDocumentLoader(remoteLoader))
+		require.NoError(t, err)
+
+		var wg sync.WaitGroup
+
+		errs := make(chan error, loads)
+
+		for i := 0; i < loads; i++ {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				rd, e := loader.LoadDocument("https://example.com/context.jsonld")
+				if e == nil && rd == nil {
+					e = errors.New("document is nil")
+				}
+
+				errs <- e
+			}()
+		}
+
+		wg.Wait()
+		close(errs)
+
+		for e := range errs {
+			require.NoError(t, e)
+		}
+
+		require.EqualValues(t, 1, atomic.LoadInt32(&remoteLoader.calls))
+	})
+
+	t.Run("Failed fetch is shared and retried by the next load", func(t *testing.T) {
+		store := mockldstore.NewMockContextStore()
+		remoteLoader := &countingRemoteDocumentLoader{err: errors.New("load error")}
+
+		loader, err := ld.NewDocumentLoader(createMockProvider(withContextStore(store)),
+			ld.WithRemoteDocumentLoader(remoteLoader))
+		require.NoError(t, err)
+
+		_, err = loader.LoadDocument("https://example.com/context.jsonld")
+		require.EqualError(t, err, "load remote context document: load error")
+
+		_, err = loader.LoadDocument("https://example.com/context.jsonld")
+		require.Error(t, err)
+		require.EqualValues(t, 2, atomic.LoadInt32(&remoteLoader.calls))
+	})
+}
+
+func TestLoadDocumentWithHostRateLimit(t *testing.T) {
+	store := mockldstore.NewMockContextStore()
+	remoteLoader := &countingRemoteDocumentLoader{}
+
+	loader, err := ld.NewDocumentLoader(createMockProvider(withContextStore(store)),
+		ld.WithRemoteDocumentLoader(remoteLoader),
+		ld.WithHostRateLimit(rate.Every(time.Hour), 1),
+		ld.WithLoadTimeout(50*time.Millisecond))
+	require.NoError(t, err)
+
+	_, err = loader.LoadDocument("https://example.com/context1.jsonld")
+	require.NoError(t, err)
+
+	// other host has its own limit
+	_, err = loader.LoadDocument("https://example.org/context1.jsonld")
+	require.NoError(t, err)
+
+	// already loaded context is not fetched again
+	_, err = loader.LoadDocument("https://example.com/context1.jsonld")
+	require.NoError(t, err)
+
+	_, err = loader.LoadDocument("https://example.com/context2.jsonld")
+	require.Error(t, err)
+	require.True(t, errors.Is(err, ld.ErrLoadTimeout))
+	require.Contains(t, err.Error(), "due to rate limit of host example.com")
+
+	require.EqualValues(t, 2, atomic.LoadInt32(&remoteLoader.calls))
+}
+
+func TestLoadDocumentWithHostRateLimitManyHosts(t *testing.T) {
+	store := mockldstore.NewMockContextStore()
+	remoteLoader := &countingRemoteDocumentLoader{}
+
+	loader, err := ld.NewDocumentLoader(createMockProvider(withContextStore(store)),
+		ld.WithRemoteDocumentLoader(remoteLoader),
+		ld.WithHostRateLimit(rate.Every(time.Hour), 1),
+		ld.WithLoadTimeout(50*time.Millisecond))
+	require.NoError(t, err)
+
+	const hosts = 1100
+
+	for i := 0; i < hosts; i++ {
+		_, err = loader.LoadDocument(fmt.Sprintf("https://host%d.example.com/context.jsonld", i))
+		require.NoError(t, err)
+	}
+
+	// the limiter of the most recently used host is kept
+	_, err = loader.LoadDocument(fmt.Sprintf("https://host%d.example.com/other.jsonld", hosts-1))
+	require.Error(t, err)
+	require.True(t, errors.Is(err, ld.ErrLoadTimeout))
+
+	require.EqualValues(t, hosts, atomic.LoadInt32(&remoteLoader.calls))
+}
+
+func TestLoadDocumentWithMaxCachedContexts(t *testing.T) {
+	const (
+		contextA = "https://example.com/a.jsonld"
+		contextB = "https://example.com/b.jsonld"
+		contextC = "https://example.com/c.jsonld"
+	)
+
+	store := mockldstore.NewMockContextStore()
+	remoteLoader := &countingRemoteDocumentLoader{}
+
+	loader, err := ld.NewDocumentLoader(createMockProvider(withContextStore(store)),
+		ld.WithRemoteDocumentLoader(remoteLoader),
+		ld.WithMaxCachedContexts(2))
+	require.NoError(t, err)
+
+	load := func(u string) {
+		t.Helper()
+
+		rd, e := loader.LoadDocument(u)
+		require.NoError(t, e)
+		require.NotNil(t, rd)
+	}
+
+	load(contextA)
+	load(contextB)
+	load(contextA) // a becomes the most recently used
+	require.EqualValues(t, 2, atomic.LoadInt32(&remoteLoader.calls))
+
+	load(contextC) // b is evicted
+	require.EqualValues(t, 3, atomic.LoadInt32(&remoteLoader.calls))
+
+	load(contextA)
+	require.EqualValues(t, 3, atomic.LoadInt32(&remoteLoader.calls))
+
+	load(contextB) // c is evicted
+	require.EqualValues(t, 4, atomic.LoadInt32(&remoteLoader.calls))
+
+	load(contextA)
+	require.EqualValues(t, 4, atomic.LoadInt32(&remoteLoader.calls))
+
+	require.Equal(t, ld.Stats{Hits: 3, Misses: 4, Evictions: 2}, loader.Stats())
+
+	// fetched documents are not saved in the store
+	_, err = store.Get(contextA)
+	require.ErrorIs(t, err, storage.ErrDataNotFound)
+
+	// embedded contexts are never evicted
+	for _, c := range embed.Contexts {
+		load(c.URL)
+	}
+
+	require.EqualValues(t, 4, atomic.LoadInt32(&remoteLoader.calls))
+	require.Equal(t, ld.Stats{Hits: uint64(3 + len(embed.Contexts)), Misses: 4, Evictions: 2}, loader.Stats())
+}
+
+func TestLoadDocumentWithContextIntegrity(t *testing.T) {
+	const (
+		contextURL  = "https://example.com/context.jsonld"
+		tamperedURL = "https://example.com/tampered.jsonld"
+	)
+
+	document, err := jsonld.DocumentFromReader(strings.NewReader(sampleJSONLDContext))
+	require.NoError(t, err)
+
+	integrity, err := ld.ContextIntegrity(&jsonld.RemoteDocument{Document: document})
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(integrity, "sha256-"))
+
+	// formatting of the document doesn't change its integrity
+	compacted, err := jsonld.DocumentFromReader(strings.NewReader(strings.Join(strings.Fields(sampleJSONLDContext), "")))
+	require.NoError(t, err)
+
+	compactedIntegrity, err := ld.ContextIntegrity(&jsonld.RemoteDocument{Document: compacted})
+	require.NoError(t, err)
+	require.Equal(t, integrity, compactedIntegrity)
+
+	t.Run("Matching extra and remote contexts", func(t *testing.T) {
+		remoteLoader := &countingRemoteDocumentLoader{}
+
+		loader, err := ld.NewDocumentLoader(createMockProvider(),
+			ld.WithExtraContexts(ldcontext.Document{URL: contextURL, Content: json.RawMessage(sampleJSONLDContext)}),
+			ld.WithRemoteDocumentLoader(remoteLoader),
+			ld.WithContextIntegrity(map[string]string{
+				contextURL:                          integrity,
+				"https://example.com/remote.jsonld": integrity,
+			}))
+		require.NoError(t, err)
+
+		rd, err := loader.LoadDocument(contextURL)
+		require.NoError(t, err)
+		require.NotNil(t, rd)
+
+		rd, err = loader.LoadDocument("https://example.com/remote.jsonld")
+		require.NoError(t, err)
+		require.NotNil(t, rd)
+
+		// not pinned context is not checked
+		_, err = loader.LoadDocument(embed.Contexts[0].URL)
+		require.NoError(t, err)
+	})
+
+	t.Run("Tampered stored context is rejected", func(t *testing.T) {
+		tampered := strings.Replace(sampleJSONLDContext, "http://xmlns.com/foaf/0.1/name", "https://evil.com/name", 1)
+
+		loader, err := ld.NewDocumentLoader(createMockProvider(),
+			ld.WithExtraContexts(ldcontext.Document{URL: tamperedURL, Content: json.RawMessage(tampered)}),
+			ld.WithContextIntegrity(map[string]string{tamperedURL: integrity}))
+		require.NoError(t, err)
+
+		rd, err := loader.LoadDocument(tamperedURL)
+		require.ErrorIs(t, err, ld.ErrContextIntegrity)
+		require.Contains(t, err.Error(), "expected "+integrity)
+		require.Nil(t, rd)
+	})
+
+	t.Run("Tampered remote context is rejected and not saved", func(t *testing.T) {
+		store := mockldstore.NewMockContextStore()
+
+		loader, err := ld.NewDocumentLoader(createMockProvider(withContextStore(store)),
+			ld.WithRemoteDocumentLoader(&countingRemoteDocumentLoader{}),
+			ld.WithContextIntegrity(map[string]string{
+				tamperedURL: "sha256-" + base64.StdEncoding.EncodeToString(make([]byte, sha256.Size)),
+			}))
+		require.NoError(t, err)
+
+		rd, err := loader.LoadDocument(tamperedURL)
+		require.ErrorIs(t, err, ld.ErrContextIntegrity)
+		require.Nil(t, rd)
+
+		_, err = store.Get(tamperedURL)
+		require.ErrorIs(t, err, storage.ErrDataNotFound)
+	})
+
+	t.Run("Invalid integrity", func(t *testing.T) {
+		for _, invalid := range []string{"sha384-" + integrity[len("sha256-"):], "sha256-invalid", "sha256-YWJj"} {
+			_, err := ld.NewDocumentLoader(createMockProvider(),
+				ld.WithContextIntegrity(map[string]string{contextURL: invalid}))
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "context integrity of "+contextURL)
+		}
+	})
+}
+
+func TestDocumentLoaderStats(t *testing.T) {
+	loader, err := ld.NewDocumentLoader(createMockProvider())
+	require.NoError(t, err)
+
+	_, err = loader.LoadDocument(embed.Contexts[0].URL)
+	require.NoError(t, err)
+
+	_, err = loader.LoadDocument("https://example.com/context.jsonld")
+	require.ErrorIs(t, err, ld.ErrContextNotFound)
+
+	require.Equal(t, ld.Stats{Hits: 1, Misses: 1}, loader.Stats())
+}
+
 func assertContextInStore(t *testing.T, store storage.Store, url, value string) {
 	t.Helper()
 
@@ -307,6 +643,29 @@ func (m *mockRemoteDocumentLoader) LoadDocument(string) (*jsonld.RemoteDocument,
 	}, nil
 }
 
+type countingRemoteDocumentLoader struct {
+	calls int32
+	delay time.Duration
+	err   error
+}
+
+func (m *countingRemoteDocumentLoader) LoadDocument(u string) (*jsonld.RemoteDocument, error) {
+	atomic.AddInt32(&m.calls, 1)
+
+	time.Sleep(m.delay)
+
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	document, err := jsonld.DocumentFromReader(strings.NewReader(sampleJSONLDContext))
+	if err != nil {
+		return nil, err
+	}
+
+	return &jsonld.RemoteDocument{DocumentURL: u, Document: document}, nil
+}
+
 type mockRemoteProvider struct {
 	Documents   []ldcontext.Document
 	ErrContexts error
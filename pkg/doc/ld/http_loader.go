@@ -0,0 +1,151 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ld
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	jsonld "github.com/piprate/json-gold/ld"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+)
+
+var logger = log.New("aries-framework/doc/ld")
+
+// ErrTooManyRedirects is returned when fetching the context document follows a redirect loop or more redirects
+// than allowed by the HTTP document loader.
+var ErrTooManyRedirects = errors.New("too many redirects")
+
+const (
+	defaultMaxRedirects = 10
+	acceptContextHeader = "application/ld+json, application/json;q=0.9, */*;q=0.1"
+)
+
+// HTTPDocumentLoader fetches JSON-LD context documents from their HTTP(S) URLs. Unlike the default loader
+// of json-gold, it never reads local files, and it bounds the redirects and the duration of each request.
+// It can be used as the remote loader of DocumentLoader (see WithRemoteDocumentLoader).
+type HTTPDocumentLoader struct {
+	httpClient *http.Client
+}
+
+type httpLoaderOpts struct {
+	maxRedirects   int
+	requestTimeout time.Duration
+	transport      http.RoundTripper
+}
+
+// HTTPLoaderOpt configures HTTPDocumentLoader during creation.
+type HTTPLoaderOpt func(opts *httpLoaderOpts)
+
+// WithMaxRedirects bounds the number of redirects followed to fetch a context document to n, 0 disables
+// redirects. A longer redirect chain or a redirect loop is rejected with ErrTooManyRedirects.
+// The default is 10 redirects.
+func WithMaxRedirects(n int) HTTPLoaderOpt {
+	return func(opts *httpLoaderOpts) {
+		opts.maxRedirects = n
+	}
+}
+
+// WithRequestTimeout bounds each fetch of a context document, including the redirects and reading the body.
+// By default, there is no timeout.
+func WithRequestTimeout(d time.Duration) HTTPLoaderOpt {
+	return func(opts *httpLoaderOpts) {
+		opts.requestTimeout = d
+	}
+}
+
+// WithHTTPTransport sets the transport of the HTTP client, e.g. with custom TLS configuration.
+// By default, http.DefaultTransport is used.
+func WithHTTPTransport(transport http.RoundTripper) HTTPLoaderOpt {
+	return func(opts *httpLoaderOpts) {
+		opts.transport = transport
+	}
+}
+
+// NewHTTPDocumentLoader returns a new HTTPDocumentLoader instance.
+func NewHTTPDocumentLoader(opts ...HTTPLoaderOpt) *HTTPDocumentLoader {
+	loaderOpts := &httpLoaderOpts{maxRedirects: defaultMaxRedirects}
+
+	for _, opt := range opts {
+		opt(loaderOpts)
+	}
+
+	return &HTTPDocumentLoader{
+		httpClient: &http.Client{
+			Transport:     loaderOpts.transport,
+			Timeout:       loaderOpts.requestTimeout,
+			CheckRedirect: checkRedirect(loaderOpts.maxRedirects),
+		},
+	}
+}
+
+func checkRedirect(maxRedirects int) func(req *http.Request, via []*http.Request) error {
+	return func(req *http.Request, via []*http.Request) error {
+		for _, r := range via {
+			if r.URL.String() == req.URL.String() {
+				return fmt.Errorf("%w: redirect loop to %s", ErrTooManyRedirects, req.URL)
+			}
+		}
+
+		// via holds the requests made so far, the first of them is not a redirect
+		if len(via) > maxRedirects {
+			return fmt.Errorf("%w: more than %d redirects", ErrTooManyRedirects, maxRedirects)
+		}
+
+		return nil
+	}
+}
+
+// LoadDocument fetches the context document from its HTTP(S) URL.
+func (l *HTTPDocumentLoader) LoadDocument(u string) (*jsonld.RemoteDocument, error) {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return nil, fmt.Errorf("parse context URL: %w", err)
+	}
+
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return nil, fmt.Errorf("context URL %s is not an HTTP(S) URL", u)
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("new request: %w", err)
+	}
+
+	req.Header.Set("Accept", acceptContextHeader)
+
+	resp, err := l.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch context %s: %w", u, err)
+	}
+
+	defer func() {
+		e := resp.Body.Close()
+		if e != nil {
+			logger.Warnf("failed to close response body: %s", e.Error())
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch context %s: response status code: %d", u, resp.StatusCode)
+	}
+
+	doc, err := jsonld.DocumentFromReader(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read context %s: %w", u, err)
+	}
+
+	return &jsonld.RemoteDocument{
+		DocumentURL: resp.Request.URL.String(),
+		Document:    doc,
+	}, nil
+}
@@ -0,0 +1,111 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ld_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/ld"
+)
+
+func TestHTTPDocumentLoader(t *testing.T) {
+	// /context serves the context, /hop/{n} redirects to /hop/{n-1} and /hop/0 to /context,
+	// /loop/a and /loop/b redirect to each other
+	mux := http.NewServeMux()
+	mux.HandleFunc("/context", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/ld+json")
+		_, err := w.Write([]byte(sampleJSONLDContext))
+		require.NoError(t, err)
+	})
+	mux.HandleFunc("/hop/", func(w http.ResponseWriter, r *http.Request) {
+		n, err := strconv.Atoi(r.URL.Path[len("/hop/"):])
+		require.NoError(t, err)
+
+		if n == 0 {
+			http.Redirect(w, r, "/context", http.StatusFound)
+
+			return
+		}
+
+		http.Redirect(w, r, fmt.Sprintf("/hop/%d", n-1), http.StatusFound)
+	})
+	mux.HandleFunc("/loop/a", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/loop/b", http.StatusFound)
+	})
+	mux.HandleFunc("/loop/b", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/loop/a", http.StatusFound)
+	})
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(time.Second):
+		case <-r.Context().Done():
+		}
+	})
+	mux.HandleFunc("/missing", http.NotFound)
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	t.Run("success", func(t *testing.T) {
+		rd, err := ld.NewHTTPDocumentLoader().LoadDocument(server.URL + "/context")
+		require.NoError(t, err)
+		require.Equal(t, server.URL+"/context", rd.DocumentURL)
+		require.NotNil(t, rd.Document)
+	})
+
+	t.Run("success - redirects within the limit", func(t *testing.T) {
+		// /hop/2 -> /hop/1 -> /hop/0 -> /context
+		rd, err := ld.NewHTTPDocumentLoader(ld.WithMaxRedirects(3)).LoadDocument(server.URL + "/hop/2")
+		require.NoError(t, err)
+		require.Equal(t, server.URL+"/context", rd.DocumentURL)
+	})
+
+	t.Run("error - redirect chain over the limit", func(t *testing.T) {
+		_, err := ld.NewHTTPDocumentLoader(ld.WithMaxRedirects(3)).LoadDocument(server.URL + "/hop/3")
+		require.Error(t, err)
+		require.True(t, errors.Is(err, ld.ErrTooManyRedirects))
+		require.Contains(t, err.Error(), "more than 3 redirects")
+	})
+
+	t.Run("error - redirects disabled", func(t *testing.T) {
+		_, err := ld.NewHTTPDocumentLoader(ld.WithMaxRedirects(0)).LoadDocument(server.URL + "/hop/0")
+		require.Error(t, err)
+		require.True(t, errors.Is(err, ld.ErrTooManyRedirects))
+	})
+
+	t.Run("error - redirect loop", func(t *testing.T) {
+		_, err := ld.NewHTTPDocumentLoader().LoadDocument(server.URL + "/loop/a")
+		require.Error(t, err)
+		require.True(t, errors.Is(err, ld.ErrTooManyRedirects))
+		require.Contains(t, err.Error(), "redirect loop to "+server.URL+"/loop/a")
+	})
+
+	t.Run("error - request timeout", func(t *testing.T) {
+		_, err := ld.NewHTTPDocumentLoader(ld.WithRequestTimeout(50 * time.Millisecond)).
+			LoadDocument(server.URL + "/slow")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "Client.Timeout exceeded")
+	})
+
+	t.Run("error - not found", func(t *testing.T) {
+		_, err := ld.NewHTTPDocumentLoader().LoadDocument(server.URL + "/missing")
+		require.EqualError(t, err, "fetch context "+server.URL+"/missing: response status code: 404")
+	})
+
+	t.Run("error - not an HTTP URL", func(t *testing.T) {
+		_, err := ld.NewHTTPDocumentLoader().LoadDocument("/etc/passwd")
+		require.EqualError(t, err, "context URL /etc/passwd is not an HTTP(S) URL")
+	})
+}
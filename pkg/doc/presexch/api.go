@@ -17,6 +17,7 @@ import (
 	"github.com/PaesslerAG/jsonpath"
 	"github.com/piprate/json-gold/ld"
 
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 )
 
@@ -33,6 +34,9 @@ const (
 
 	submissionProperty    = "presentation_submission"
 	descriptorMapProperty = "descriptor_map"
+
+	vpJWTClaim = "vp"
+	vcJWTClaim = "vc"
 )
 
 // PresentationSubmission is the container for the descriptor_map:
@@ -247,7 +251,7 @@ func selectVC(typelessVerifiable interface{},
 	var err error
 
 	for {
-		typelessVerifiable, err = selectByPath(builder, typelessVerifiable, mapping.Path)
+		typelessVerifiable, err = selectByPathThroughJWT(builder, typelessVerifiable, mapping.Path)
 		if err != nil {
 			return nil, fmt.Errorf("failed to select vc from submission: %w", err)
 		}
@@ -445,6 +449,36 @@ func selectByPath(builder gval.Language, vp interface{}, jsonPath string) (inter
 	return cred, nil
 }
 
+// selectByPathThroughJWT selects by path like selectByPath, but when the path traverses a JWT boundary, i.e. v is
+// a JWT-encoded presentation or credential, the path is resolved into its decoded claims. As the path may address
+// either the claims (e.g. $.vp.verifiableCredential[0]) or the embedded presentation or credential
+// (e.g. $.verifiableCredential[0]), the latter is tried if the path doesn't resolve into the claims.
+// The JWT signature isn't verified here, the selected credential is verified when it's parsed.
+func selectByPathThroughJWT(builder gval.Language, v interface{}, jsonPath string) (interface{}, error) {
+	token, ok := v.(string)
+	if !ok || jsonPath == "$" || !(jwt.IsJWS(token) || jwt.IsJWTUnsecured(token)) {
+		return selectByPath(builder, v, jsonPath)
+	}
+
+	pJWT, _, err := jwt.Parse(token, jwt.WithSignatureVerifier(&noVerifier{}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode JWT: %w", err)
+	}
+
+	selected, err := selectByPath(builder, pJWT.Payload, jsonPath)
+	if err == nil {
+		return selected, nil
+	}
+
+	for _, claim := range []string{vpJWTClaim, vcJWTClaim} {
+		if embedded, ok := pJWT.Payload[claim].(map[string]interface{}); ok {
+			return selectByPath(builder, embedded, jsonPath)
+		}
+	}
+
+	return nil, err
+}
+
 func stringsContain(s []string, val string) bool {
 	for i := range s {
 		if s[i] == val {
@@ -416,6 +416,63 @@ func TestPresentationDefinition_Match(t *testing.T) {
 		require.Equal(t, expectedNested.ID, result.Credential.ID)
 	})
 
+	t.Run("match jwt credential nested in jwt presentation", func(t *testing.T) {
+		uri := randomURI()
+		contextLoader := createTestDocumentLoader(t, uri)
+		agent := newAgent(t)
+
+		expected := newSignedJWTVC(t, agent, []string{uri})
+
+		defs := &PresentationDefinition{
+			InputDescriptors: []*InputDescriptor{{
+				ID: uuid.New().String(),
+				Schema: []*Schema{{
+					URI: fmt.Sprintf("%s#%s", verifiable.ContextID, verifiable.VCType),
+				}},
+			}},
+		}
+
+		for _, nestedPath := range []string{"$.vp.verifiableCredential[0]", "$.verifiableCredential[0]"} {
+			vp := newJWTVP(t, &PresentationSubmission{DescriptorMap: []*InputDescriptorMapping{{
+				ID:     defs.InputDescriptors[0].ID,
+				Format: "jwt_vp",
+				Path:   "$",
+				PathNested: &InputDescriptorMapping{
+					ID:     defs.InputDescriptors[0].ID,
+					Format: "jwt_vc",
+					Path:   nestedPath,
+				},
+			}}}, expected)
+
+			matched, err := defs.Match([]*verifiable.Presentation{vp}, contextLoader,
+				WithCredentialOptions(
+					verifiable.WithJSONLDDocumentLoader(contextLoader),
+					verifiable.WithPublicKeyFetcher(verifiable.NewVDRKeyResolver(agent.VDRegistry()).PublicKeyFetcher()),
+				),
+			)
+			require.NoError(t, err, nestedPath)
+			require.Len(t, matched, 1)
+			result, ok := matched[defs.InputDescriptors[0].ID]
+			require.True(t, ok)
+			require.Equal(t, expected.ID, result.Credential.ID)
+			require.Equal(t, expected.JWT, result.Credential.JWT)
+		}
+
+		vp := newJWTVP(t, &PresentationSubmission{DescriptorMap: []*InputDescriptorMapping{{
+			ID:   defs.InputDescriptors[0].ID,
+			Path: "$",
+			PathNested: &InputDescriptorMapping{
+				ID:   defs.InputDescriptors[0].ID,
+				Path: "$.vp.verifiableCredential[1]",
+			},
+		}}}, expected)
+
+		_, err := defs.Match([]*verifiable.Presentation{vp}, contextLoader,
+			WithCredentialOptions(verifiable.WithJSONLDDocumentLoader(contextLoader)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to select vc from submission")
+	})
+
 	t.Run("match with self referencing", func(t *testing.T) {
 		uri := randomURI()
 		contextLoader := createTestDocumentLoader(t, uri)
@@ -1197,6 +1254,21 @@ func newVP(t *testing.T, submission *PresentationSubmission, vcs ...*verifiable.
 	return vp
 }
 
+func newJWTVP(t *testing.T, submission *PresentationSubmission,
+	vcs ...*verifiable.Credential) *verifiable.Presentation {
+	t.Helper()
+
+	vp := newVP(t, submission, vcs...)
+
+	claims, err := vp.JWTClaims(nil, false)
+	require.NoError(t, err)
+
+	vp.JWT, err = claims.MarshalUnsecuredJWT()
+	require.NoError(t, err)
+
+	return vp
+}
+
 func toMap(t *testing.T, v interface{}) map[string]interface{} {
 	bits, err := json.Marshal(v)
 	require.NoError(t, err)
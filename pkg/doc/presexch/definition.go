@@ -372,21 +372,32 @@ func makeRequirement(requirements []*SubmissionRequirement, descriptors []*Input
 // CreateVP creates verifiable presentation.
 func (pd *PresentationDefinition) CreateVP(credentials []*verifiable.Credential,
 	documentLoader ld.DocumentLoader, opts ...verifiable.CredentialOpt) (*verifiable.Presentation, error) {
-	applicableCredentials, submission, err := presentationData(pd, credentials, documentLoader, false, opts...)
+	vp, _, err := pd.CreateVPWithMapping(credentials, documentLoader, opts...)
+
+	return vp, err
+}
+
+// CreateVPWithMapping creates verifiable presentation like CreateVP. The credential of the presentation which
+// satisfies each input descriptor is returned alongside, keyed by input descriptor ID. If several credentials
+// satisfy an input descriptor, the first one in the order of the given credentials is selected.
+func (pd *PresentationDefinition) CreateVPWithMapping(credentials []*verifiable.Credential,
+	documentLoader ld.DocumentLoader, opts ...verifiable.CredentialOpt,
+) (*verifiable.Presentation, map[string]*verifiable.Credential, error) {
+	applicableCredentials, submission, mapping, err := presentationData(pd, credentials, documentLoader, false, opts...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	vp, err := presentation(applicableCredentials...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	vp.CustomFields = verifiable.CustomFields{
 		submissionProperty: submission,
 	}
 
-	return vp, nil
+	return vp, mapping, nil
 }
 
 // CreateVPArray creates a list of verifiable presentations, with one presentation for each provided credential.
@@ -396,7 +407,7 @@ func (pd *PresentationDefinition) CreateVPArray(
 	documentLoader ld.DocumentLoader,
 	opts ...verifiable.CredentialOpt,
 ) ([]*verifiable.Presentation, *PresentationSubmission, error) {
-	applicableCredentials, submission, err := presentationData(pd, credentials, documentLoader, true, opts...)
+	applicableCredentials, submission, _, err := presentationData(pd, credentials, documentLoader, true, opts...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -421,22 +432,22 @@ func presentationData(
 	documentLoader ld.DocumentLoader,
 	separatePresentations bool,
 	opts ...verifiable.CredentialOpt,
-) ([]*verifiable.Credential, *PresentationSubmission, error) {
+) ([]*verifiable.Credential, *PresentationSubmission, map[string]*verifiable.Credential, error) {
 	if err := pd.ValidateSchema(); err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	req, err := makeRequirement(pd.SubmissionRequirements, pd.InputDescriptors)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	format, result, err := pd.applyRequirement(req, credentials, documentLoader, opts...)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
-	applicableCredentials, descriptors := merge(format, result, separatePresentations)
+	applicableCredentials, descriptors, mapping := merge(format, result, separatePresentations)
 
 	submission := &PresentationSubmission{
 		ID:            uuid.New().String(),
@@ -444,7 +455,7 @@ func presentationData(
 		DescriptorMap: descriptors,
 	}
 
-	return applicableCredentials, submission, nil
+	return applicableCredentials, submission, mapping, nil
 }
 
 func presentation(credentials ...*verifiable.Credential) (*verifiable.Presentation, error) {
@@ -1300,9 +1311,11 @@ func merge(
 	presentationFormat string,
 	setOfCredentials map[string][]*verifiable.Credential,
 	separatePresentations bool,
-) ([]*verifiable.Credential, []*InputDescriptorMapping) { //nolint:lll
+) ([]*verifiable.Credential, []*InputDescriptorMapping, map[string]*verifiable.Credential) { //nolint:lll
 	setOfCreds := make(map[string]int)
 	setOfDescriptors := make(map[string]struct{})
+	// the first credential of each descriptor
+	selected := make(map[string]*verifiable.Credential)
 
 	var (
 		result      []*verifiable.Credential
@@ -1326,6 +1339,10 @@ func merge(
 				setOfCreds[credential.ID] = len(result) - 1
 			}
 
+			if _, ok := selected[descriptorID]; !ok {
+				selected[descriptorID] = result[setOfCreds[credential.ID]]
+			}
+
 			vcFormat := FormatLDPVC
 			if credential.JWT != "" {
 				vcFormat = FormatJWTVC
@@ -1354,9 +1371,9 @@ func merge(
 		}
 	}
 
-	sort.Sort(byID(descriptors))
+	sort.Stable(byID(descriptors))
 
-	return result, descriptors
+	return result, descriptors, selected
 }
 
 type byID []*InputDescriptorMapping
@@ -2017,6 +2017,66 @@ func TestPresentationDefinition_CreateVP(t *testing.T) {
 	})
 }
 
+func TestPresentationDefinition_CreateVPWithMapping(t *testing.T) {
+	lddl := createTestJSONLDDocumentLoader(t)
+
+	degreeDescriptorID := uuid.New().String()
+	documentDescriptorID := uuid.New().String()
+
+	pd := &PresentationDefinition{
+		ID: uuid.New().String(),
+		InputDescriptors: []*InputDescriptor{{
+			ID: degreeDescriptorID,
+			Schema: []*Schema{{
+				URI: "https://example.org/examples#UniversityDegreeCredential",
+			}},
+		}, {
+			ID: documentDescriptorID,
+			Schema: []*Schema{{
+				URI: "https://example.org/examples#DocumentVerification",
+			}},
+		}},
+	}
+
+	newVC := func(ctx, typ string) *verifiable.Credential {
+		return &verifiable.Credential{
+			Context: []string{verifiable.ContextURI, ctx},
+			Types:   []string{verifiable.VCType, typ},
+			ID:      uuid.New().String(),
+		}
+	}
+
+	document := newVC("https://trustbloc.github.io/context/vc/examples-v1.jsonld", "DocumentVerification")
+	degree1 := newVC("https://www.w3.org/2018/credentials/examples/v1", "UniversityDegreeCredential")
+	degree2 := newVC("https://www.w3.org/2018/credentials/examples/v1", "UniversityDegreeCredential")
+
+	for i := 0; i < 5; i++ {
+		vp, mapping, err := pd.CreateVPWithMapping([]*verifiable.Credential{document, degree1, degree2}, lddl)
+		require.NoError(t, err)
+		require.NotNil(t, vp)
+		require.Len(t, vp.Credentials(), 3)
+
+		checkSubmission(t, vp, pd)
+		checkVP(t, vp)
+
+		require.Len(t, mapping, 2)
+		// the first of the credentials satisfying the descriptor is selected
+		require.Equal(t, degree1.ID, mapping[degreeDescriptorID].ID)
+		require.Equal(t, document.ID, mapping[documentDescriptorID].ID)
+
+		// mapped credentials are the credentials of the presentation
+		require.Contains(t, vp.Credentials(), mapping[degreeDescriptorID])
+		require.Contains(t, vp.Credentials(), mapping[documentDescriptorID])
+	}
+
+	t.Run("no credentials", func(t *testing.T) {
+		vp, mapping, err := pd.CreateVPWithMapping([]*verifiable.Credential{document}, lddl)
+		require.ErrorIs(t, err, ErrNoCredentials)
+		require.Nil(t, vp)
+		require.Nil(t, mapping)
+	})
+}
+
 func TestPresentationDefinition_CreateVPArray(t *testing.T) {
 	lddl := createTestJSONLDDocumentLoader(t)
 
@@ -0,0 +1,170 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package presexch
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// CredentialQuery is a simple query for the credentials that can satisfy an input descriptor.
+// It allows a wallet to preselect candidate credentials by type and issuer before running Match.
+type CredentialQuery struct {
+	// InputDescriptorID is the ID of the input descriptor the query is derived from.
+	InputDescriptorID string
+	// Types are the credential types that are all required.
+	Types []string
+	// Issuers are the allowed issuers, any of them satisfies the query. Empty means any issuer.
+	Issuers []string
+	// SchemaURIs are the schema URIs of the input descriptor.
+	SchemaURIs []string
+	// FieldPaths are the JSONPaths of the constrained fields. Each element lists alternative paths
+	// of a single field, any of which must be present in the credential.
+	FieldPaths [][]string
+}
+
+// ToCredentialQuery translates the presentation definition into the credential queries, one per input descriptor.
+// Credential types are taken from the "type" field constraints with "const" or "contains.const" filters,
+// issuers are taken from the "issuer" (or JWT "iss") field constraints with "const" or "enum" filters.
+// Other filters are not translated; the credentials selected by the query still have to be matched
+// against the presentation definition.
+func ToCredentialQuery(def *PresentationDefinition) ([]CredentialQuery, error) {
+	if def == nil {
+		return nil, errors.New("presentation definition is not provided")
+	}
+
+	queries := make([]CredentialQuery, 0, len(def.InputDescriptors))
+
+	for _, descriptor := range def.InputDescriptors {
+		query, err := descriptorToQuery(descriptor)
+		if err != nil {
+			return nil, fmt.Errorf("input descriptor %s: %w", descriptor.ID, err)
+		}
+
+		queries = append(queries, query)
+	}
+
+	return queries, nil
+}
+
+func descriptorToQuery(descriptor *InputDescriptor) (CredentialQuery, error) {
+	query := CredentialQuery{InputDescriptorID: descriptor.ID}
+
+	for _, schema := range descriptor.Schema {
+		query.SchemaURIs = append(query.SchemaURIs, schema.URI)
+	}
+
+	if descriptor.Constraints == nil {
+		return query, nil
+	}
+
+	for _, field := range descriptor.Constraints.Fields {
+		query.FieldPaths = append(query.FieldPaths, field.Path)
+
+		if field.Filter == nil {
+			continue
+		}
+
+		switch {
+		case hasQueryPath(field.Path, typeQueryPaths):
+			types, err := filterTypes(field.Filter)
+			if err != nil {
+				return CredentialQuery{}, fmt.Errorf("type constraint: %w", err)
+			}
+
+			query.Types = appendUnique(query.Types, types...)
+		case hasQueryPath(field.Path, issuerQueryPaths):
+			issuers, err := filterValues(field.Filter)
+			if err != nil {
+				return CredentialQuery{}, fmt.Errorf("issuer constraint: %w", err)
+			}
+
+			query.Issuers = appendUnique(query.Issuers, issuers...)
+		}
+	}
+
+	return query, nil
+}
+
+var (
+	typeQueryPaths   = []string{"$.type"}                           //nolint:gochecknoglobals
+	issuerQueryPaths = []string{"$.issuer", "$.issuer.id", "$.iss"} //nolint:gochecknoglobals
+)
+
+func hasQueryPath(paths, queryPaths []string) bool {
+	for _, p := range paths {
+		if stringsContain(queryPaths, normalizeQueryPath(p)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// normalizeQueryPath converts bracket notation to dot notation and drops the "vc" claim of JWT credentials,
+// e.g. $['vc']['type'] becomes $.type.
+func normalizeQueryPath(path string) string {
+	path = strings.NewReplacer("['", ".", "']", "", `["`, ".", `"]`, "").Replace(path)
+
+	if strings.HasPrefix(path, "$.vc.") {
+		path = "$." + strings.TrimPrefix(path, "$.vc.")
+	}
+
+	return path
+}
+
+func filterTypes(filter *Filter) ([]string, error) {
+	if filter.Const != nil {
+		return filterValues(filter)
+	}
+
+	if c, ok := filter.Contains["const"]; ok {
+		typ, ok := c.(string)
+		if !ok {
+			return nil, fmt.Errorf("%v is not a string", c)
+		}
+
+		return []string{typ}, nil
+	}
+
+	return nil, nil
+}
+
+func filterValues(filter *Filter) ([]string, error) {
+	if filter.Const != nil {
+		value, ok := filter.Const.(string)
+		if !ok {
+			return nil, fmt.Errorf("%v is not a string", filter.Const)
+		}
+
+		return []string{value}, nil
+	}
+
+	var values []string
+
+	for _, e := range filter.Enum {
+		value, ok := e.(string)
+		if !ok {
+			return nil, fmt.Errorf("%v is not a string", e)
+		}
+
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
+func appendUnique(values []string, newValues ...string) []string {
+	for _, v := range newValues {
+		if !stringsContain(values, v) {
+			values = append(values, v)
+		}
+	}
+
+	return values
+}
@@ -0,0 +1,133 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package presexch_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	. "github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
+)
+
+func TestToCredentialQuery(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		def := &PresentationDefinition{}
+
+		err := json.Unmarshal([]byte(`{
+  "id": "32f54163-7166-48f1-93d8-ff217bdb0653",
+  "input_descriptors": [
+    {
+      "id": "university_degree",
+      "schema": [{"uri": "https://www.w3.org/2018/credentials/examples/v1#UniversityDegreeCredential"}],
+      "constraints": {
+        "fields": [
+          {
+            "path": ["$.type", "$.vc.type"],
+            "filter": {"type": "array", "contains": {"const": "UniversityDegreeCredential"}}
+          },
+          {
+            "path": ["$.issuer", "$.vc.issuer", "$.iss"],
+            "filter": {"type": "string", "enum": ["did:example:university", "did:example:college"]}
+          },
+          {
+            "path": ["$.credentialSubject.degree.type", "$.vc.credentialSubject.degree.type"]
+          }
+        ]
+      }
+    },
+    {
+      "id": "driving_license",
+      "constraints": {
+        "fields": [
+          {
+            "path": ["$['vc']['type']"],
+            "filter": {"type": "array", "contains": {"const": "DrivingLicenseCredential"}}
+          },
+          {
+            "path": ["$.issuer.id"],
+            "filter": {"type": "string", "const": "did:example:dmv"}
+          },
+          {
+            "path": ["$.credentialSubject.age"],
+            "filter": {"type": "number", "minimum": 18}
+          }
+        ]
+      }
+    },
+    {
+      "id": "any_credential"
+    }
+  ]
+}`), def)
+		require.NoError(t, err)
+
+		queries, err := ToCredentialQuery(def)
+		require.NoError(t, err)
+		require.Equal(t, []CredentialQuery{
+			{
+				InputDescriptorID: "university_degree",
+				Types:             []string{"UniversityDegreeCredential"},
+				Issuers:           []string{"did:example:university", "did:example:college"},
+				SchemaURIs:        []string{"https://www.w3.org/2018/credentials/examples/v1#UniversityDegreeCredential"},
+				FieldPaths: [][]string{
+					{"$.type", "$.vc.type"},
+					{"$.issuer", "$.vc.issuer", "$.iss"},
+					{"$.credentialSubject.degree.type", "$.vc.credentialSubject.degree.type"},
+				},
+			},
+			{
+				InputDescriptorID: "driving_license",
+				Types:             []string{"DrivingLicenseCredential"},
+				Issuers:           []string{"did:example:dmv"},
+				FieldPaths: [][]string{
+					{"$['vc']['type']"},
+					{"$.issuer.id"},
+					{"$.credentialSubject.age"},
+				},
+			},
+			{
+				InputDescriptorID: "any_credential",
+			},
+		}, queries)
+	})
+
+	t.Run("error - presentation definition is not provided", func(t *testing.T) {
+		queries, err := ToCredentialQuery(nil)
+		require.EqualError(t, err, "presentation definition is not provided")
+		require.Nil(t, queries)
+	})
+
+	t.Run("error - type constraint is not a string", func(t *testing.T) {
+		queries, err := ToCredentialQuery(&PresentationDefinition{
+			InputDescriptors: []*InputDescriptor{{
+				ID: "descriptor",
+				Constraints: &Constraints{Fields: []*Field{{
+					Path:   []string{"$.type"},
+					Filter: &Filter{Contains: map[string]interface{}{"const": 1}},
+				}}},
+			}},
+		})
+		require.EqualError(t, err, "input descriptor descriptor: type constraint: 1 is not a string")
+		require.Nil(t, queries)
+	})
+
+	t.Run("error - issuer constraint is not a string", func(t *testing.T) {
+		queries, err := ToCredentialQuery(&PresentationDefinition{
+			InputDescriptors: []*InputDescriptor{{
+				ID: "descriptor",
+				Constraints: &Constraints{Fields: []*Field{{
+					Path:   []string{"$.vc.issuer"},
+					Filter: &Filter{Enum: []StrOrInt{"did:example:issuer", 1}},
+				}}},
+			}},
+		})
+		require.EqualError(t, err, "input descriptor descriptor: issuer constraint: 1 is not a string")
+		require.Nil(t, queries)
+	})
+}
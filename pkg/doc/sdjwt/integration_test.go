@@ -9,7 +9,9 @@ package sdjwt
 import (
 	"bytes"
 	"crypto"
+	"crypto/ecdsa"
 	"crypto/ed25519"
+	"crypto/elliptic"
 	"crypto/rand"
 	"encoding/json"
 	"fmt"
@@ -21,6 +23,7 @@ import (
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
 	afjwt "github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/sdjwt/common"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/sdjwt/holder"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/sdjwt/issuer"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/sdjwt/verifier"
@@ -329,6 +332,91 @@ func TestSDJWTFlow(t *testing.T) {
 	})
 }
 
+func TestSDJWTFlowES256(t *testing.T) {
+	r := require.New(t)
+
+	issuerPrivateKey, e := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	r.NoError(e)
+
+	signer := afjwt.NewES256Signer(issuerPrivateKey, nil)
+	signatureVerifier := afjwt.NewES256Verifier(&issuerPrivateKey.PublicKey)
+
+	holderPrivateKey, e := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	r.NoError(e)
+
+	holderPublicJWK, e := jwksupport.JWKFromKey(&holderPrivateKey.PublicKey)
+	r.NoError(e)
+
+	claims := map[string]interface{}{
+		"given_name": "Albert",
+		"last_name":  "Smith",
+		"email":      "albert@example.com",
+	}
+
+	const testAudience = "https://test.com/verifier"
+	const testNonce = "nonce"
+
+	// Issuer will issue SD-JWT for three claims bound to the holder public key.
+	token, e := issuer.New(testIssuer, claims, nil, signer, issuer.WithHolderPublicKey(holderPublicJWK))
+	r.NoError(e)
+
+	combinedFormatForIssuance, e := token.Serialize(false)
+	r.NoError(e)
+
+	holderClaims, e := holder.Parse(combinedFormatForIssuance, holder.WithSignatureVerifier(signatureVerifier))
+	r.NoError(e)
+	r.Len(holderClaims, 3)
+
+	bindingInfo := &holder.BindingInfo{
+		Payload: holder.BindingPayload{
+			Nonce:    testNonce,
+			Audience: testAudience,
+			IssuedAt: jwt.NewNumericDate(time.Now()),
+		},
+		Signer: afjwt.NewES256Signer(holderPrivateKey, nil),
+	}
+
+	t.Run("success - subset of claims with holder binding", func(t *testing.T) {
+		selectedDisclosures := getDisclosuresFromClaimNames([]string{"given_name", "email"}, holderClaims)
+
+		combinedFormatForPresentation, err := holder.CreatePresentation(combinedFormatForIssuance, selectedDisclosures,
+			holder.WithHolderBinding(bindingInfo))
+		r.NoError(err)
+
+		verifiedClaims, err := verifier.Parse(combinedFormatForPresentation,
+			verifier.WithSignatureVerifier(signatureVerifier),
+			verifier.WithHolderBindingRequired(true),
+			verifier.WithExpectedAudienceForHolderBinding(testAudience),
+			verifier.WithExpectedNonceForHolderBinding(testNonce))
+		r.NoError(err)
+
+		r.Equal("Albert", verifiedClaims["given_name"])
+		r.Equal("albert@example.com", verifiedClaims["email"])
+		r.NotContains(verifiedClaims, "last_name")
+	})
+
+	t.Run("error - disclosure digest is not in the SD-JWT", func(t *testing.T) {
+		otherToken, err := issuer.New(testIssuer, claims, nil, signer)
+		r.NoError(err)
+
+		otherCombinedFormatForIssuance, err := otherToken.Serialize(false)
+		r.NoError(err)
+
+		cfi := common.ParseCombinedFormatForIssuance(combinedFormatForIssuance)
+		otherCFI := common.ParseCombinedFormatForIssuance(otherCombinedFormatForIssuance)
+
+		cfp := common.CombinedFormatForPresentation{
+			SDJWT:       cfi.SDJWT,
+			Disclosures: otherCFI.Disclosures[:1],
+		}
+
+		verifiedClaims, err := verifier.Parse(cfp.Serialize(), verifier.WithSignatureVerifier(signatureVerifier))
+		r.Error(err)
+		r.Contains(err.Error(), "not found in SD-JWT disclosure digests")
+		r.Nil(verifiedClaims)
+	})
+}
+
 func createComplexClaims() map[string]interface{} {
 	claims := map[string]interface{}{
 		"sub":          "john_doe_42",
@@ -116,16 +116,7 @@ func WithLeewayForClaimsValidation(duration time.Duration) ParseOpt {
 //
 // The Verifier will not, however, learn any claim values not disclosed in the Disclosures.
 func Parse(combinedFormatForPresentation string, opts ...ParseOpt) (map[string]interface{}, error) {
-	defaultSigningAlgorithms := []string{"EdDSA", "RS256"}
-	pOpts := &parseOpts{
-		issuerSigningAlgorithms:   defaultSigningAlgorithms,
-		holderSigningAlgorithms:   defaultSigningAlgorithms,
-		leewayForClaimsValidation: jwt.DefaultLeeway,
-	}
-
-	for _, opt := range opts {
-		opt(pOpts)
-	}
+	pOpts := getParseOpts(opts)
 
 	var jwtOpts []afgjwt.ParseOpt
 	jwtOpts = append(jwtOpts,
@@ -169,7 +160,7 @@ func Parse(combinedFormatForPresentation string, opts ...ParseOpt) (map[string]i
 		return nil, err
 	}
 
-	err = verifyHolderBinding(signedJWT, cfp.HolderBinding, pOpts)
+	err = verifyHolderBinding(signedJWT.Payload, cfp.HolderBinding, pOpts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to verify holder binding: %w", err)
 	}
@@ -177,7 +168,35 @@ func Parse(combinedFormatForPresentation string, opts ...ParseOpt) (map[string]i
 	return getDisclosedClaims(cfp.Disclosures, signedJWT)
 }
 
-func verifyHolderBinding(sdJWT *afgjwt.JSONWebToken, holderBinding string, pOpts *parseOpts) error {
+// VerifyHolderBinding verifies the Holder Binding JWT using the holder public key (cnf) of the SD-JWT claims.
+// It is meant for an SD-JWT whose signature and disclosures are already verified by the caller, so only
+// the holder binding options (WithHolderBindingRequired, WithHolderSigningAlgorithms,
+// WithExpectedAudienceForHolderBinding, WithExpectedNonceForHolderBinding and WithLeewayForClaimsValidation) apply.
+func VerifyHolderBinding(sdJWTClaims map[string]interface{}, holderBinding string, opts ...ParseOpt) error {
+	err := verifyHolderBinding(sdJWTClaims, holderBinding, getParseOpts(opts))
+	if err != nil {
+		return fmt.Errorf("failed to verify holder binding: %w", err)
+	}
+
+	return nil
+}
+
+func getParseOpts(opts []ParseOpt) *parseOpts {
+	defaultSigningAlgorithms := []string{"EdDSA", "RS256", "ES256"}
+	pOpts := &parseOpts{
+		issuerSigningAlgorithms:   defaultSigningAlgorithms,
+		holderSigningAlgorithms:   defaultSigningAlgorithms,
+		leewayForClaimsValidation: jwt.DefaultLeeway,
+	}
+
+	for _, opt := range opts {
+		opt(pOpts)
+	}
+
+	return pOpts
+}
+
+func verifyHolderBinding(sdJWTClaims map[string]interface{}, holderBinding string, pOpts *parseOpts) error {
 	if pOpts.holderBindingRequired && holderBinding == "" {
 		return fmt.Errorf("holder binding is required")
 	}
@@ -187,7 +206,7 @@ func verifyHolderBinding(sdJWT *afgjwt.JSONWebToken, holderBinding string, pOpts
 		return nil
 	}
 
-	signatureVerifier, err := getSignatureVerifier(utils.CopyMap(sdJWT.Payload))
+	signatureVerifier, err := getSignatureVerifier(utils.CopyMap(sdJWTClaims))
 	if err != nil {
 		return fmt.Errorf("failed to get signature verifier from presentation claims: %w", err)
 	}
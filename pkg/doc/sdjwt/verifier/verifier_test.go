@@ -712,6 +712,71 @@ func TestHolderBinding(t *testing.T) {
 	})
 }
 
+func TestVerifyHolderBinding(t *testing.T) {
+	r := require.New(t)
+
+	_, issuerPrivateKey, e := ed25519.GenerateKey(rand.Reader)
+	r.NoError(e)
+
+	holderPubKey, holderPrivKey, e := ed25519.GenerateKey(rand.Reader)
+	r.NoError(e)
+
+	holderPublicJWK, e := jwksupport.JWKFromKey(holderPubKey)
+	r.NoError(e)
+
+	token, e := issuer.New(testIssuer, map[string]interface{}{"given_name": "Albert"}, nil,
+		afjwt.NewEd25519Signer(issuerPrivateKey), issuer.WithHolderPublicKey(holderPublicJWK))
+	r.NoError(e)
+
+	holderBinding, e := holder.CreateHolderBinding(&holder.BindingInfo{
+		Payload: holder.BindingPayload{
+			Nonce:    testNonce,
+			Audience: testAudience,
+			IssuedAt: jwt.NewNumericDate(time.Now()),
+		},
+		Signer: afjwt.NewEd25519Signer(holderPrivKey),
+	})
+	r.NoError(e)
+
+	t.Run("success", func(t *testing.T) {
+		err := VerifyHolderBinding(token.SignedJWT.Payload, holderBinding,
+			WithHolderBindingRequired(true),
+			WithExpectedAudienceForHolderBinding(testAudience),
+			WithExpectedNonceForHolderBinding(testNonce))
+		require.NoError(t, err)
+	})
+
+	t.Run("success - holder binding not required and not present", func(t *testing.T) {
+		require.NoError(t, VerifyHolderBinding(token.SignedJWT.Payload, ""))
+	})
+
+	t.Run("error - holder binding required", func(t *testing.T) {
+		err := VerifyHolderBinding(token.SignedJWT.Payload, "", WithHolderBindingRequired(true))
+		require.EqualError(t, err, "failed to verify holder binding: holder binding is required")
+	})
+
+	t.Run("error - unexpected nonce", func(t *testing.T) {
+		err := VerifyHolderBinding(token.SignedJWT.Payload, holderBinding, WithExpectedNonceForHolderBinding("other"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "nonce value 'nonce' does not match expected nonce value 'other'")
+	})
+
+	t.Run("error - signed by other key", func(t *testing.T) {
+		_, otherPrivKey, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		otherBinding, err := holder.CreateHolderBinding(&holder.BindingInfo{
+			Payload: holder.BindingPayload{Nonce: testNonce},
+			Signer:  afjwt.NewEd25519Signer(otherPrivKey),
+		})
+		require.NoError(t, err)
+
+		err = VerifyHolderBinding(token.SignedJWT.Payload, otherBinding)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to parse holder binding")
+	})
+}
+
 func TestVerifySigningAlgorithm(t *testing.T) {
 	r := require.New(t)
 
@@ -0,0 +1,58 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package proof
+
+import (
+	"reflect"
+	"sync"
+)
+
+// CanonicalDocumentCache caches the digest of the canonical form of a JSON-LD document without its proofs.
+// The canonical document doesn't depend on the proof, so when several proofs of a document are verified
+// with the same signature suite (e.g. a proof set of Ed25519Signature2018 proofs), the document is canonicalized
+// once. A cache must be used for a single document and the same JSON-LD processor options only.
+type CanonicalDocumentCache struct {
+	mu      sync.Mutex
+	digests map[canonicalDocumentKey][]byte
+}
+
+type canonicalDocumentKey struct {
+	suite signatureSuite
+	// the document is compacted before canonicalization of detached JWS proofs only
+	jws bool
+}
+
+// NewCanonicalDocumentCache creates a new CanonicalDocumentCache.
+func NewCanonicalDocumentCache() *CanonicalDocumentCache {
+	return &CanonicalDocumentCache{digests: map[canonicalDocumentKey][]byte{}}
+}
+
+// digest returns the cached document digest of the suite, or computes and caches it.
+// A nil cache or a suite of a non-comparable type (which can't be a map key) always computes the digest.
+func (c *CanonicalDocumentCache) digest(suite signatureSuite, jws bool,
+	compute func() ([]byte, error)) ([]byte, error) {
+	if c == nil || !reflect.TypeOf(suite).Comparable() {
+		return compute()
+	}
+
+	key := canonicalDocumentKey{suite: suite, jws: jws}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if digest, ok := c.digests[key]; ok {
+		return digest, nil
+	}
+
+	digest, err := compute()
+	if err != nil {
+		return nil, err
+	}
+
+	c.digests[key] = digest
+
+	return digest, nil
+}
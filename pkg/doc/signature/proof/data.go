@@ -44,11 +44,18 @@ const (
 // In case of "jws", verify data is built as JSON Web Signature (JWS) with detached payload.
 func CreateVerifyData(suite signatureSuite, jsonldDoc map[string]interface{}, proof *Proof,
 	opts ...jsonld.ProcessorOpts) ([]byte, error) {
+	return CreateVerifyDataWithCache(suite, jsonldDoc, proof, nil, opts...)
+}
+
+// CreateVerifyDataWithCache is like CreateVerifyData, but takes the digest of the canonical document
+// from the cache if it was already computed for another proof of the document. The cache may be nil.
+func CreateVerifyDataWithCache(suite signatureSuite, jsonldDoc map[string]interface{}, proof *Proof,
+	cache *CanonicalDocumentCache, opts ...jsonld.ProcessorOpts) ([]byte, error) {
 	switch proof.SignatureRepresentation {
 	case SignatureProofValue:
-		return CreateVerifyHash(suite, jsonldDoc, proof.JSONLdObject(), opts...)
+		return createVerifyHash(suite, jsonldDoc, proof.JSONLdObject(), cache, opts...)
 	case SignatureJWS:
-		return createVerifyJWS(suite, jsonldDoc, proof, opts...)
+		return createVerifyJWS(suite, jsonldDoc, proof, cache, opts...)
 	}
 
 	return nil, fmt.Errorf("unsupported signature representation: %v", proof.SignatureRepresentation)
@@ -58,6 +65,11 @@ func CreateVerifyData(suite signatureSuite, jsonldDoc map[string]interface{}, pr
 // Algorithm steps are described here https://w3c-dvcg.github.io/ld-signatures/#create-verify-hash-algorithm
 func CreateVerifyHash(suite signatureSuite, jsonldDoc, proofOptions map[string]interface{},
 	opts ...jsonld.ProcessorOpts) ([]byte, error) {
+	return createVerifyHash(suite, jsonldDoc, proofOptions, nil, opts...)
+}
+
+func createVerifyHash(suite signatureSuite, jsonldDoc, proofOptions map[string]interface{},
+	cache *CanonicalDocumentCache, opts ...jsonld.ProcessorOpts) ([]byte, error) {
 	// in  order to generate canonical form we need context
 	// if context is not passed, use document's context
 	// spec doesn't mention anything about context
@@ -73,13 +85,18 @@ func CreateVerifyHash(suite signatureSuite, jsonldDoc, proofOptions map[string]i
 
 	proofOptionsDigest := suite.GetDigest(canonicalProofOptions)
 
-	canonicalDoc, err := prepareCanonicalDocument(suite, jsonldDoc, opts...)
+	docDigest, err := cache.digest(suite, false, func() ([]byte, error) {
+		canonicalDoc, e := prepareCanonicalDocument(suite, jsonldDoc, opts...)
+		if e != nil {
+			return nil, e
+		}
+
+		return suite.GetDigest(canonicalDoc), nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	docDigest := suite.GetDigest(canonicalDoc)
-
 	return append(proofOptionsDigest, docDigest...), nil
 }
 
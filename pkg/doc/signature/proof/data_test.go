@@ -120,6 +120,71 @@ func TestCreateVerifyData(t *testing.T) {
 	require.Nil(t, signature)
 }
 
+func TestCreateVerifyDataWithCache(t *testing.T) {
+	created, err := time.Parse(time.RFC3339, "2018-03-15T00:00:00Z")
+	require.NoError(t, err)
+
+	var doc map[string]interface{}
+	err = json.Unmarshal([]byte(validDoc), &doc)
+	require.NoError(t, err)
+
+	for _, representation := range []SignatureRepresentation{SignatureProofValue, SignatureJWS} {
+		suite := &countingSignatureSuite{}
+		cache := NewCanonicalDocumentCache()
+
+		for i, creator := range []string{"key1", "key2"} {
+			p := &Proof{
+				Type:                    "type",
+				Created:                 util.NewTime(created),
+				Creator:                 creator,
+				JWS:                     "jws header..",
+				SignatureRepresentation: representation,
+			}
+
+			cached, err := CreateVerifyDataWithCache(suite, doc, p, cache, ldtestutil.WithDocumentLoader(t))
+			require.NoError(t, err)
+
+			expected, err := CreateVerifyData(&mockSignatureSuite{}, doc, p, ldtestutil.WithDocumentLoader(t))
+			require.NoError(t, err)
+			require.Equal(t, expected, cached)
+
+			// the proof options are canonicalized for every proof, the document for the first proof only
+			require.Equal(t, i+2, suite.count)
+		}
+	}
+
+	t.Run("document canonicalization error is not cached", func(t *testing.T) {
+		p := &Proof{
+			Type:                    "type",
+			Created:                 util.NewTime(created),
+			Creator:                 "key1",
+			SignatureRepresentation: SignatureProofValue,
+		}
+
+		invalidDoc := map[string]interface{}{"@context": doc["@context"], "type": 777}
+		cache := NewCanonicalDocumentCache()
+
+		for i := 0; i < 2; i++ {
+			_, err = CreateVerifyDataWithCache(&mockSignatureSuite{}, invalidDoc, p, cache, ldtestutil.WithDocumentLoader(t))
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "invalid type value")
+		}
+	})
+}
+
+// countingSignatureSuite counts the canonicalized documents.
+type countingSignatureSuite struct {
+	mockSignatureSuite
+	count int
+}
+
+func (s *countingSignatureSuite) GetCanonicalDocument(doc map[string]interface{},
+	opts ...jsonld.ProcessorOpts) ([]byte, error) {
+	s.count++
+
+	return s.mockSignatureSuite.GetCanonicalDocument(doc, opts...)
+}
+
 type mockSignatureSuite struct {
 	compactProof bool
 }
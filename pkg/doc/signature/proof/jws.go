@@ -67,7 +67,7 @@ func getJWTHeader(jwt string) (string, error) {
 // JSON and Signature documents and by preliminary JSON-LD compacting of JSON document.
 // The current implementation is based on the https://github.com/digitalbazaar/jsonld-signatures.
 func createVerifyJWS(suite signatureSuite, jsonldDoc map[string]interface{}, p *Proof,
-	opts ...jsonld.ProcessorOpts) ([]byte, error) {
+	cache *CanonicalDocumentCache, opts ...jsonld.ProcessorOpts) ([]byte, error) {
 	proofOptions := p.JSONLdObject()
 
 	canonicalProofOptions, err := prepareJWSProof(suite, proofOptions, opts...)
@@ -77,13 +77,18 @@ func createVerifyJWS(suite signatureSuite, jsonldDoc map[string]interface{}, p *
 
 	proofOptionsDigest := suite.GetDigest(canonicalProofOptions)
 
-	canonicalDoc, err := prepareDocumentForJWS(suite, jsonldDoc, opts...)
+	docDigest, err := cache.digest(suite, true, func() ([]byte, error) {
+		canonicalDoc, e := prepareDocumentForJWS(suite, jsonldDoc, opts...)
+		if e != nil {
+			return nil, e
+		}
+
+		return suite.GetDigest(canonicalDoc), nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	docDigest := suite.GetDigest(canonicalDoc)
-
 	verifyData := append(proofOptionsDigest, docDigest...)
 
 	jwtHeader, err := getJWTHeader(p.JWS)
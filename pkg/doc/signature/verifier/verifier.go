@@ -66,8 +66,17 @@ func New(resolver keyResolver, suites ...SignatureSuite) (*DocumentVerifier, err
 	}, nil
 }
 
-// Verify will verify document proofs.
+// Verify will verify document proofs. The document is canonicalized once for all proofs verified
+// with the same signature suite.
 func (dv *DocumentVerifier) Verify(jsonLdDoc []byte, opts ...jsonld.ProcessorOpts) error {
+	return dv.VerifyWithCache(jsonLdDoc, proof.NewCanonicalDocumentCache(), opts...)
+}
+
+// VerifyWithCache is like Verify, but the canonical document is taken from the cache, which can be shared
+// by several verifications of the same document, e.g. when the proofs of a proof set are verified separately.
+// A nil cache disables the caching, the document is canonicalized for every proof.
+func (dv *DocumentVerifier) VerifyWithCache(jsonLdDoc []byte, cache *proof.CanonicalDocumentCache,
+	opts ...jsonld.ProcessorOpts) error {
 	var jsonLdObject map[string]interface{}
 
 	err := json.Unmarshal(jsonLdDoc, &jsonLdObject)
@@ -75,11 +84,12 @@ func (dv *DocumentVerifier) Verify(jsonLdDoc []byte, opts ...jsonld.ProcessorOpt
 		return fmt.Errorf("failed to unmarshal json ld document: %w", err)
 	}
 
-	return dv.verifyObject(jsonLdObject, opts...)
+	return dv.verifyObject(jsonLdObject, cache, opts...)
 }
 
 // verifyObject will verify document proofs for JSON LD object.
-func (dv *DocumentVerifier) verifyObject(jsonLdObject map[string]interface{}, opts ...jsonld.ProcessorOpts) error {
+func (dv *DocumentVerifier) verifyObject(jsonLdObject map[string]interface{}, cache *proof.CanonicalDocumentCache,
+	opts ...jsonld.ProcessorOpts) error {
 	proofs, err := proof.GetProofs(jsonLdObject)
 	if err != nil {
 		return err
@@ -101,7 +111,7 @@ func (dv *DocumentVerifier) verifyObject(jsonLdObject map[string]interface{}, op
 			return err
 		}
 
-		message, err := proof.CreateVerifyData(suite, jsonLdObject, p, opts...)
+		message, err := proof.CreateVerifyDataWithCache(suite, jsonLdObject, p, cache, opts...)
 		if err != nil {
 			return err
 		}
@@ -6,9 +6,13 @@ SPDX-License-Identifier: Apache-2.0
 package json
 
 import (
+	"bytes"
 	"encoding/json"
 )
 
+// utf8BOM is the UTF-8 encoded byte order mark.
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF} //nolint:gochecknoglobals
+
 // MarshalWithCustomFields marshals value merged with custom fields defined in the map into JSON bytes.
 func MarshalWithCustomFields(v interface{}, cf map[string]interface{}) ([]byte, error) {
 	// Merge value and custom fields into the joint map.
@@ -121,3 +125,11 @@ func ToMaps(v []interface{}) ([]map[string]interface{}, error) {
 
 	return maps, nil
 }
+
+// TrimBOM strips a leading UTF-8 byte order mark and the surrounding whitespace from JSON bytes.
+// Some servers prepend those to the JSON documents they serve, which breaks strict parsing.
+func TrimBOM(data []byte) []byte {
+	data = bytes.TrimLeft(data, " \t\r\n")
+
+	return bytes.TrimSpace(bytes.TrimPrefix(data, utf8BOM))
+}
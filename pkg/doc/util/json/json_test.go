@@ -123,3 +123,11 @@ func Test_toMaps(t *testing.T) {
 	require.Error(t, err)
 	require.Empty(t, maps)
 }
+
+func Test_trimBOM(t *testing.T) {
+	require.Equal(t, []byte(`{"a":"b"}`), TrimBOM([]byte("\xEF\xBB\xBF{\"a\":\"b\"}")))
+	require.Equal(t, []byte(`{"a":"b"}`), TrimBOM([]byte(" \n\xEF\xBB\xBF\t{\"a\":\"b\"}\r\n")))
+	require.Equal(t, []byte(`"eyJ.eyJ.sig"`), TrimBOM([]byte("  \"eyJ.eyJ.sig\"\n")))
+	require.Equal(t, []byte(`{}`), TrimBOM([]byte(`{}`)))
+	require.Empty(t, TrimBOM([]byte("\xEF\xBB\xBF ")))
+}
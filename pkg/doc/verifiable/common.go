@@ -145,6 +145,14 @@ func (r *VDRKeyResolver) resolvePublicKey(issuerDID, keyID string) (*verifier.Pu
 		return nil, fmt.Errorf("resolve DID %s: %w", issuerDID, err)
 	}
 
+	if vm, ok := lookupVerificationMethod(docResolution.DIDDocument, keyID); ok {
+		return &verifier.PublicKey{
+			Type:  vm.Type,
+			Value: vm.Value,
+			JWK:   vm.JSONWebKey(),
+		}, nil
+	}
+
 	for _, verifications := range docResolution.DIDDocument.VerificationMethods() {
 		for _, verification := range verifications {
 			if strings.Contains(verification.VerificationMethod.ID, keyID) &&
@@ -161,6 +169,27 @@ func (r *VDRKeyResolver) resolvePublicKey(issuerDID, keyID string) (*verifier.Pu
 	return nil, fmt.Errorf("public key with KID %s is not found for DID %s", keyID, issuerDID)
 }
 
+// lookupVerificationMethod finds the verification method by its ID or fragment using the doc index.
+// Key agreement keys are not used for signature verification.
+func lookupVerificationMethod(doc *did.Doc, keyID string) (*did.VerificationMethod, bool) {
+	vm, ok := doc.VerificationMethodByID(keyID)
+	if !ok && !strings.HasPrefix(keyID, "#") {
+		vm, ok = doc.VerificationMethodByID("#" + keyID)
+	}
+
+	if !ok {
+		return nil, false
+	}
+
+	for _, ka := range doc.KeyAgreement {
+		if ka.Embedded && ka.VerificationMethod.ID == vm.ID {
+			return nil, false
+		}
+	}
+
+	return vm, true
+}
+
 // PublicKeyFetcher returns Public Key Fetcher via DID resolution mechanism.
 func (r *VDRKeyResolver) PublicKeyFetcher() PublicKeyFetcher {
 	return r.resolvePublicKey
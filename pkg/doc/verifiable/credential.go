@@ -11,7 +11,6 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io/ioutil"
 	"net/http"
 	"reflect"
 	"strings"
@@ -233,6 +232,9 @@ const DefaultSchemaTemplate = `{
 // https://www.w3.org/TR/vc-data-model/#data-schemas
 const jsonSchema2018Type = "JsonSchemaValidator2018"
 
+// proofCreatedClockSkew is the allowed clock skew between the created of the proof and the issuance date of VC.
+const proofCreatedClockSkew = time.Minute
+
 const (
 	// https://www.w3.org/TR/vc-data-model/#base-context
 	baseContext = "https://www.w3.org/2018/credentials/v1"
@@ -304,6 +306,7 @@ type ExpirableSchemaCache struct {
 // CredentialSchemaLoader defines expirable cache.
 type CredentialSchemaLoader struct {
 	schemaDownloadClient *http.Client
+	dereferencer         URIDereferencer
 	cache                SchemaCache
 	jsonLoader           gojsonschema.JSONLoader
 }
@@ -326,6 +329,14 @@ func (b *CredentialSchemaLoaderBuilder) SetSchemaDownloadClient(client *http.Cli
 	return b
 }
 
+// SetURIDereferencer sets the dereferencer of the schema URIs, by default the schema is downloaded
+// with the HTTP client.
+func (b *CredentialSchemaLoaderBuilder) SetURIDereferencer(
+	dereferencer URIDereferencer) *CredentialSchemaLoaderBuilder {
+	b.loader.dereferencer = dereferencer
+	return b
+}
+
 // SetCache defines SchemaCache.
 func (b *CredentialSchemaLoaderBuilder) SetCache(cache SchemaCache) *CredentialSchemaLoaderBuilder {
 	b.loader.cache = cache
@@ -393,6 +404,65 @@ func (sc *ExpirableSchemaCache) Get(k string) ([]byte, bool) {
 // Evidence defines evidence of Verifiable Credential.
 type Evidence interface{}
 
+// TypedEvidence is an evidence object of Verifiable Credential, see Credential.Evidences.
+// Evidence type can be defined either as a single string or as an array, it is always decoded into Types.
+type TypedEvidence struct {
+	ID    string
+	Types []string
+
+	CustomFields CustomFields
+}
+
+// MarshalJSON defines custom marshalling of TypedEvidence to JSON.
+func (e TypedEvidence) MarshalJSON() ([]byte, error) {
+	alias := rawEvidence{
+		ID: e.ID,
+	}
+
+	if len(e.Types) > 0 {
+		alias.Type = typesToRaw(e.Types)
+	}
+
+	data, err := jsonutil.MarshalWithCustomFields(alias, e.CustomFields)
+	if err != nil {
+		return nil, fmt.Errorf("marshal Evidence: %w", err)
+	}
+
+	return data, nil
+}
+
+// UnmarshalJSON defines custom unmarshalling of TypedEvidence from JSON.
+func (e *TypedEvidence) UnmarshalJSON(data []byte) error {
+	alias := rawEvidence{}
+
+	e.CustomFields = make(CustomFields)
+
+	err := jsonutil.UnmarshalWithCustomFields(data, &alias, e.CustomFields)
+	if err != nil {
+		return fmt.Errorf("unmarshal Evidence: %w", err)
+	}
+
+	e.ID = alias.ID
+	e.Types = nil
+
+	if alias.Type != nil {
+		e.Types, err = decodeType(alias.Type)
+		if err != nil {
+			return fmt.Errorf("unmarshal Evidence: %w", err)
+		}
+	}
+
+	return nil
+}
+
+type rawEvidence struct {
+	ID   string      `json:"id,omitempty"`
+	Type interface{} `json:"type,omitempty"`
+}
+
+// TermsOfUse defines terms of use of Verifiable Credential.
+type TermsOfUse = TypedID
+
 // Issuer of the Verifiable Credential.
 type Issuer struct {
 	ID string `json:"id,omitempty"`
@@ -509,7 +579,7 @@ type Credential struct {
 	Status         *TypedID
 	Schemas        []TypedID
 	Evidence       Evidence
-	TermsOfUse     []TypedID
+	TermsOfUse     []TermsOfUse
 	RefreshService []TypedID
 	JWT            string
 
@@ -582,11 +652,20 @@ type credentialOpts struct {
 	modelValidationMode   vcModelValidationMode
 	allowedCustomContexts map[string]bool
 	allowedCustomTypes    map[string]bool
+	allowedContexts       map[string]bool
 	disabledProofCheck    bool
 	strictValidation      bool
 	ldpSuites             []verifier.SignatureSuite
+	proofVerifier         ProofVerifier
 	defaultSchema         string
 	disableValidation     bool
+	termsOfUseValidator   func(termsOfUse []TermsOfUse) error
+
+	proofCreatedConsistency        bool
+	verificationTimeout            time.Duration
+	bindVerificationMethodToIssuer bool
+	expectedProofNonce             []byte
+	verificationMethod             string
 
 	jsonldCredentialOpts
 }
@@ -601,6 +680,24 @@ func WithDisabledProofCheck() CredentialOpt {
 	}
 }
 
+// WithExpectedProofNonce requires every embedded linked data proof of the credential to have the given nonce
+// (e.g. a nonce chosen by the verifier), otherwise the parsing fails with ErrProofNonceMismatch.
+func WithExpectedProofNonce(nonce []byte) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.expectedProofNonce = nonce
+	}
+}
+
+// WithVerificationMethod pins the proof check to the verification method with the given ID
+// (e.g. the currently active key of the issuer). Only the embedded proofs made by this verification method
+// (the JWT with this "kid") are checked and accepted, the proofs of other verification methods are ignored.
+// The parsing fails with ErrNoProofOfVerificationMethod if the credential has no proof of the verification method.
+func WithVerificationMethod(id string) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.verificationMethod = id
+	}
+}
+
 // WithCredDisableValidation options for disabling of JSON-LD and json-schema validation.
 func WithCredDisableValidation() CredentialOpt {
 	return func(opts *credentialOpts) {
@@ -608,6 +705,15 @@ func WithCredDisableValidation() CredentialOpt {
 	}
 }
 
+// WithTermsOfUseValidator option is for enforcing a policy on the terms of use of the credential.
+// The validator is called with the parsed terms of use (empty if the credential has none),
+// and the parsing fails if it returns an error.
+func WithTermsOfUseValidator(validator func(termsOfUse []TermsOfUse) error) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.termsOfUseValidator = validator
+	}
+}
+
 // WithSchema option to set custom schema.
 func WithSchema(schema string) CredentialOpt {
 	return func(opts *credentialOpts) {
@@ -676,6 +782,18 @@ func WithBaseContextExtendedValidation(customContexts, customTypes []string) Cre
 	}
 }
 
+// WithAllowedContexts pins the JSON-LD contexts the credential may reference. The credential is rejected
+// if its @context contains a URL which is not in the list or an inline context. The check is made before
+// the embedded proof check, so no context outside the list is ever loaded.
+func WithAllowedContexts(urls ...string) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.allowedContexts = make(map[string]bool)
+		for _, url := range urls {
+			opts.allowedContexts[url] = true
+		}
+	}
+}
+
 // WithJSONLDDocumentLoader defines a JSON-LD document loader.
 func WithJSONLDDocumentLoader(documentLoader jsonld.DocumentLoader) CredentialOpt {
 	return func(opts *credentialOpts) {
@@ -718,6 +836,42 @@ func WithEmbeddedSignatureSuites(suites ...verifier.SignatureSuite) CredentialOp
 	}
 }
 
+// WithProofVerifier delegates the signature check of the JWT proof and of the embedded linked data proofs
+// of the default signature suites to the ProofVerifier, e.g. to verify with a remote KMS or HSM.
+// The public keys are still resolved by the public key fetcher. By default, signatures are verified locally.
+func WithProofVerifier(v ProofVerifier) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.proofVerifier = v
+	}
+}
+
+// WithProofCreatedConsistency enables the check that no proof of VC is created before the issuance date of VC.
+// A proof created earlier than the issuance date by more than the clock skew of one minute is rejected.
+// Proofs without the created timestamp and VC without the issuance date are not checked.
+func WithProofCreatedConsistency() CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.proofCreatedConsistency = true
+	}
+}
+
+// WithVerificationTimeout bounds the cryptographic verification of the proofs of VC (e.g. RDF canonicalization
+// of a large credential and signature check) independently of the fetch timeouts. If the verification takes
+// longer, the parsing fails with ErrVerificationTimeout.
+func WithVerificationTimeout(d time.Duration) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.verificationTimeout = d
+	}
+}
+
+// WithBindVerificationMethodToIssuer requires the verification method of each proof of VC (the "kid" of a JWT)
+// to belong to the issuer, i.e. its DID must be the issuer DID, so the public key is resolved from the issuer's
+// DID document. A proof made with a key of another DID is rejected.
+func WithBindVerificationMethodToIssuer() CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.bindVerificationMethodToIssuer = true
+	}
+}
+
 // parseIssuer parses raw issuer.
 //
 // Issuer can be defined by:
@@ -811,6 +965,7 @@ func ParseCredential(vcData []byte, opts ...CredentialOpt) (*Credential, error)
 	// Apply options.
 	vcOpts := getCredentialOpts(opts)
 
+	vcData = jsonutil.TrimBOM(vcData)
 	vcStr := unwrapStringVC(vcData)
 
 	var (
@@ -829,6 +984,10 @@ func ParseCredential(vcData []byte, opts ...CredentialOpt) (*Credential, error)
 			return nil, fmt.Errorf("decode new JWT credential: %w", err)
 		}
 
+		if err = checkAllowedContexts(vcDataDecoded, vcOpts.allowedContexts); err != nil {
+			return nil, err
+		}
+
 		if err = validateDisclosures(vcDataDecoded, disclosures); err != nil {
 			return nil, err
 		}
@@ -855,12 +1014,52 @@ func ParseCredential(vcData []byte, opts ...CredentialOpt) (*Credential, error)
 		}
 	}
 
+	if vcOpts.proofCreatedConsistency {
+		err = checkProofCreated(vc)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if vcOpts.termsOfUseValidator != nil {
+		err = vcOpts.termsOfUseValidator(vc.TermsOfUse)
+		if err != nil {
+			return nil, fmt.Errorf("validate terms of use: %w", err)
+		}
+	}
+
 	vc.JWT = externalJWT
 	vc.SDHolderBinding = holderBinding
 
 	return vc, nil
 }
 
+// checkProofCreated checks that no proof is created before the issuance date of the credential.
+func checkProofCreated(vc *Credential) error {
+	if vc.Issued == nil {
+		return nil
+	}
+
+	for i, proof := range vc.Proofs {
+		created, ok := proof["created"].(string)
+		if !ok {
+			continue
+		}
+
+		createdTime, err := util.ParseTimeWrapper(created)
+		if err != nil {
+			return fmt.Errorf("parse created of proof %d: %w", i, err)
+		}
+
+		if createdTime.Time.Add(proofCreatedClockSkew).Before(vc.Issued.Time) {
+			return fmt.Errorf("proof %d is created at %s before the issuance date %s", i,
+				createdTime.FormatToString(), vc.Issued.FormatToString())
+		}
+	}
+
+	return nil
+}
+
 func validateDisclosures(vcBytes []byte, disclosures []string) error {
 	if len(disclosures) == 0 {
 		return nil
@@ -1110,6 +1309,33 @@ func parseTypedID(data json.RawMessage) ([]TypedID, error) {
 	return nil, err
 }
 
+// Evidences decodes the evidence of the credential, a single object or an array of objects, into typed evidence.
+// An error is returned if an evidence entry is not an object.
+func (vc *Credential) Evidences() ([]TypedEvidence, error) {
+	if vc.Evidence == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(vc.Evidence)
+	if err != nil {
+		return nil, fmt.Errorf("decode evidence: %w", err)
+	}
+
+	var singleEvidence TypedEvidence
+
+	if err = json.Unmarshal(data, &singleEvidence); err == nil {
+		return []TypedEvidence{singleEvidence}, nil
+	}
+
+	var evidence []TypedEvidence
+
+	if err = json.Unmarshal(data, &evidence); err != nil {
+		return nil, fmt.Errorf("decode evidence: %w", err)
+	}
+
+	return evidence, nil
+}
+
 func parseDisclosures(disclosures []string) ([]*common.DisclosureClaim, error) {
 	if len(disclosures) == 0 {
 		return nil, nil
@@ -1198,7 +1424,37 @@ func decodeJWTVC(vcStr string, vcOpts *credentialOpts) ([]byte, error) {
 		return nil, errors.New("public key fetcher is not defined")
 	}
 
-	vcDecodedBytes, err := decodeCredJWS(vcStr, !vcOpts.disabledProofCheck, vcOpts.publicKeyFetcher)
+	if vcOpts.bindVerificationMethodToIssuer && !vcOpts.disabledProofCheck {
+		unverifiedBytes, err := decodeCredJWS(vcStr, false, nil)
+		if err != nil {
+			return nil, fmt.Errorf("JWS decoding: %w", err)
+		}
+
+		vcOpts, err = bindFetcherToIssuer(unverifiedBytes, vcOpts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if vcOpts.verificationMethod != "" && !vcOpts.disabledProofCheck {
+		vcOpts = pinFetcherToVerificationMethod(vcOpts)
+	}
+
+	var vcDecodedBytes []byte
+
+	err := withVerificationTimeout(vcOpts.verificationTimeout, func() error {
+		var e error
+
+		if vcOpts.proofVerifier != nil && !vcOpts.disabledProofCheck {
+			vcDecodedBytes, e = decodeCredJWSWithProofVerifier(vcStr, vcOpts.publicKeyFetcher, vcOpts.proofVerifier)
+
+			return e
+		}
+
+		vcDecodedBytes, e = decodeCredJWS(vcStr, !vcOpts.disabledProofCheck, vcOpts.publicKeyFetcher)
+
+		return e
+	})
 	if err != nil {
 		return nil, fmt.Errorf("JWS decoding: %w", err)
 	}
@@ -1216,8 +1472,125 @@ func decodeLDVC(vcData []byte, vcStr string, vcOpts *credentialOpts) ([]byte, er
 		}
 	}
 
+	// Check the contexts before the proof check which loads them.
+	if err := checkAllowedContexts(vcData, vcOpts.allowedContexts); err != nil {
+		return nil, err
+	}
+
+	if vcOpts.bindVerificationMethodToIssuer && !vcOpts.disabledProofCheck {
+		var err error
+
+		vcOpts, err = bindFetcherToIssuer(vcData, vcOpts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	proofCheckOpts := getEmbeddedProofCheckOpts(vcOpts)
+
 	// Embedded proof.
-	return vcData, checkEmbeddedProof(vcData, getEmbeddedProofCheckOpts(vcOpts))
+	if err := checkEmbeddedProof(vcData, proofCheckOpts); err != nil {
+		return nil, err
+	}
+
+	return vcData, checkSubjectProofs(vcData, proofCheckOpts)
+}
+
+// bindFetcherToIssuer returns the options with the public key fetcher restricted to the verification methods
+// of the issuer DID of the credential.
+func bindFetcherToIssuer(vcBytes []byte, vcOpts *credentialOpts) (*credentialOpts, error) {
+	var raw struct {
+		Issuer json.RawMessage `json:"issuer,omitempty"`
+	}
+
+	if err := json.Unmarshal(vcBytes, &raw); err != nil {
+		return nil, fmt.Errorf("unmarshal issuer of credential: %w", err)
+	}
+
+	issuer, err := parseIssuer(raw.Issuer)
+	if err != nil {
+		return nil, fmt.Errorf("parse issuer of credential: %w", err)
+	}
+
+	issuerDID, _, _ := strings.Cut(issuer.ID, "#")
+	if !strings.HasPrefix(issuerDID, "did:") {
+		return nil, fmt.Errorf("verification method can't be bound to issuer '%s' which is not DID", issuer.ID)
+	}
+
+	fetcher := vcOpts.publicKeyFetcher
+	if fetcher == nil {
+		return vcOpts, nil
+	}
+
+	boundOpts := *vcOpts
+	boundOpts.publicKeyFetcher = func(didID, keyID string) (*verifier.PublicKey, error) {
+		if didID != issuerDID {
+			return nil, fmt.Errorf("verification method %s#%s doesn't belong to issuer %s",
+				didID, strings.TrimPrefix(keyID, "#"), issuerDID)
+		}
+
+		return fetcher(didID, keyID)
+	}
+
+	return &boundOpts, nil
+}
+
+// pinFetcherToVerificationMethod returns the options with the public key fetcher restricted to
+// the verification method of the options.
+func pinFetcherToVerificationMethod(vcOpts *credentialOpts) *credentialOpts {
+	fetcher := vcOpts.publicKeyFetcher
+	if fetcher == nil {
+		return vcOpts
+	}
+
+	pinnedOpts := *vcOpts
+	pinnedOpts.publicKeyFetcher = func(didID, keyID string) (*verifier.PublicKey, error) {
+		vmID := didID + "#" + strings.TrimPrefix(keyID, "#")
+		if vmID != vcOpts.verificationMethod {
+			return nil, fmt.Errorf("%w %s: proof is made by %s",
+				ErrNoProofOfVerificationMethod, vcOpts.verificationMethod, vmID)
+		}
+
+		return fetcher(didID, keyID)
+	}
+
+	return &pinnedOpts
+}
+
+func checkAllowedContexts(vcBytes []byte, allowedContexts map[string]bool) error {
+	if allowedContexts == nil {
+		return nil
+	}
+
+	var raw struct {
+		Context interface{} `json:"@context,omitempty"`
+	}
+
+	err := json.Unmarshal(vcBytes, &raw)
+	if err != nil {
+		return fmt.Errorf("unmarshal @context of credential: %w", err)
+	}
+
+	if raw.Context == nil {
+		return nil
+	}
+
+	contexts, customContexts, err := decodeContext(raw.Context)
+	if err != nil {
+		return err
+	}
+
+	if len(customContexts) > 0 {
+		return errors.New("inline @context is not allowed")
+	}
+
+	for _, context := range contexts {
+		if !allowedContexts[context] {
+			return fmt.Errorf("not allowed @context: %s", context)
+		}
+	}
+
+	return nil
 }
 
 // JWTVCToJSON parses a JWT VC without verifying, and returns the JSON VC contents.
@@ -1234,6 +1607,10 @@ func getEmbeddedProofCheckOpts(vcOpts *credentialOpts) *embeddedProofCheckOpts {
 		publicKeyFetcher:     vcOpts.publicKeyFetcher,
 		disabledProofCheck:   vcOpts.disabledProofCheck,
 		ldpSuites:            vcOpts.ldpSuites,
+		proofVerifier:        vcOpts.proofVerifier,
+		verificationTimeout:  vcOpts.verificationTimeout,
+		expectedNonce:        vcOpts.expectedProofNonce,
+		verificationMethod:   vcOpts.verificationMethod,
 		jsonldCredentialOpts: vcOpts.jsonldCredentialOpts,
 	}
 }
@@ -1460,8 +1837,13 @@ func getJSONSchema(url string, opts *credentialOpts) ([]byte, error) {
 	loader := opts.schemaLoader
 	cache := loader.cache
 
+	dereferencer := loader.dereferencer
+	if dereferencer == nil {
+		dereferencer = NewHTTPURIDereferencer(loader.schemaDownloadClient)
+	}
+
 	if cache == nil {
-		return loadJSONSchema(url, loader.schemaDownloadClient)
+		return loadJSONSchema(url, dereferencer)
 	}
 
 	// Check the cache first.
@@ -1469,7 +1851,7 @@ func getJSONSchema(url string, opts *credentialOpts) ([]byte, error) {
 		return cachedBytes, nil
 	}
 
-	schemaBytes, err := loadJSONSchema(url, loader.schemaDownloadClient)
+	schemaBytes, err := loadJSONSchema(url, dereferencer)
 	if err != nil {
 		return nil, err
 	}
@@ -1480,31 +1862,18 @@ func getJSONSchema(url string, opts *credentialOpts) ([]byte, error) {
 	return schemaBytes, nil
 }
 
-func loadJSONSchema(url string, client *http.Client) ([]byte, error) {
-	resp, err := client.Get(url)
+func loadJSONSchema(url string, dereferencer URIDereferencer) ([]byte, error) {
+	schemaBytes, err := dereferencer.Dereference(url)
 	if err != nil {
-		return nil, fmt.Errorf("load credential schema: %w", err)
-	}
-
-	defer func() {
-		e := resp.Body.Close()
-		if e != nil {
-			logger.Errorf("closing response body failed [%v]", e)
+		var statusErr *HTTPStatusError
+		if errors.As(err, &statusErr) {
+			return nil, fmt.Errorf("credential schema endpoint %w", err)
 		}
-	}()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("credential schema endpoint HTTP failure [%v]", resp.StatusCode)
-	}
-
-	var gotBody []byte
 
-	gotBody, err = ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("credential schema: read response body: %w", err)
+		return nil, fmt.Errorf("load credential schema: %w", err)
 	}
 
-	return gotBody, nil
+	return schemaBytes, nil
 }
 
 // JWTClaims converts Verifiable Credential into JWT Credential claims, which can be than serialized
@@ -1574,6 +1943,80 @@ func subjectIDFromMap(subject map[string]interface{}) (string, error) {
 	return subjectID, nil
 }
 
+// DecodeSubject decodes the (first) credential subject into the value pointed to by into,
+// e.g. a pointer to a domain-specific struct.
+// A subject defined as a bare string is decoded as an object with only "id" set.
+func (vc *Credential) DecodeSubject(into interface{}) error {
+	subjects, err := rawSubjects(vc.Subject)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(subjects[0], into)
+	if err != nil {
+		return fmt.Errorf("decode credential subject: %w", err)
+	}
+
+	return nil
+}
+
+// DecodeSubjects decodes all credential subjects into the slice pointed to by into,
+// e.g. a pointer to a slice of domain-specific structs.
+// A subject defined as a bare string is decoded as an object with only "id" set.
+func (vc *Credential) DecodeSubjects(into interface{}) error {
+	subjects, err := rawSubjects(vc.Subject)
+	if err != nil {
+		return err
+	}
+
+	subjectsBytes, err := json.Marshal(subjects)
+	if err != nil {
+		return fmt.Errorf("marshal credential subjects: %w", err)
+	}
+
+	err = json.Unmarshal(subjectsBytes, into)
+	if err != nil {
+		return fmt.Errorf("decode credential subjects: %w", err)
+	}
+
+	return nil
+}
+
+// rawSubjects converts subject(s) of any supported kind to a non-empty slice of JSON objects.
+func rawSubjects(subject interface{}) ([]json.RawMessage, error) {
+	if subject == nil {
+		return nil, errors.New("no subject is defined")
+	}
+
+	subjectBytes, err := subjectToBytes(subject)
+	if err != nil {
+		return nil, err
+	}
+
+	var subjectID string
+
+	if err = json.Unmarshal(subjectBytes, &subjectID); err == nil {
+		subjectBytes, err = json.Marshal(map[string]string{"id": subjectID})
+		if err != nil {
+			return nil, fmt.Errorf("marshal subject ID: %w", err)
+		}
+
+		return []json.RawMessage{subjectBytes}, nil
+	}
+
+	var subjects []json.RawMessage
+
+	if err = json.Unmarshal(subjectBytes, &subjects); err != nil {
+		return []json.RawMessage{subjectBytes}, nil
+	}
+
+	if len(subjects) == 0 {
+		return nil, errors.New("no subject is defined")
+	}
+
+	return subjects, nil
+}
+
 func (vc *Credential) raw() (*rawCredential, error) {
 	rawRefreshService, err := typedIDsToRaw(vc.RefreshService)
 	if err != nil {
@@ -0,0 +1,70 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/piprate/json-gold/ld"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+)
+
+// Compact JSON-LD compacts the credential to the target context, e.g. for stable display or storage.
+// The target context may be a context value (an IRI, a context object or an array of them) or
+// a JSON-LD document holding it under "@context".
+// Credentials parsed from a JWT (or SD-JWT, with all disclosures applied) are compacted in their JSON-LD form.
+func (vc *Credential) Compact(targetContext interface{}, loader ld.DocumentLoader) (map[string]interface{}, error) {
+	docMap, err := vc.jsonLDMap()
+	if err != nil {
+		return nil, fmt.Errorf("compact credential: %w", err)
+	}
+
+	contextMap, ok := targetContext.(map[string]interface{})
+	if !ok || contextMap["@context"] == nil {
+		contextMap = map[string]interface{}{"@context": targetContext}
+	}
+
+	compacted, err := jsonld.Default().Compact(docMap, contextMap, jsonld.WithDocumentLoader(loader))
+	if err != nil {
+		return nil, fmt.Errorf("compact credential: %w", err)
+	}
+
+	return compacted, nil
+}
+
+// jsonLDMap returns the credential as JSON-LD document, normalizing credentials parsed from a JWT.
+func (vc *Credential) jsonLDMap() (map[string]interface{}, error) {
+	cred := vc
+
+	if vc.JWT != "" {
+		display, err := vc.CreateDisplayCredential(DisplayAllDisclosures())
+		if err != nil {
+			return nil, err
+		}
+
+		normalized := *display
+		normalized.JWT = ""
+		normalized.SDJWTHashAlg = ""
+		normalized.SDJWTDisclosures = nil
+		cred = &normalized
+	}
+
+	vcBytes, err := cred.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	var docMap map[string]interface{}
+
+	err = json.Unmarshal(vcBytes, &docMap)
+	if err != nil {
+		return nil, err
+	}
+
+	return docMap, nil
+}
@@ -0,0 +1,143 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	afgojwt "github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/ldcontext"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+// nolint: lll
+const (
+	didConfigContextURI = "https://identity.foundation/.well-known/did-configuration/v1"
+
+	didConfigContext = `{
+  "@context": [
+    {
+      "@version": 1.1,
+      "@protected": true,
+      "LinkedDomains": "https://identity.foundation/.well-known/resources/did-configuration/#LinkedDomains",
+      "DomainLinkageCredential": "https://identity.foundation/.well-known/resources/did-configuration/#DomainLinkageCredential",
+      "origin": "https://identity.foundation/.well-known/resources/did-configuration/#origin",
+      "linked_dids": "https://identity.foundation/.well-known/resources/did-configuration/#linked_dids"
+    }
+  ]
+}`
+
+	domainLinkageCredential = `{
+  "@context": [
+    "https://www.w3.org/2018/credentials/v1",
+    "https://identity.foundation/.well-known/did-configuration/v1"
+  ],
+  "issuer": "did:key:z6MkoTHsgNNrby8JzCNQ1iRLyW5QQ6R8Xuu6AA8igGrMVPUM",
+  "issuanceDate": "2020-12-04T14:08:28-06:00",
+  "expirationDate": "2025-12-04T14:08:28-06:00",
+  "type": ["VerifiableCredential", "DomainLinkageCredential"],
+  "credentialSubject": {
+    "id": "did:key:z6MkoTHsgNNrby8JzCNQ1iRLyW5QQ6R8Xuu6AA8igGrMVPUM",
+    "origin": "https://identity.foundation"
+  }
+}`
+
+	originIRI = "https://identity.foundation/.well-known/resources/did-configuration/#origin"
+)
+
+func TestCredential_Compact(t *testing.T) {
+	loader := createTestDocumentLoader(t, ldcontext.Document{
+		URL:     didConfigContextURI,
+		Content: json.RawMessage(didConfigContext),
+	})
+
+	vc, err := ParseCredential([]byte(domainLinkageCredential), WithJSONLDDocumentLoader(loader))
+	require.NoError(t, err)
+
+	_, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	signer := afgojwt.NewEd25519Signer(privKey)
+	didConfigTarget := []interface{}{ContextURI, didConfigContextURI}
+
+	t.Run("compact expanded credential back to did configuration context", func(t *testing.T) {
+		// compacting to the credentials context only leaves did configuration terms expanded
+		expanded, err := vc.Compact(ContextURI, loader)
+		require.NoError(t, err)
+		require.Equal(t, []interface{}{
+			"VerifiableCredential",
+			"https://identity.foundation/.well-known/resources/did-configuration/#DomainLinkageCredential",
+		}, expanded["type"])
+		require.Contains(t, expanded["credentialSubject"], originIRI)
+
+		compacted, err := vc.Compact(didConfigTarget, loader)
+		require.NoError(t, err)
+		requireCompactedDLC(t, compacted)
+	})
+
+	t.Run("target context given as JSON-LD document", func(t *testing.T) {
+		compacted, err := vc.Compact(map[string]interface{}{"@context": didConfigTarget}, loader)
+		require.NoError(t, err)
+		requireCompactedDLC(t, compacted)
+	})
+
+	t.Run("credential parsed from JWT", func(t *testing.T) {
+		jwtClaims, err := vc.JWTClaims(false)
+		require.NoError(t, err)
+
+		cryptoSigner, err := newCryptoSigner(kms.ED25519Type)
+		require.NoError(t, err)
+
+		jws, err := jwtClaims.MarshalJWS(EdDSA, cryptoSigner, vc.Issuer.ID+"#keys-1")
+		require.NoError(t, err)
+
+		jwtVC, err := ParseCredential([]byte(jws), WithJSONLDDocumentLoader(loader),
+			WithDisabledProofCheck())
+		require.NoError(t, err)
+		require.NotEmpty(t, jwtVC.JWT)
+
+		compacted, err := jwtVC.Compact(didConfigTarget, loader)
+		require.NoError(t, err)
+		requireCompactedDLC(t, compacted)
+		require.NotContains(t, compacted, "jwt")
+	})
+
+	t.Run("credential parsed from SD-JWT", func(t *testing.T) {
+		sdJWT, err := vc.MakeSDJWT(signer, vc.Issuer.ID+"#keys-1")
+		require.NoError(t, err)
+
+		sdJWTVC, err := ParseCredential([]byte(sdJWT), WithJSONLDDocumentLoader(loader), WithDisabledProofCheck())
+		require.NoError(t, err)
+		require.NotEmpty(t, sdJWTVC.SDJWTHashAlg)
+
+		compacted, err := sdJWTVC.Compact(didConfigTarget, loader)
+		require.NoError(t, err)
+		requireCompactedDLC(t, compacted)
+		require.NotContains(t, compacted, "_sd_alg")
+	})
+
+	t.Run("error - target context can't be loaded", func(t *testing.T) {
+		_, err := vc.Compact("https://example.com/unknown/context", loader)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "compact credential")
+	})
+}
+
+func requireCompactedDLC(t *testing.T, compacted map[string]interface{}) {
+	t.Helper()
+
+	require.Equal(t, []interface{}{ContextURI, didConfigContextURI}, compacted["@context"])
+	require.Equal(t, []interface{}{"VerifiableCredential", "DomainLinkageCredential"}, compacted["type"])
+	require.Equal(t, map[string]interface{}{
+		"id":     "did:key:z6MkoTHsgNNrby8JzCNQ1iRLyW5QQ6R8Xuu6AA8igGrMVPUM",
+		"origin": "https://identity.foundation",
+	}, compacted["credentialSubject"])
+}
@@ -6,6 +6,8 @@ SPDX-License-Identifier: Apache-2.0
 package verifiable
 
 // MarshalJWS serializes JWT into signed form (JWS).
+// The claims are serialized deterministically (object keys in lexicographic order, numbers in their shortest
+// JSON form), so the same credential signed with a deterministic algorithm like EdDSA gives the same JWS.
 func (jcc *JWTCredClaims) MarshalJWS(signatureAlg JWSAlgorithm, signer Signer, keyID string) (string, error) {
 	return marshalJWS(jcc, signatureAlg, signer, keyID)
 }
@@ -26,3 +28,17 @@ func decodeCredJWS(rawJwt string, checkProof bool, fetcher PublicKeyFetcher) ([]
 		return unmarshalJWSClaims(rawJwt, checkProof, fetcher)
 	})
 }
+
+func decodeCredJWSWithProofVerifier(rawJwt string, fetcher PublicKeyFetcher,
+	proofVerifier ProofVerifier) ([]byte, error) {
+	return decodeCredJWT(rawJwt, func(vcJWTBytes string) (*JWTCredClaims, error) {
+		var claims JWTCredClaims
+
+		err := unmarshalVerifiedJWS(rawJwt, proofVerifierJWS(fetcher, proofVerifier), &claims)
+		if err != nil {
+			return nil, err
+		}
+
+		return &claims, nil
+	})
+}
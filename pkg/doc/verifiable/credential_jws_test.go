@@ -8,7 +8,9 @@ package verifiable
 import (
 	"crypto/rand"
 	"crypto/rsa"
+	"encoding/base64"
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/go-jose/go-jose/v3"
@@ -50,6 +52,55 @@ func TestJWTCredClaimsMarshalJWS(t *testing.T) {
 	})
 }
 
+func TestJWTCredClaimsMarshalJWS_Deterministic(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	issue := func(customFields CustomFields) string {
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		vc.CustomFields = customFields
+
+		jwtClaims, err := vc.JWTClaims(false)
+		require.NoError(t, err)
+
+		// EdDSA signature is deterministic (RFC 8032), i.e. it doesn't need a random nonce
+		jws, err := jwtClaims.MarshalJWS(EdDSA, signer, "did:123#key1")
+		require.NoError(t, err)
+
+		return jws
+	}
+
+	// same custom fields built in a different insertion order
+	first := CustomFields{"score": 1.5, "big": 1e21, "nested": map[string]interface{}{"z": 1, "a": 2.0}}
+	second := CustomFields{"nested": map[string]interface{}{"a": 2.0, "z": 1}, "big": 1e21, "score": 1.5}
+
+	jws := issue(first)
+	require.Equal(t, jws, issue(second))
+	require.Equal(t, jws, issue(first))
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.Split(jws, ".")[1])
+	require.NoError(t, err)
+	require.Contains(t, string(payload), `"big":1e+21,`)
+	require.Contains(t, string(payload), `"nested":{"a":2,"z":1}`)
+
+	marshalUnsecured := func() string {
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		jwtClaims, err := vc.JWTClaims(true)
+		require.NoError(t, err)
+
+		unsecuredJWT, err := jwtClaims.MarshalUnsecuredJWT()
+		require.NoError(t, err)
+
+		return unsecuredJWT
+	}
+
+	require.Equal(t, marshalUnsecured(), marshalUnsecured())
+}
+
 type invalidCredClaims struct {
 	*jwt.Claims
 
@@ -73,6 +73,16 @@ func TestParseCredentialFromJWS(t *testing.T) {
 		require.Equal(t, vc, vcFromJWT)
 	})
 
+	t.Run("Decoding credential from JWS with BOM and surrounding whitespace", func(t *testing.T) {
+		jws := createEdDSAJWS(t, testCred, ed25519Signer, false)
+
+		vcFromJWT, err := parseTestCredential(t,
+			append([]byte("\xEF\xBB\xBF \n"), append(jws, '\n')...),
+			WithPublicKeyFetcher(ed25519KeyFetcher))
+		require.NoError(t, err)
+		require.Equal(t, string(jws), vcFromJWT.JWT)
+	})
+
 	t.Run("Decoding credential from JWS with minimized fields of \"vc\" claim", func(t *testing.T) {
 		vcFromJWT, err := parseTestCredential(t,
 			createEdDSAJWS(t, testCred, ed25519Signer, true),
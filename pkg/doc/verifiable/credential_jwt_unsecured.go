@@ -10,6 +10,7 @@ import (
 )
 
 // MarshalUnsecuredJWT serialized JWT into unsecured JWT.
+// The claims are serialized deterministically, see MarshalJWS.
 func (jcc *JWTCredClaims) MarshalUnsecuredJWT() (string, error) {
 	return marshalUnsecuredJWT(nil, jcc)
 }
@@ -7,10 +7,15 @@ package verifiable
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
 )
 
+const assertionMethodProofPurpose = "assertionMethod"
+
 // AddLinkedDataProof appends proof to the Verifiable Credential.
 func (vc *Credential) AddLinkedDataProof(context *LinkedDataProofContext, jsonldOpts ...jsonld.ProcessorOpts) error {
 	vcBytes, err := vc.MarshalJSON()
@@ -27,3 +32,18 @@ func (vc *Credential) AddLinkedDataProof(context *LinkedDataProofContext, jsonld
 
 	return nil
 }
+
+// AddEd25519Signature2018JWSProof appends Ed25519Signature2018 proof to the Verifiable Credential.
+// The signature is represented by a detached JWS with unencoded payload ({"alg":"EdDSA","b64":false,"crit":["b64"]}),
+// which is the form used e.g. by DomainLinkageCredential of the DID configuration.
+func (vc *Credential) AddEd25519Signature2018JWSProof(signer Signer, verificationMethod string, created time.Time,
+	jsonldOpts ...jsonld.ProcessorOpts) error {
+	return vc.AddLinkedDataProof(&LinkedDataProofContext{
+		SignatureType:           ed25519Signature2018,
+		Suite:                   ed25519signature2018.New(suite.WithSigner(signer)),
+		SignatureRepresentation: SignatureJWS,
+		Created:                 &created,
+		VerificationMethod:      verificationMethod,
+		Purpose:                 assertionMethodProofPurpose,
+	}, jsonldOpts...)
+}
@@ -14,6 +14,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/btcsuite/btcutil/base58"
 	"github.com/google/uuid"
@@ -33,6 +34,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2020"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/jsonwebsignature2020"
 	sigverifier "github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
 	jsonutil "github.com/hyperledger/aries-framework-go/pkg/doc/util/json"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
@@ -1069,6 +1071,71 @@ func TestParseCredential_ProofCreatedWithMillisec(t *testing.T) {
 	require.NotNil(t, vc)
 }
 
+func TestParseCredential_WithProofCreatedConsistency(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	sigSuite := ed25519signature2018.New(
+		suite.WithSigner(signer),
+		suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier()))
+
+	issued := time.Date(2020, 3, 10, 4, 24, 12, 0, time.UTC)
+
+	signVC := func(t *testing.T, created time.Time) []byte {
+		t.Helper()
+
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		vc.Issued = util.NewTime(issued)
+
+		err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+			SignatureType:           "Ed25519Signature2018",
+			SignatureRepresentation: SignatureJWS,
+			Suite:                   sigSuite,
+			VerificationMethod:      "did:example:123456#key1",
+			Created:                 &created,
+		}, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+		require.NoError(t, err)
+
+		vcBytes, err := json.Marshal(vc)
+		require.NoError(t, err)
+
+		return vcBytes
+	}
+
+	parseOpts := []CredentialOpt{
+		WithEmbeddedSignatureSuites(sigSuite),
+		WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)),
+	}
+
+	t.Run("proof created before issuance is rejected", func(t *testing.T) {
+		vcBytes := signVC(t, issued.Add(-time.Hour))
+
+		_, err := parseTestCredential(t, vcBytes, append(parseOpts, WithProofCreatedConsistency())...)
+		require.EqualError(t, err,
+			"proof 0 is created at 2020-03-10T03:24:12Z before the issuance date 2020-03-10T04:24:12Z")
+
+		// not checked by default
+		_, err = parseTestCredential(t, vcBytes, parseOpts...)
+		require.NoError(t, err)
+	})
+
+	t.Run("proof created within clock skew is accepted", func(t *testing.T) {
+		vcBytes := signVC(t, issued.Add(-30*time.Second))
+
+		_, err := parseTestCredential(t, vcBytes, append(parseOpts, WithProofCreatedConsistency())...)
+		require.NoError(t, err)
+	})
+
+	t.Run("proof created after issuance is accepted", func(t *testing.T) {
+		vcBytes := signVC(t, issued.Add(time.Hour))
+
+		_, err := parseTestCredential(t, vcBytes, append(parseOpts, WithProofCreatedConsistency())...)
+		require.NoError(t, err)
+	})
+}
+
 func TestParseCredentialWithSeveralLinkedDataProofs(t *testing.T) {
 	r := require.New(t)
 
@@ -1253,6 +1320,41 @@ func TestCredential_AddLinkedDataProof(t *testing.T) {
 		r.Equal(originalVCMap, vcMap)
 	})
 
+	t.Run("Add Ed25519Signature2018 proof with detached JWS to VC", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		r.NoError(err)
+
+		created := time.Date(2020, time.December, 4, 20, 8, 28, 0, time.UTC)
+
+		err = vc.AddEd25519Signature2018JWSProof(signer, "did:example:xyz#key-1", created,
+			jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+		r.NoError(err)
+		r.Len(vc.Proofs, 1)
+
+		r.Equal("Ed25519Signature2018", vc.Proofs[0]["type"])
+		r.Equal("assertionMethod", vc.Proofs[0]["proofPurpose"])
+		r.Equal("did:example:xyz#key-1", vc.Proofs[0]["verificationMethod"])
+		r.Equal("2020-12-04T20:08:28Z", vc.Proofs[0]["created"])
+
+		jws, ok := vc.Proofs[0]["jws"].(string)
+		r.True(ok)
+
+		jwsParts := strings.Split(jws, ".")
+		r.Len(jwsParts, 3)
+		r.Empty(jwsParts[1])
+
+		header, err := base64.RawURLEncoding.DecodeString(jwsParts[0])
+		r.NoError(err)
+		r.JSONEq(`{"alg":"EdDSA","b64":false,"crit":["b64"]}`, string(header))
+
+		vcBytes, err := vc.MarshalJSON()
+		r.NoError(err)
+
+		_, err = parseTestCredential(t, vcBytes,
+			WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)))
+		r.NoError(err)
+	})
+
 	t.Run("Add invalid Linked Data proof to VC", func(t *testing.T) {
 		vc, err := parseTestCredential(t, []byte(validCredential))
 		require.NoError(t, err)
@@ -1360,3 +1462,270 @@ func (s *bbsSigner) textToLines(txt string) [][]byte {
 
 	return linesBytes
 }
+
+// slowSuite delays the signature verification of the wrapped suite.
+type slowSuite struct {
+	sigverifier.SignatureSuite
+	delay time.Duration
+}
+
+func (s *slowSuite) Verify(pubKey *sigverifier.PublicKey, doc, signature []byte) error {
+	time.Sleep(s.delay)
+
+	return s.SignatureSuite.Verify(pubKey, doc, signature)
+}
+
+func TestParseCredential_WithVerificationTimeout(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	sigSuite := ed25519signature2018.New(
+		suite.WithSigner(signer),
+		suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier()))
+
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	require.NoError(t, err)
+
+	err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+		SignatureType:           "Ed25519Signature2018",
+		SignatureRepresentation: SignatureJWS,
+		Suite:                   sigSuite,
+		VerificationMethod:      "did:example:123456#key1",
+	}, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+	require.NoError(t, err)
+
+	vcBytes, err := json.Marshal(vc)
+	require.NoError(t, err)
+
+	slow := &slowSuite{SignatureSuite: sigSuite, delay: time.Second}
+	fetcher := WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519))
+
+	t.Run("slow verification times out", func(t *testing.T) {
+		start := time.Now()
+
+		_, err := parseTestCredential(t, vcBytes, WithEmbeddedSignatureSuites(slow), fetcher,
+			WithVerificationTimeout(50*time.Millisecond))
+		require.ErrorIs(t, err, ErrVerificationTimeout)
+		require.Contains(t, err.Error(), "proof verification timed out after 50ms")
+		require.Less(t, time.Since(start), slow.delay)
+
+		results, err := VerifyAllProofs(vc, SingleKey(signer.PublicKeyBytes(), kms.ED25519),
+			WithEmbeddedSignatureSuites(slow), WithJSONLDDocumentLoader(createTestDocumentLoader(t)),
+			WithVerificationTimeout(50*time.Millisecond))
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		require.ErrorIs(t, results[0].Err, ErrVerificationTimeout)
+	})
+
+	t.Run("verification within timeout", func(t *testing.T) {
+		_, err := parseTestCredential(t, vcBytes, WithEmbeddedSignatureSuites(sigSuite), fetcher,
+			WithVerificationTimeout(time.Minute))
+		require.NoError(t, err)
+	})
+
+	t.Run("JWT verification times out", func(t *testing.T) {
+		jwtClaims, err := vc.JWTClaims(false)
+		require.NoError(t, err)
+
+		jws, err := jwtClaims.MarshalJWS(EdDSA, signer, "did:example:123456#key1")
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(t, []byte(jws), WithVerificationTimeout(50*time.Millisecond),
+			WithPublicKeyFetcher(func(issuerID, keyID string) (*sigverifier.PublicKey, error) {
+				time.Sleep(time.Second)
+
+				return &sigverifier.PublicKey{Type: kms.ED25519, Value: signer.PublicKeyBytes()}, nil
+			}))
+		require.ErrorIs(t, err, ErrVerificationTimeout)
+	})
+}
+
+func TestParseCredential_WithBindVerificationMethodToIssuer(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	sigSuite := ed25519signature2018.New(
+		suite.WithSigner(signer),
+		suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier()))
+
+	const (
+		issuerVM  = "did:example:76e12ec712ebc6f1c221ebfeb1f#key1"
+		foreignVM = "did:example:attacker#key1"
+	)
+
+	signVC := func(t *testing.T, verificationMethod string) []byte {
+		t.Helper()
+
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+			SignatureType:           "Ed25519Signature2018",
+			SignatureRepresentation: SignatureJWS,
+			Suite:                   sigSuite,
+			VerificationMethod:      verificationMethod,
+		}, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+		require.NoError(t, err)
+
+		vcBytes, err := json.Marshal(vc)
+		require.NoError(t, err)
+
+		return vcBytes
+	}
+
+	signJWT := func(t *testing.T, kid string) []byte {
+		t.Helper()
+
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		jwtClaims, err := vc.JWTClaims(false)
+		require.NoError(t, err)
+
+		jws, err := jwtClaims.MarshalJWS(EdDSA, signer, kid)
+		require.NoError(t, err)
+
+		return []byte(jws)
+	}
+
+	// the fetcher returns the signing key for any verification method, like a resolver of a DID controlled
+	// by the attacker would do for the foreign one
+	parseOpts := []CredentialOpt{
+		WithEmbeddedSignatureSuites(sigSuite),
+		WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)),
+		WithBindVerificationMethodToIssuer(),
+	}
+
+	t.Run("linked data proof of issuer's verification method", func(t *testing.T) {
+		_, err := parseTestCredential(t, signVC(t, issuerVM), parseOpts...)
+		require.NoError(t, err)
+	})
+
+	t.Run("linked data proof of foreign verification method is rejected", func(t *testing.T) {
+		vcBytes := signVC(t, foreignVM)
+
+		_, err := parseTestCredential(t, vcBytes, parseOpts...)
+		require.Error(t, err)
+		require.Contains(t, err.Error(),
+			"verification method did:example:attacker#key1 doesn't belong to issuer did:example:76e12ec712ebc6f1c221ebfeb1f")
+
+		// not checked by default
+		_, err = parseTestCredential(t, vcBytes, parseOpts[:2]...)
+		require.NoError(t, err)
+	})
+
+	t.Run("JWT of issuer's verification method", func(t *testing.T) {
+		_, err := parseTestCredential(t, signJWT(t, issuerVM), parseOpts...)
+		require.NoError(t, err)
+	})
+
+	t.Run("JWT of foreign verification method is rejected", func(t *testing.T) {
+		_, err := parseTestCredential(t, signJWT(t, foreignVM), parseOpts...)
+		require.Error(t, err)
+		require.Contains(t, err.Error(),
+			"verification method did:example:attacker#key1 doesn't belong to issuer did:example:76e12ec712ebc6f1c221ebfeb1f")
+	})
+
+	t.Run("issuer is not DID", func(t *testing.T) {
+		vcBytes := signVC(t, issuerVM)
+
+		var raw map[string]interface{}
+		require.NoError(t, json.Unmarshal(vcBytes, &raw))
+
+		raw["issuer"] = "https://example.edu/issuers/14"
+
+		vcBytes, err := json.Marshal(raw)
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(t, vcBytes, parseOpts...)
+		require.EqualError(t, err, "decode new credential: "+
+			"verification method can't be bound to issuer 'https://example.edu/issuers/14' which is not DID")
+	})
+}
+
+func TestParseCredential_WithVerificationMethod(t *testing.T) {
+	activeSigner, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	revokedSigner, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	const (
+		activeVM  = "did:example:76e12ec712ebc6f1c221ebfeb1f#key1"
+		revokedVM = "did:example:76e12ec712ebc6f1c221ebfeb1f#key2"
+	)
+
+	loader := createTestDocumentLoader(t)
+
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	require.NoError(t, err)
+
+	addProof := func(sigSuite *ed25519signature2018.Suite, verificationMethod string) {
+		err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+			SignatureType:           "Ed25519Signature2018",
+			SignatureRepresentation: SignatureJWS,
+			Suite:                   sigSuite,
+			VerificationMethod:      verificationMethod,
+		}, jsonldsig.WithDocumentLoader(loader))
+		require.NoError(t, err)
+	}
+
+	addProof(ed25519signature2018.New(suite.WithSigner(activeSigner)), activeVM)
+	addProof(ed25519signature2018.New(suite.WithSigner(revokedSigner)), revokedVM)
+
+	require.Len(t, vc.Proofs, 2)
+
+	vcBytes, err := json.Marshal(vc)
+	require.NoError(t, err)
+
+	// the DID document has the active key only, the other one was removed after the key rotation
+	fetcher := WithPublicKeyFetcher(func(issuerID, keyID string) (*sigverifier.PublicKey, error) {
+		if issuerID+"#"+strings.TrimPrefix(keyID, "#") != activeVM {
+			return nil, fmt.Errorf("public key %s not found", keyID)
+		}
+
+		return &sigverifier.PublicKey{Type: kms.ED25519, Value: activeSigner.PublicKeyBytes()}, nil
+	})
+
+	t.Run("proof of pinned verification method is accepted", func(t *testing.T) {
+		vcDecoded, err := parseTestCredential(t, vcBytes, fetcher, WithVerificationMethod(activeVM))
+		require.NoError(t, err)
+		require.Len(t, vcDecoded.Proofs, 2)
+
+		// all proofs are checked by default
+		_, err = parseTestCredential(t, vcBytes, fetcher)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "public key #key2 not found")
+	})
+
+	t.Run("proof of other verification method is rejected", func(t *testing.T) {
+		_, err := parseTestCredential(t, vcBytes, fetcher, WithVerificationMethod(revokedVM))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "public key #key2 not found")
+
+		vcWithRevokedProof, err := parseTestCredential(t, vcBytes, WithDisabledProofCheck())
+		require.NoError(t, err)
+
+		vcWithRevokedProof.Proofs = vcWithRevokedProof.Proofs[1:]
+
+		vcWithRevokedBytes, err := json.Marshal(vcWithRevokedProof)
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(t, vcWithRevokedBytes, fetcher, WithVerificationMethod(activeVM))
+		require.ErrorIs(t, err, ErrNoProofOfVerificationMethod)
+	})
+
+	t.Run("JWT", func(t *testing.T) {
+		jwtClaims, err := vc.JWTClaims(false)
+		require.NoError(t, err)
+
+		jws, err := jwtClaims.MarshalJWS(EdDSA, activeSigner, activeVM)
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(t, []byte(jws), fetcher, WithVerificationMethod(activeVM))
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(t, []byte(jws), fetcher, WithVerificationMethod(revokedVM))
+		require.ErrorIs(t, err, ErrNoProofOfVerificationMethod)
+	})
+}
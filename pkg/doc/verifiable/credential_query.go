@@ -0,0 +1,95 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/PaesslerAG/jsonpath"
+)
+
+// Query evaluates the JSONPath expression against the JSON of the whole credential
+// (e.g. "$.credentialSubject.origin" or "$.credentialSubject.degrees[0].type") and returns the matched values.
+//
+// A definite path (without wildcards, recursive descent, filters, slices or unions) matches a single value,
+// which is returned as a one-element slice even if the value itself is an array. A path which doesn't match
+// anything returns an empty result. The credential parsed from JWT is queried in its JSON-LD form.
+func (vc *Credential) Query(jsonPath string) ([]interface{}, error) {
+	eval, err := jsonpath.New(jsonPath)
+	if err != nil {
+		return nil, fmt.Errorf("parse JSONPath %s: %w", jsonPath, err)
+	}
+
+	raw, err := vc.raw()
+	if err != nil {
+		return nil, fmt.Errorf("query credential: %w", err)
+	}
+
+	rawBytes, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("query credential: %w", err)
+	}
+
+	var doc interface{}
+
+	err = json.Unmarshal(rawBytes, &doc)
+	if err != nil {
+		return nil, fmt.Errorf("query credential: %w", err)
+	}
+
+	// evaluation fails on unknown keys, out of bound indices etc., i.e. when the path matches nothing
+	result, err := eval(context.Background(), doc)
+	if err != nil {
+		return []interface{}{}, nil
+	}
+
+	if isDefiniteJSONPath(jsonPath) {
+		return []interface{}{result}, nil
+	}
+
+	values, ok := result.([]interface{})
+	if !ok {
+		return []interface{}{result}, nil
+	}
+
+	return values, nil
+}
+
+// isDefiniteJSONPath reports whether the path selects a single value, ignoring quoted member names.
+func isDefiniteJSONPath(jsonPath string) bool {
+	var (
+		quote     rune
+		inBracket bool
+		prev      rune
+	)
+
+	for _, c := range jsonPath {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '*' || c == '?' || (c == '.' && prev == '.'):
+			return false
+		case c == '[':
+			inBracket = true
+		case c == ']':
+			inBracket = false
+		case inBracket && strings.ContainsRune(":,", c):
+			return false
+		}
+
+		prev = c
+	}
+
+	return true
+}
@@ -0,0 +1,96 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCredential_Query(t *testing.T) {
+	vc := &Credential{
+		Context: []string{ContextURI},
+		Types:   []string{VCType, "UniversityDegreeCredential"},
+		Issuer:  Issuer{ID: "did:example:issuer"},
+		Subject: Subject{
+			ID: "did:example:subject",
+			CustomFields: CustomFields{
+				"origin": "https://example.com",
+				"degrees": []interface{}{
+					map[string]interface{}{"type": "BachelorDegree", "name": "Bachelor of Science"},
+					map[string]interface{}{"type": "MasterDegree", "name": "Master of Arts"},
+				},
+			},
+		},
+	}
+
+	t.Run("nested subject field", func(t *testing.T) {
+		values, err := vc.Query("$.credentialSubject.origin")
+		require.NoError(t, err)
+		require.Equal(t, []interface{}{"https://example.com"}, values)
+
+		values, err = vc.Query(`$["credentialSubject"]["degrees"][1]["name"]`)
+		require.NoError(t, err)
+		require.Equal(t, []interface{}{"Master of Arts"}, values)
+	})
+
+	t.Run("array index", func(t *testing.T) {
+		values, err := vc.Query("$.credentialSubject.degrees[0].type")
+		require.NoError(t, err)
+		require.Equal(t, []interface{}{"BachelorDegree"}, values)
+
+		values, err = vc.Query("$.type[1]")
+		require.NoError(t, err)
+		require.Equal(t, []interface{}{"UniversityDegreeCredential"}, values)
+	})
+
+	t.Run("definite path to an array", func(t *testing.T) {
+		values, err := vc.Query("$.type")
+		require.NoError(t, err)
+		require.Equal(t, []interface{}{[]interface{}{VCType, "UniversityDegreeCredential"}}, values)
+	})
+
+	t.Run("several matches", func(t *testing.T) {
+		values, err := vc.Query("$.credentialSubject.degrees[*].type")
+		require.NoError(t, err)
+		require.Equal(t, []interface{}{"BachelorDegree", "MasterDegree"}, values)
+
+		values, err = vc.Query("$..name")
+		require.NoError(t, err)
+		require.ElementsMatch(t, []interface{}{"Bachelor of Science", "Master of Arts"}, values)
+
+		values, err = vc.Query(`$.credentialSubject.degrees[?(@.type == "MasterDegree")].name`)
+		require.NoError(t, err)
+		require.Equal(t, []interface{}{"Master of Arts"}, values)
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		values, err := vc.Query("$.credentialSubject.unknown")
+		require.NoError(t, err)
+		require.Empty(t, values)
+
+		values, err = vc.Query("$.credentialSubject.degrees[5]")
+		require.NoError(t, err)
+		require.Empty(t, values)
+	})
+
+	t.Run("credential parsed from JWT", func(t *testing.T) {
+		jwtVC := *vc
+		jwtVC.JWT = "header.payload.signature"
+
+		values, err := jwtVC.Query("$.issuer")
+		require.NoError(t, err)
+		require.Equal(t, []interface{}{"did:example:issuer"}, values)
+	})
+
+	t.Run("invalid JSONPath", func(t *testing.T) {
+		_, err := vc.Query("$.credentialSubject[")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse JSONPath $.credentialSubject[")
+	})
+}
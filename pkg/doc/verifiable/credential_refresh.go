@@ -0,0 +1,82 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// HTTPClient represents an HTTP client.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// ErrNoRefreshService is returned by Credential.Refresh if the credential has no refresh service
+// with an HTTP(S) endpoint (or an id supported by the dereferencer of Credential.RefreshWithDereferencer).
+var ErrNoRefreshService = errors.New("credential has no HTTP(S) refresh service")
+
+// refreshAccept is the Accept header of the HTTP requests to refresh services.
+const refreshAccept = "application/vc+ld+json, application/vc+jwt, application/json"
+
+// Refresh fetches an updated version of the credential from its refreshService (the first one with an HTTP(S) id)
+// and parses it with the options, e.g. WithPublicKeyFetcher to check its proof.
+// The refreshed credential must be issued by the same issuer, have all the types of the credential
+// and must not be expired.
+func (vc *Credential) Refresh(client HTTPClient, opts ...CredentialOpt) (*Credential, error) {
+	return vc.RefreshWithDereferencer(&HTTPURIDereferencer{client: client, accept: refreshAccept}, opts...)
+}
+
+// RefreshWithDereferencer is like Refresh, but the updated version of the credential is fetched from
+// the first refreshService whose id is supported by the dereferencer.
+func (vc *Credential) RefreshWithDereferencer(dereferencer URIDereferencer,
+	opts ...CredentialOpt) (*Credential, error) {
+	vcBytes, err := vc.fetchRefreshedCredential(dereferencer)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshed, err := ParseCredential(vcBytes, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("refresh credential: parse refreshed credential: %w", err)
+	}
+
+	if refreshed.Issuer.ID != vc.Issuer.ID {
+		return nil, fmt.Errorf("refresh credential: refreshed credential issuer %s is different from issuer %s",
+			refreshed.Issuer.ID, vc.Issuer.ID)
+	}
+
+	for _, t := range vc.Types {
+		if !contains(refreshed.Types, t) {
+			return nil, fmt.Errorf("refresh credential: refreshed credential is not of type %s", t)
+		}
+	}
+
+	if refreshed.Expired != nil && refreshed.Expired.Time.Before(time.Now()) {
+		return nil, fmt.Errorf("refresh credential: refreshed credential expired at %s", refreshed.Expired.Time)
+	}
+
+	return refreshed, nil
+}
+
+func (vc *Credential) fetchRefreshedCredential(dereferencer URIDereferencer) ([]byte, error) {
+	for _, rs := range vc.RefreshService {
+		vcBytes, err := dereferencer.Dereference(rs.ID)
+		if errors.Is(err, ErrUnsupportedURI) {
+			continue
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("refresh credential: refresh service %s: %w", rs.ID, err)
+		}
+
+		return vcBytes, nil
+	}
+
+	return nil, ErrNoRefreshService
+}
@@ -0,0 +1,127 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+func TestCredential_Refresh(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	fetcher := createDIDKeyFetcher(t, signer.PublicKeyBytes(), "76e12ec712ebc6f1c221ebfeb1f")
+
+	expiredVC, err := parseTestCredential(t, []byte(jwtTestCredential))
+	require.NoError(t, err)
+
+	nextYear := time.Now().AddDate(1, 0, 0).UTC().Format(time.RFC3339)
+
+	renewedVCJWS := func(t *testing.T, cred string) []byte {
+		t.Helper()
+
+		return createEdDSAJWS(t, []byte(strings.Replace(cred, "2020-01-01T19:23:24Z", nextYear, 1)), signer, false)
+	}
+
+	newRefreshServer := func(t *testing.T, status int, body []byte) *httptest.Server {
+		t.Helper()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodGet, r.Method)
+
+			w.WriteHeader(status)
+			_, err := w.Write(body)
+			require.NoError(t, err)
+		}))
+		t.Cleanup(server.Close)
+
+		return server
+	}
+
+	withRefreshService := func(vc Credential, endpoint string) *Credential {
+		vc.RefreshService = []TypedID{
+			{ID: "urn:uuid:not-http", Type: "ManualRefreshService2018"},
+			{ID: endpoint, Type: "ManualRefreshService2018"},
+		}
+
+		return &vc
+	}
+
+	t.Run("success", func(t *testing.T) {
+		server := newRefreshServer(t, http.StatusOK, renewedVCJWS(t, jwtTestCredential))
+
+		vc := withRefreshService(*expiredVC, server.URL+"/refresh/3732")
+
+		refreshed, err := vc.Refresh(server.Client(),
+			WithPublicKeyFetcher(fetcher), WithJSONLDDocumentLoader(createTestDocumentLoader(t)))
+		require.NoError(t, err)
+		require.NotEmpty(t, refreshed.JWT)
+		require.Equal(t, vc.Issuer.ID, refreshed.Issuer.ID)
+		require.True(t, refreshed.Expired.Time.After(time.Now()))
+	})
+
+	t.Run("error - no refresh service", func(t *testing.T) {
+		_, err := expiredVC.Refresh(http.DefaultClient)
+		require.True(t, errors.Is(err, ErrNoRefreshService))
+	})
+
+	t.Run("error - refresh service HTTP failure", func(t *testing.T) {
+		server := newRefreshServer(t, http.StatusNotFound, nil)
+
+		vc := withRefreshService(*expiredVC, server.URL)
+
+		_, err := vc.Refresh(server.Client(), WithPublicKeyFetcher(fetcher))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "HTTP failure [404]")
+	})
+
+	t.Run("error - invalid proof of refreshed credential", func(t *testing.T) {
+		otherSigner, err := newCryptoSigner(kms.ED25519Type)
+		require.NoError(t, err)
+
+		server := newRefreshServer(t, http.StatusOK, renewedVCJWS(t, jwtTestCredential))
+
+		vc := withRefreshService(*expiredVC, server.URL)
+
+		_, err = vc.Refresh(server.Client(),
+			WithPublicKeyFetcher(createDIDKeyFetcher(t, otherSigner.PublicKeyBytes(), "76e12ec712ebc6f1c221ebfeb1f")),
+			WithJSONLDDocumentLoader(createTestDocumentLoader(t)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse refreshed credential")
+	})
+
+	t.Run("error - refreshed credential of another issuer", func(t *testing.T) {
+		server := newRefreshServer(t, http.StatusOK, renewedVCJWS(t,
+			strings.Replace(jwtTestCredential, "did:example:76e12ec712ebc6f1c221ebfeb1f", "did:example:other", 1)))
+
+		vc := withRefreshService(*expiredVC, server.URL)
+
+		_, err := vc.Refresh(server.Client(),
+			WithDisabledProofCheck(), WithJSONLDDocumentLoader(createTestDocumentLoader(t)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "is different from issuer")
+	})
+
+	t.Run("error - refreshed credential is expired", func(t *testing.T) {
+		server := newRefreshServer(t, http.StatusOK, createEdDSAJWS(t, []byte(jwtTestCredential), signer, false))
+
+		vc := withRefreshService(*expiredVC, server.URL)
+
+		_, err := vc.Refresh(server.Client(),
+			WithPublicKeyFetcher(fetcher), WithJSONLDDocumentLoader(createTestDocumentLoader(t)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "refreshed credential expired")
+	})
+}
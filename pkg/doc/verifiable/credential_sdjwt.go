@@ -12,6 +12,7 @@ import (
 	"fmt"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/sdjwt/common"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/sdjwt/holder"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/sdjwt/issuer"
@@ -235,7 +236,8 @@ func filterDisclosures(
 }
 
 type makeSDJWTOpts struct {
-	hashAlg crypto.Hash
+	hashAlg         crypto.Hash
+	holderPublicKey *jwk.JWK
 }
 
 // MakeSDJWTOption provides an option for creating an SD-JWT from a VC.
@@ -248,6 +250,14 @@ func MakeSDJWTWithHash(hash crypto.Hash) MakeSDJWTOption {
 	}
 }
 
+// MakeSDJWTWithHolderPublicKey binds an SD-JWT VC to the holder public key (cnf claim), which is used to verify
+// the holder binding JWT of the presented SD-JWT VC.
+func MakeSDJWTWithHolderPublicKey(holderPublicKey *jwk.JWK) MakeSDJWTOption {
+	return func(opts *makeSDJWTOpts) {
+		opts.holderPublicKey = holderPublicKey
+	}
+}
+
 // MakeSDJWT creates an SD-JWT in combined format for issuance, with all fields in credentialSubject converted
 // recursively into selectively-disclosable SD-JWT claims.
 func (vc *Credential) MakeSDJWT(signer jose.Signer, signingKeyID string, options ...MakeSDJWTOption) (string, error) {
@@ -302,6 +312,10 @@ func makeSDJWT(vc *Credential, signer jose.Signer, signingKeyID string, options
 		issuerOptions = append(issuerOptions, issuer.WithHashAlgorithm(opts.hashAlg))
 	}
 
+	if opts.holderPublicKey != nil {
+		issuerOptions = append(issuerOptions, issuer.WithHolderPublicKey(opts.holderPublicKey))
+	}
+
 	sdjwt, err := issuer.NewFromVC(claimMap, headers, signer, issuerOptions...)
 	if err != nil {
 		return nil, fmt.Errorf("creating SD-JWT from VC: %w", err)
@@ -310,6 +324,19 @@ func makeSDJWT(vc *Credential, signer jose.Signer, signingKeyID string, options
 	return sdjwt, nil
 }
 
+// DisclosedClaims returns the claims revealed by the disclosures of an SD-JWT credential (e.g. the ones selected
+// by the holder for a presentation), keyed by claim name. The claims are returned as disclosed, use
+// CreateDisplayCredential to get the credential with the disclosed claims in their place.
+func (vc *Credential) DisclosedClaims() map[string]interface{} {
+	claims := make(map[string]interface{}, len(vc.SDJWTDisclosures))
+
+	for _, disclosure := range vc.SDJWTDisclosures {
+		claims[disclosure.Name] = disclosure.Value
+	}
+
+	return claims
+}
+
 type displayCredOpts struct {
 	displayAll   bool
 	displayGiven []string
@@ -10,14 +10,17 @@ import (
 	"crypto/ed25519"
 	"crypto/rand"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/go-jose/go-jose/v3/jwt"
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/sdjwt/holder"
 
@@ -510,3 +513,138 @@ func createTestSDJWTCred(t *testing.T, privKey ed25519.PrivateKey) (sdJWTCred st
 
 	return sdjwt, srcVC.Issuer.ID
 }
+
+func TestParsePresentationWithSDJWT(t *testing.T) {
+	issuerPubKey, issuerPrivKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	holderPubKey, holderPrivKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	holderJWK, err := jwksupport.JWKFromKey(holderPubKey)
+	require.NoError(t, err)
+
+	srcVC, err := parseTestCredential(t, []byte(jwtTestCredential))
+	require.NoError(t, err)
+
+	sdJWT, err := srcVC.MakeSDJWT(afgojwt.NewEd25519Signer(issuerPrivKey), srcVC.Issuer.ID+"#keys-1",
+		MakeSDJWTWithHolderPublicKey(holderJWK))
+	require.NoError(t, err)
+
+	issuerKeyFetcher := createDIDKeyFetcher(t, issuerPubKey, srcVC.Issuer.ID)
+
+	heldVC, err := ParseCredential([]byte(sdJWT), WithPublicKeyFetcher(issuerKeyFetcher))
+	require.NoError(t, err)
+
+	const (
+		audience = "did:example:verifier"
+		nonce    = "nonce-123"
+	)
+
+	newVP := func(t *testing.T, signer jose.Signer, disclose ...string) []byte {
+		t.Helper()
+
+		opts := []MarshalDisclosureOption{DiscloseGivenRequired(disclose)}
+
+		if signer != nil {
+			opts = append(opts, DisclosureHolderBinding(&holder.BindingInfo{
+				Payload: holder.BindingPayload{
+					Nonce:    nonce,
+					Audience: audience,
+					IssuedAt: jwt.NewNumericDate(time.Now()),
+				},
+				Signer: signer,
+			}))
+		}
+
+		presentedVC, err := heldVC.MarshalWithDisclosure(opts...)
+		require.NoError(t, err)
+
+		vpBytes, err := json.Marshal(map[string]interface{}{
+			"@context":             []string{ContextURI},
+			"type":                 []string{VPType},
+			"verifiableCredential": []string{presentedVC},
+		})
+		require.NoError(t, err)
+
+		return vpBytes
+	}
+
+	holderSigner := afgojwt.NewEd25519Signer(holderPrivKey)
+
+	t.Run("success - selected claims are disclosed", func(t *testing.T) {
+		vp, err := newTestPresentation(t, newVP(t, holderSigner, "university"),
+			WithPresPublicKeyFetcher(issuerKeyFetcher),
+			WithPresExpectedSDJWTHolderBinding(audience, nonce))
+		require.NoError(t, err)
+		require.Len(t, vp.Credentials(), 1)
+
+		vc, ok := vp.Credentials()[0].(*Credential)
+		require.True(t, ok)
+		require.Equal(t, map[string]interface{}{"university": "MIT"}, vc.DisclosedClaims())
+		require.NotEmpty(t, vc.SDHolderBinding)
+
+		displayVC, err := vc.CreateDisplayCredential(DisplayAllDisclosures())
+		require.NoError(t, err)
+
+		subjects, ok := displayVC.Subject.([]Subject)
+		require.True(t, ok)
+		require.Equal(t, map[string]interface{}{"university": "MIT"}, subjects[0].CustomFields["degree"])
+	})
+
+	t.Run("success - holder binding is not required by default", func(t *testing.T) {
+		vp, err := newTestPresentation(t, newVP(t, nil, "type", "university"),
+			WithPresPublicKeyFetcher(issuerKeyFetcher))
+		require.NoError(t, err)
+
+		vc, ok := vp.Credentials()[0].(*Credential)
+		require.True(t, ok)
+		require.Len(t, vc.DisclosedClaims(), 2)
+	})
+
+	t.Run("error - holder binding is required", func(t *testing.T) {
+		_, err := newTestPresentation(t, newVP(t, nil, "university"),
+			WithPresPublicKeyFetcher(issuerKeyFetcher),
+			WithPresExpectedSDJWTHolderBinding(audience, nonce))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "holder binding is required")
+	})
+
+	t.Run("error - holder binding signed by other key", func(t *testing.T) {
+		_, otherPrivKey, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		_, err = newTestPresentation(t, newVP(t, afgojwt.NewEd25519Signer(otherPrivKey), "university"),
+			WithPresPublicKeyFetcher(issuerKeyFetcher))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to verify holder binding")
+	})
+
+	t.Run("error - unexpected nonce", func(t *testing.T) {
+		_, err := newTestPresentation(t, newVP(t, holderSigner, "university"),
+			WithPresPublicKeyFetcher(issuerKeyFetcher),
+			WithPresExpectedSDJWTHolderBinding(audience, "other-nonce"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "does not match expected nonce value 'other-nonce'")
+	})
+
+	t.Run("error - disclosure not contained in SD-JWT", func(t *testing.T) {
+		presentedVC, err := heldVC.MarshalWithDisclosure(DiscloseGivenRequired([]string{"university"}))
+		require.NoError(t, err)
+
+		cfp := common.ParseCombinedFormatForPresentation(presentedVC)
+		cfp.Disclosures = append(cfp.Disclosures,
+			base64.RawURLEncoding.EncodeToString([]byte(`["salt","university","Harvard"]`)))
+
+		vpBytes, err := json.Marshal(map[string]interface{}{
+			"@context":             []string{ContextURI},
+			"type":                 []string{VPType},
+			"verifiableCredential": []string{cfp.Serialize()},
+		})
+		require.NoError(t, err)
+
+		_, err = newTestPresentation(t, vpBytes, WithPresPublicKeyFetcher(issuerKeyFetcher))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid SDJWT disclosures")
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"crypto/ed25519"
 	"crypto/rand"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -110,6 +111,12 @@ func TestParseCredential(t *testing.T) {
 		require.Len(t, vc.TermsOfUse, 1)
 	})
 
+	t.Run("test creation of new Verifiable Credential from JSON with BOM and leading whitespace", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte("\xEF\xBB\xBF\r\n\t"+validCredential), WithStrictValidation())
+		require.NoError(t, err)
+		require.Equal(t, "http://example.edu/credentials/1872", vc.ID)
+	})
+
 	t.Run("test a try to create a new Verifiable Credential from JSON with invalid structure", func(t *testing.T) {
 		emptyJSONDoc := "{}"
 		vc, err := parseTestCredential(t, []byte(emptyJSONDoc))
@@ -904,6 +911,56 @@ func TestWithBaseContextExtendedValidation(t *testing.T) {
 		opts.allowedCustomTypes)
 }
 
+func TestWithAllowedContexts(t *testing.T) {
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	require.NoError(t, err)
+
+	allowedContexts := WithAllowedContexts(vc.Context...)
+
+	t.Run("all contexts are allowed", func(t *testing.T) {
+		_, err = parseTestCredential(t, []byte(validCredential), allowedContexts)
+		require.NoError(t, err)
+	})
+
+	t.Run("unexpected context is rejected", func(t *testing.T) {
+		_, err = parseTestCredential(t, []byte(validCredential), WithAllowedContexts(vc.Context[:2]...))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "not allowed @context: "+vc.Context[2])
+	})
+
+	t.Run("inline context is rejected", func(t *testing.T) {
+		vcWithInline := *vc
+		vcWithInline.CustomContext = []interface{}{map[string]interface{}{"name": "http://schema.org/name"}}
+
+		vcBytes, err := vcWithInline.MarshalJSON()
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(t, vcBytes, allowedContexts)
+		require.EqualError(t, err, "decode new credential: inline @context is not allowed")
+	})
+
+	t.Run("unexpected context of JWT credential is rejected", func(t *testing.T) {
+		credClaims, err := vc.JWTClaims(true)
+		require.NoError(t, err)
+
+		unsecuredJWT, err := credClaims.MarshalUnsecuredJWT()
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(t, []byte(unsecuredJWT), allowedContexts)
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(t, []byte(unsecuredJWT), WithAllowedContexts(baseContext))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "not allowed @context: "+vc.Context[1])
+	})
+
+	t.Run("no contexts are allowed", func(t *testing.T) {
+		_, err = parseTestCredential(t, []byte(validCredential), WithAllowedContexts())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "not allowed @context: "+baseContext)
+	})
+}
+
 func TestWithJSONLDDocumentLoader(t *testing.T) {
 	documentLoader := ld.NewDefaultDocumentLoader(nil)
 	credentialOpt := WithJSONLDDocumentLoader(documentLoader)
@@ -1284,6 +1341,117 @@ func Test_SubjectID(t *testing.T) {
 	})
 }
 
+func TestCredential_DecodeSubject(t *testing.T) {
+	type domainLinkageSubject struct {
+		ID     string `json:"id"`
+		Origin string `json:"origin"`
+	}
+
+	const domainLinkageCredential = `{
+  "@context": [
+    "https://www.w3.org/2018/credentials/v1",
+    "https://identity.foundation/.well-known/did-configuration/v1"
+  ],
+  "issuer": "did:key:z6MkoTHsgNNrby8JzCNQ1iRLyW5QQ6R8Xuu6AA8igGrMVPUM",
+  "issuanceDate": "2020-12-04T14:08:28-06:00",
+  "expirationDate": "2025-12-04T14:08:28-06:00",
+  "type": [
+    "VerifiableCredential",
+    "DomainLinkageCredential"
+  ],
+  "credentialSubject": {
+    "id": "did:key:z6MkoTHsgNNrby8JzCNQ1iRLyW5QQ6R8Xuu6AA8igGrMVPUM",
+    "origin": "https://identity.foundation"
+  }
+}`
+
+	t.Run("decode DomainLinkageCredential subject", func(t *testing.T) {
+		vc, err := ParseCredential([]byte(domainLinkageCredential),
+			WithCredDisableValidation(), WithDisabledProofCheck())
+		require.NoError(t, err)
+
+		var subject domainLinkageSubject
+
+		err = vc.DecodeSubject(&subject)
+		require.NoError(t, err)
+		require.Equal(t, "did:key:z6MkoTHsgNNrby8JzCNQ1iRLyW5QQ6R8Xuu6AA8igGrMVPUM", subject.ID)
+		require.Equal(t, "https://identity.foundation", subject.Origin)
+
+		var subjects []domainLinkageSubject
+
+		err = vc.DecodeSubjects(&subjects)
+		require.NoError(t, err)
+		require.Equal(t, []domainLinkageSubject{subject}, subjects)
+	})
+
+	t.Run("decode multiple subjects", func(t *testing.T) {
+		vc := &Credential{Subject: []Subject{
+			{ID: "did:example:1", CustomFields: CustomFields{"origin": "https://one.example.com"}},
+			{ID: "did:example:2", CustomFields: CustomFields{"origin": "https://two.example.com"}},
+		}}
+
+		var subjects []domainLinkageSubject
+
+		err := vc.DecodeSubjects(&subjects)
+		require.NoError(t, err)
+		require.Equal(t, []domainLinkageSubject{
+			{ID: "did:example:1", Origin: "https://one.example.com"},
+			{ID: "did:example:2", Origin: "https://two.example.com"},
+		}, subjects)
+
+		var first domainLinkageSubject
+
+		err = vc.DecodeSubject(&first)
+		require.NoError(t, err)
+		require.Equal(t, subjects[0], first)
+	})
+
+	t.Run("decode bare string subject", func(t *testing.T) {
+		vc := &Credential{Subject: "did:example:ebfeb1f712ebc6f1c276e12ecaa"}
+
+		var subject domainLinkageSubject
+
+		err := vc.DecodeSubject(&subject)
+		require.NoError(t, err)
+		require.Equal(t, domainLinkageSubject{ID: "did:example:ebfeb1f712ebc6f1c276e12ecaa"}, subject)
+
+		var subjects []domainLinkageSubject
+
+		err = vc.DecodeSubjects(&subjects)
+		require.NoError(t, err)
+		require.Equal(t, []domainLinkageSubject{subject}, subjects)
+	})
+
+	t.Run("error - no subject", func(t *testing.T) {
+		var subject domainLinkageSubject
+
+		err := (&Credential{}).DecodeSubject(&subject)
+		require.EqualError(t, err, "no subject is defined")
+
+		err = (&Credential{Subject: []Subject{}}).DecodeSubjects(&[]domainLinkageSubject{})
+		require.EqualError(t, err, "no subject is defined")
+	})
+
+	t.Run("error - subject of incompatible structure", func(t *testing.T) {
+		vc := &Credential{Subject: map[string]interface{}{
+			"id":     "did:example:ebfeb1f712ebc6f1c276e12ecaa",
+			"origin": 42,
+		}}
+
+		var subject domainLinkageSubject
+
+		err := vc.DecodeSubject(&subject)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decode credential subject")
+
+		var subjects []domainLinkageSubject
+
+		err = vc.DecodeSubjects(&subjects)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decode credential subjects")
+	})
+}
+
 func TestRawCredentialSerialization(t *testing.T) {
 	cBytes := []byte(validCredential)
 
@@ -1894,6 +2062,139 @@ func TestDecodeWithNullValues(t *testing.T) {
 	require.NotNil(t, vc)
 }
 
+func TestCredential_EvidenceAndTermsOfUse(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		evidence, err := vc.Evidences()
+		require.NoError(t, err)
+		require.Len(t, evidence, 2)
+		require.Equal(t, "https://example.edu/evidence/f2aeec97-fc0d-42bf-8ca7-0548192d4231", evidence[0].ID)
+		require.Equal(t, []string{"DocumentVerification"}, evidence[0].Types)
+		require.Equal(t, "https://example.edu/issuers/14", evidence[0].CustomFields["verifier"])
+		require.Equal(t, "DriversLicense", evidence[0].CustomFields["evidenceDocument"])
+		require.Equal(t, []string{"SupportingActivity"}, evidence[1].Types)
+
+		require.Len(t, vc.TermsOfUse, 1)
+		require.Equal(t, "http://example.com/policies/credential/4", vc.TermsOfUse[0].ID)
+		require.Equal(t, "IssuerPolicy", vc.TermsOfUse[0].Type)
+		require.Equal(t, "http://example.com/profiles/credential", vc.TermsOfUse[0].CustomFields["profile"])
+		require.NotNil(t, vc.TermsOfUse[0].CustomFields["prohibition"])
+
+		vcBytes, err := vc.MarshalJSON()
+		require.NoError(t, err)
+
+		vc2, err := parseTestCredential(t, vcBytes)
+		require.NoError(t, err)
+		require.Equal(t, vc.Evidence, vc2.Evidence)
+		require.Equal(t, vc.TermsOfUse, vc2.TermsOfUse)
+	})
+
+	t.Run("single evidence object", func(t *testing.T) {
+		vcMap, err := jsonutil.ToMap(validCredential)
+		require.NoError(t, err)
+
+		vcMap["evidence"] = map[string]interface{}{
+			"id":       "https://example.edu/evidence/1",
+			"type":     "DocumentVerification",
+			"verifier": "https://example.edu/issuers/14",
+		}
+
+		vcBytes, err := json.Marshal(vcMap)
+		require.NoError(t, err)
+
+		vc, err := parseTestCredential(t, vcBytes)
+		require.NoError(t, err)
+
+		evidence, err := vc.Evidences()
+		require.NoError(t, err)
+		require.Equal(t, []TypedEvidence{{
+			ID:           "https://example.edu/evidence/1",
+			Types:        []string{"DocumentVerification"},
+			CustomFields: CustomFields{"verifier": "https://example.edu/issuers/14"},
+		}}, evidence)
+	})
+
+	t.Run("evidence is serialized as parsed", func(t *testing.T) {
+		for _, evidence := range []interface{}{
+			[]interface{}{map[string]interface{}{"id": "https://example.edu/evidence/1", "type": "DocumentVerification"}},
+			map[string]interface{}{"id": "https://example.edu/evidence/1", "type": "DocumentVerification"},
+			"https://example.edu/evidence/1",
+		} {
+			vcMap, err := jsonutil.ToMap(validCredential)
+			require.NoError(t, err)
+
+			vcMap["evidence"] = evidence
+
+			vcBytes, err := json.Marshal(vcMap)
+			require.NoError(t, err)
+
+			// the JSON schema of the credential allows only evidence objects
+			vc, err := parseTestCredential(t, vcBytes, WithCredDisableValidation())
+			require.NoError(t, err)
+
+			vcBytes, err = vc.MarshalJSON()
+			require.NoError(t, err)
+
+			vcMap, err = jsonutil.ToMap(vcBytes)
+			require.NoError(t, err)
+			require.Equal(t, evidence, vcMap["evidence"])
+		}
+	})
+
+	t.Run("no evidence", func(t *testing.T) {
+		evidence, err := (&Credential{}).Evidences()
+		require.NoError(t, err)
+		require.Empty(t, evidence)
+	})
+
+	t.Run("error - evidence is not an object", func(t *testing.T) {
+		for _, evidence := range []interface{}{
+			"https://example.edu/evidence/1",
+			[]interface{}{"https://example.edu/evidence/1"},
+			map[string]interface{}{"type": 5},
+		} {
+			vc := &Credential{Evidence: evidence}
+
+			typedEvidence, err := vc.Evidences()
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "decode evidence")
+			require.Nil(t, typedEvidence)
+		}
+
+		_, err := (&Credential{Evidence: make(chan int)}).Evidences()
+		require.Error(t, err)
+	})
+
+	t.Run("terms of use validator", func(t *testing.T) {
+		var validated []TermsOfUse
+
+		vc, err := parseTestCredential(t, []byte(validCredential), WithTermsOfUseValidator(
+			func(termsOfUse []TermsOfUse) error {
+				validated = termsOfUse
+
+				return nil
+			}))
+		require.NoError(t, err)
+		require.NotNil(t, vc)
+		require.Equal(t, vc.TermsOfUse, validated)
+
+		vc, err = parseTestCredential(t, []byte(validCredential), WithTermsOfUseValidator(
+			func(termsOfUse []TermsOfUse) error {
+				for _, tou := range termsOfUse {
+					if tou.Type == "IssuerPolicy" {
+						return errors.New("issuer policy is not accepted")
+					}
+				}
+
+				return nil
+			}))
+		require.EqualError(t, err, "validate terms of use: issuer policy is not accepted")
+		require.Nil(t, vc)
+	})
+}
+
 func TestCredential_raw(t *testing.T) {
 	t.Run("Serialize with invalid refresh service", func(t *testing.T) {
 		vc, err := parseTestCredential(t, []byte(validCredential))
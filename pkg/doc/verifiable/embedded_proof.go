@@ -6,12 +6,16 @@ SPDX-License-Identifier: Apache-2.0
 package verifiable
 
 import (
+	"bytes"
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/proof"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbsblssignature2020"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbsblssignatureproof2020"
@@ -53,9 +57,59 @@ type embeddedProofCheckOpts struct {
 
 	ldpSuites []verifier.SignatureSuite
 
+	// proofVerifier verifies the signatures of the default signature suites, nil means the local crypto.
+	proofVerifier ProofVerifier
+
+	// proofThreshold is a minimal number of valid proofs made by distinct verification methods,
+	// 0 means that all proofs must be valid.
+	proofThreshold int
+
+	// verificationTimeout bounds the cryptographic verification of the proofs, 0 means no bound.
+	verificationTimeout time.Duration
+
+	// expectedNonce is the nonce every proof must have, nil means that the nonce is not checked.
+	expectedNonce []byte
+
+	// verificationMethod is the only verification method whose proofs are accepted, empty means any.
+	verificationMethod string
+
 	jsonldCredentialOpts
 }
 
+// ErrVerificationTimeout is returned if the cryptographic verification of the proofs exceeds
+// the verification timeout.
+var ErrVerificationTimeout = errors.New("proof verification timed out")
+
+// ErrProofNonceMismatch is returned if the nonce of an embedded proof differs from the expected one.
+var ErrProofNonceMismatch = errors.New("proof nonce mismatch")
+
+// ErrNoProofOfVerificationMethod is returned if no embedded proof is made by the required verification method.
+var ErrNoProofOfVerificationMethod = errors.New("no proof made by the required verification method")
+
+// withVerificationTimeout runs verify with a deadline. Signature suites are not context aware,
+// so verify keeps running in the background after the deadline is exceeded and its result is discarded.
+func withVerificationTimeout(timeout time.Duration, verify func() error) error {
+	if timeout <= 0 {
+		return verify()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	errCh := make(chan error, 1)
+
+	go func() {
+		errCh <- verify()
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("%w after %s", ErrVerificationTimeout, timeout)
+	}
+}
+
 func checkEmbeddedProof(docBytes []byte, opts *embeddedProofCheckOpts) error {
 	if opts.disabledProofCheck {
 		return nil
@@ -80,6 +134,25 @@ func checkEmbeddedProof(docBytes []byte, opts *embeddedProofCheckOpts) error {
 		return fmt.Errorf("check embedded proof: %w", err)
 	}
 
+	checkedDoc := docBytes
+
+	if opts.verificationMethod != "" {
+		proofs, err = filterProofsByVerificationMethod(proofs, opts.verificationMethod)
+		if err != nil {
+			return fmt.Errorf("check embedded proof: %w", err)
+		}
+
+		// the proofs of other verification methods are neither checked nor accepted
+		jsonldDoc["proof"] = proofs
+		checkedDoc, _ = json.Marshal(jsonldDoc) //nolint:errcheck
+	}
+
+	if opts.expectedNonce != nil {
+		if err = checkProofNonce(proofs, opts.expectedNonce); err != nil {
+			return fmt.Errorf("check embedded proof: %w", err)
+		}
+	}
+
 	ldpSuites, err := getSuites(proofs, opts)
 	if err != nil {
 		return err
@@ -89,15 +162,22 @@ func checkEmbeddedProof(docBytes []byte, opts *embeddedProofCheckOpts) error {
 		return errors.New("public key fetcher is not defined")
 	}
 
-	checkedDoc := docBytes
-
 	if len(opts.externalContext) > 0 {
 		// Use external contexts for check of the linked data proofs to enrich JSON-LD context vocabulary.
 		jsonldDoc["@context"] = jsonld.AppendExternalContexts(jsonldDoc["@context"], opts.externalContext...)
 		checkedDoc, _ = json.Marshal(jsonldDoc) //nolint:errcheck
 	}
 
-	err = checkLinkedDataProof(checkedDoc, ldpSuites, opts.publicKeyFetcher, &opts.jsonldCredentialOpts)
+	if opts.proofThreshold > 0 {
+		return withVerificationTimeout(opts.verificationTimeout, func() error {
+			return checkProofThreshold(jsonldDoc, proofs, ldpSuites, opts)
+		})
+	}
+
+	err = withVerificationTimeout(opts.verificationTimeout, func() error {
+		return checkLinkedDataProof(checkedDoc, ldpSuites, opts.publicKeyFetcher, &opts.jsonldCredentialOpts,
+			proof.NewCanonicalDocumentCache())
+	})
 	if err != nil {
 		return fmt.Errorf("check embedded proof: %w", err)
 	}
@@ -105,6 +185,127 @@ func checkEmbeddedProof(docBytes []byte, opts *embeddedProofCheckOpts) error {
 	return nil
 }
 
+// SubjectProofError is returned if the embedded proof of a credential subject is not valid.
+type SubjectProofError struct {
+	// Index of the subject in the credentialSubject array (0 for a single subject).
+	Index int
+	// SubjectID is the id of the subject, empty if the subject has no id.
+	SubjectID string
+	Err       error
+}
+
+func (e *SubjectProofError) Error() string {
+	return fmt.Sprintf("check proof of credential subject %d [%s]: %v", e.Index, e.SubjectID, e.Err)
+}
+
+func (e *SubjectProofError) Unwrap() error {
+	return e.Err
+}
+
+// checkSubjectProofs checks the proofs embedded into the credential subjects, if any. A subject proof
+// is checked as a proof of the subject object, which has the JSON-LD context of the credential.
+func checkSubjectProofs(vcBytes []byte, opts *embeddedProofCheckOpts) error {
+	if opts.disabledProofCheck {
+		return nil
+	}
+
+	var raw struct {
+		Context interface{}     `json:"@context,omitempty"`
+		Subject json.RawMessage `json:"credentialSubject,omitempty"`
+	}
+
+	if err := json.Unmarshal(vcBytes, &raw); err != nil || len(raw.Subject) == 0 {
+		// the credential is validated later
+		return nil //nolint:nilerr
+	}
+
+	var subjects []map[string]interface{}
+
+	if err := json.Unmarshal(raw.Subject, &subjects); err != nil {
+		var subject map[string]interface{}
+
+		if json.Unmarshal(raw.Subject, &subject) != nil {
+			// the subject is e.g. a string, so there is no proof
+			return nil
+		}
+
+		subjects = []map[string]interface{}{subject}
+	}
+
+	// the subjects are not proved by the issuer, so their proofs are not pinned to a verification method
+	subjectOpts := *opts
+	subjectOpts.verificationMethod = ""
+
+	for i, subject := range subjects {
+		if proofElement, ok := subject["proof"]; !ok || proofElement == nil {
+			continue
+		}
+
+		if _, ok := subject["@context"]; !ok {
+			subject["@context"] = raw.Context
+		}
+
+		subjectBytes, err := json.Marshal(subject)
+		if err == nil {
+			err = checkEmbeddedProof(subjectBytes, &subjectOpts)
+		}
+
+		if err != nil {
+			return &SubjectProofError{Index: i, SubjectID: safeStringValue(subject["id"]), Err: err}
+		}
+	}
+
+	return nil
+}
+
+// checkProofThreshold checks every proof of the proof set separately and requires at least
+// opts.proofThreshold of them to be valid. Several valid proofs of the same verification method are counted once.
+func checkProofThreshold(jsonldDoc map[string]interface{}, proofs []map[string]interface{},
+	ldpSuites []verifier.SignatureSuite, opts *embeddedProofCheckOpts) error {
+	if len(proofs) < opts.proofThreshold {
+		return fmt.Errorf("check embedded proof: %d proof(s) found, at least %d required",
+			len(proofs), opts.proofThreshold)
+	}
+
+	verified := make(map[string]struct{})
+
+	// the proofs are checked separately, but the canonical document without proofs is the same
+	cache := proof.NewCanonicalDocumentCache()
+
+	var firstErr error
+
+	for _, p := range proofs {
+		jsonldDoc["proof"] = p
+
+		proofDoc, err := json.Marshal(jsonldDoc)
+		if err == nil {
+			err = checkLinkedDataProof(proofDoc, ldpSuites, opts.publicKeyFetcher, &opts.jsonldCredentialOpts, cache)
+		}
+
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+
+			continue
+		}
+
+		verified[safeStringValue(p["verificationMethod"])] = struct{}{}
+	}
+
+	if len(verified) < opts.proofThreshold && firstErr == nil {
+		return fmt.Errorf("check embedded proof: %d of %d required proofs are made by distinct verification methods",
+			len(verified), opts.proofThreshold)
+	}
+
+	if len(verified) < opts.proofThreshold {
+		return fmt.Errorf("check embedded proof: %d of %d required proofs are valid: %w",
+			len(verified), opts.proofThreshold, firstErr)
+	}
+
+	return nil
+}
+
 // nolint:gocyclo
 func getSuites(proofs []map[string]interface{}, opts *embeddedProofCheckOpts) ([]verifier.SignatureSuite, error) {
 	ldpSuites := opts.ldpSuites
@@ -119,25 +320,26 @@ func getSuites(proofs []map[string]interface{}, opts *embeddedProofCheckOpts) ([
 			switch t {
 			case ed25519Signature2018:
 				ldpSuites = append(ldpSuites, ed25519signature2018.New(
-					suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier())))
+					suite.WithVerifier(opts.suiteVerifier(t, ed25519signature2018.NewPublicKeyVerifier()))))
 			case ed25519Signature2020:
 				ldpSuites = append(ldpSuites, ed25519signature2020.New(
-					suite.WithVerifier(ed25519signature2020.NewPublicKeyVerifier())))
+					suite.WithVerifier(opts.suiteVerifier(t, ed25519signature2020.NewPublicKeyVerifier()))))
 			case jsonWebSignature2020:
 				ldpSuites = append(ldpSuites, jsonwebsignature2020.New(
-					suite.WithVerifier(jsonwebsignature2020.NewPublicKeyVerifier())))
+					suite.WithVerifier(opts.suiteVerifier(t, jsonwebsignature2020.NewPublicKeyVerifier()))))
 			case ecdsaSecp256k1Signature2019:
 				ldpSuites = append(ldpSuites, ecdsasecp256k1signature2019.New(
-					suite.WithVerifier(ecdsasecp256k1signature2019.NewPublicKeyVerifier())))
+					suite.WithVerifier(opts.suiteVerifier(t, ecdsasecp256k1signature2019.NewPublicKeyVerifier()))))
 			case bbsBlsSignature2020:
 				ldpSuites = append(ldpSuites, bbsblssignature2020.New(
-					suite.WithVerifier(bbsblssignature2020.NewG2PublicKeyVerifier())))
+					suite.WithVerifier(opts.suiteVerifier(t, bbsblssignature2020.NewG2PublicKeyVerifier()))))
 			case bbsBlsSignatureProof2020:
 				nonce, err := getNonce(proofs[i])
 				if err != nil {
 					return nil, err
 				}
 
+				// the derived proof is verified with the nonce, so it is always checked locally
 				ldpSuites = append(ldpSuites, bbsblssignatureproof2020.New(
 					suite.WithVerifier(bbsblssignatureproof2020.NewG2PublicKeyVerifier(nonce))))
 			}
@@ -160,6 +362,40 @@ func getNonce(proof map[string]interface{}) ([]byte, error) {
 	return []byte{}, nil
 }
 
+// checkProofNonce checks that every proof has the expected nonce.
+func checkProofNonce(proofs []map[string]interface{}, expected []byte) error {
+	for _, p := range proofs {
+		ldProof, err := proof.NewProof(p)
+		if err != nil {
+			return err
+		}
+
+		if !bytes.Equal(ldProof.Nonce, expected) {
+			return ErrProofNonceMismatch
+		}
+	}
+
+	return nil
+}
+
+// filterProofsByVerificationMethod returns the proofs made by the verification method.
+func filterProofsByVerificationMethod(proofs []map[string]interface{},
+	verificationMethod string) ([]map[string]interface{}, error) {
+	var filtered []map[string]interface{}
+
+	for _, p := range proofs {
+		if safeStringValue(p["verificationMethod"]) == verificationMethod {
+			filtered = append(filtered, p)
+		}
+	}
+
+	if len(filtered) == 0 {
+		return nil, fmt.Errorf("%w %s", ErrNoProofOfVerificationMethod, verificationMethod)
+	}
+
+	return filtered, nil
+}
+
 func getProofs(proofElement interface{}) ([]map[string]interface{}, error) {
 	switch p := proofElement.(type) {
 	case map[string]interface{}:
@@ -182,3 +418,80 @@ func getProofs(proofElement interface{}) ([]map[string]interface{}, error) {
 
 	return nil, errors.New("invalid proof type")
 }
+
+// ProofResult is the verification result of a single embedded proof.
+type ProofResult struct {
+	Type               string
+	VerificationMethod string
+	// Err is nil if the proof is valid.
+	Err error
+}
+
+// VerifyAllProofs verifies every embedded linked data proof of the credential separately and returns
+// the result of each proof in the order of the proof set. The public keys are resolved by the fetcher,
+// e.g. NewVDRKeyResolver(vdr).PublicKeyFetcher(). Only WithJSONLDDocumentLoader, WithExternalJSONLDContext,
+// WithJSONLDOnlyValidRDF and WithEmbeddedSignatureSuites options are taken into account.
+// An error is returned only if the proofs can't be checked at all.
+func VerifyAllProofs(vc *Credential, fetcher PublicKeyFetcher, opts ...CredentialOpt) ([]ProofResult, error) {
+	if fetcher == nil {
+		return nil, errors.New("public key fetcher is not defined")
+	}
+
+	vcOpts := getCredentialOpts(opts)
+
+	raw, err := vc.raw()
+	if err != nil {
+		return nil, fmt.Errorf("verify all proofs: %w", err)
+	}
+
+	raw.JWT = ""
+
+	rawBytes, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("verify all proofs: %w", err)
+	}
+
+	var jsonldDoc map[string]interface{}
+
+	err = json.Unmarshal(rawBytes, &jsonldDoc)
+	if err != nil {
+		return nil, fmt.Errorf("verify all proofs: %w", err)
+	}
+
+	if len(vcOpts.externalContext) > 0 {
+		jsonldDoc["@context"] = jsonld.AppendExternalContexts(jsonldDoc["@context"], vcOpts.externalContext...)
+	}
+
+	results := make([]ProofResult, len(vc.Proofs))
+
+	for i, p := range vc.Proofs {
+		results[i] = ProofResult{
+			Type:               safeStringValue(p["type"]),
+			VerificationMethod: safeStringValue(p["verificationMethod"]),
+			Err:                verifyProof(jsonldDoc, p, fetcher, vcOpts),
+		}
+	}
+
+	return results, nil
+}
+
+func verifyProof(jsonldDoc map[string]interface{}, proof Proof, fetcher PublicKeyFetcher,
+	vcOpts *credentialOpts) error {
+	ldpSuites, err := getSuites([]map[string]interface{}{proof},
+		&embeddedProofCheckOpts{ldpSuites: vcOpts.ldpSuites, proofVerifier: vcOpts.proofVerifier})
+	if err != nil {
+		return err
+	}
+
+	jsonldDoc["proof"] = map[string]interface{}(proof)
+
+	proofDoc, err := json.Marshal(jsonldDoc)
+	if err != nil {
+		return fmt.Errorf("marshal credential with proof: %w", err)
+	}
+
+	return withVerificationTimeout(vcOpts.verificationTimeout, func() error {
+		// a single proof is checked, there is nothing to cache
+		return checkLinkedDataProof(proofDoc, ldpSuites, fetcher, &vcOpts.jsonldCredentialOpts, nil)
+	})
+}
@@ -6,13 +6,19 @@ SPDX-License-Identifier: Apache-2.0
 package verifiable
 
 import (
+	"encoding/json"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	jsonldsig "github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/proof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/signer"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/signature"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 )
 
@@ -208,3 +214,202 @@ func Test_getSuites(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, suites, 4)
 }
+
+func TestVerifyAllProofs(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	otherSigner, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	pubKeyFetcher := func(issuerID, keyID string) (*verifier.PublicKey, error) {
+		if issuerID+keyID != "did:example:123456#key1" {
+			return nil, fmt.Errorf("unknown key %s%s", issuerID, keyID)
+		}
+
+		return &verifier.PublicKey{Type: kms.ED25519, Value: signer.PublicKeyBytes()}, nil
+	}
+
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	require.NoError(t, err)
+
+	for _, s := range []struct {
+		signer             signature.Signer
+		verificationMethod string
+	}{
+		{signer: signer, verificationMethod: "did:example:123456#key1"},
+		// valid key id, but signed by another key
+		{signer: otherSigner, verificationMethod: "did:example:123456#key1"},
+		{signer: otherSigner, verificationMethod: "did:example:123456#key2"},
+	} {
+		err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+			SignatureType:           "Ed25519Signature2018",
+			SignatureRepresentation: SignatureJWS,
+			Suite:                   ed25519signature2018.New(suite.WithSigner(s.signer)),
+			VerificationMethod:      s.verificationMethod,
+		}, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+		require.NoError(t, err)
+	}
+
+	t.Run("result of every proof", func(t *testing.T) {
+		results, err := VerifyAllProofs(vc, pubKeyFetcher, WithJSONLDDocumentLoader(createTestDocumentLoader(t)))
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+
+		for _, result := range results {
+			require.Equal(t, ed25519Signature2018, result.Type)
+		}
+
+		require.Equal(t, "did:example:123456#key1", results[0].VerificationMethod)
+		require.NoError(t, results[0].Err)
+
+		require.Equal(t, "did:example:123456#key1", results[1].VerificationMethod)
+		require.Error(t, results[1].Err)
+		require.Contains(t, results[1].Err.Error(), "check linked data proof")
+
+		require.Equal(t, "did:example:123456#key2", results[2].VerificationMethod)
+		require.Error(t, results[2].Err)
+		require.Contains(t, results[2].Err.Error(), "unknown key did:example:123456#key2")
+	})
+
+	t.Run("unsupported proof type", func(t *testing.T) {
+		vcCopy := *vc
+		vcCopy.Proofs = []Proof{{"type": "UnknownSignature", "verificationMethod": "did:example:123456#key1"}}
+
+		results, err := VerifyAllProofs(&vcCopy, pubKeyFetcher, WithJSONLDDocumentLoader(createTestDocumentLoader(t)))
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		require.EqualError(t, results[0].Err, "check embedded proof: unsupported proof type: UnknownSignature")
+	})
+
+	t.Run("credential without proofs", func(t *testing.T) {
+		vcCopy := *vc
+		vcCopy.Proofs = nil
+
+		results, err := VerifyAllProofs(&vcCopy, pubKeyFetcher)
+		require.NoError(t, err)
+		require.Empty(t, results)
+	})
+
+	t.Run("public key fetcher is not defined", func(t *testing.T) {
+		results, err := VerifyAllProofs(vc, nil)
+		require.EqualError(t, err, "public key fetcher is not defined")
+		require.Nil(t, results)
+	})
+}
+
+func TestCheckSubjectProofs(t *testing.T) {
+	loader := createTestDocumentLoader(t)
+
+	issuerSigner, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	subjectSigners := make([]signature.Signer, 2)
+
+	for i := range subjectSigners {
+		subjectSigners[i], err = newCryptoSigner(kms.ED25519Type)
+		require.NoError(t, err)
+	}
+
+	keys := map[string]signature.Signer{
+		"did:example:76e12ec712ebc6f1c221ebfeb1f#key1": issuerSigner,
+		"did:example:subject0#key1":                    subjectSigners[0],
+		"did:example:subject1#key1":                    subjectSigners[1],
+	}
+
+	pubKeyFetcher := func(issuerID, keyID string) (*verifier.PublicKey, error) {
+		s, ok := keys[issuerID+keyID]
+		if !ok {
+			return nil, fmt.Errorf("unknown key %s%s", issuerID, keyID)
+		}
+
+		return &verifier.PublicKey{Type: kms.ED25519, Value: s.PublicKeyBytes()}, nil
+	}
+
+	vc, err := parseTestCredential(t, []byte(validCredential), WithDisabledProofCheck())
+	require.NoError(t, err)
+
+	// newSubject returns the subject with its own proof made by the signer
+	newSubject := func(t *testing.T, id string, s signature.Signer) Subject {
+		t.Helper()
+
+		subjectBytes, err := json.Marshal(map[string]interface{}{"@context": vc.Context, "id": id})
+		require.NoError(t, err)
+
+		signedBytes, err := signer.New(ed25519signature2018.New(suite.WithSigner(s))).Sign(&signer.Context{
+			SignatureType:           "Ed25519Signature2018",
+			SignatureRepresentation: proof.SignatureJWS,
+			VerificationMethod:      id + "#key1",
+		}, subjectBytes, jsonldsig.WithDocumentLoader(loader))
+		require.NoError(t, err)
+
+		var signed map[string]interface{}
+
+		require.NoError(t, json.Unmarshal(signedBytes, &signed))
+
+		return Subject{ID: id, CustomFields: CustomFields{"proof": signed["proof"]}}
+	}
+
+	// newCredential returns the credential with the subjects and the proof of the issuer
+	newCredential := func(t *testing.T, subjects ...Subject) []byte {
+		t.Helper()
+
+		vcCopy := *vc
+		vcCopy.Subject = subjects
+
+		err := vcCopy.AddLinkedDataProof(&LinkedDataProofContext{
+			SignatureType:           "Ed25519Signature2018",
+			SignatureRepresentation: SignatureJWS,
+			Suite:                   ed25519signature2018.New(suite.WithSigner(issuerSigner)),
+			VerificationMethod:      "did:example:76e12ec712ebc6f1c221ebfeb1f#key1",
+		}, jsonldsig.WithDocumentLoader(loader))
+		require.NoError(t, err)
+
+		vcBytes, err := vcCopy.MarshalJSON()
+		require.NoError(t, err)
+
+		return vcBytes
+	}
+
+	t.Run("success - proofs of all subjects are valid", func(t *testing.T) {
+		vcBytes := newCredential(t,
+			newSubject(t, "did:example:subject0", subjectSigners[0]),
+			newSubject(t, "did:example:subject1", subjectSigners[1]))
+
+		vcParsed, err := parseTestCredential(t, vcBytes, WithPublicKeyFetcher(pubKeyFetcher))
+		require.NoError(t, err)
+
+		subjects, ok := vcParsed.Subject.([]Subject)
+		require.True(t, ok)
+		require.Len(t, subjects, 2)
+		require.NotNil(t, subjects[1].CustomFields["proof"])
+	})
+
+	t.Run("success - single subject with proof", func(t *testing.T) {
+		vcBytes := newCredential(t, newSubject(t, "did:example:subject0", subjectSigners[0]))
+
+		_, err := parseTestCredential(t, vcBytes, WithPublicKeyFetcher(pubKeyFetcher))
+		require.NoError(t, err)
+	})
+
+	t.Run("error - proof of a subject is invalid", func(t *testing.T) {
+		// the proof of the second subject is made by the key of the first subject
+		vcBytes := newCredential(t,
+			newSubject(t, "did:example:subject0", subjectSigners[0]),
+			newSubject(t, "did:example:subject1", subjectSigners[0]))
+
+		_, err := parseTestCredential(t, vcBytes, WithPublicKeyFetcher(pubKeyFetcher))
+		require.Error(t, err)
+
+		var subjectProofErr *SubjectProofError
+
+		require.ErrorAs(t, err, &subjectProofErr)
+		require.Equal(t, 1, subjectProofErr.Index)
+		require.Equal(t, "did:example:subject1", subjectProofErr.SubjectID)
+		require.Contains(t, err.Error(), "check proof of credential subject 1 [did:example:subject1]")
+
+		// the subject proofs are not checked if the proof check is disabled
+		_, err = parseTestCredential(t, vcBytes, WithDisabledProofCheck())
+		require.NoError(t, err)
+	})
+}
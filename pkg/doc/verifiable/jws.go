@@ -80,6 +80,10 @@ func unmarshalJWS(rawJwt string, checkProof bool, fetcher PublicKeyFetcher, clai
 		verifier = &noVerifier{}
 	}
 
+	return unmarshalVerifiedJWS(rawJwt, verifier, claims)
+}
+
+func unmarshalVerifiedJWS(rawJwt string, verifier jose.SignatureVerifier, claims interface{}) error {
 	_, claimsRaw, err := jwt.Parse(rawJwt, jwt.WithSignatureVerifier(verifier))
 	if err != nil {
 		return fmt.Errorf("parse JWT: %w", err)
@@ -62,12 +62,15 @@ type LinkedDataProofContext struct {
 	Challenge               string                  // optional
 	Domain                  string                  // optional
 	Purpose                 string                  // optional
+	Nonce                   []byte                  // optional
 	// CapabilityChain must be an array. Each element is either a string or an object.
 	CapabilityChain []interface{}
 }
 
+// checkLinkedDataProof checks the linked data proofs of the document. The cache of the canonical document
+// may be shared by the checks of the same document.
 func checkLinkedDataProof(jsonldBytes []byte, suites []verifier.SignatureSuite,
-	pubKeyFetcher PublicKeyFetcher, jsonldOpts *jsonldCredentialOpts) error {
+	pubKeyFetcher PublicKeyFetcher, jsonldOpts *jsonldCredentialOpts, cache *proof.CanonicalDocumentCache) error {
 	documentVerifier, err := verifier.New(&keyResolverAdapter{pubKeyFetcher}, suites...)
 	if err != nil {
 		return fmt.Errorf("create new signature verifier: %w", err)
@@ -75,7 +78,7 @@ func checkLinkedDataProof(jsonldBytes []byte, suites []verifier.SignatureSuite,
 
 	processorOpts := mapJSONLDProcessorOpts(jsonldOpts)
 
-	err = documentVerifier.Verify(jsonldBytes, processorOpts...)
+	err = documentVerifier.VerifyWithCache(jsonldBytes, cache, processorOpts...)
 	if err != nil {
 		return fmt.Errorf("check linked data proof: %w", err)
 	}
@@ -139,6 +142,7 @@ func mapContext(context *LinkedDataProofContext) *signer.Context {
 		Challenge:               context.Challenge,
 		Domain:                  context.Domain,
 		Purpose:                 context.Purpose,
+		Nonce:                   context.Nonce,
 		CapabilityChain:         context.CapabilityChain,
 	}
 }
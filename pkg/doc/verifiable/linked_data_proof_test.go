@@ -8,6 +8,7 @@ package verifiable
 import (
 	"crypto/ed25519"
 	"crypto/rand"
+	"encoding/json"
 	"errors"
 	"testing"
 	"time"
@@ -16,10 +17,12 @@ import (
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/proof"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ecdsasecp256k1signature2019"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/ldtestutil"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 )
 
@@ -194,3 +197,209 @@ func prepareVCWithSecp256k1LDP(t *testing.T, vcJSON string, signer Signer) *Cred
 
 	return vc
 }
+
+func TestCheckLinkedDataProof_CanonicalDocumentCache(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	vcBytes := prepareVCWithEd25519LDPs(t, signer, 3)
+	loader := createTestDocumentLoader(t)
+	fetcher := SingleKey(signer.PublicKeyBytes(), kms.ED25519)
+
+	t.Run("cached canonical document gives the same result", func(t *testing.T) {
+		cached := &countingCanonicalizationSuite{Suite: ed25519signature2018.New(
+			suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier()), suite.WithCompactProof())}
+		notCached := &countingCanonicalizationSuite{Suite: ed25519signature2018.New(
+			suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier()), suite.WithCompactProof())}
+
+		err = checkLinkedDataProof(vcBytes, []verifier.SignatureSuite{cached}, fetcher,
+			&jsonldCredentialOpts{jsonldDocumentLoader: loader}, proof.NewCanonicalDocumentCache())
+		require.NoError(t, err)
+
+		err = checkLinkedDataProof(vcBytes, []verifier.SignatureSuite{notCached}, fetcher,
+			&jsonldCredentialOpts{jsonldDocumentLoader: loader}, nil)
+		require.NoError(t, err)
+
+		// the proof options are canonicalized for every proof, the document only once
+		require.Equal(t, 3+1, cached.count)
+		require.Equal(t, 3+3, notCached.count)
+	})
+
+	t.Run("invalid proof fails with and without the cache", func(t *testing.T) {
+		var vcMap map[string]interface{}
+
+		require.NoError(t, json.Unmarshal(vcBytes, &vcMap))
+
+		proofs, ok := vcMap["proof"].([]interface{})
+		require.True(t, ok)
+
+		otherSigner, err := newCryptoSigner(kms.ED25519Type)
+		require.NoError(t, err)
+
+		// the last proof is made with another key
+		var otherVCMap map[string]interface{}
+
+		require.NoError(t, json.Unmarshal(prepareVCWithEd25519LDPs(t, otherSigner, 1), &otherVCMap))
+
+		otherProof, ok := otherVCMap["proof"].(map[string]interface{})
+		require.True(t, ok)
+
+		proofs[len(proofs)-1] = otherProof
+
+		tamperedBytes, err := json.Marshal(vcMap)
+		require.NoError(t, err)
+
+		verifierSuite := ed25519signature2018.New(
+			suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier()), suite.WithCompactProof())
+
+		for _, cache := range []*proof.CanonicalDocumentCache{proof.NewCanonicalDocumentCache(), nil} {
+			err = checkLinkedDataProof(tamperedBytes, []verifier.SignatureSuite{verifierSuite}, fetcher,
+				&jsonldCredentialOpts{jsonldDocumentLoader: loader}, cache)
+			require.EqualError(t, err, "check linked data proof: ed25519: invalid signature")
+		}
+	})
+}
+
+func TestLinkedDataProofNonce(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	vc, err := parseTestCredential(t, []byte(validCredential), WithDisabledProofCheck())
+	require.NoError(t, err)
+
+	nonce := []byte("verifier nonce")
+
+	err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+		SignatureType:           "Ed25519Signature2018",
+		Suite:                   ed25519signature2018.New(suite.WithSigner(signer)),
+		SignatureRepresentation: SignatureJWS,
+		VerificationMethod:      "did:example:123456#key1",
+		Nonce:                   nonce,
+	}, jsonld.WithDocumentLoader(createTestDocumentLoader(t)))
+	require.NoError(t, err)
+
+	require.Len(t, vc.Proofs, 1)
+	require.NotEmpty(t, vc.Proofs[0]["nonce"])
+
+	vcBytes, err := vc.MarshalJSON()
+	require.NoError(t, err)
+
+	fetcher := WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519))
+
+	t.Run("nonce is preserved", func(t *testing.T) {
+		vcDecoded, err := parseTestCredential(t, vcBytes, fetcher, WithExpectedProofNonce(nonce))
+		require.NoError(t, err)
+		require.Equal(t, vc.Proofs, vcDecoded.Proofs)
+
+		ldProof, err := proof.NewProof(vcDecoded.Proofs[0])
+		require.NoError(t, err)
+		require.Equal(t, nonce, ldProof.Nonce)
+	})
+
+	t.Run("nonce is not checked by default", func(t *testing.T) {
+		_, err := parseTestCredential(t, vcBytes, fetcher)
+		require.NoError(t, err)
+	})
+
+	t.Run("nonce mismatch", func(t *testing.T) {
+		_, err := parseTestCredential(t, vcBytes, fetcher, WithExpectedProofNonce([]byte("other nonce")))
+		require.ErrorIs(t, err, ErrProofNonceMismatch)
+
+		vp, err := NewPresentation(WithCredentials(vc))
+		require.NoError(t, err)
+
+		// the nonce is checked before the signature
+		vp.Proofs = []Proof{vc.Proofs[0]}
+
+		vpBytes, err := vp.MarshalJSON()
+		require.NoError(t, err)
+
+		_, err = newTestPresentation(t, vpBytes, WithPresExpectedProofNonce([]byte("other nonce")),
+			WithPresPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)))
+		require.ErrorIs(t, err, ErrProofNonceMismatch)
+	})
+
+	t.Run("proof without nonce", func(t *testing.T) {
+		vcWithoutNonce := prepareVCWithEd25519LDPs(t, signer, 1)
+
+		_, err := parseTestCredential(t, vcWithoutNonce, fetcher, WithExpectedProofNonce(nonce))
+		require.ErrorIs(t, err, ErrProofNonceMismatch)
+	})
+}
+
+func BenchmarkCheckLinkedDataProof(b *testing.B) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(b, err)
+
+	vcBytes := prepareVCWithEd25519LDPs(b, signer, 3)
+
+	loader, err := ldtestutil.DocumentLoader()
+	require.NoError(b, err)
+
+	fetcher := SingleKey(signer.PublicKeyBytes(), kms.ED25519)
+	suites := []verifier.SignatureSuite{ed25519signature2018.New(
+		suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier()), suite.WithCompactProof())}
+
+	b.Run("canonical document per proof", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			err := checkLinkedDataProof(vcBytes, suites, fetcher, &jsonldCredentialOpts{jsonldDocumentLoader: loader}, nil)
+			require.NoError(b, err)
+		}
+	})
+
+	b.Run("canonical document cached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			err := checkLinkedDataProof(vcBytes, suites, fetcher, &jsonldCredentialOpts{jsonldDocumentLoader: loader},
+				proof.NewCanonicalDocumentCache())
+			require.NoError(b, err)
+		}
+	})
+}
+
+// prepareVCWithEd25519LDPs returns a credential with a proof set of n Ed25519Signature2018 proofs.
+func prepareVCWithEd25519LDPs(tb testing.TB, signer Signer, n int) []byte {
+	tb.Helper()
+
+	loader, err := ldtestutil.DocumentLoader()
+	require.NoError(tb, err)
+
+	vc, err := ParseCredential([]byte(validCredential),
+		WithJSONLDDocumentLoader(loader),
+		WithDisabledProofCheck())
+	require.NoError(tb, err)
+
+	ed25519SignerSuite := ed25519signature2018.New(
+		suite.WithSigner(signer),
+		suite.WithCompactProof())
+
+	for i := 0; i < n; i++ {
+		created := time.Date(2018, 3, 15, 0, 0, i, 0, time.UTC)
+
+		err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+			SignatureType:           "Ed25519Signature2018",
+			Suite:                   ed25519SignerSuite,
+			SignatureRepresentation: SignatureJWS,
+			Created:                 &created,
+			VerificationMethod:      "did:example:123456#key1",
+		}, jsonld.WithDocumentLoader(loader))
+		require.NoError(tb, err)
+	}
+
+	vcBytes, err := vc.MarshalJSON()
+	require.NoError(tb, err)
+
+	return vcBytes
+}
+
+// countingCanonicalizationSuite counts the canonicalized documents.
+type countingCanonicalizationSuite struct {
+	*ed25519signature2018.Suite
+	count int
+}
+
+func (s *countingCanonicalizationSuite) GetCanonicalDocument(doc map[string]interface{},
+	opts ...jsonld.ProcessorOpts) ([]byte, error) {
+	s.count++
+
+	return s.Suite.GetCanonicalDocument(doc, opts...)
+}
@@ -0,0 +1,160 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/sdjwt/common"
+)
+
+// MediaType defines the serialization form of a Verifiable Credential or Presentation.
+type MediaType string
+
+const (
+	// MediaTypeLDCredential is a Verifiable Credential in JSON-LD form.
+	MediaTypeLDCredential MediaType = "application/vc+ld+json"
+
+	// MediaTypeJWTCredential is a Verifiable Credential in JWT form.
+	MediaTypeJWTCredential MediaType = "application/vc+jwt"
+
+	// MediaTypeSDJWTCredential is a Verifiable Credential in SD-JWT combined form.
+	MediaTypeSDJWTCredential MediaType = "application/vc+sd-jwt"
+
+	// MediaTypeLDPresentation is a Verifiable Presentation in JSON-LD form.
+	MediaTypeLDPresentation MediaType = "application/vp+ld+json"
+
+	// MediaTypeJWTPresentation is a Verifiable Presentation in JWT form.
+	MediaTypeJWTPresentation MediaType = "application/vp+jwt"
+
+	// MediaTypeCBOR is a CBOR encoded document (a CBOR map), which is not decoded any further.
+	MediaTypeCBOR MediaType = "application/cbor"
+)
+
+const (
+	jwtVCClaim = "vc"
+	jwtVPClaim = "vp"
+
+	cborMapMin        = 0xa0 // CBOR major type 5 (map) with 0 items
+	cborMapIndefinite = 0xbf // CBOR major type 5 (map) of indefinite length
+)
+
+// DetectMediaType inspects the structure of the raw data and detects whether it is
+// a Verifiable Credential or Presentation and in which serialization form, without parsing it.
+//
+// JWT and SD-JWT forms can be passed as is, as a JSON string or wrapped into {"jwt": "..."} object.
+// Signatures are not checked.
+func DetectMediaType(data []byte) (MediaType, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return "", errors.New("empty data")
+	}
+
+	if data[0] >= cborMapMin && data[0] <= cborMapIndefinite {
+		return MediaTypeCBOR, nil
+	}
+
+	str := unwrapStringVC(data)
+
+	if strings.Contains(str, common.CombinedFormatSeparator) {
+		sdJWT := strings.Split(str, common.CombinedFormatSeparator)[0]
+		if jwt.IsJWS(sdJWT) {
+			return detectJWTMediaType(sdJWT, MediaTypeSDJWTCredential)
+		}
+	}
+
+	if jwt.IsJWS(str) || jwt.IsJWTUnsecured(str) {
+		return detectJWTMediaType(str, MediaTypeJWTCredential)
+	}
+
+	if data[0] == '{' {
+		return detectJSONLDMediaType(data)
+	}
+
+	return "", errors.New("unknown media type")
+}
+
+func detectJWTMediaType(jwtStr string, credentialMediaType MediaType) (MediaType, error) {
+	payloadBytes, err := base64.RawURLEncoding.DecodeString(strings.Split(jwtStr, ".")[1])
+	if err != nil {
+		return "", fmt.Errorf("decode JWT payload: %w", err)
+	}
+
+	var claims map[string]interface{}
+
+	err = json.Unmarshal(payloadBytes, &claims)
+	if err != nil {
+		return "", fmt.Errorf("unmarshal JWT payload: %w", err)
+	}
+
+	if _, ok := claims[jwtVPClaim]; ok {
+		return MediaTypeJWTPresentation, nil
+	}
+
+	if vcClaim, ok := claims[jwtVCClaim]; ok {
+		if hasSDAlg(claims) || hasSDAlg(vcClaim) {
+			// SD-JWT without disclosures has no combined format separator
+			return MediaTypeSDJWTCredential, nil
+		}
+
+		return credentialMediaType, nil
+	}
+
+	// SD-JWT credentials may have the credential claims on the top level.
+	if credentialMediaType == MediaTypeSDJWTCredential {
+		return credentialMediaType, nil
+	}
+
+	return "", errors.New("JWT has neither vc nor vp claim")
+}
+
+func hasSDAlg(claims interface{}) bool {
+	claimsMap, ok := claims.(map[string]interface{})
+	if !ok {
+		return false
+	}
+
+	_, ok = claimsMap[common.SDAlgorithmKey]
+
+	return ok
+}
+
+func detectJSONLDMediaType(data []byte) (MediaType, error) {
+	var doc map[string]interface{}
+
+	err := json.Unmarshal(data, &doc)
+	if err != nil {
+		return "", fmt.Errorf("unmarshal JSON: %w", err)
+	}
+
+	types, _ := decodeType(doc["type"]) //nolint:errcheck
+
+	if _, ok := doc["verifiableCredential"]; ok || contains(types, vpType) {
+		return MediaTypeLDPresentation, nil
+	}
+
+	if _, ok := doc["credentialSubject"]; ok || contains(types, vcType) {
+		return MediaTypeLDCredential, nil
+	}
+
+	return "", errors.New("JSON is neither a verifiable credential nor a verifiable presentation")
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
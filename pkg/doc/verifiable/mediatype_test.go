@@ -0,0 +1,124 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	_ "embed"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	afgojwt "github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+//go:embed testdata/domain_linkage_interop.jwt
+var domainLinkageInteropJWT []byte //nolint:gochecknoglobals
+
+func TestDetectMediaType(t *testing.T) {
+	_, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	signer := afgojwt.NewEd25519Signer(privKey)
+
+	cryptoSigner, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	require.NoError(t, err)
+
+	vcClaims, err := vc.JWTClaims(false)
+	require.NoError(t, err)
+
+	vcJWT, err := vcClaims.MarshalJWS(EdDSA, cryptoSigner, "did:example:abc123#key-1")
+	require.NoError(t, err)
+
+	vcUnsecuredJWT, err := vcClaims.MarshalUnsecuredJWT()
+	require.NoError(t, err)
+
+	vcSDJWT, err := vc.MakeSDJWT(signer, "did:example:abc123#key-1")
+	require.NoError(t, err)
+
+	vp, err := newTestPresentation(t, []byte(validPresentation), WithPresDisabledProofCheck())
+	require.NoError(t, err)
+
+	vpClaims, err := vp.JWTClaims([]string{}, false)
+	require.NoError(t, err)
+
+	vpJWT, err := vpClaims.MarshalJWS(EdDSA, cryptoSigner, "did:example:abc123#key-1")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		data      []byte
+		mediaType MediaType
+	}{
+		{name: "JSON-LD credential", data: []byte(validCredential), mediaType: MediaTypeLDCredential},
+		{name: "JSON-LD presentation", data: []byte(validPresentation), mediaType: MediaTypeLDPresentation},
+		{name: "JWT credential", data: []byte(vcJWT), mediaType: MediaTypeJWTCredential},
+		{name: "quoted JWT credential", data: []byte(`"` + vcJWT + `"`), mediaType: MediaTypeJWTCredential},
+		{
+			name:      "wrapped JWT credential",
+			data:      []byte(fmt.Sprintf(`{"jwt": %q}`, vcJWT)),
+			mediaType: MediaTypeJWTCredential,
+		},
+		{name: "unsecured JWT credential", data: []byte(vcUnsecuredJWT), mediaType: MediaTypeJWTCredential},
+		{name: "interop JWT credential", data: domainLinkageInteropJWT, mediaType: MediaTypeJWTCredential},
+		{name: "SD-JWT credential", data: []byte(vcSDJWT), mediaType: MediaTypeSDJWTCredential},
+		{name: "JWT presentation", data: []byte(vpJWT), mediaType: MediaTypeJWTPresentation},
+		{name: "CBOR", data: []byte{0xa2, 0x61, 0x61, 0x01, 0x61, 0x62, 0x02}, mediaType: MediaTypeCBOR},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			mediaType, err := DetectMediaType(tc.data)
+			require.NoError(t, err)
+			require.Equal(t, tc.mediaType, mediaType)
+		})
+	}
+
+	t.Run("error - empty data", func(t *testing.T) {
+		mediaType, err := DetectMediaType([]byte("  \n"))
+		require.EqualError(t, err, "empty data")
+		require.Empty(t, mediaType)
+	})
+
+	t.Run("error - unknown data", func(t *testing.T) {
+		mediaType, err := DetectMediaType([]byte("not a credential"))
+		require.EqualError(t, err, "unknown media type")
+		require.Empty(t, mediaType)
+	})
+
+	t.Run("error - JSON is not a credential or presentation", func(t *testing.T) {
+		mediaType, err := DetectMediaType([]byte(`{"id": "http://example.edu/credentials/1872"}`))
+		require.EqualError(t, err, "JSON is neither a verifiable credential nor a verifiable presentation")
+		require.Empty(t, mediaType)
+	})
+
+	t.Run("error - invalid JSON", func(t *testing.T) {
+		mediaType, err := DetectMediaType([]byte(`{"id": `))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unmarshal JSON")
+		require.Empty(t, mediaType)
+	})
+
+	t.Run("error - JWT without vc or vp claim", func(t *testing.T) {
+		token, err := afgojwt.NewSigned(map[string]interface{}{"iss": "did:example:abc123"}, nil, signer)
+		require.NoError(t, err)
+
+		jws, err := token.Serialize(false)
+		require.NoError(t, err)
+
+		mediaType, err := DetectMediaType([]byte(jws))
+		require.EqualError(t, err, "JWT has neither vc nor vp claim")
+		require.Empty(t, mediaType)
+	})
+}
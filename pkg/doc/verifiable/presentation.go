@@ -16,6 +16,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
 	docjsonld "github.com/hyperledger/aries-framework-go/pkg/doc/jsonld"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	sdjwtverifier "github.com/hyperledger/aries-framework-go/pkg/doc/sdjwt/verifier"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
 	jsonutil "github.com/hyperledger/aries-framework-go/pkg/doc/util/json"
 )
@@ -357,6 +358,12 @@ type presentationOpts struct {
 	requireVC           bool
 	requireProof        bool
 	disableJSONLDChecks bool
+	proofThreshold      int
+	expectedProofNonce  []byte
+
+	sdJWTHolderBindingRequired bool
+	sdJWTHolderBindingAudience string
+	sdJWTHolderBindingNonce    string
 
 	jsonldCredentialOpts
 }
@@ -386,6 +393,22 @@ func WithPresDisabledProofCheck() PresentationOpt {
 	}
 }
 
+// WithPresExpectedProofNonce requires every embedded linked data proof of VP to have the given nonce
+// (e.g. a nonce chosen by the verifier), otherwise the parsing fails with ErrProofNonceMismatch.
+func WithPresExpectedProofNonce(nonce []byte) PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.expectedProofNonce = nonce
+	}
+}
+
+// WithPresProofThreshold relaxes the check of the embedded proof set of VP (e.g. proofs of several holders):
+// at least threshold proofs made by distinct verification methods must be valid. By default, all proofs must be valid.
+func WithPresProofThreshold(threshold int) PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.proofThreshold = threshold
+	}
+}
+
 // WithPresStrictValidation enabled strict JSON-LD validation of VP.
 // In case of JSON-LD validation, the comparison of JSON-LD VP document after compaction with original VP one is made.
 // In case of mismatch a validation exception is raised.
@@ -411,12 +434,28 @@ func WithDisabledJSONLDChecks() PresentationOpt {
 	}
 }
 
+// WithPresExpectedSDJWTHolderBinding requires every SD-JWT credential of VP to have a holder binding JWT
+// with the expected audience and nonce (an empty value is not checked), e.g. the verifier and the nonce
+// of the presentation request.
+func WithPresExpectedSDJWTHolderBinding(audience, nonce string) PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.sdJWTHolderBindingRequired = true
+		opts.sdJWTHolderBindingAudience = audience
+		opts.sdJWTHolderBindingNonce = nonce
+	}
+}
+
 // ParsePresentation creates an instance of Verifiable Presentation by reading a JSON document from bytes.
 // It also applies miscellaneous options like custom decoders or settings of schema validation.
+//
+// SD-JWT credentials of VP (combined format for presentation) are parsed into Credential with the disclosures
+// selected by the holder, see Credential.DisclosedClaims. The digest of every disclosure is checked against
+// the SD-JWT and, unless the proof check is disabled, the holder binding JWT (if any) is verified with the
+// holder public key (cnf) of the SD-JWT.
 func ParsePresentation(vpData []byte, opts ...PresentationOpt) (*Presentation, error) {
 	vpOpts := getPresentationOpts(opts)
 
-	vpDataDecoded, vpRaw, vpJWT, err := decodeRawPresentation(vpData, vpOpts)
+	vpDataDecoded, vpRaw, vpJWT, err := decodeRawPresentation(jsonutil.TrimBOM(vpData), vpOpts)
 	if err != nil {
 		return nil, err
 	}
@@ -512,8 +551,18 @@ func decodeCredentials(rawCred interface{}, opts *presentationOpts) ([]interface
 			}
 
 			vc, err := ParseCredential(bCred, credOpts...)
+			if err != nil {
+				return nil, err
+			}
+
+			if vc.SDJWTHashAlg != "" && !opts.disabledProofCheck {
+				err = verifySDJWTHolderBinding(vc, opts)
+				if err != nil {
+					return nil, err
+				}
+			}
 
-			return vc, err
+			return vc, nil
 		}
 
 		// return credential in a structure format as is
@@ -551,6 +600,22 @@ func decodeCredentials(rawCred interface{}, opts *presentationOpts) ([]interface
 	}
 }
 
+// verifySDJWTHolderBinding verifies the holder binding JWT of SD-JWT credential.
+func verifySDJWTHolderBinding(vc *Credential, opts *presentationOpts) error {
+	var claims map[string]interface{}
+
+	// the signature of SD-JWT is checked when parsing the credential
+	err := unmarshalJWS(vc.JWT, false, nil, &claims)
+	if err != nil {
+		return fmt.Errorf("decode SD-JWT credential claims: %w", err)
+	}
+
+	return sdjwtverifier.VerifyHolderBinding(claims, vc.SDHolderBinding,
+		sdjwtverifier.WithHolderBindingRequired(opts.sdJWTHolderBindingRequired),
+		sdjwtverifier.WithExpectedAudienceForHolderBinding(opts.sdJWTHolderBindingAudience),
+		sdjwtverifier.WithExpectedNonceForHolderBinding(opts.sdJWTHolderBindingNonce))
+}
+
 func validateVP(data []byte, opts *presentationOpts) error {
 	err := validateVPJSONSchema(data)
 	if err != nil {
@@ -609,6 +674,8 @@ func decodeRawPresentation(vpData []byte, vpOpts *presentationOpts) ([]byte, *ra
 		publicKeyFetcher:     vpOpts.publicKeyFetcher,
 		disabledProofCheck:   vpOpts.disabledProofCheck,
 		ldpSuites:            vpOpts.ldpSuites,
+		proofThreshold:       vpOpts.proofThreshold,
+		expectedNonce:        vpOpts.expectedProofNonce,
 		jsonldCredentialOpts: vpOpts.jsonldCredentialOpts,
 	}
 
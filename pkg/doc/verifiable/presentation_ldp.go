@@ -27,3 +27,18 @@ func (vp *Presentation) AddLinkedDataProof(context *LinkedDataProofContext, json
 
 	return nil
 }
+
+// AddLinkedDataProofs appends a proof per each context to the Verifiable Presentation, building a proof set.
+// It's used when the presentation is signed by several holders. Each proof is created independently of
+// the other proofs of the set.
+func (vp *Presentation) AddLinkedDataProofs(contexts []*LinkedDataProofContext,
+	jsonldOpts ...jsonld.ProcessorOpts) error {
+	for i, context := range contexts {
+		err := vp.AddLinkedDataProof(context, jsonldOpts...)
+		if err != nil {
+			return fmt.Errorf("add linked data proof %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
@@ -7,6 +7,7 @@ package verifiable
 
 import (
 	"encoding/json"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -14,7 +15,9 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
 	jsonutil "github.com/hyperledger/aries-framework-go/pkg/doc/util/json"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/signature"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 )
 
@@ -97,3 +100,114 @@ func TestPresentation_AddLinkedDataProof(t *testing.T) {
 		r.Equal("Ed25519Signature2018", newVPProof["type"])
 	})
 }
+
+func TestPresentation_AddLinkedDataProofs(t *testing.T) {
+	holder1Signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	holder2Signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	otherSigner, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	holderKeys := map[string][]byte{
+		"did:example:holder1": holder1Signer.PublicKeyBytes(),
+		"did:example:holder2": holder2Signer.PublicKeyBytes(),
+	}
+
+	pubKeyFetcher := func(issuerID, _ string) (*verifier.PublicKey, error) {
+		pubKey, ok := holderKeys[issuerID]
+		if !ok {
+			return nil, fmt.Errorf("unknown holder %s", issuerID)
+		}
+
+		return &verifier.PublicKey{Type: kms.ED25519, Value: pubKey}, nil
+	}
+
+	ldpContext := func(s signature.Signer, verificationMethod string) *LinkedDataProofContext {
+		return &LinkedDataProofContext{
+			SignatureType:           "Ed25519Signature2018",
+			SignatureRepresentation: SignatureJWS,
+			Suite:                   ed25519signature2018.New(suite.WithSigner(s)),
+			VerificationMethod:      verificationMethod,
+			Purpose:                 "authentication",
+		}
+	}
+
+	signVP := func(t *testing.T, contexts ...*LinkedDataProofContext) []byte {
+		t.Helper()
+
+		vp, err := newTestPresentation(t, []byte(validPresentation))
+		require.NoError(t, err)
+
+		err = vp.AddLinkedDataProofs(contexts, jsonld.WithDocumentLoader(createTestDocumentLoader(t)))
+		require.NoError(t, err)
+		require.Len(t, vp.Proofs, len(contexts))
+
+		vpBytes, err := json.Marshal(vp)
+		require.NoError(t, err)
+
+		return vpBytes
+	}
+
+	t.Run("all holder proofs are verified", func(t *testing.T) {
+		vpBytes := signVP(t,
+			ldpContext(holder1Signer, "did:example:holder1#key1"),
+			ldpContext(holder2Signer, "did:example:holder2#key1"))
+
+		vp, err := newTestPresentation(t, vpBytes, WithPresPublicKeyFetcher(pubKeyFetcher))
+		require.NoError(t, err)
+		require.Len(t, vp.Proofs, 2)
+
+		_, err = newTestPresentation(t, vpBytes, WithPresPublicKeyFetcher(pubKeyFetcher), WithPresProofThreshold(2))
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid holder proof fails the check of all proofs", func(t *testing.T) {
+		vpBytes := signVP(t,
+			ldpContext(holder1Signer, "did:example:holder1#key1"),
+			ldpContext(otherSigner, "did:example:holder2#key1"))
+
+		_, err := newTestPresentation(t, vpBytes, WithPresPublicKeyFetcher(pubKeyFetcher))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "check embedded proof")
+
+		_, err = newTestPresentation(t, vpBytes, WithPresPublicKeyFetcher(pubKeyFetcher), WithPresProofThreshold(2))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "1 of 2 required proofs are valid")
+
+		// threshold of holder proofs is met
+		_, err = newTestPresentation(t, vpBytes, WithPresPublicKeyFetcher(pubKeyFetcher), WithPresProofThreshold(1))
+		require.NoError(t, err)
+	})
+
+	t.Run("proofs of the same verification method are counted once", func(t *testing.T) {
+		vpBytes := signVP(t,
+			ldpContext(holder1Signer, "did:example:holder1#key1"),
+			ldpContext(holder1Signer, "did:example:holder1#key1"))
+
+		_, err := newTestPresentation(t, vpBytes, WithPresPublicKeyFetcher(pubKeyFetcher), WithPresProofThreshold(2))
+		require.EqualError(t, err,
+			"check embedded proof: 1 of 2 required proofs are made by distinct verification methods")
+	})
+
+	t.Run("not enough proofs", func(t *testing.T) {
+		vpBytes := signVP(t, ldpContext(holder1Signer, "did:example:holder1#key1"))
+
+		_, err := newTestPresentation(t, vpBytes, WithPresPublicKeyFetcher(pubKeyFetcher), WithPresProofThreshold(2))
+		require.EqualError(t, err, "check embedded proof: 1 proof(s) found, at least 2 required")
+	})
+
+	t.Run("error - invalid context", func(t *testing.T) {
+		vp, err := newTestPresentation(t, []byte(validPresentation))
+		require.NoError(t, err)
+
+		err = vp.AddLinkedDataProofs([]*LinkedDataProofContext{
+			ldpContext(holder1Signer, "did:example:holder1#key1"),
+			{SignatureType: "Unknown", Suite: ed25519signature2018.New(suite.WithSigner(holder2Signer))},
+		}, jsonld.WithDocumentLoader(createTestDocumentLoader(t)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "add linked data proof 1")
+	})
+}
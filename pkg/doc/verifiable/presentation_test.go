@@ -146,6 +146,12 @@ func TestParsePresentation(t *testing.T) {
 		require.Equal(t, "did:example:ebfeb1f712ebc6f1c276e12ec21", vp.Holder)
 	})
 
+	t.Run("creates a new Verifiable Presentation from JSON with BOM and leading whitespace", func(t *testing.T) {
+		vp, err := newTestPresentation(t, []byte("\xEF\xBB\xBF\n"+validPresentation), WithPresStrictValidation())
+		require.NoError(t, err)
+		require.Equal(t, "urn:uuid:3978344f-8596-4c3a-a978-8fcaba3903c5", vp.ID)
+	})
+
 	t.Run("creates a new Verifiable Presentation from valid JSON without credentials", func(t *testing.T) {
 		vp, err := newTestPresentation(t, []byte(presentationWithoutCredentials), WithPresStrictValidation())
 		require.NoError(t, err)
@@ -0,0 +1,122 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbsblssignature2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ecdsasecp256k1signature2019"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/jsonwebsignature2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+)
+
+// ProofVerifier verifies the signature of a proof with the public key of the verification method.
+// It allows to delegate the cryptography of the proof check, e.g. to a remote KMS or HSM.
+type ProofVerifier interface {
+	// Verify verifies the signature of the message. The alg is the JWS algorithm of a JWT proof (e.g. ES256K)
+	// or the type of a linked data proof (e.g. EcdsaSecp256k1Signature2019).
+	Verify(alg string, pubKey *verifier.PublicKey, message, signature []byte) error
+}
+
+// LocalProofVerifier verifies proofs with the local crypto, as it is done when no ProofVerifier is defined.
+// It can be used as a fallback of a remote ProofVerifier.
+type LocalProofVerifier struct{}
+
+// NewLocalProofVerifier creates a new LocalProofVerifier.
+func NewLocalProofVerifier() *LocalProofVerifier {
+	return &LocalProofVerifier{}
+}
+
+// Verify verifies the signature of the message with the local crypto.
+func (v *LocalProofVerifier) Verify(alg string, pubKey *verifier.PublicKey, message, signature []byte) error {
+	switch alg {
+	case ed25519Signature2018:
+		return ed25519signature2018.NewPublicKeyVerifier().Verify(pubKey, message, signature)
+	case ed25519Signature2020:
+		return ed25519signature2020.NewPublicKeyVerifier().Verify(pubKey, message, signature)
+	case jsonWebSignature2020:
+		return jsonwebsignature2020.NewPublicKeyVerifier().Verify(pubKey, message, signature)
+	case ecdsaSecp256k1Signature2019:
+		return ecdsasecp256k1signature2019.NewPublicKeyVerifier().Verify(pubKey, message, signature)
+	case bbsBlsSignature2020:
+		return bbsblssignature2020.NewG2PublicKeyVerifier().Verify(pubKey, message, signature)
+	}
+
+	for _, sv := range jwsSignatureVerifiers() {
+		if sv.Algorithm() == alg {
+			return sv.Verify(pubKey, message, signature)
+		}
+	}
+
+	return fmt.Errorf("unsupported proof algorithm: %s", alg)
+}
+
+// jwsSignatureVerifiers returns the verifiers of the JWS algorithms supported by jwt.NewVerifier.
+func jwsSignatureVerifiers() []verifier.SignatureVerifier {
+	return []verifier.SignatureVerifier{
+		verifier.NewECDSAES256SignatureVerifier(),
+		verifier.NewECDSAES384SignatureVerifier(),
+		verifier.NewECDSAES521SignatureVerifier(),
+		verifier.NewEd25519SignatureVerifier(),
+		verifier.NewECDSASecp256k1SignatureVerifier(),
+		verifier.NewRSAPS256SignatureVerifier(),
+		verifier.NewRSARS256SignatureVerifier(),
+	}
+}
+
+// proofVerifierJWS verifies the signature of a JWT by the ProofVerifier, the public key is resolved
+// from the "kid" header by the fetcher.
+func proofVerifierJWS(fetcher PublicKeyFetcher, proofVerifier ProofVerifier) jose.SignatureVerifier {
+	return jose.SignatureVerifierFunc(func(joseHeaders jose.Headers, _, signingInput, signature []byte) error {
+		alg, ok := joseHeaders.Algorithm()
+		if !ok {
+			return errors.New("'alg' JOSE header is not present")
+		}
+
+		kid, _ := joseHeaders.KeyID()
+
+		didID, keyID, ok := strings.Cut(kid, "#")
+		if !ok || !strings.HasPrefix(kid, "did:") {
+			return fmt.Errorf("kid %s is not DID", kid)
+		}
+
+		pubKey, err := fetcher(didID, keyID)
+		if err != nil {
+			return err
+		}
+
+		return proofVerifier.Verify(alg, pubKey, signingInput, signature)
+	})
+}
+
+type suiteVerifier interface {
+	Verify(pubKey *verifier.PublicKey, doc, signature []byte) error
+}
+
+// suiteVerifier returns the verifier of the default signature suite of the proof type.
+func (opts *embeddedProofCheckOpts) suiteVerifier(proofType string, local suiteVerifier) suiteVerifier {
+	if opts.proofVerifier == nil {
+		return local
+	}
+
+	return &ldpProofVerifier{proofType: proofType, proofVerifier: opts.proofVerifier}
+}
+
+// ldpProofVerifier is a signature suite verifier delegating the signature check to the ProofVerifier.
+type ldpProofVerifier struct {
+	proofType     string
+	proofVerifier ProofVerifier
+}
+
+func (v *ldpProofVerifier) Verify(pubKey *verifier.PublicKey, doc, signature []byte) error {
+	return v.proofVerifier.Verify(v.proofType, pubKey, doc, signature)
+}
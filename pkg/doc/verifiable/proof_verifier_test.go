@@ -0,0 +1,95 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+// mockRemoteVerifier records the verification requests and verifies them locally.
+type mockRemoteVerifier struct {
+	algs []string
+	err  error
+}
+
+func (v *mockRemoteVerifier) Verify(alg string, pubKey *verifier.PublicKey, message, signature []byte) error {
+	v.algs = append(v.algs, alg)
+
+	if v.err != nil {
+		return v.err
+	}
+
+	return NewLocalProofVerifier().Verify(alg, pubKey, message, signature)
+}
+
+func TestWithProofVerifier(t *testing.T) {
+	t.Run("JWT proof", func(t *testing.T) {
+		signer, err := newCryptoSigner(kms.ED25519Type)
+		require.NoError(t, err)
+
+		jws := createEdDSAJWS(t, []byte(jwtTestCredential), signer, false)
+		fetcher := createDIDKeyFetcher(t, signer.PublicKeyBytes(), "76e12ec712ebc6f1c221ebfeb1f")
+
+		remote := &mockRemoteVerifier{}
+
+		_, err = parseTestCredential(t, jws, WithPublicKeyFetcher(fetcher), WithProofVerifier(remote))
+		require.NoError(t, err)
+		require.Equal(t, []string{"EdDSA"}, remote.algs)
+
+		remote = &mockRemoteVerifier{err: errors.New("remote verification failed")}
+
+		_, err = parseTestCredential(t, jws, WithPublicKeyFetcher(fetcher), WithProofVerifier(remote))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "remote verification failed")
+	})
+
+	t.Run("linked data proof", func(t *testing.T) {
+		vc, fetcher := createVCWithLinkedDataProof(t)
+
+		vcBytes, err := vc.MarshalJSON()
+		require.NoError(t, err)
+
+		remote := &mockRemoteVerifier{}
+
+		_, err = parseTestCredential(t, vcBytes, WithPublicKeyFetcher(fetcher), WithProofVerifier(remote))
+		require.NoError(t, err)
+		require.Equal(t, []string{"Ed25519Signature2018"}, remote.algs)
+
+		remote = &mockRemoteVerifier{err: errors.New("remote verification failed")}
+
+		_, err = parseTestCredential(t, vcBytes, WithPublicKeyFetcher(fetcher), WithProofVerifier(remote))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "remote verification failed")
+	})
+}
+
+func TestLocalProofVerifier_Verify(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ECDSASecp256k1TypeIEEEP1363)
+	require.NoError(t, err)
+
+	msg := []byte("test message")
+
+	signature, err := signer.Sign(msg)
+	require.NoError(t, err)
+
+	pubKey := &verifier.PublicKey{Type: "EcdsaSecp256k1VerificationKey2019", Value: signer.PublicKeyBytes()}
+
+	v := NewLocalProofVerifier()
+
+	require.NoError(t, v.Verify("ES256K", pubKey, msg, signature))
+
+	err = v.Verify("ES256K", pubKey, []byte("other message"), signature)
+	require.Error(t, err)
+
+	err = v.Verify("unknown", pubKey, msg, signature)
+	require.EqualError(t, err, "unsupported proof algorithm: unknown")
+}
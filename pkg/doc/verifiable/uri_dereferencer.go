@@ -0,0 +1,131 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// URIDereferencer dereferences the URIs of the resources referenced by a credential, i.e. its credentialSchema,
+// the status list of its credentialStatus and its refreshService. A custom dereferencer allows to fetch resources
+// by non-HTTP URIs, e.g. a status list hosted by a DID resolved with the VDR.
+type URIDereferencer interface {
+	// Dereference returns the content of the resource identified by the URI. ErrUnsupportedURI is returned
+	// if the dereferencer doesn't support the URI (e.g. its scheme).
+	Dereference(uri string) ([]byte, error)
+}
+
+// ErrUnsupportedURI is returned by URIDereferencer if the URI can't be dereferenced by it.
+var ErrUnsupportedURI = errors.New("unsupported URI")
+
+// HTTPStatusError is returned by HTTPURIDereferencer if the resource is fetched with a status other than 200 OK.
+type HTTPStatusError struct {
+	StatusCode int
+}
+
+func (e *HTTPStatusError) Error() string {
+	return fmt.Sprintf("HTTP failure [%v]", e.StatusCode)
+}
+
+// HTTPURIDereferencer is the default URIDereferencer fetching HTTP(S) URIs with an HTTP GET request.
+type HTTPURIDereferencer struct {
+	client HTTPClient
+	accept string
+}
+
+// NewHTTPURIDereferencer creates a new URIDereferencer fetching HTTP(S) URIs with the client.
+func NewHTTPURIDereferencer(client HTTPClient) *HTTPURIDereferencer {
+	return &HTTPURIDereferencer{client: client}
+}
+
+// Dereference fetches the resource identified by the HTTP(S) URI.
+func (d *HTTPURIDereferencer) Dereference(uri string) ([]byte, error) {
+	u, err := url.Parse(uri)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedURI, uri)
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("new request: %w", err)
+	}
+
+	if d.accept != "" {
+		req.Header.Set("Accept", d.accept)
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("httpClient do: %w", err)
+	}
+
+	defer func() {
+		e := resp.Body.Close()
+		if e != nil {
+			logger.Errorf("closing response body failed [%v]", e)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &HTTPStatusError{StatusCode: resp.StatusCode}
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+
+	return respBytes, nil
+}
+
+// ErrNoStatusList is returned by Credential.FetchStatusList if the credential status doesn't reference
+// a status list credential.
+var ErrNoStatusList = errors.New("credential status has no status list credential")
+
+// status list credential properties of StatusList2021Entry and RevocationList2020Status credential statuses.
+//
+//nolint:gochecknoglobals
+var statusListCredentialFields = []string{"statusListCredential", "revocationListCredential"}
+
+// FetchStatusList dereferences the status list credential referenced by the credentialStatus of the credential
+// (the statusListCredential of StatusList2021Entry or the revocationListCredential of RevocationList2020Status)
+// and parses it with the options, e.g. WithPublicKeyFetcher to check its proof.
+func (vc *Credential) FetchStatusList(dereferencer URIDereferencer, opts ...CredentialOpt) (*Credential, error) {
+	if vc.Status == nil {
+		return nil, ErrNoStatusList
+	}
+
+	var statusListURI string
+
+	for _, field := range statusListCredentialFields {
+		if uri, ok := vc.Status.CustomFields[field].(string); ok && uri != "" {
+			statusListURI = uri
+
+			break
+		}
+	}
+
+	if statusListURI == "" {
+		return nil, ErrNoStatusList
+	}
+
+	vcBytes, err := dereferencer.Dereference(statusListURI)
+	if err != nil {
+		return nil, fmt.Errorf("fetch status list %s: %w", statusListURI, err)
+	}
+
+	statusList, err := ParseCredential(vcBytes, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("fetch status list %s: parse status list credential: %w", statusListURI, err)
+	}
+
+	return statusList, nil
+}
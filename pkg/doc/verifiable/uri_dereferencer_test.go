@@ -0,0 +1,227 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/model"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
+)
+
+const (
+	statusListIssuer = "did:example:status-list-issuer"
+
+	statusListCredential = `{
+  "@context": [
+    "https://www.w3.org/2018/credentials/v1",
+    "https://w3id.org/vc/status-list/2021/v1"
+  ],
+  "id": "did:example:status-list-issuer?service=status-list",
+  "type": ["VerifiableCredential", "StatusList2021Credential"],
+  "issuer": "did:example:status-list-issuer",
+  "issuanceDate": "2021-04-05T14:27:40Z",
+  "credentialSubject": {
+    "id": "did:example:status-list-issuer?service=status-list#list",
+    "type": "StatusList2021",
+    "statusPurpose": "revocation",
+    "encodedList": "H4sIAAAAAAAAA-3BMQEAAADCoPVPbQwfoAAAAAAAAAAAAAAAAAAAAIC3AYbSVKsAQAAA"
+  }
+}`
+)
+
+// didServiceDereferencer dereferences DID URLs with a service query to the resource at the service endpoint.
+type didServiceDereferencer struct {
+	vdr  vdrapi.Registry
+	next URIDereferencer
+}
+
+func (d *didServiceDereferencer) Dereference(uri string) ([]byte, error) {
+	if !strings.HasPrefix(uri, "did:") {
+		return nil, ErrUnsupportedURI
+	}
+
+	didURL, err := did.ParseDIDURL(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	docResolution, err := d.vdr.Resolve(didURL.DID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, svc := range docResolution.DIDDocument.Service {
+		if len(didURL.Queries["service"]) == 1 && strings.HasSuffix(svc.ID, "#"+didURL.Queries["service"][0]) {
+			endpoint, err := svc.ServiceEndpoint.URI()
+			if err != nil {
+				return nil, err
+			}
+
+			return d.next.Dereference(endpoint)
+		}
+	}
+
+	return nil, fmt.Errorf("service of DID URL %s not found", uri)
+}
+
+func newStatusListServer(t *testing.T, body string) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/status/1" {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		_, err := w.Write([]byte(body))
+		require.NoError(t, err)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func newDIDServiceDereferencer(server *httptest.Server) *didServiceDereferencer {
+	return &didServiceDereferencer{
+		vdr: &mockvdr.MockVDRegistry{
+			ResolveFunc: func(didID string, _ ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+				if didID != statusListIssuer {
+					return nil, vdrapi.ErrNotFound
+				}
+
+				return &did.DocResolution{DIDDocument: &did.Doc{
+					ID: statusListIssuer,
+					Service: []did.Service{{
+						ID:              statusListIssuer + "#status-list",
+						Type:            "StatusList2021",
+						ServiceEndpoint: model.NewDIDCommV1Endpoint(server.URL + "/status/1"),
+					}},
+				}}, nil
+			},
+		},
+		next: NewHTTPURIDereferencer(server.Client()),
+	}
+}
+
+func TestHTTPURIDereferencer(t *testing.T) {
+	server := newStatusListServer(t, statusListCredential)
+
+	dereferencer := NewHTTPURIDereferencer(server.Client())
+
+	t.Run("success", func(t *testing.T) {
+		content, err := dereferencer.Dereference(server.URL + "/status/1")
+		require.NoError(t, err)
+		require.Equal(t, statusListCredential, string(content))
+	})
+
+	t.Run("error - unsupported URI", func(t *testing.T) {
+		_, err := dereferencer.Dereference(statusListIssuer + "?service=status-list")
+		require.True(t, errors.Is(err, ErrUnsupportedURI))
+	})
+
+	t.Run("error - HTTP failure", func(t *testing.T) {
+		_, err := dereferencer.Dereference(server.URL + "/status/2")
+		require.EqualError(t, err, "HTTP failure [404]")
+
+		var statusErr *HTTPStatusError
+		require.True(t, errors.As(err, &statusErr))
+		require.Equal(t, http.StatusNotFound, statusErr.StatusCode)
+	})
+}
+
+func TestCredential_FetchStatusList(t *testing.T) {
+	server := newStatusListServer(t, statusListCredential)
+
+	vc := &Credential{
+		Issuer: Issuer{ID: statusListIssuer},
+		Status: &TypedID{
+			ID:   statusListIssuer + "?service=status-list#94567",
+			Type: "StatusList2021Entry",
+			CustomFields: CustomFields{
+				"statusPurpose":        "revocation",
+				"statusListIndex":      "94567",
+				"statusListCredential": statusListIssuer + "?service=status-list",
+			},
+		},
+	}
+
+	t.Run("status list hosted by DID", func(t *testing.T) {
+		statusList, err := vc.FetchStatusList(newDIDServiceDereferencer(server),
+			WithDisabledProofCheck(), WithJSONLDDocumentLoader(createTestDocumentLoader(t)))
+		require.NoError(t, err)
+		require.Equal(t, statusListIssuer, statusList.Issuer.ID)
+		require.Contains(t, statusList.Types, "StatusList2021Credential")
+	})
+
+	t.Run("error - status list hosted by DID isn't supported by HTTP dereferencer", func(t *testing.T) {
+		_, err := vc.FetchStatusList(NewHTTPURIDereferencer(server.Client()))
+		require.Error(t, err)
+		require.True(t, errors.Is(err, ErrUnsupportedURI))
+	})
+
+	t.Run("error - no status list", func(t *testing.T) {
+		_, err := (&Credential{}).FetchStatusList(NewHTTPURIDereferencer(server.Client()))
+		require.True(t, errors.Is(err, ErrNoStatusList))
+
+		_, err = (&Credential{Status: &TypedID{ID: "urn:uuid:status", Type: "CredentialStatusList2017"}}).
+			FetchStatusList(NewHTTPURIDereferencer(server.Client()))
+		require.True(t, errors.Is(err, ErrNoStatusList))
+	})
+
+	t.Run("error - invalid status list credential", func(t *testing.T) {
+		invalidServer := newStatusListServer(t, "invalid")
+
+		_, err := vc.FetchStatusList(newDIDServiceDereferencer(invalidServer), WithDisabledProofCheck())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse status list credential")
+	})
+}
+
+func TestCredential_RefreshWithDereferencer(t *testing.T) {
+	expiredVC, err := parseTestCredential(t, []byte(jwtTestCredential))
+	require.NoError(t, err)
+
+	// the refreshed credential is served by the service of the DID
+	server := newStatusListServer(t, jwtTestCredential)
+
+	vc := *expiredVC
+	vc.RefreshService = []TypedID{{ID: statusListIssuer + "?service=status-list", Type: "ManualRefreshService2018"}}
+
+	_, err = vc.RefreshWithDereferencer(newDIDServiceDereferencer(server),
+		WithDisabledProofCheck(), WithJSONLDDocumentLoader(createTestDocumentLoader(t)))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "refreshed credential expired")
+
+	_, err = vc.Refresh(server.Client())
+	require.True(t, errors.Is(err, ErrNoRefreshService))
+}
+
+func TestCredentialSchemaLoaderBuilder_SetURIDereferencer(t *testing.T) {
+	server := newStatusListServer(t, `{"type": "object"}`)
+
+	loader := NewCredentialSchemaLoaderBuilder().
+		SetURIDereferencer(newDIDServiceDereferencer(server)).
+		Build()
+
+	schema, err := getJSONSchema(statusListIssuer+"?service=status-list", &credentialOpts{schemaLoader: loader})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"type": "object"}`, string(schema))
+
+	_, err = getJSONSchema(statusListIssuer+"?service=other", &credentialOpts{schemaLoader: loader})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "load credential schema: service of DID URL")
+}
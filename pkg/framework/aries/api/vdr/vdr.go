@@ -15,6 +15,15 @@ import (
 // ErrNotFound is returned when a DID resolver does not find the DID.
 var ErrNotFound = errors.New("DID does not exist")
 
+// ErrDIDNotFound is returned when a DID resolver does not find the DID. It is the same error as ErrNotFound.
+var ErrDIDNotFound = ErrNotFound
+
+// ErrResolverUnavailable is returned when a DID resolver can not be reached or fails to process the request.
+var ErrResolverUnavailable = errors.New("DID resolver is unavailable")
+
+// ErrInvalidDID is returned when a DID to be resolved is malformed.
+var ErrInvalidDID = errors.New("invalid DID")
+
 const (
 	// DIDCommServiceType default DID Communication service endpoint type.
 	DIDCommServiceType = "did-communication"
@@ -35,6 +35,7 @@ import (
 	ldstore "github.com/hyperledger/aries-framework-go/pkg/store/ld"
 	"github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/jwk"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/key"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/peer"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
@@ -82,6 +83,7 @@ type Aries struct {
 	mediaTypeProfiles          []string
 	inboundEnvelopeHandler     inbound.MessageHandler
 	didRotator                 middleware.DIDCommMessageMiddleware
+	protocolEvents             *service.ProtocolEventStream
 }
 
 // Option configures the framework.
@@ -401,6 +403,7 @@ func (a *Aries) Context() (*context.Provider, error) {
 		context.WithServiceMsgTypeTargets(a.servicesMsgTypeTargets...),
 		context.WithDIDRotator(&a.didRotator),
 		context.WithInboundEnvelopeHandler(&a.inboundEnvelopeHandler),
+		context.WithProtocolEvents(a.protocolEvents),
 	)
 }
 
@@ -412,6 +415,12 @@ func (a *Aries) Messenger() service.Messenger {
 
 // Close frees resources being maintained by the framework.
 func (a *Aries) Close() error {
+	if a.protocolEvents != nil {
+		if err := a.protocolEvents.Close(); err != nil {
+			return fmt.Errorf("failed to close the protocol event stream: %w", err)
+		}
+	}
+
 	if a.storeProvider != nil {
 		err := a.storeProvider.Close()
 		if err != nil {
@@ -514,7 +523,7 @@ func createVDR(frameworkOpts *Aries) error {
 	)
 
 	k := key.New()
-	opts = append(opts, vdr.WithVDR(k))
+	opts = append(opts, vdr.WithVDR(k), vdr.WithVDR(jwk.New()))
 
 	frameworkOpts.vdrRegistry = vdr.New(opts...)
 
@@ -705,6 +714,7 @@ func startTransports(frameworkOpts *Aries) error {
 func loadServices(frameworkOpts *Aries) error { // nolint:funlen
 	// uninitialized
 	frameworkOpts.inboundEnvelopeHandler = inbound.MessageHandler{}
+	frameworkOpts.protocolEvents = service.NewProtocolEventStream(service.DefaultProtocolEventBuffer)
 
 	ctx, err := context.New(
 		context.WithOutboundDispatcher(frameworkOpts.outboundDispatcher),
@@ -727,6 +737,7 @@ func loadServices(frameworkOpts *Aries) error { // nolint:funlen
 		context.WithInboundEnvelopeHandler(&frameworkOpts.inboundEnvelopeHandler),
 		context.WithServiceMsgTypeTargets(frameworkOpts.servicesMsgTypeTargets...),
 		context.WithDIDRotator(&frameworkOpts.didRotator),
+		context.WithProtocolEvents(frameworkOpts.protocolEvents),
 	)
 	if err != nil {
 		return fmt.Errorf("create context failed: %w", err)
@@ -739,6 +750,13 @@ func loadServices(frameworkOpts *Aries) error { // nolint:funlen
 		}
 
 		frameworkOpts.services = append(frameworkOpts.services, svc)
+
+		if eventSvc, ok := svc.(service.Event); ok {
+			if e := frameworkOpts.protocolEvents.Listen(eventSvc); e != nil {
+				return fmt.Errorf("listen to protocol events of %s: %w", svc.Name(), e)
+			}
+		}
+
 		// after service was successfully created we need to add it to the context
 		// since the introduce protocol depends on did-exchange
 		if e := context.WithProtocolServices(frameworkOpts.services...)(ctx); e != nil {
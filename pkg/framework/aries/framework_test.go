@@ -216,8 +216,15 @@ func TestFramework(t *testing.T) {
 
 		_, err = ctx.Service(didexchange.DIDExchange)
 		require.NoError(t, err)
+
+		events := ctx.ProtocolEvents().Subscribe()
+
 		err = aries.Close()
 		require.NoError(t, err)
+
+		// the protocol event stream is closed with the framework
+		_, ok := <-events
+		require.False(t, ok)
 	})
 
 	t.Run("test protocol svc - with user provided protocol", func(t *testing.T) {
@@ -70,6 +70,7 @@ type Provider struct {
 	inboundEnvelopeHandler     InboundEnvelopeHandler
 	didRotator                 *middleware.DIDCommMessageMiddleware
 	connectionRecorder         *connection.Recorder
+	protocolEvents             *service.ProtocolEventStream
 }
 
 // InboundEnvelopeHandler handles inbound envelopes, processing then dispatching to a protocol service based on the
@@ -232,6 +233,12 @@ func (p *Provider) DIDRotator() *middleware.DIDCommMessageMiddleware {
 	return p.didRotator
 }
 
+// ProtocolEvents returns the stream of the lifecycle events (state changes and errors) of all protocol services.
+// Use ProtocolEvents().Subscribe() to receive them on a single channel.
+func (p *Provider) ProtocolEvents() *service.ProtocolEventStream {
+	return p.protocolEvents
+}
+
 // InboundDIDCommMessageHandler provides a supplier of inbound handlers with all loaded protocol services.
 func (p *Provider) InboundDIDCommMessageHandler() func() service.InboundHandler {
 	return func() service.InboundHandler {
@@ -357,6 +364,14 @@ func WithDIDRotator(didRotator *middleware.DIDCommMessageMiddleware) ProviderOpt
 	}
 }
 
+// WithProtocolEvents injects the protocol event stream into the context.
+func WithProtocolEvents(protocolEvents *service.ProtocolEventStream) ProviderOption {
+	return func(opts *Provider) error {
+		opts.protocolEvents = protocolEvents
+		return nil
+	}
+}
+
 // WithOutboundDispatcher injects an outbound dispatcher into the context.
 func WithOutboundDispatcher(outboundDispatcher dispatcher.Outbound) ProviderOption {
 	return func(opts *Provider) error {
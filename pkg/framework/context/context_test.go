@@ -717,6 +717,13 @@ func TestNewProvider(t *testing.T) {
 		require.Equal(t, didRotator, prov.DIDRotator())
 	})
 
+	t.Run("test new with protocol events", func(t *testing.T) {
+		protocolEvents := service.NewProtocolEventStream(0)
+		prov, err := New(WithProtocolEvents(protocolEvents))
+		require.NoError(t, err)
+		require.Equal(t, protocolEvents, prov.ProtocolEvents())
+	})
+
 	t.Run("test new with secret lock service", func(t *testing.T) {
 		mSecLck := &mocklock.MockSecretLock{}
 		prov, err := New(WithSecretLock(mSecLck))
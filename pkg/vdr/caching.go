@@ -0,0 +1,279 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vdr
+
+import (
+	"container/list"
+	"errors"
+	"sync"
+	"time"
+
+	diddoc "github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+)
+
+const (
+	defaultCacheTTL = 5 * time.Minute
+
+	noCacheOpt = "noCache"
+)
+
+// CachingResolver decorates the VDR registry with caching of DID resolution results.
+//
+// Only resolutions without DID method options (but WithNoCache) are served from the cache. A resolution is cached
+// for the TTL, or until the 'nextUpdate' time of the DID document metadata if it comes first. When the resolution
+// reports the DID as deactivated, the cached document is evicted, so stale active documents are not served.
+// If the cache size is limited, the least recently used DIDs are evicted first.
+// The cache holds its own copy of each resolution and hands out copies (refer diddoc.Doc.Clone),
+// so the callers may mutate the resolved documents.
+type CachingResolver struct {
+	inner          vdrapi.Registry
+	ttl            time.Duration
+	deactivatedTTL time.Duration
+	maxSize        int
+	onChange       func(did string, diff *diddoc.DocDiff)
+	now            func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List // of *cacheEntry, the most recently used first
+}
+
+type cacheEntry struct {
+	did           string
+	docResolution *diddoc.DocResolution
+	expires       time.Time
+}
+
+// CachingOption configures the caching resolver.
+type CachingOption func(r *CachingResolver)
+
+// WithCacheTTL sets how long a resolved DID document is cached. Defaults to 5 minutes.
+func WithCacheTTL(ttl time.Duration) CachingOption {
+	return func(r *CachingResolver) {
+		r.ttl = ttl
+	}
+}
+
+// WithDeactivatedTTL enables caching of deactivated DID resolutions for the given time (negative caching).
+// By default, deactivated DIDs are not cached.
+func WithDeactivatedTTL(ttl time.Duration) CachingOption {
+	return func(r *CachingResolver) {
+		r.deactivatedTTL = ttl
+	}
+}
+
+// WithCacheMaxSize limits the number of cached DIDs, the least recently used DID is evicted when the cache is full.
+// By default, the cache size is not limited.
+func WithCacheMaxSize(maxSize int) CachingOption {
+	return func(r *CachingResolver) {
+		r.maxSize = maxSize
+	}
+}
+
+// WithNoCache is a resolve option making the caching resolver bypass the cache: the DID is resolved by the inner
+// registry and the fresh resolution replaces the cached one.
+func WithNoCache() vdrapi.DIDMethodOption {
+	return vdrapi.WithOption(noCacheOpt, true)
+}
+
+// WithOnChange sets the callback invoked when a DID document is refreshed (resolved again after the TTL expired)
+// and differs from the cached one, e.g. when the keys of the DID were rotated. The callback is called synchronously
+// by Resolve with the difference of the documents, refer diddoc.Diff.
+func WithOnChange(onChange func(did string, diff *diddoc.DocDiff)) CachingOption {
+	return func(r *CachingResolver) {
+		r.onChange = onChange
+	}
+}
+
+// NewCachingResolver returns a new caching resolver decorating the inner registry.
+func NewCachingResolver(inner vdrapi.Registry, opts ...CachingOption) *CachingResolver {
+	r := &CachingResolver{
+		inner:   inner,
+		ttl:     defaultCacheTTL,
+		now:     time.Now,
+		entries: map[string]*list.Element{},
+		lru:     list.New(),
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// Resolve did document, from the cache if it holds a fresh resolution of the DID.
+func (r *CachingResolver) Resolve(did string, opts ...vdrapi.DIDMethodOption) (*diddoc.DocResolution, error) {
+	cacheable, noCache := cachingOptions(opts)
+
+	if cacheable && !noCache {
+		if docResolution, ok := r.get(did); ok {
+			return docResolution, nil
+		}
+	}
+
+	docResolution, err := r.inner.Resolve(did, opts...)
+	if err != nil {
+		if errors.Is(err, vdrapi.ErrNotFound) {
+			r.evict(did)
+		}
+
+		return nil, err
+	}
+
+	switch {
+	case isDeactivated(docResolution):
+		r.evict(did)
+
+		if cacheable && r.deactivatedTTL > 0 {
+			r.put(did, docResolution, r.deactivatedTTL)
+		}
+	case cacheable && r.ttl > 0:
+		previous := r.put(did, docResolution, r.ttl)
+
+		r.notifyChange(did, previous, docResolution)
+	}
+
+	return docResolution, nil
+}
+
+// cachingOptions returns whether the resolution with the options can be cached, i.e. there is no DID method option
+// but WithNoCache, and whether the cache must be bypassed.
+func cachingOptions(opts []vdrapi.DIDMethodOption) (bool, bool) {
+	if len(opts) == 0 {
+		return true, false
+	}
+
+	didOpts := &vdrapi.DIDMethodOpts{Values: map[string]interface{}{}}
+
+	for _, opt := range opts {
+		opt(didOpts)
+	}
+
+	noCache, _ := didOpts.Values[noCacheOpt].(bool)
+	delete(didOpts.Values, noCacheOpt)
+
+	return len(didOpts.Values) == 0, noCache
+}
+
+func (r *CachingResolver) notifyChange(did string, previous, current *diddoc.DocResolution) {
+	if r.onChange == nil || previous == nil || previous.DIDDocument == nil || current.DIDDocument == nil {
+		return
+	}
+
+	if diff := diddoc.Diff(previous.DIDDocument, current.DIDDocument); !diff.IsEmpty() {
+		r.onChange(did, diff)
+	}
+}
+
+// Create a new DID Document.
+func (r *CachingResolver) Create(method string, did *diddoc.Doc,
+	opts ...vdrapi.DIDMethodOption) (*diddoc.DocResolution, error) {
+	return r.inner.Create(method, did, opts...)
+}
+
+// Update did document and evict it from the cache.
+func (r *CachingResolver) Update(did *diddoc.Doc, opts ...vdrapi.DIDMethodOption) error {
+	r.evict(did.ID)
+
+	return r.inner.Update(did, opts...)
+}
+
+// Deactivate did document and evict it from the cache.
+func (r *CachingResolver) Deactivate(did string, opts ...vdrapi.DIDMethodOption) error {
+	r.evict(did)
+
+	return r.inner.Deactivate(did, opts...)
+}
+
+// Close frees resources of the inner registry.
+func (r *CachingResolver) Close() error {
+	return r.inner.Close()
+}
+
+func (r *CachingResolver) get(did string) (*diddoc.DocResolution, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	elem, ok := r.entries[did]
+	if !ok {
+		return nil, false
+	}
+
+	entry := elem.Value.(*cacheEntry) //nolint:forcetypeassert
+
+	// the expired entry is kept until it is refreshed to detect the changes of the document
+	if !r.now().Before(entry.expires) {
+		return nil, false
+	}
+
+	r.lru.MoveToFront(elem)
+
+	return entry.docResolution.Clone(), true
+}
+
+// put caches the resolution and returns the previously cached one, if any.
+func (r *CachingResolver) put(did string, docResolution *diddoc.DocResolution,
+	ttl time.Duration) *diddoc.DocResolution {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry := &cacheEntry{did: did, docResolution: docResolution.Clone(), expires: r.expiry(docResolution, ttl)}
+
+	if elem, ok := r.entries[did]; ok {
+		previous := elem.Value.(*cacheEntry).docResolution //nolint:forcetypeassert
+
+		elem.Value = entry
+		r.lru.MoveToFront(elem)
+
+		return previous
+	}
+
+	r.entries[did] = r.lru.PushFront(entry)
+
+	for r.maxSize > 0 && r.lru.Len() > r.maxSize {
+		r.remove(r.lru.Back())
+	}
+
+	return nil
+}
+
+// expiry returns the expiry time of the resolution cached for the TTL, which is capped by the 'nextUpdate'
+// freshness hint of the DID document metadata.
+func (r *CachingResolver) expiry(docResolution *diddoc.DocResolution, ttl time.Duration) time.Time {
+	expires := r.now().Add(ttl)
+
+	if docResolution.DocumentMetadata == nil || docResolution.DocumentMetadata.NextUpdate == "" {
+		return expires
+	}
+
+	nextUpdate, err := time.Parse(time.RFC3339, docResolution.DocumentMetadata.NextUpdate)
+	if err == nil && nextUpdate.Before(expires) {
+		return nextUpdate
+	}
+
+	return expires
+}
+
+func (r *CachingResolver) evict(did string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if elem, ok := r.entries[did]; ok {
+		r.remove(elem)
+	}
+}
+
+func (r *CachingResolver) remove(elem *list.Element) {
+	r.lru.Remove(elem)
+	delete(r.entries, elem.Value.(*cacheEntry).did) //nolint:forcetypeassert
+}
+
+func isDeactivated(docResolution *diddoc.DocResolution) bool {
+	return docResolution.DocumentMetadata != nil && docResolution.DocumentMetadata.Deactivated
+}
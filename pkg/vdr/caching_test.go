@@ -0,0 +1,526 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vdr
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
+)
+
+const cachedDID = "did:example:123"
+
+type resolverState struct {
+	calls       int
+	deactivated bool
+	nextUpdate  string
+	err         error
+	doc         *did.Doc
+}
+
+func newMockRegistry(state *resolverState) *mockvdr.MockVDRegistry {
+	return &mockvdr.MockVDRegistry{
+		ResolveFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+			state.calls++
+
+			if state.err != nil {
+				return nil, state.err
+			}
+
+			doc := state.doc
+			if doc == nil {
+				doc = &did.Doc{ID: didID}
+			}
+
+			return &did.DocResolution{
+				DIDDocument:      doc,
+				DocumentMetadata: &did.DocumentMetadata{Deactivated: state.deactivated, NextUpdate: state.nextUpdate},
+			}, nil
+		},
+	}
+}
+
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
+}
+
+func newTestCachingResolver(state *resolverState, opts ...CachingOption) (*CachingResolver, *testClock) {
+	clock := &testClock{now: time.Now()}
+
+	r := NewCachingResolver(newMockRegistry(state), opts...)
+	r.now = clock.Now
+
+	return r, clock
+}
+
+func TestCachingResolver_Resolve(t *testing.T) {
+	t.Run("success - resolution is cached until TTL expires", func(t *testing.T) {
+		state := &resolverState{}
+		r, clock := newTestCachingResolver(state, WithCacheTTL(time.Minute))
+
+		docResolution, err := r.Resolve(cachedDID)
+		require.NoError(t, err)
+		require.Equal(t, cachedDID, docResolution.DIDDocument.ID)
+
+		cached, err := r.Resolve(cachedDID)
+		require.NoError(t, err)
+		require.Equal(t, docResolution, cached)
+		require.NotSame(t, docResolution, cached)
+		require.Equal(t, 1, state.calls)
+
+		clock.now = clock.now.Add(time.Minute)
+
+		_, err = r.Resolve(cachedDID)
+		require.NoError(t, err)
+		require.Equal(t, 2, state.calls)
+	})
+
+	t.Run("success - mutation of resolved document doesn't affect the cache", func(t *testing.T) {
+		state := &resolverState{}
+		r, _ := newTestCachingResolver(state, WithCacheTTL(time.Minute))
+
+		docResolution, err := r.Resolve(cachedDID)
+		require.NoError(t, err)
+
+		docResolution.DIDDocument.ID = "did:example:mutated"
+		docResolution.DocumentMetadata.Deactivated = true
+
+		cached, err := r.Resolve(cachedDID)
+		require.NoError(t, err)
+		require.Equal(t, cachedDID, cached.DIDDocument.ID)
+		require.False(t, cached.DocumentMetadata.Deactivated)
+
+		cached.DIDDocument.AlsoKnownAs = append(cached.DIDDocument.AlsoKnownAs, "https://example.com")
+
+		cached, err = r.Resolve(cachedDID)
+		require.NoError(t, err)
+		require.Empty(t, cached.DIDDocument.AlsoKnownAs)
+		require.Equal(t, 1, state.calls)
+	})
+
+	t.Run("success - resolution with options is not served from the cache", func(t *testing.T) {
+		state := &resolverState{}
+		r, _ := newTestCachingResolver(state)
+
+		_, err := r.Resolve(cachedDID)
+		require.NoError(t, err)
+
+		_, err = r.Resolve(cachedDID, vdrapi.WithOption("versionID", "1"))
+		require.NoError(t, err)
+		require.Equal(t, 2, state.calls)
+
+		_, err = r.Resolve(cachedDID)
+		require.NoError(t, err)
+		require.Equal(t, 2, state.calls)
+	})
+
+	t.Run("success - deactivated DID is evicted", func(t *testing.T) {
+		state := &resolverState{}
+		r, clock := newTestCachingResolver(state, WithCacheTTL(time.Minute))
+
+		docResolution, err := r.Resolve(cachedDID)
+		require.NoError(t, err)
+		require.False(t, docResolution.DocumentMetadata.Deactivated)
+
+		state.deactivated = true
+		clock.now = clock.now.Add(time.Minute)
+
+		docResolution, err = r.Resolve(cachedDID)
+		require.NoError(t, err)
+		require.True(t, docResolution.DocumentMetadata.Deactivated)
+		require.Equal(t, 2, state.calls)
+
+		// deactivated DID is not cached by default
+		docResolution, err = r.Resolve(cachedDID)
+		require.NoError(t, err)
+		require.True(t, docResolution.DocumentMetadata.Deactivated)
+		require.Equal(t, 3, state.calls)
+	})
+
+	t.Run("success - deactivation detected with resolve options evicts cached document", func(t *testing.T) {
+		state := &resolverState{}
+		r, _ := newTestCachingResolver(state)
+
+		_, err := r.Resolve(cachedDID)
+		require.NoError(t, err)
+
+		state.deactivated = true
+
+		docResolution, err := r.Resolve(cachedDID, vdrapi.WithOption("versionID", "2"))
+		require.NoError(t, err)
+		require.True(t, docResolution.DocumentMetadata.Deactivated)
+
+		docResolution, err = r.Resolve(cachedDID)
+		require.NoError(t, err)
+		require.True(t, docResolution.DocumentMetadata.Deactivated)
+		require.Equal(t, 3, state.calls)
+	})
+
+	t.Run("success - deactivated DID is negatively cached", func(t *testing.T) {
+		state := &resolverState{deactivated: true}
+		r, clock := newTestCachingResolver(state, WithCacheTTL(time.Hour), WithDeactivatedTTL(time.Second))
+
+		_, err := r.Resolve(cachedDID)
+		require.NoError(t, err)
+
+		docResolution, err := r.Resolve(cachedDID)
+		require.NoError(t, err)
+		require.True(t, docResolution.DocumentMetadata.Deactivated)
+		require.Equal(t, 1, state.calls)
+
+		clock.now = clock.now.Add(time.Second)
+
+		_, err = r.Resolve(cachedDID)
+		require.NoError(t, err)
+		require.Equal(t, 2, state.calls)
+	})
+
+	t.Run("success - DID that is not found anymore is evicted", func(t *testing.T) {
+		state := &resolverState{}
+		r, clock := newTestCachingResolver(state, WithCacheTTL(time.Minute))
+
+		_, err := r.Resolve(cachedDID)
+		require.NoError(t, err)
+
+		state.err = fmt.Errorf("resolve: %w", vdrapi.ErrNotFound)
+		clock.now = clock.now.Add(time.Minute)
+
+		_, err = r.Resolve(cachedDID)
+		require.True(t, errors.Is(err, vdrapi.ErrNotFound))
+
+		state.err = nil
+
+		_, err = r.Resolve(cachedDID)
+		require.NoError(t, err)
+		require.Equal(t, 3, state.calls)
+	})
+
+	t.Run("error - resolution failure is not cached", func(t *testing.T) {
+		state := &resolverState{err: errors.New("resolve error")}
+		r, _ := newTestCachingResolver(state)
+
+		_, err := r.Resolve(cachedDID)
+		require.EqualError(t, err, "resolve error")
+
+		_, err = r.Resolve(cachedDID)
+		require.EqualError(t, err, "resolve error")
+		require.Equal(t, 2, state.calls)
+	})
+}
+
+func TestCachingResolver_OnChange(t *testing.T) {
+	docWithKey := func(value string) *did.Doc {
+		vm := did.NewVerificationMethodFromBytes(cachedDID+"#key-1", "Ed25519VerificationKey2018", cachedDID,
+			[]byte(value))
+
+		return &did.Doc{
+			ID:                 cachedDID,
+			VerificationMethod: []did.VerificationMethod{*vm},
+			AssertionMethod:    []did.Verification{*did.NewReferencedVerification(vm, did.AssertionMethod)},
+		}
+	}
+
+	t.Run("success - key rotation is detected on refresh", func(t *testing.T) {
+		var changes []*did.DocDiff
+
+		state := &resolverState{doc: docWithKey("key 1")}
+		r, clock := newTestCachingResolver(state, WithCacheTTL(time.Minute),
+			WithOnChange(func(didID string, diff *did.DocDiff) {
+				require.Equal(t, cachedDID, didID)
+
+				changes = append(changes, diff)
+			}))
+
+		_, err := r.Resolve(cachedDID)
+		require.NoError(t, err)
+
+		// the cached document is served, the rotation is not seen yet
+		state.doc = docWithKey("key 2")
+
+		_, err = r.Resolve(cachedDID)
+		require.NoError(t, err)
+		require.Empty(t, changes)
+
+		clock.now = clock.now.Add(time.Minute)
+
+		_, err = r.Resolve(cachedDID)
+		require.NoError(t, err)
+		require.Len(t, changes, 1)
+		require.Len(t, changes[0].AddedVerificationMethods, 1)
+		require.Equal(t, []byte("key 2"), changes[0].AddedVerificationMethods[0].Value)
+		require.Len(t, changes[0].RemovedVerificationMethods, 1)
+		require.Equal(t, []byte("key 1"), changes[0].RemovedVerificationMethods[0].Value)
+		require.Equal(t, []did.VerificationRelationship{did.AssertionMethod}, changes[0].ChangedRelationships)
+	})
+
+	t.Run("success - unchanged document is not reported", func(t *testing.T) {
+		called := false
+
+		state := &resolverState{doc: docWithKey("key 1")}
+		r, clock := newTestCachingResolver(state, WithCacheTTL(time.Minute),
+			WithOnChange(func(string, *did.DocDiff) {
+				called = true
+			}))
+
+		_, err := r.Resolve(cachedDID)
+		require.NoError(t, err)
+
+		state.doc = docWithKey("key 1")
+		clock.now = clock.now.Add(time.Minute)
+
+		_, err = r.Resolve(cachedDID)
+		require.NoError(t, err)
+		require.Equal(t, 2, state.calls)
+		require.False(t, called)
+	})
+
+	t.Run("success - first resolution is not reported", func(t *testing.T) {
+		called := false
+
+		r, _ := newTestCachingResolver(&resolverState{doc: docWithKey("key 1")},
+			WithOnChange(func(string, *did.DocDiff) {
+				called = true
+			}))
+
+		_, err := r.Resolve(cachedDID)
+		require.NoError(t, err)
+		require.False(t, called)
+	})
+}
+
+func TestCachingResolver_UpdateAndDeactivate(t *testing.T) {
+	state := &resolverState{}
+	r, _ := newTestCachingResolver(state)
+
+	_, err := r.Resolve(cachedDID)
+	require.NoError(t, err)
+
+	require.NoError(t, r.Update(&did.Doc{ID: cachedDID}))
+
+	_, err = r.Resolve(cachedDID)
+	require.NoError(t, err)
+	require.Equal(t, 2, state.calls)
+
+	require.NoError(t, r.Deactivate(cachedDID))
+
+	state.deactivated = true
+
+	docResolution, err := r.Resolve(cachedDID)
+	require.NoError(t, err)
+	require.True(t, docResolution.DocumentMetadata.Deactivated)
+	require.Equal(t, 3, state.calls)
+
+	docResolution, err = r.Create("example", &did.Doc{ID: cachedDID})
+	require.NoError(t, err)
+	require.NotNil(t, docResolution)
+
+	require.NoError(t, r.Close())
+}
+
+func TestCachingResolver_MaxSize(t *testing.T) {
+	state := &resolverState{}
+	r, _ := newTestCachingResolver(state, WithCacheMaxSize(2))
+
+	for _, didID := range []string{"did:example:a", "did:example:b", "did:example:a"} {
+		_, err := r.Resolve(didID)
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, 2, state.calls)
+
+	// did:example:b is the least recently used DID.
+	_, err := r.Resolve("did:example:c")
+	require.NoError(t, err)
+	require.Equal(t, 3, state.calls)
+
+	_, err = r.Resolve("did:example:a")
+	require.NoError(t, err)
+	require.Equal(t, 3, state.calls)
+
+	_, err = r.Resolve("did:example:b")
+	require.NoError(t, err)
+	require.Equal(t, 4, state.calls)
+
+	// did:example:c was evicted by did:example:b.
+	_, err = r.Resolve("did:example:c")
+	require.NoError(t, err)
+	require.Equal(t, 5, state.calls)
+}
+
+func TestCachingResolver_NextUpdate(t *testing.T) {
+	t.Run("success - next update before TTL expires", func(t *testing.T) {
+		state := &resolverState{}
+		r, clock := newTestCachingResolver(state, WithCacheTTL(time.Hour))
+
+		state.nextUpdate = clock.now.Add(time.Minute).Format(time.RFC3339)
+
+		_, err := r.Resolve(cachedDID)
+		require.NoError(t, err)
+
+		clock.now = clock.now.Add(30 * time.Second)
+
+		_, err = r.Resolve(cachedDID)
+		require.NoError(t, err)
+		require.Equal(t, 1, state.calls)
+
+		clock.now = clock.now.Add(time.Minute)
+
+		_, err = r.Resolve(cachedDID)
+		require.NoError(t, err)
+		require.Equal(t, 2, state.calls)
+	})
+
+	t.Run("success - next update after TTL expires", func(t *testing.T) {
+		state := &resolverState{}
+		r, clock := newTestCachingResolver(state, WithCacheTTL(time.Minute))
+
+		state.nextUpdate = clock.now.Add(time.Hour).Format(time.RFC3339)
+
+		_, err := r.Resolve(cachedDID)
+		require.NoError(t, err)
+
+		clock.now = clock.now.Add(2 * time.Minute)
+
+		_, err = r.Resolve(cachedDID)
+		require.NoError(t, err)
+		require.Equal(t, 2, state.calls)
+	})
+
+	t.Run("success - next update in the past is not cached", func(t *testing.T) {
+		state := &resolverState{}
+		r, clock := newTestCachingResolver(state)
+
+		state.nextUpdate = clock.now.Add(-time.Minute).Format(time.RFC3339)
+
+		for i := 0; i < 2; i++ {
+			_, err := r.Resolve(cachedDID)
+			require.NoError(t, err)
+		}
+
+		require.Equal(t, 2, state.calls)
+	})
+
+	t.Run("success - invalid next update is ignored", func(t *testing.T) {
+		state := &resolverState{nextUpdate: "tomorrow"}
+		r, _ := newTestCachingResolver(state)
+
+		for i := 0; i < 2; i++ {
+			_, err := r.Resolve(cachedDID)
+			require.NoError(t, err)
+		}
+
+		require.Equal(t, 1, state.calls)
+	})
+}
+
+func TestCachingResolver_WithNoCache(t *testing.T) {
+	state := &resolverState{doc: &did.Doc{ID: cachedDID, AlsoKnownAs: []string{"did:example:v1"}}}
+	r, _ := newTestCachingResolver(state)
+
+	_, err := r.Resolve(cachedDID)
+	require.NoError(t, err)
+
+	state.doc = &did.Doc{ID: cachedDID, AlsoKnownAs: []string{"did:example:v2"}}
+
+	docResolution, err := r.Resolve(cachedDID, WithNoCache())
+	require.NoError(t, err)
+	require.Equal(t, 2, state.calls)
+	require.Equal(t, []string{"did:example:v2"}, docResolution.DIDDocument.AlsoKnownAs)
+
+	// the fresh resolution replaced the cached one.
+	docResolution, err = r.Resolve(cachedDID)
+	require.NoError(t, err)
+	require.Equal(t, 2, state.calls)
+	require.Equal(t, []string{"did:example:v2"}, docResolution.DIDDocument.AlsoKnownAs)
+
+	// other options still bypass the cache without caching the resolution.
+	_, err = r.Resolve(cachedDID, WithNoCache(), vdrapi.WithOption("versionId", "1"))
+	require.NoError(t, err)
+	require.Equal(t, 3, state.calls)
+}
+
+func TestCachingResolver_Concurrency(t *testing.T) {
+	const (
+		nbDIDs       = 20
+		nbGoroutines = 10
+		nbResolves   = 200
+	)
+
+	var calls int32
+
+	inner := &mockvdr.MockVDRegistry{
+		ResolveFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+			atomic.AddInt32(&calls, 1)
+
+			return &did.DocResolution{DIDDocument: &did.Doc{ID: didID}}, nil
+		},
+	}
+
+	r := NewCachingResolver(inner, WithCacheMaxSize(nbDIDs/2))
+
+	var wg sync.WaitGroup
+
+	errs := make(chan error, nbGoroutines*nbResolves)
+
+	for g := 0; g < nbGoroutines; g++ {
+		wg.Add(1)
+
+		go func(g int) {
+			defer wg.Done()
+
+			for i := 0; i < nbResolves; i++ {
+				didID := fmt.Sprintf("did:example:%d", (g+i)%nbDIDs)
+
+				var opts []vdrapi.DIDMethodOption
+
+				switch i % 10 {
+				case 0:
+					opts = append(opts, WithNoCache())
+				case 1:
+					errs <- r.Deactivate(didID)
+
+					continue
+				}
+
+				docResolution, err := r.Resolve(didID, opts...)
+				if err == nil && docResolution.DIDDocument.ID != didID {
+					err = fmt.Errorf("resolved %s instead of %s", docResolution.DIDDocument.ID, didID)
+				}
+
+				errs <- err
+			}
+		}(g)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	require.Less(t, int(atomic.LoadInt32(&calls)), nbGoroutines*nbResolves)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	require.LessOrEqual(t, r.lru.Len(), nbDIDs/2)
+	require.Equal(t, r.lru.Len(), len(r.entries))
+}
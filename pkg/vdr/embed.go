@@ -0,0 +1,113 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vdr
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+
+	diddoc "github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+)
+
+const embedFileExt = ".json"
+
+// EmbedVDR resolves DIDs from DID documents (or DID resolution results) kept as JSON files in a file system,
+// e.g. embed.FS. It is read only and intended for self-contained binaries, CI and offline demos.
+type EmbedVDR struct {
+	fsys fs.FS
+	dir  string
+}
+
+// NewEmbedVDR returns a new VDR resolving DIDs from the JSON files in the dir of fsys.
+// The file of a DID is named by EmbedFileName.
+func NewEmbedVDR(fsys fs.FS, dir string) *EmbedVDR {
+	return &EmbedVDR{fsys: fsys, dir: dir}
+}
+
+// EmbedFileName returns the name of the JSON file holding the DID document of the DID.
+// Every character of the DID other than an ASCII letter, digit, '.' or '-' is replaced by '_',
+// e.g. did:example:123 is kept in did_example_123.json.
+func EmbedFileName(did string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-':
+			return r
+		default:
+			return '_'
+		}
+	}, did) + embedFileExt
+}
+
+// Accept accepts the DID method if there is a DID document of the method in the file system.
+func (v *EmbedVDR) Accept(method string, _ ...vdrapi.DIDMethodOption) bool {
+	prefix := strings.TrimSuffix(EmbedFileName("did:"+method+":"), embedFileExt)
+
+	matches, err := fs.Glob(v.fsys, path.Join(v.dir, prefix+"*"+embedFileExt))
+
+	return err == nil && len(matches) > 0
+}
+
+// Read resolves the DID from its JSON file.
+func (v *EmbedVDR) Read(did string, _ ...vdrapi.DIDMethodOption) (*diddoc.DocResolution, error) {
+	if _, err := diddoc.Parse(did); err != nil {
+		return nil, fmt.Errorf("embed vdr Read: %w", err)
+	}
+
+	data, err := fs.ReadFile(v.fsys, path.Join(v.dir, EmbedFileName(did)))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("embed vdr Read: %s: %w", did, vdrapi.ErrNotFound)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("embed vdr Read: read DID document: %w", err)
+	}
+
+	docResolution, err := diddoc.ParseDocumentResolution(data)
+	if errors.Is(err, diddoc.ErrDIDDocumentNotExist) {
+		var doc *diddoc.Doc
+
+		doc, err = diddoc.ParseDocument(data)
+		if err == nil {
+			docResolution = &diddoc.DocResolution{DIDDocument: doc}
+		}
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("embed vdr Read: parse DID document: %w", err)
+	}
+
+	if docResolution.DIDDocument.ID != did {
+		return nil, fmt.Errorf("embed vdr Read: DID document ID %s doesn't match DID %s",
+			docResolution.DIDDocument.ID, did)
+	}
+
+	return docResolution, nil
+}
+
+// Create is not supported.
+func (v *EmbedVDR) Create(*diddoc.Doc, ...vdrapi.DIDMethodOption) (*diddoc.DocResolution, error) {
+	return nil, fmt.Errorf("not supported")
+}
+
+// Update is not supported.
+func (v *EmbedVDR) Update(*diddoc.Doc, ...vdrapi.DIDMethodOption) error {
+	return fmt.Errorf("not supported")
+}
+
+// Deactivate is not supported.
+func (v *EmbedVDR) Deactivate(string, ...vdrapi.DIDMethodOption) error {
+	return fmt.Errorf("not supported")
+}
+
+// Close frees resources being maintained by VDR.
+func (v *EmbedVDR) Close() error {
+	return nil
+}
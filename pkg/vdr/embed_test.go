@@ -0,0 +1,97 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vdr
+
+import (
+	"embed"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+)
+
+//go:embed testdata/embed
+var embedFS embed.FS
+
+func TestEmbedFileName(t *testing.T) {
+	require.Equal(t, "did_example_123.json", EmbedFileName("did:example:123"))
+	require.Equal(t, "did_web_example.com_user_alice.json", EmbedFileName("did:web:example.com:user:alice"))
+	require.Equal(t, "did_example_a_b_c.json", EmbedFileName("did:example:a/b?c"))
+	require.Equal(t, "did_example_..__.json", EmbedFileName("did:example:../*"))
+}
+
+func TestEmbedVDR_Read(t *testing.T) {
+	v := NewEmbedVDR(embedFS, "testdata/embed")
+
+	t.Run("success - DID document", func(t *testing.T) {
+		docResolution, err := v.Read("did:example:123456789abcdefghi")
+		require.NoError(t, err)
+		require.Equal(t, "did:example:123456789abcdefghi", docResolution.DIDDocument.ID)
+		require.Len(t, docResolution.DIDDocument.VerificationMethod, 1)
+		require.Len(t, docResolution.DIDDocument.Authentication, 1)
+	})
+
+	t.Run("success - DID resolution result", func(t *testing.T) {
+		docResolution, err := v.Read("did:example:resolution")
+		require.NoError(t, err)
+		require.Equal(t, "did:example:resolution", docResolution.DIDDocument.ID)
+		require.Equal(t, "1", docResolution.DocumentMetadata.VersionID)
+	})
+
+	t.Run("success - through registry", func(t *testing.T) {
+		registry := New(WithVDR(v))
+
+		docResolution, err := registry.Resolve("did:example:123456789abcdefghi")
+		require.NoError(t, err)
+		require.Equal(t, "did:example:123456789abcdefghi", docResolution.DIDDocument.ID)
+	})
+
+	t.Run("error - not found", func(t *testing.T) {
+		_, err := v.Read("did:example:unknown")
+		require.Error(t, err)
+		require.True(t, errors.Is(err, vdrapi.ErrNotFound))
+	})
+
+	t.Run("error - invalid DID", func(t *testing.T) {
+		_, err := v.Read("not-a-did")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "embed vdr Read")
+	})
+
+	t.Run("error - invalid DID document", func(t *testing.T) {
+		_, err := v.Read("did:example:invalid")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse DID document")
+	})
+
+	t.Run("error - DID document ID mismatch", func(t *testing.T) {
+		_, err := v.Read("did:example:mismatch")
+		require.EqualError(t, err,
+			"embed vdr Read: DID document ID did:example:other doesn't match DID did:example:mismatch")
+	})
+}
+
+func TestEmbedVDR_Accept(t *testing.T) {
+	v := NewEmbedVDR(embedFS, "testdata/embed")
+
+	require.True(t, v.Accept("example"))
+	require.False(t, v.Accept("key"))
+	require.False(t, v.Accept("*"))
+	require.False(t, NewEmbedVDR(embedFS, "testdata/missing").Accept("example"))
+}
+
+func TestEmbedVDR_Unsupported(t *testing.T) {
+	v := NewEmbedVDR(embedFS, "testdata/embed")
+
+	_, err := v.Create(nil)
+	require.EqualError(t, err, "not supported")
+	require.EqualError(t, v.Update(nil), "not supported")
+	require.EqualError(t, v.Deactivate("did:example:123456789abcdefghi"), "not supported")
+	require.NoError(t, v.Close())
+}
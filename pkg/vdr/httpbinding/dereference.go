@@ -0,0 +1,134 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package httpbinding
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+)
+
+// dereferencingResultType is the media type of DID URL dereferencing result.
+const dereferencingResultType = `application/ld+json;profile="https://w3id.org/did-url-dereferencing"`
+
+// Errors of DID URL dereferencing metadata (https://w3c-ccg.github.io/did-resolution/#errors).
+const (
+	DereferencingErrorNotFound      = "notFound"
+	DereferencingErrorInvalidDIDURL = "invalidDidUrl"
+	DereferencingErrorInvalidDID    = "invalidDid"
+)
+
+// DereferencingMetadata is the DID URL dereferencing metadata returned by the resolver.
+type DereferencingMetadata struct {
+	ContentType string `json:"contentType,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+// DereferenceResult is the result of DID URL dereferencing.
+type DereferenceResult struct {
+	// Content is the dereferenced resource (contentStream of the dereferencing result).
+	Content json.RawMessage
+	// ContentMetadata is the metadata of the dereferenced resource.
+	ContentMetadata map[string]interface{}
+	// DereferencingMetadata is the metadata of the dereferencing process, nil if the resolver didn't return any.
+	DereferencingMetadata *DereferencingMetadata
+}
+
+type rawDereferenceResult struct {
+	DereferencingMetadata *DereferencingMetadata `json:"didDereferencingMetadata"`
+	ContentStream         json.RawMessage        `json:"contentStream"`
+	ContentMetadata       map[string]interface{} `json:"contentMetadata"`
+}
+
+// Dereference dereferences DID URL via HTTP(s) endpoint (https://w3c-ccg.github.io/did-resolution/#dereferencing).
+// If the dereferencing metadata reports an error, the result is returned along with the error, which
+// is classified as vdrapi.ErrDIDNotFound (notFound) or vdrapi.ErrInvalidDID (invalidDid, invalidDidUrl).
+func (v *VDR) Dereference(didURL string) (*DereferenceResult, error) {
+	if _, err := did.ParseDIDURL(didURL); err != nil {
+		return nil, classifyError(vdrapi.ErrInvalidDID, err)
+	}
+
+	reqURL, err := url.ParseRequestURI(v.endpointURL)
+	if err != nil {
+		return nil, fmt.Errorf("url parse request uri failed: %w", err)
+	}
+
+	// query and fragment of the DID URL are escaped as a part of the path
+	reqURL.Path = path.Join(reqURL.Path, didURL)
+
+	req, err := v.newRequest(reqURL.String(), dereferencingResultType)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return nil, classifyError(vdrapi.ErrResolverUnavailable, fmt.Errorf("HTTP Get request failed: %w", err))
+	}
+
+	defer closeResponseBody(resp.Body)
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading response body failed: %w", err)
+	}
+
+	raw := &rawDereferenceResult{}
+
+	// resolver may report dereferencing error with any status code (e.g. 404 for notFound)
+	if err = json.Unmarshal(body, raw); err == nil && raw.DereferencingMetadata != nil {
+		result := &DereferenceResult{
+			Content:               raw.ContentStream,
+			ContentMetadata:       raw.ContentMetadata,
+			DereferencingMetadata: raw.DereferencingMetadata,
+		}
+
+		if raw.DereferencingMetadata.Error != "" {
+			return result, dereferencingError(didURL, raw.DereferencingMetadata.Error)
+		}
+
+		return result, nil
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		return &DereferenceResult{Content: body}, nil
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return nil, vdrapi.ErrDIDNotFound
+	}
+
+	err = fmt.Errorf("unsupported response from DID resolver [%v] header [%s] body [%s]",
+		resp.StatusCode, resp.Header.Get("Content-type"), body)
+
+	switch {
+	case resp.StatusCode == http.StatusBadRequest:
+		return nil, classifyError(vdrapi.ErrInvalidDID, err)
+	case resp.StatusCode >= http.StatusInternalServerError:
+		return nil, classifyError(vdrapi.ErrResolverUnavailable, err)
+	}
+
+	return nil, err
+}
+
+func dereferencingError(didURL, code string) error {
+	err := fmt.Errorf("dereference %s: dereferencing error %s", didURL, code)
+
+	switch code {
+	case DereferencingErrorNotFound:
+		return classifyError(vdrapi.ErrDIDNotFound, err)
+	case DereferencingErrorInvalidDIDURL, DereferencingErrorInvalidDID:
+		return classifyError(vdrapi.ErrInvalidDID, err)
+	}
+
+	return err
+}
@@ -0,0 +1,140 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package httpbinding
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+)
+
+const (
+	dereferencedKey = `{
+  "id": "did:example:334455#key-1",
+  "type": "Ed25519VerificationKey2018",
+  "controller": "did:example:334455",
+  "publicKeyBase58": "H3C2AVvLMv6gmMNam3uVAjZpfkcJCwDwnZn6z3wXmqPV"
+}`
+
+	dereferencingResult = `{
+  "@context": "https://w3id.org/did-resolution/v1",
+  "contentStream": ` + dereferencedKey + `,
+  "contentMetadata": {"created": "2021-01-01T00:00:00Z"},
+  "didDereferencingMetadata": {"contentType": "application/did+ld+json"}
+}`
+
+	dereferencingNotFound = `{
+  "@context": "https://w3id.org/did-resolution/v1",
+  "contentStream": null,
+  "contentMetadata": {},
+  "didDereferencingMetadata": {"error": "notFound"}
+}`
+)
+
+func TestDereference(t *testing.T) {
+	newResolver := func(t *testing.T, status int, body string) *VDR {
+		t.Helper()
+
+		testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			require.Equal(t, "/did:example:334455%23key-1", req.RequestURI)
+			require.Equal(t, dereferencingResultType, req.Header.Get("Accept"))
+
+			res.Header().Add("Content-type", ldJSON)
+			res.WriteHeader(status)
+			_, err := res.Write([]byte(body))
+			require.NoError(t, err)
+		}))
+
+		t.Cleanup(testServer.Close)
+
+		resolver, err := New(testServer.URL)
+		require.NoError(t, err)
+
+		return resolver
+	}
+
+	t.Run("success - dereferencing result", func(t *testing.T) {
+		result, err := newResolver(t, http.StatusOK, dereferencingResult).Dereference("did:example:334455#key-1")
+		require.NoError(t, err)
+		require.JSONEq(t, dereferencedKey, string(result.Content))
+		require.Equal(t, "2021-01-01T00:00:00Z", result.ContentMetadata["created"])
+		require.Equal(t, &DereferencingMetadata{ContentType: didLDJson}, result.DereferencingMetadata)
+	})
+
+	t.Run("success - plain content", func(t *testing.T) {
+		result, err := newResolver(t, http.StatusOK, dereferencedKey).Dereference("did:example:334455#key-1")
+		require.NoError(t, err)
+		require.JSONEq(t, dereferencedKey, string(result.Content))
+		require.Nil(t, result.DereferencingMetadata)
+	})
+
+	t.Run("error - notFound dereferencing error", func(t *testing.T) {
+		for _, status := range []int{http.StatusOK, http.StatusNotFound} {
+			result, err := newResolver(t, status, dereferencingNotFound).Dereference("did:example:334455#key-1")
+			require.Error(t, err)
+			require.True(t, errors.Is(err, vdrapi.ErrDIDNotFound))
+			require.EqualError(t, err, "dereference did:example:334455#key-1: dereferencing error notFound")
+
+			require.NotNil(t, result)
+			require.Equal(t, DereferencingErrorNotFound, result.DereferencingMetadata.Error)
+		}
+	})
+
+	t.Run("error - invalidDidUrl dereferencing error", func(t *testing.T) {
+		result, err := newResolver(t, http.StatusBadRequest,
+			`{"didDereferencingMetadata": {"error": "invalidDidUrl"}}`).Dereference("did:example:334455#key-1")
+		require.Error(t, err)
+		require.True(t, errors.Is(err, vdrapi.ErrInvalidDID))
+		require.Equal(t, DereferencingErrorInvalidDIDURL, result.DereferencingMetadata.Error)
+	})
+
+	t.Run("error - unclassified dereferencing error", func(t *testing.T) {
+		_, err := newResolver(t, http.StatusInternalServerError,
+			`{"didDereferencingMetadata": {"error": "internalError"}}`).Dereference("did:example:334455#key-1")
+		require.EqualError(t, err, "dereference did:example:334455#key-1: dereferencing error internalError")
+		require.False(t, errors.Is(err, vdrapi.ErrDIDNotFound))
+		require.False(t, errors.Is(err, vdrapi.ErrInvalidDID))
+	})
+
+	t.Run("error - status without dereferencing metadata", func(t *testing.T) {
+		_, err := newResolver(t, http.StatusNotFound, "").Dereference("did:example:334455#key-1")
+		require.True(t, errors.Is(err, vdrapi.ErrDIDNotFound))
+
+		_, err = newResolver(t, http.StatusBadRequest, "").Dereference("did:example:334455#key-1")
+		require.True(t, errors.Is(err, vdrapi.ErrInvalidDID))
+
+		_, err = newResolver(t, http.StatusBadGateway, "").Dereference("did:example:334455#key-1")
+		require.True(t, errors.Is(err, vdrapi.ErrResolverUnavailable))
+
+		_, err = newResolver(t, http.StatusForbidden, "").Dereference("did:example:334455#key-1")
+		require.Contains(t, err.Error(), "unsupported response from DID resolver [403]")
+	})
+
+	t.Run("error - invalid DID URL", func(t *testing.T) {
+		resolver, err := New("https://localhost")
+		require.NoError(t, err)
+
+		_, err = resolver.Dereference("not-a-did-url")
+		require.True(t, errors.Is(err, vdrapi.ErrInvalidDID))
+	})
+
+	t.Run("error - resolver unavailable", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {}))
+		testServer.Close()
+
+		resolver, err := New(testServer.URL)
+		require.NoError(t, err)
+
+		_, err = resolver.Dereference("did:example:334455#key-1")
+		require.True(t, errors.Is(err, vdrapi.ErrResolverUnavailable))
+	})
+}
@@ -6,9 +6,11 @@ SPDX-License-Identifier: Apache-2.0
 package httpbinding
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"mime"
 	"net/http"
 	"net/url"
 	"path"
@@ -24,16 +26,30 @@ const (
 	// VersionTimeOpt version time opt this option is not mandatory.
 	VersionTimeOpt = "versionTime"
 	didLDJson      = "application/did+ld+json"
+	didJSON        = "application/did+json"
+	ldJSON         = "application/ld+json"
+	plainJSON      = "application/json"
 )
 
-// resolveDID makes DID resolution via HTTP.
-func (v *VDR) resolveDID(uri string) ([]byte, error) {
+// ErrInvalidMetadata is returned when the metadata of DID resolution result is rejected by validation.
+var ErrInvalidMetadata = errors.New("invalid DID resolution metadata")
+
+// supportedContentTypes are the response content types the resolver knows how to parse.
+var supportedContentTypes = map[string]bool{ //nolint:gochecknoglobals
+	didLDJson: true,
+	didJSON:   true,
+	ldJSON:    true,
+	plainJSON: true,
+}
+
+// newRequest creates the resolver GET request with the Accept and Authorization headers.
+func (v *VDR) newRequest(uri, accept string) (*http.Request, error) {
 	req, err := http.NewRequest(http.MethodGet, uri, nil)
 	if err != nil {
 		return nil, fmt.Errorf("HTTP create get request failed: %w", err)
 	}
 
-	req.Header.Add("Accept", didLDJson)
+	req.Header.Add("Accept", accept)
 
 	authToken := v.resolveAuthToken
 
@@ -50,9 +66,24 @@ func (v *VDR) resolveDID(uri string) ([]byte, error) {
 		req.Header.Add("Authorization", authToken)
 	}
 
+	return req, nil
+}
+
+// resolveDID makes DID resolution via HTTP and returns the response body with its media type.
+func (v *VDR) resolveDID(uri string) ([]byte, string, error) {
+	accept := didLDJson
+	if len(v.contentTypes) > 0 {
+		accept = strings.Join(v.contentTypes, ", ")
+	}
+
+	req, err := v.newRequest(uri, accept)
+	if err != nil {
+		return nil, "", err
+	}
+
 	resp, err := v.client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("HTTP Get request failed: %w", err)
+		return nil, "", classifyError(vdrapi.ErrResolverUnavailable, fmt.Errorf("HTTP Get request failed: %w", err))
 	}
 
 	defer closeResponseBody(resp.Body)
@@ -61,17 +92,62 @@ func (v *VDR) resolveDID(uri string) ([]byte, error) {
 
 	gotBody, err = ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("reading response body failed: %w", err)
+		return nil, "", fmt.Errorf("reading response body failed: %w", err)
 	}
 
-	if resp.StatusCode == http.StatusOK && strings.Contains(resp.Header.Get("Content-type"), didLDJson) {
-		return gotBody, nil
-	} else if resp.StatusCode == http.StatusNotFound {
-		return nil, vdrapi.ErrNotFound
+	contentType := responseMediaType(resp.Header.Get("Content-type"))
+
+	switch {
+	case resp.StatusCode == http.StatusOK && supportedContentTypes[contentType]:
+		return gotBody, contentType, nil
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return nil, "", vdrapi.ErrDIDNotFound
 	}
 
-	return nil, fmt.Errorf("unsupported response from DID resolver [%v] header [%s] body [%s]",
+	err = fmt.Errorf("unsupported response from DID resolver [%v] header [%s] body [%s]",
 		resp.StatusCode, resp.Header.Get("Content-type"), gotBody)
+
+	switch {
+	case resp.StatusCode == http.StatusBadRequest:
+		return nil, "", classifyError(vdrapi.ErrInvalidDID, err)
+	case resp.StatusCode >= http.StatusInternalServerError:
+		return nil, "", classifyError(vdrapi.ErrResolverUnavailable, err)
+	}
+
+	return nil, "", err
+}
+
+// responseMediaType returns the media type of the Content-Type header without its parameters (e.g. charset).
+func responseMediaType(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+
+	return mediaType
+}
+
+// classifiedError keeps the original error message while also matching one of the
+// classification errors (vdrapi.ErrDIDNotFound, vdrapi.ErrResolverUnavailable, vdrapi.ErrInvalidDID).
+type classifiedError struct {
+	kind error
+	err  error
+}
+
+func classifyError(kind, err error) error {
+	return &classifiedError{kind: kind, err: err}
+}
+
+func (e *classifiedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *classifiedError) Unwrap() error {
+	return e.err
+}
+
+func (e *classifiedError) Is(target error) bool {
+	return target == e.kind //nolint:errorlint
 }
 
 // Read implements didresolver.DidMethod.Read interface (https://w3c-ccg.github.io/did-resolution/#resolving-input)
@@ -108,6 +184,11 @@ func (v *VDR) Read(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolu
 		return nil, fmt.Errorf("versionID and versionTime can not set at same time")
 	}
 
+	parsedDID, err := did.Parse(didID)
+	if err != nil {
+		return nil, classifyError(vdrapi.ErrInvalidDID, err)
+	}
+
 	reqURL, err := url.ParseRequestURI(v.endpointURL)
 	if err != nil {
 		return nil, fmt.Errorf("url parse request uri failed: %w", err)
@@ -123,7 +204,7 @@ func (v *VDR) Read(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolu
 		reqURL.RawQuery = fmt.Sprintf("versionTime=%s", versionTime)
 	}
 
-	data, err := v.resolveDID(reqURL.String())
+	data, contentType, err := v.resolveDID(reqURL.String())
 	if err != nil {
 		return nil, err
 	}
@@ -132,15 +213,45 @@ func (v *VDR) Read(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolu
 		return nil, vdrapi.ErrNotFound
 	}
 
-	documentResolution, err := did.ParseDocumentResolution(data)
+	docResolution, err := parseResponse(data, contentType)
 	if err != nil {
+		return nil, err
+	}
+
+	if v.validateMetadata {
+		err = validateMetadata(parsedDID.Method, data, docResolution)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return docResolution, nil
+}
+
+// parseResponse parses the resolver response according to its content type.
+// JSON-LD responses may contain either DID resolution result or DID document, application/did+json
+// responses contain plain DID document which may have no @context.
+func parseResponse(data []byte, contentType string) (*did.DocResolution, error) {
+	if contentType != didJSON {
+		documentResolution, err := did.ParseDocumentResolution(data)
+		if err == nil {
+			return documentResolution, nil
+		}
+
 		if !errors.Is(err, did.ErrDIDDocumentNotExist) {
 			return nil, err
 		}
 
 		logger.Warnf("parse document resolution failed %w", err)
-	} else {
-		return documentResolution, nil
+	}
+
+	if contentType == didJSON || contentType == plainJSON {
+		var err error
+
+		data, err = withDefaultContext(data)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	didDoc, err := did.ParseDocument(data)
@@ -152,3 +263,82 @@ func (v *VDR) Read(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolu
 
 	return &did.DocResolution{DIDDocument: didDoc}, nil
 }
+
+// validateMetadata checks that the DID document metadata references only well-formed DIDs of the resolved method
+// and that the DID resolution metadata (which is not kept in the DocResolution) reports no error.
+func validateMetadata(method string, data []byte, docResolution *did.DocResolution) error {
+	var raw struct {
+		ResolutionMetadata json.RawMessage `json:"didResolutionMetadata"`
+	}
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidMetadata, err.Error())
+	}
+
+	if len(raw.ResolutionMetadata) != 0 && string(raw.ResolutionMetadata) != "null" {
+		var resolutionMetadata map[string]interface{}
+
+		if err := json.Unmarshal(raw.ResolutionMetadata, &resolutionMetadata); err != nil {
+			return fmt.Errorf("%w: didResolutionMetadata is not an object", ErrInvalidMetadata)
+		}
+
+		if resolutionErr, ok := resolutionMetadata["error"]; ok {
+			return fmt.Errorf("%w: resolution error %v reported with DID document", ErrInvalidMetadata, resolutionErr)
+		}
+	}
+
+	docMetadata := docResolution.DocumentMetadata
+	if docMetadata == nil {
+		return nil
+	}
+
+	if docMetadata.CanonicalID != "" {
+		if err := validateMetadataDID(method, docMetadata.CanonicalID); err != nil {
+			return fmt.Errorf("%w: canonicalId: %s", ErrInvalidMetadata, err.Error())
+		}
+	}
+
+	for _, equivalentID := range docMetadata.EquivalentID {
+		if err := validateMetadataDID(method, equivalentID); err != nil {
+			return fmt.Errorf("%w: equivalentId: %s", ErrInvalidMetadata, err.Error())
+		}
+	}
+
+	return nil
+}
+
+func validateMetadataDID(method, id string) error {
+	// a DID URL (path, query or fragment) is not a DID
+	if strings.ContainsAny(id, "/?# ") {
+		return fmt.Errorf("%s is not a DID", id)
+	}
+
+	parsed, err := did.Parse(id)
+	if err != nil {
+		return err
+	}
+
+	if parsed.Method != method {
+		return fmt.Errorf("%s has method %s, expected %s", id, parsed.Method, method)
+	}
+
+	return nil
+}
+
+// withDefaultContext adds DID v1 context to the plain JSON DID document if it has no @context.
+func withDefaultContext(data []byte) ([]byte, error) {
+	var raw map[string]json.RawMessage
+
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal DID document: %w", err)
+	}
+
+	if _, ok := raw["@context"]; ok {
+		return data, nil
+	}
+
+	raw["@context"] = json.RawMessage(`"` + did.ContextV1 + `"`)
+
+	return json.Marshal(raw)
+}
@@ -37,6 +37,18 @@ const doc = `{
   ]
 }`
 
+const plainDoc = `{
+  "id": "did:example:334455",
+  "verificationMethod": [
+    {
+      "id": "did:example:334455#key-1",
+      "type": "Ed25519VerificationKey2018",
+      "controller": "did:example:334455",
+      "publicKeyBase58": "B12NYF8RrR3h41TDCTJojY59usg3mbtbjnFs7Eud1Y6u"
+    }
+  ]
+}`
+
 const didResolutionData = `{
   "@context": "https://w3id.org/did-resolution/v1",
   "didDocument": ` + doc + `
@@ -288,6 +300,273 @@ func TestRead_HTTPGetFailed(t *testing.T) {
 	require.Contains(t, err.Error(), "unsupported response from DID resolver")
 }
 
+func TestRead_ContentTypes(t *testing.T) {
+	newResolver := func(t *testing.T, contentType, body string, opts ...Option) *VDR {
+		t.Helper()
+
+		testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			require.Equal(t, "application/did+json, application/did+ld+json", req.Header.Get("Accept"))
+			res.Header().Add("Content-type", contentType)
+			res.WriteHeader(http.StatusOK)
+			_, err := res.Write([]byte(body))
+			require.NoError(t, err)
+		}))
+
+		t.Cleanup(testServer.Close)
+
+		resolver, err := New(testServer.URL,
+			WithAcceptContentTypes("application/did+json", "application/did+ld+json"))
+		require.NoError(t, err)
+
+		return resolver
+	}
+
+	const peerDID = "did:peer:21tDAKCERh95uGgKbJNHYp"
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		id          string
+	}{
+		{name: "did+ld+json document", contentType: "application/did+ld+json", body: doc, id: peerDID},
+		{
+			name:        "did+ld+json resolution with parameters",
+			contentType: `application/did+ld+json; charset=utf-8`,
+			body:        didResolutionData,
+			id:          peerDID,
+		},
+		{name: "ld+json resolution", contentType: "application/ld+json", body: didResolutionData, id: peerDID},
+		{name: "did+json without context", contentType: "application/did+json", body: plainDoc, id: "did:example:334455"},
+		{name: "did+json with context", contentType: "application/did+json", body: doc, id: peerDID},
+		{name: "json resolution", contentType: "application/json", body: didResolutionData, id: peerDID},
+		{name: "json document without context", contentType: "application/json", body: plainDoc, id: "did:example:334455"},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			gotDocument, err := newResolver(t, tc.contentType, tc.body).Read("did:example:334455")
+			require.NoError(t, err)
+			require.Equal(t, tc.id, gotDocument.DIDDocument.ID)
+			require.NotEmpty(t, gotDocument.DIDDocument.VerificationMethod)
+		})
+	}
+
+	t.Run("did+json without context gets default context", func(t *testing.T) {
+		gotDocument, err := newResolver(t, "application/did+json", plainDoc).Read("did:example:334455")
+		require.NoError(t, err)
+		require.Equal(t, did.ContextV1, gotDocument.DIDDocument.Context)
+		require.Equal(t, "did:example:334455#key-1", gotDocument.DIDDocument.VerificationMethod[0].ID)
+	})
+
+	t.Run("error - unsupported content type", func(t *testing.T) {
+		_, err := newResolver(t, "text/plain", plainDoc).Read("did:example:334455")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unsupported response from DID resolver")
+	})
+
+	t.Run("error - invalid did+json document", func(t *testing.T) {
+		_, err := newResolver(t, "application/did+json", "[]").Read("did:example:334455")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unmarshal DID document")
+	})
+}
+
+func TestRead_MetadataValidation(t *testing.T) {
+	newResolver := func(t *testing.T, body string, opts ...Option) *VDR {
+		t.Helper()
+
+		testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			res.Header().Add("Content-type", "application/did+ld+json")
+			res.WriteHeader(http.StatusOK)
+			_, err := res.Write([]byte(body))
+			require.NoError(t, err)
+		}))
+
+		t.Cleanup(testServer.Close)
+
+		resolver, err := New(testServer.URL, opts...)
+		require.NoError(t, err)
+
+		return resolver
+	}
+
+	resolution := func(resolutionMetadata, documentMetadata string) string {
+		return `{
+  "@context": "https://w3id.org/did-resolution/v1",
+  "didDocument": ` + doc + `,
+  "didResolutionMetadata": ` + resolutionMetadata + `,
+  "didDocumentMetadata": ` + documentMetadata + `
+}`
+	}
+
+	t.Run("success - valid metadata", func(t *testing.T) {
+		body := resolution(`{"contentType": "application/did+ld+json"}`, `{
+  "canonicalId": "did:peer:21tDAKCERh95uGgKbJNHYp",
+  "equivalentId": ["did:peer:21tDAKCERh95uGgKbJNHYp", "did:peer:21tDAKCERh95uGgKbJNHYp:long-form"]
+}`)
+
+		docResolution, err := newResolver(t, body, WithMetadataValidation()).Read("did:peer:21tDAKCERh95uGgKbJNHYp")
+		require.NoError(t, err)
+		require.Equal(t, "did:peer:21tDAKCERh95uGgKbJNHYp", docResolution.DocumentMetadata.CanonicalID)
+	})
+
+	t.Run("success - no metadata", func(t *testing.T) {
+		_, err := newResolver(t, doc, WithMetadataValidation()).Read("did:peer:21tDAKCERh95uGgKbJNHYp")
+		require.NoError(t, err)
+	})
+
+	t.Run("success - malformed metadata is not validated by default", func(t *testing.T) {
+		body := resolution(`{}`, `{"canonicalId": "did:evil:21tDAKCERh95uGgKbJNHYp"}`)
+
+		docResolution, err := newResolver(t, body).Read("did:peer:21tDAKCERh95uGgKbJNHYp")
+		require.NoError(t, err)
+		require.Equal(t, "did:evil:21tDAKCERh95uGgKbJNHYp", docResolution.DocumentMetadata.CanonicalID)
+	})
+
+	tests := []struct {
+		name               string
+		resolutionMetadata string
+		documentMetadata   string
+		errMsg             string
+	}{
+		{
+			name:             "canonicalId of another method",
+			documentMetadata: `{"canonicalId": "did:evil:21tDAKCERh95uGgKbJNHYp"}`,
+			errMsg:           "canonicalId: did:evil:21tDAKCERh95uGgKbJNHYp has method evil, expected peer",
+		},
+		{
+			name:             "canonicalId is not a DID",
+			documentMetadata: `{"canonicalId": "https://example.com"}`,
+			errMsg:           "canonicalId: https://example.com is not a DID",
+		},
+		{
+			name:             "canonicalId is a DID URL",
+			documentMetadata: `{"canonicalId": "did:peer:21tDAKCERh95uGgKbJNHYp#key-1"}`,
+			errMsg:           "canonicalId: did:peer:21tDAKCERh95uGgKbJNHYp#key-1 is not a DID",
+		},
+		{
+			name:             "malformed canonicalId",
+			documentMetadata: `{"canonicalId": "did:peer"}`,
+			errMsg:           "canonicalId: invalid did: did:peer",
+		},
+		{
+			name:             "equivalentId of another method",
+			documentMetadata: `{"equivalentId": ["did:peer:21tDAKCERh95uGgKbJNHYp", "did:web:example.com"]}`,
+			errMsg:           "equivalentId: did:web:example.com has method web, expected peer",
+		},
+		{
+			name:               "resolution error reported with DID document",
+			resolutionMetadata: `{"error": "notFound"}`,
+			documentMetadata:   `{}`,
+			errMsg:             "resolution error notFound reported with DID document",
+		},
+		{
+			name:               "resolution metadata is not an object",
+			resolutionMetadata: `"metadata"`,
+			documentMetadata:   `{}`,
+			errMsg:             "didResolutionMetadata is not an object",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run("error - "+tc.name, func(t *testing.T) {
+			resolutionMetadata := tc.resolutionMetadata
+			if resolutionMetadata == "" {
+				resolutionMetadata = "{}"
+			}
+
+			body := resolution(resolutionMetadata, tc.documentMetadata)
+
+			docResolution, err := newResolver(t, body, WithMetadataValidation()).Read("did:peer:21tDAKCERh95uGgKbJNHYp")
+			require.Error(t, err)
+			require.Nil(t, docResolution)
+			require.True(t, errors.Is(err, ErrInvalidMetadata))
+			require.Contains(t, err.Error(), tc.errMsg)
+		})
+	}
+}
+
+func TestRead_ErrorClassification(t *testing.T) {
+	newResolver := func(t *testing.T, status int) *VDR {
+		t.Helper()
+
+		testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			res.WriteHeader(status)
+		}))
+
+		t.Cleanup(testServer.Close)
+
+		resolver, err := New(testServer.URL)
+		require.NoError(t, err)
+
+		return resolver
+	}
+
+	t.Run("not found", func(t *testing.T) {
+		for _, status := range []int{http.StatusNotFound, http.StatusGone} {
+			_, err := newResolver(t, status).Read("did:example:334455")
+			require.Error(t, err)
+			require.True(t, errors.Is(err, vdrapi.ErrDIDNotFound))
+			require.True(t, errors.Is(err, vdrapi.ErrNotFound))
+		}
+	})
+
+	t.Run("resolver unavailable - 5xx status", func(t *testing.T) {
+		statuses := []int{http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable}
+
+		for _, status := range statuses {
+			_, err := newResolver(t, status).Read("did:example:334455")
+			require.Error(t, err)
+			require.True(t, errors.Is(err, vdrapi.ErrResolverUnavailable))
+			require.False(t, errors.Is(err, vdrapi.ErrDIDNotFound))
+			require.Contains(t, err.Error(), "unsupported response from DID resolver")
+		}
+	})
+
+	t.Run("resolver unavailable - connection error", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {}))
+		testServer.Close()
+
+		resolver, err := New(testServer.URL)
+		require.NoError(t, err)
+
+		_, err = resolver.Read("did:example:334455")
+		require.Error(t, err)
+		require.True(t, errors.Is(err, vdrapi.ErrResolverUnavailable))
+		require.Contains(t, err.Error(), "HTTP Get request failed")
+	})
+
+	t.Run("invalid DID - malformed", func(t *testing.T) {
+		resolver, err := New("https://localhost")
+		require.NoError(t, err)
+
+		_, err = resolver.Read("not-a-did")
+		require.Error(t, err)
+		require.True(t, errors.Is(err, vdrapi.ErrInvalidDID))
+		require.Contains(t, err.Error(), "invalid did: not-a-did")
+	})
+
+	t.Run("invalid DID - rejected by resolver", func(t *testing.T) {
+		_, err := newResolver(t, http.StatusBadRequest).Read("did:example:334455")
+		require.Error(t, err)
+		require.True(t, errors.Is(err, vdrapi.ErrInvalidDID))
+		require.False(t, errors.Is(err, vdrapi.ErrResolverUnavailable))
+	})
+
+	t.Run("unclassified status", func(t *testing.T) {
+		_, err := newResolver(t, http.StatusForbidden).Read("did:example:334455")
+		require.Error(t, err)
+		require.False(t, errors.Is(err, vdrapi.ErrDIDNotFound))
+		require.False(t, errors.Is(err, vdrapi.ErrResolverUnavailable))
+		require.False(t, errors.Is(err, vdrapi.ErrInvalidDID))
+	})
+}
+
 func TestDIDResolver_Accept(t *testing.T) {
 	resolver, err := New("localhost:8080", WithResolveAuthToken("tk1"))
 	require.NoError(t, err)
@@ -29,6 +29,8 @@ type VDR struct {
 	endpointURL       string
 	client            *http.Client
 	accept            Accept
+	contentTypes      []string
+	validateMetadata  bool
 	resolveAuthToken  string
 	authTokenProvider authTokenProvider
 }
@@ -104,6 +106,25 @@ func WithAccept(accept Accept) Option {
 	}
 }
 
+// WithAcceptContentTypes option sets the content types sent in the Accept header of the resolution request
+// (e.g. application/did+ld+json, application/did+json), in order of preference.
+// The response is parsed according to the returned Content-Type.
+// Defaults to application/did+ld+json.
+func WithAcceptContentTypes(contentTypes ...string) Option {
+	return func(opts *VDR) {
+		opts.contentTypes = contentTypes
+	}
+}
+
+// WithMetadataValidation option enables validation of the resolution metadata returned by an untrusted resolver.
+// canonicalId and equivalentId must be well-formed DIDs of the resolved DID method and
+// the resolution metadata must not report an error, otherwise the resolution result is rejected.
+func WithMetadataValidation() Option {
+	return func(opts *VDR) {
+		opts.validateMetadata = true
+	}
+}
+
 // WithResolveAuthToken add auth token for resolve.
 func WithResolveAuthToken(authToken string) Option {
 	return func(opts *VDR) {
@@ -0,0 +1,69 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jwk
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+)
+
+// Create creates a did:jwk DID document from the public JWK of the first verification method of didDoc.
+// The DID is the base64url encoded JSON of the JWK, so that the document can be resolved without any registry.
+func (v *VDR) Create(didDoc *did.Doc, _ ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+	if didDoc == nil || len(didDoc.VerificationMethod) == 0 {
+		return nil, fmt.Errorf("verification method is empty")
+	}
+
+	j := didDoc.VerificationMethod[0].JSONWebKey()
+	if j == nil {
+		return nil, fmt.Errorf("verification method has no JWK")
+	}
+
+	jwkBytes, err := json.Marshal(j)
+	if err != nil {
+		return nil, fmt.Errorf("marshal JWK: %w", err)
+	}
+
+	fields, err := checkPublicJWK(jwkBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	didJWK := fmt.Sprintf("did:%s:%s", DIDMethod, base64.RawURLEncoding.EncodeToString(jwkBytes))
+
+	doc, err := createDoc(didJWK, j, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	return &did.DocResolution{Context: []string{schemaResV1}, DIDDocument: doc}, nil
+}
+
+// checkPublicJWK checks that the JWK is a public key of a supported key type, it returns the JWK members.
+func checkPublicJWK(jwkBytes []byte) (map[string]interface{}, error) {
+	var fields map[string]interface{}
+
+	if err := json.Unmarshal(jwkBytes, &fields); err != nil {
+		return nil, fmt.Errorf("unmarshal JWK: %w", err)
+	}
+
+	for _, member := range privateKeyMembers {
+		if _, ok := fields[member]; ok {
+			return nil, fmt.Errorf("JWK must not contain private key member '%s'", member)
+		}
+	}
+
+	if kty, _ := fields["kty"].(string); !supportedKeyTypes[kty] {
+		return nil, fmt.Errorf("unsupported JWK key type '%s'", kty)
+	}
+
+	return fields, nil
+}
@@ -0,0 +1,114 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jwk
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	gojose "github.com/go-jose/go-jose/v3"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
+)
+
+func TestCreate(t *testing.T) {
+	v := New()
+
+	edPubKey, edPrivKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+
+	secp256k1Key, err := ecdsa.GenerateKey(btcec.S256(), rand.Reader)
+	require.NoError(t, err)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	secp256k1JWK := &jwk.JWK{JSONWebKey: gojose.JSONWebKey{Key: &secp256k1Key.PublicKey}, Kty: "EC", Crv: "secp256k1"}
+
+	tests := []struct {
+		name string
+		key  interface{}
+		crv  string
+	}{
+		{name: "Ed25519", key: edPubKey, crv: "Ed25519"},
+		{name: "P-384", key: &ecKey.PublicKey, crv: "P-384"},
+		{name: "secp256k1", key: secp256k1JWK, crv: "secp256k1"},
+		{name: "RSA", key: &rsaKey.PublicKey},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			docResolution, err := v.Create(createDocWithKey(t, tc.key))
+			require.NoError(t, err)
+
+			doc := docResolution.DIDDocument
+			require.True(t, strings.HasPrefix(doc.ID, "did:jwk:"))
+			require.Len(t, doc.VerificationMethod, 1)
+			require.Equal(t, doc.ID+"#0", doc.VerificationMethod[0].ID)
+			require.Equal(t, tc.crv, doc.VerificationMethod[0].JSONWebKey().Crv)
+			require.Len(t, doc.Authentication, 1)
+			require.Len(t, doc.KeyAgreement, 1)
+
+			// the created DID is resolved to the same document.
+			resolved, err := v.Read(doc.ID)
+			require.NoError(t, err)
+			require.Equal(t, doc.ID, resolved.DIDDocument.ID)
+			require.Equal(t, doc.VerificationMethod[0].Value, resolved.DIDDocument.VerificationMethod[0].Value)
+		})
+	}
+
+	t.Run("error - private key", func(t *testing.T) {
+		_, err := v.Create(createDocWithKey(t, edPrivKey))
+		require.EqualError(t, err, "JWK must not contain private key member 'd'")
+	})
+
+	t.Run("error - no verification method", func(t *testing.T) {
+		_, err := v.Create(&did.Doc{})
+		require.EqualError(t, err, "verification method is empty")
+
+		_, err = v.Create(nil)
+		require.EqualError(t, err, "verification method is empty")
+	})
+
+	t.Run("error - verification method without JWK", func(t *testing.T) {
+		vm := did.NewVerificationMethodFromBytes("#key-1", "Ed25519VerificationKey2018", "", edPubKey)
+
+		_, err := v.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{*vm}})
+		require.EqualError(t, err, "verification method has no JWK")
+	})
+}
+
+func createDocWithKey(t *testing.T, key interface{}) *did.Doc {
+	t.Helper()
+
+	j, ok := key.(*jwk.JWK)
+	if !ok {
+		var err error
+
+		j, err = jwksupport.JWKFromKey(key)
+		require.NoError(t, err)
+	}
+
+	vm, err := did.NewVerificationMethodFromJWK("#key-1", jsonWebKey2020, "", j)
+	require.NoError(t, err)
+
+	return &did.Doc{VerificationMethod: []did.VerificationMethod{*vm}}
+}
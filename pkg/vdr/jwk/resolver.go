@@ -0,0 +1,104 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jwk
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+)
+
+const (
+	schemaResV1         = "https://w3id.org/did-resolution/v1"
+	schemaDIDV1         = "https://www.w3.org/ns/did/v1"
+	schemaJWS2020V1     = "https://w3id.org/security/suites/jws-2020/v1"
+	jsonWebKey2020      = "JsonWebKey2020"
+	verificationMethodN = "#0"
+	useEncryption       = "enc"
+	useSignature        = "sig"
+	x25519Crv           = "X25519"
+)
+
+//nolint:gochecknoglobals
+var (
+	supportedKeyTypes = map[string]bool{"EC": true, "OKP": true, "RSA": true}
+	privateKeyMembers = []string{"d", "p", "q", "dp", "dq", "qi", "oth", "k"}
+)
+
+// Read decodes the JWK of the did:jwk value and expands it to a DID document.
+func (v *VDR) Read(didJWK string, _ ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+	parsed, err := did.Parse(didJWK)
+	if err != nil {
+		return nil, fmt.Errorf("jwk vdr Read: failed to parse DID: %w", err)
+	}
+
+	if parsed.Method != DIDMethod {
+		return nil, fmt.Errorf("jwk vdr Read: invalid did:jwk method: %s", parsed.Method)
+	}
+
+	jwkBytes, err := base64.RawURLEncoding.DecodeString(parsed.MethodSpecificID)
+	if err != nil {
+		return nil, fmt.Errorf("jwk vdr Read: invalid did:jwk method ID: %w", err)
+	}
+
+	fields, err := checkPublicJWK(jwkBytes)
+	if err != nil {
+		return nil, fmt.Errorf("jwk vdr Read: %w", err)
+	}
+
+	j := &jwk.JWK{}
+
+	if err = j.UnmarshalJSON(jwkBytes); err != nil {
+		return nil, fmt.Errorf("jwk vdr Read: %w", err)
+	}
+
+	doc, err := createDoc(parsed.String(), j, fields)
+	if err != nil {
+		return nil, fmt.Errorf("jwk vdr Read: %w", err)
+	}
+
+	return &did.DocResolution{Context: []string{schemaResV1}, DIDDocument: doc}, nil
+}
+
+// createDoc creates the DID document with the single verification method of the JWK, which is referenced by
+// all the verification relationships, or by keyAgreement only for an encryption key ('use' is "enc" or X25519 key)
+// and by all the relationships but keyAgreement for a signing key ('use' is "sig").
+func createDoc(didJWK string, j *jwk.JWK, fields map[string]interface{}) (*did.Doc, error) {
+	vm, err := did.NewVerificationMethodFromJWK(didJWK+verificationMethodN, jsonWebKey2020, didJWK, j)
+	if err != nil {
+		return nil, fmt.Errorf("create verification method: %w", err)
+	}
+
+	use, _ := fields["use"].(string)
+	encryptionOnly := use == useEncryption || j.Crv == x25519Crv
+
+	if encryptionOnly && use == useSignature {
+		return nil, fmt.Errorf("%s key can't be used for signatures", j.Crv)
+	}
+
+	doc := &did.Doc{
+		Context:            []string{schemaDIDV1, schemaJWS2020V1},
+		ID:                 didJWK,
+		VerificationMethod: []did.VerificationMethod{*vm},
+	}
+
+	if !encryptionOnly {
+		doc.Authentication = []did.Verification{*did.NewReferencedVerification(vm, did.Authentication)}
+		doc.AssertionMethod = []did.Verification{*did.NewReferencedVerification(vm, did.AssertionMethod)}
+		doc.CapabilityDelegation = []did.Verification{*did.NewReferencedVerification(vm, did.CapabilityDelegation)}
+		doc.CapabilityInvocation = []did.Verification{*did.NewReferencedVerification(vm, did.CapabilityInvocation)}
+	}
+
+	if use != useSignature {
+		doc.KeyAgreement = []did.Verification{*did.NewReferencedVerification(vm, did.KeyAgreement)}
+	}
+
+	return doc, nil
+}
@@ -0,0 +1,132 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jwk
+
+import (
+	"crypto/ecdsa"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+)
+
+// Examples of the did:jwk specification.
+const (
+	didJWKP256 = "did:jwk:eyJjcnYiOiJQLTI1NiIsImt0eSI6IkVDIiwieCI6ImFjYklRaXVNczNpOF91c3pFakoydHBUdFJNNEVVM3l6OTFQSDZ" +
+		"DZEgyVjAiLCJ5IjoiX0tjeUxqOXZXTXB0bm1LdG00NkdxRHo4d2Y3NEk1TEtncmwyR3pIM25TRSJ9"
+	didJWKX25519 = "did:jwk:eyJraWQiOiJ1cm46aWV0ZjpwYXJhbXM6b2F1dGg6andrLXRodW1icHJpbnQ6c2hhLTI1NjpGZk1iek9qTW1RNGV" +
+		"mVDZrdndUSUpqZWxUcWpsMHhqRUlXUTJxb2JzUk1NIiwia3R5IjoiT0tQIiwiY3J2IjoiWDI1NTE5IiwiYWxnIjoiRUNESC1FUyIsIngiOi" +
+		"JBRXlVaWNsRmJCQUlLN2Yzamt5TENSc2kxQWlkNkZiN2RmbWVmWFJEMnRBIn0"
+)
+
+func TestRead(t *testing.T) {
+	v := New()
+
+	t.Run("P-256 key", func(t *testing.T) {
+		docResolution, err := v.Read(didJWKP256)
+		require.NoError(t, err)
+
+		doc := docResolution.DIDDocument
+		require.Equal(t, didJWKP256, doc.ID)
+		require.Equal(t, []string{schemaDIDV1, schemaJWS2020V1}, doc.Context)
+		require.Len(t, doc.VerificationMethod, 1)
+
+		vm := doc.VerificationMethod[0]
+		require.Equal(t, didJWKP256+"#0", vm.ID)
+		require.Equal(t, jsonWebKey2020, vm.Type)
+		require.Equal(t, didJWKP256, vm.Controller)
+		require.Equal(t, "P-256", vm.JSONWebKey().Crv)
+		require.IsType(t, &ecdsa.PublicKey{}, vm.JSONWebKey().Key)
+
+		for _, verifications := range [][]did.Verification{doc.Authentication, doc.AssertionMethod,
+			doc.CapabilityDelegation, doc.CapabilityInvocation, doc.KeyAgreement} {
+			require.Len(t, verifications, 1)
+			require.Equal(t, vm.ID, verifications[0].VerificationMethod.ID)
+		}
+
+		// the document is valid JSON-LD DID document.
+		docBytes, err := doc.JSONBytes()
+		require.NoError(t, err)
+
+		parsedDoc, err := did.ParseDocument(docBytes)
+		require.NoError(t, err)
+		require.Equal(t, didJWKP256+"#0", parsedDoc.Authentication[0].VerificationMethod.ID)
+	})
+
+	t.Run("X25519 key is used for key agreement only", func(t *testing.T) {
+		docResolution, err := v.Read(didJWKX25519)
+		require.NoError(t, err)
+
+		doc := docResolution.DIDDocument
+		require.Len(t, doc.KeyAgreement, 1)
+		require.Equal(t, didJWKX25519+"#0", doc.KeyAgreement[0].VerificationMethod.ID)
+		require.Empty(t, doc.Authentication)
+		require.Empty(t, doc.AssertionMethod)
+		require.Empty(t, doc.CapabilityDelegation)
+		require.Empty(t, doc.CapabilityInvocation)
+	})
+
+	t.Run("use of the key", func(t *testing.T) {
+		sigDID := "did:jwk:" + base64.RawURLEncoding.EncodeToString([]byte(
+			`{"kty":"OKP","crv":"Ed25519","use":"sig","x":"11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"}`))
+
+		docResolution, err := v.Read(sigDID)
+		require.NoError(t, err)
+		require.Len(t, docResolution.DIDDocument.Authentication, 1)
+		require.Len(t, docResolution.DIDDocument.AssertionMethod, 1)
+		require.Empty(t, docResolution.DIDDocument.KeyAgreement)
+
+		encDID := "did:jwk:" + base64.RawURLEncoding.EncodeToString([]byte(
+			`{"kty":"EC","crv":"P-256","use":"enc","x":"acbIQiuMs3i8_uszEjJ2tpTtRM4EU3yz91PH6CdH2V0",`+
+				`"y":"_KcyLj9vWMptnmKtm46GqDz8wf74I5LKgrl2GzH3nSE"}`))
+
+		docResolution, err = v.Read(encDID)
+		require.NoError(t, err)
+		require.Empty(t, docResolution.DIDDocument.Authentication)
+		require.Len(t, docResolution.DIDDocument.KeyAgreement, 1)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			did    string
+			errMsg string
+		}{
+			{name: "invalid DID", did: "invalid", errMsg: "failed to parse DID"},
+			{name: "other method", did: "did:key:z6MkiTBz1ymuepAQ4HEHYSF1H8quG5GLVVQR3djdX3mDooWp",
+				errMsg: "invalid did:jwk method: key"},
+			{name: "not base64url", did: "did:jwk:e30=", errMsg: "invalid did:jwk method ID"},
+			{name: "not JSON", did: "did:jwk:" + base64.RawURLEncoding.EncodeToString([]byte("jwk")),
+				errMsg: "unmarshal JWK"},
+			{name: "private key", did: "did:jwk:" + base64.RawURLEncoding.EncodeToString([]byte(
+				`{"kty":"OKP","crv":"Ed25519","x":"11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo",`+
+					`"d":"nWGxne_9WmC6hEr0kuwsxERJxWl7MmkZcDusAxyuf2A"}`)),
+				errMsg: "JWK must not contain private key member 'd'"},
+			{name: "symmetric key", did: "did:jwk:" + base64.RawURLEncoding.EncodeToString([]byte(
+				`{"kty":"oct","k":"GawgguFyGrWKav7AX4VKUg"}`)), errMsg: "JWK must not contain private key member 'k'"},
+			{name: "unsupported key type", did: "did:jwk:" + base64.RawURLEncoding.EncodeToString([]byte(
+				`{"kty":"oct"}`)), errMsg: "unsupported JWK key type 'oct'"},
+			{name: "invalid key", did: "did:jwk:" + base64.RawURLEncoding.EncodeToString([]byte(
+				`{"kty":"EC","crv":"P-256","x":"AA","y":"AA"}`)), errMsg: "jwk vdr Read"},
+			{name: "X25519 signing key", did: "did:jwk:" + base64.RawURLEncoding.EncodeToString([]byte(
+				`{"kty":"OKP","crv":"X25519","use":"sig","x":"AEyUiclFbBAIK7f3jkyLCRsi1Aid6Fb7dfmefXRD2tA"}`)),
+				errMsg: "X25519 key can't be used for signatures"},
+		}
+
+		for _, tc := range tests {
+			tc := tc
+
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := v.Read(tc.did)
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+}
@@ -0,0 +1,101 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package key
+
+import (
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+)
+
+// BatchError holds the errors of the DIDs which failed to resolve in ResolveBatch, keyed by DID.
+type BatchError map[string]error
+
+// Error returns the errors of all DIDs, sorted by DID.
+func (e BatchError) Error() string {
+	dids := make([]string, 0, len(e))
+
+	for d := range e {
+		dids = append(dids, d)
+	}
+
+	sort.Strings(dids)
+
+	msgs := make([]string, len(dids))
+
+	for i, d := range dids {
+		msgs[i] = d + ": " + e[d].Error()
+	}
+
+	return "resolve batch: " + strings.Join(msgs, "; ")
+}
+
+// ResolveBatch expands many did:key values to DID documents concurrently.
+// The returned map holds the resolution of every DID which was resolved successfully.
+// If any DID fails to resolve, a BatchError with the error of each failed DID is returned as well.
+func ResolveBatch(dids []string) (map[string]*did.DocResolution, error) {
+	v := New()
+
+	jobs := make(chan string)
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results = make(map[string]*did.DocResolution, len(dids))
+		errs    = BatchError{}
+	)
+
+	workers := runtime.NumCPU()
+	if workers > len(dids) {
+		workers = len(dids)
+	}
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for didKey := range jobs {
+				docResolution, err := v.Read(didKey)
+
+				mu.Lock()
+
+				if err != nil {
+					errs[didKey] = err
+				} else {
+					results[didKey] = docResolution
+				}
+
+				mu.Unlock()
+			}
+		}()
+	}
+
+	seen := make(map[string]struct{}, len(dids))
+
+	for _, didKey := range dids {
+		if _, ok := seen[didKey]; ok {
+			continue
+		}
+
+		seen[didKey] = struct{}{}
+		jobs <- didKey
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return results, errs
+	}
+
+	return results, nil
+}
@@ -0,0 +1,54 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package key
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveBatch(t *testing.T) {
+	const (
+		didEd25519 = "did:key:z6MkpTHR8VNsBxYAAWHut2Geadd9jSwuBV8xRoAnwWsdvktH"
+		didP256    = "did:key:zDnaerx9CtbPJ1q36T5Ln5wYt3MQYeGRG5ehnPAmxcf5mDZpv"
+		didX25519  = "did:key:z6LSbysY2xFMRpGMhb7tFTLMpeuPRaqaWM1yECx2AtzE3KCc"
+	)
+
+	t.Run("mix of valid and malformed did:key values", func(t *testing.T) {
+		results, err := ResolveBatch([]string{didEd25519, "did:key:invalid", didP256, "invalid", didX25519, didEd25519})
+		require.Error(t, err)
+
+		var batchErr BatchError
+
+		require.True(t, errors.As(err, &batchErr))
+		require.Len(t, batchErr, 3)
+		require.Contains(t, batchErr["did:key:invalid"].Error(), "invalid did:key method ID: invalid")
+		require.Contains(t, batchErr["invalid"].Error(), "invalid did: invalid")
+		require.Contains(t, batchErr[didX25519].Error(), "unsupported key multicodec code [0xec]")
+		require.Contains(t, err.Error(), "resolve batch: did:key:invalid: ")
+
+		require.Len(t, results, 2)
+		require.Equal(t, didEd25519, results[didEd25519].DIDDocument.ID)
+		require.Equal(t, didP256, results[didP256].DIDDocument.ID)
+
+		assertEd25519Doc(t, results[didEd25519].DIDDocument)
+	})
+
+	t.Run("all valid", func(t *testing.T) {
+		results, err := ResolveBatch([]string{didEd25519, didP256})
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+	})
+
+	t.Run("empty batch", func(t *testing.T) {
+		results, err := ResolveBatch(nil)
+		require.NoError(t, err)
+		require.Empty(t, results)
+	})
+}
@@ -20,6 +20,10 @@ const (
 )
 
 // parseDIDWeb consumes a did:web identifier and returns the URL location of the did Doc.
+// The first colon-separated component of the method-specific ID is the domain, with the port colon percent-encoded
+// (e.g. example.com%3A3000). The other components are the path of the did.json document, e.g.
+// did:web:example.com:user:alice is located at https://example.com/user/alice/did.json, whereas a DID without
+// a path is located at the .well-known/did.json of the domain.
 func parseDIDWeb(id string, useHTTP bool) (string, string, error) {
 	var address, host string
 
@@ -30,12 +34,18 @@ func parseDIDWeb(id string, useHTTP bool) (string, string, error) {
 
 	pathComponents := strings.Split(parsedDID.MethodSpecificID, ":")
 
-	pathComponents[0], err = url.QueryUnescape(pathComponents[0])
+	domain, err := parseDomain(pathComponents[0])
 	if err != nil {
-		return address, host, fmt.Errorf("error parsing did:web did")
+		return address, host, fmt.Errorf("error parsing did:web did --> %w", err)
 	}
 
-	host = strings.Split(pathComponents[0], ":")[0]
+	for _, component := range pathComponents[1:] {
+		if err = checkPathComponent(component); err != nil {
+			return address, host, fmt.Errorf("error parsing did:web did --> %w", err)
+		}
+	}
+
+	host = domain.Hostname()
 
 	protocol := "https://"
 	if useHTTP {
@@ -44,10 +54,44 @@ func parseDIDWeb(id string, useHTTP bool) (string, string, error) {
 
 	switch len(pathComponents) {
 	case 1:
-		address = protocol + pathComponents[0] + defaultPath
+		address = protocol + domain.Host + defaultPath
 	default:
-		address = protocol + strings.Join(pathComponents, "/") + documentPath
+		address = protocol + domain.Host + "/" + strings.Join(pathComponents[1:], "/") + documentPath
 	}
 
 	return address, host, nil
 }
+
+// parseDomain decodes the domain component of a did:web DID, which may have a percent-encoded port.
+func parseDomain(component string) (*url.URL, error) {
+	domain, err := url.PathUnescape(component)
+	if err != nil {
+		return nil, fmt.Errorf("invalid domain %s: %w", component, err)
+	}
+
+	u, err := url.Parse("//" + domain)
+	if err != nil {
+		return nil, fmt.Errorf("invalid domain %s: %w", component, err)
+	}
+
+	// the decoded domain must not add any URL component (e.g. a path or user info) to the host.
+	if u.Host == "" || u.Host != domain || u.Hostname() == "" {
+		return nil, fmt.Errorf("invalid domain %s", component)
+	}
+
+	return u, nil
+}
+
+// checkPathComponent checks that a path component of a did:web DID is a single non-empty URL path segment.
+func checkPathComponent(component string) error {
+	segment, err := url.PathUnescape(component)
+	if err != nil {
+		return fmt.Errorf("invalid path component %s: %w", component, err)
+	}
+
+	if segment == "" || segment == "." || segment == ".." || strings.Contains(segment, "/") {
+		return fmt.Errorf("invalid path component '%s'", component)
+	}
+
+	return nil
+}
@@ -62,6 +62,62 @@ func TestParseDID(t *testing.T) {
 		require.Equal(t, "localhost", host)
 	})
 
+	t.Run("test parse path-based did", func(t *testing.T) {
+		tests := []struct {
+			did     string
+			address string
+			host    string
+		}{
+			{did: "did:web:example.com", address: "https://example.com/.well-known/did.json", host: "example.com"},
+			{
+				did:     "did:web:example.com%3A3000",
+				address: "https://example.com:3000/.well-known/did.json",
+				host:    "example.com",
+			},
+			{did: "did:web:example.com:user:alice", address: "https://example.com/user/alice/did.json", host: "example.com"},
+			{
+				did:     "did:web:example.com%3A3000:user:alice",
+				address: "https://example.com:3000/user/alice/did.json",
+				host:    "example.com",
+			},
+			{
+				did:     "did:web:example.com:users:alice%20smith",
+				address: "https://example.com/users/alice%20smith/did.json",
+				host:    "example.com",
+			},
+			{did: "did:web:127.0.0.1%3a8443:alice", address: "https://127.0.0.1:8443/alice/did.json", host: "127.0.0.1"},
+		}
+
+		for _, tc := range tests {
+			address, host, err := parseDIDWeb(tc.did, false)
+			require.NoError(t, err, tc.did)
+			require.Equal(t, tc.address, address)
+			require.Equal(t, tc.host, host)
+		}
+
+		address, _, err := parseDIDWeb("did:web:example.com%3A3000:user:alice", true)
+		require.NoError(t, err)
+		require.Equal(t, "http://example.com:3000/user/alice/did.json", address)
+	})
+
+	t.Run("test parse did with invalid domain or path", func(t *testing.T) {
+		for _, did := range []string{
+			"did:web:example.com%2Fuser",
+			"did:web:alice%40example.com",
+			"did:web:example.com%3F",
+			"did:web:%3A3000",
+			"did:web:example.com%ZZ",
+			"did:web:example.com:user::alice",
+			"did:web:example.com:user:",
+			"did:web:example.com:..:alice",
+			"did:web:example.com:user%2Falice",
+			"did:web:example.com:user%ZZ",
+		} {
+			_, _, err := parseDIDWeb(did, false)
+			require.Error(t, err, did)
+		}
+	})
+
 	t.Run("test parse did failure", func(t *testing.T) {
 		v := New()
 		doc, err := v.Read(invalidDIDNoMethod)
@@ -176,16 +232,40 @@ func TestResolveWebFixtures(t *testing.T) {
 	require.NoError(t, err)
 
 	s := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.URL.Path != "/alice/did.json" {
+		var doc []byte
+
+		switch r.URL.Path {
+		case "/alice/did.json":
+			doc = aliceDoc
+		case "/.well-known/did.json":
+			doc = []byte(validDoc)
+		default:
 			http.NotFound(w, r)
 			return
 		}
 
-		_, err := w.Write(aliceDoc)
+		_, err := w.Write(doc)
 		require.NoError(t, err)
 	}))
 	defer s.Close()
 
+	t.Run("resolve did:web:host", func(t *testing.T) {
+		did := fmt.Sprintf("did:web:%s", urlapi.QueryEscape(strings.TrimPrefix(s.URL, "https://")))
+
+		v := New()
+		docResolution, err := v.Read(did, vdrapi.WithOption(HTTPClientOpt, s.Client()))
+		require.NoError(t, err)
+		require.Equal(t, "did:web:www.example.org", docResolution.DIDDocument.ID)
+	})
+
+	t.Run("resolve did:web:host:bob not found", func(t *testing.T) {
+		did := fmt.Sprintf("did:web:%s:bob", urlapi.QueryEscape(strings.TrimPrefix(s.URL, "https://")))
+
+		v := New()
+		_, err := v.Read(did, vdrapi.WithOption(HTTPClientOpt, s.Client()))
+		require.EqualError(t, err, "http server returned status code [404]")
+	})
+
 	t.Run("resolve did:web:host:alice", func(t *testing.T) {
 		did := fmt.Sprintf("did:web:%s:alice", urlapi.QueryEscape(strings.TrimPrefix(s.URL, "https://")))
 